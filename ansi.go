@@ -0,0 +1,133 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// ANSI escape sequences for terminal colors
+const (
+	ANSIReset   = "\x1b[0m"
+	ANSIBold    = "\x1b[1m"
+	ANSIRed     = "\x1b[31m"
+	ANSIGreen   = "\x1b[32m"
+	ANSIYellow  = "\x1b[33m"
+	ANSIBlue    = "\x1b[34m"
+	ANSIMagenta = "\x1b[35m"
+	ANSICyan    = "\x1b[36m"
+	ANSIGray    = "\x1b[90m"
+)
+
+// ANSIRGB returns the ANSI escape sequence
+// for a 24 bit true color foreground color.
+func ANSIRGB(r, g, b uint8) string {
+	return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", r, g, b)
+}
+
+// ANSIColorize wraps str with the color escape sequence and ANSIReset.
+// An empty color returns str unchanged.
+func ANSIColorize(str, color string) string {
+	if color == "" {
+		return str
+	}
+	return color + str + ANSIReset
+}
+
+// StripANSI removes all ANSI escape sequences from str.
+//
+// The CSV, HTML, and Excel writers strip ANSI escape sequences
+// from formatted cells so that terminal formatters
+// like ColorizeNegativeNumbers can be shared with them.
+func StripANSI(str string) string {
+	if strings.IndexByte(str, '\x1b') < 0 {
+		return str
+	}
+	var b strings.Builder
+	b.Grow(len(str))
+	for i := 0; i < len(str); {
+		if str[i] == '\x1b' {
+			i += ansiEscapeLen(str[i:])
+			continue
+		}
+		b.WriteByte(str[i])
+		i++
+	}
+	return b.String()
+}
+
+// ColorizeNegativeNumbers returns a CellFormatter for terminal output
+// that formats numeric cells with fmt.Sprint and wraps
+// negative numbers with the passed ANSI color sequence.
+// The result is raw because it contains escape sequences.
+// Null and non numeric cells return errors.ErrUnsupported.
+func ColorizeNegativeNumbers(color string) CellFormatter {
+	return CellFormatterFunc(func(ctx context.Context, view View, row, col int) (string, bool, error) {
+		num, str, ok := numericCell(view, row, col)
+		if !ok {
+			return "", false, errors.ErrUnsupported
+		}
+		if num < 0 {
+			str = ANSIColorize(str, color)
+		}
+		return str, true, nil
+	})
+}
+
+// ColorScale returns a CellFormatter for terminal output
+// that formats numeric cells with fmt.Sprint and colors them
+// with one of the passed ANSI color sequences
+// by splitting the range from min to max into len(colors)
+// buckets of equal size.
+// Values outside of the range use the first or last color.
+// The result is raw because it contains escape sequences.
+// Null and non numeric cells return errors.ErrUnsupported.
+//
+// Example:
+//
+//	ColorScale(0, 100, ANSIRed, ANSIYellow, ANSIGreen)
+func ColorScale(min, max float64, colors ...string) CellFormatter {
+	return CellFormatterFunc(func(ctx context.Context, view View, row, col int) (string, bool, error) {
+		num, str, ok := numericCell(view, row, col)
+		if !ok || len(colors) == 0 {
+			return "", false, errors.ErrUnsupported
+		}
+		bucket := 0
+		if max > min && !math.IsNaN(num) {
+			bucket = int((num - min) / (max - min) * float64(len(colors)))
+			bucket = clamp(bucket, 0, len(colors)-1)
+		}
+		return ANSIColorize(str, colors[bucket]), true, nil
+	})
+}
+
+func clamp(v, lo, hi int) int {
+	return min(max(v, lo), hi)
+}
+
+// numericCell returns the float64 value and the fmt.Sprint
+// string of a cell with an integer or float value
+// or false for other values.
+func numericCell(view View, row, col int) (num float64, str string, ok bool) {
+	v := AsReflectCellView(view).ReflectCell(row, col)
+	if IsNullLike(v) {
+		return 0, "", false
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		num = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		num = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		num = v.Float()
+	default:
+		return 0, "", false
+	}
+	return num, fmt.Sprint(v.Interface()), true
+}
@@ -0,0 +1,65 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		str  string
+		want string
+	}{
+		{str: "", want: ""},
+		{str: "plain", want: "plain"},
+		{str: ANSIColorize("-1", ANSIRed), want: "-1"},
+		{str: ANSIRGB(1, 2, 3) + "x" + ANSIReset + "y", want: "xy"},
+		{str: "\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", want: "link"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			require.Equal(t, tt.want, StripANSI(tt.str))
+		})
+	}
+}
+
+func TestColorizeNegativeNumbers(t *testing.T) {
+	ctx := context.Background()
+	view := &AnyValuesView{
+		Cols: []string{"A"},
+		Rows: [][]any{{-1.5}, {2}, {"text"}, {nil}},
+	}
+	formatter := ColorizeNegativeNumbers(ANSIRed)
+
+	str, raw, err := formatter.FormatCell(ctx, view, 0, 0)
+	require.NoError(t, err)
+	require.True(t, raw)
+	require.Equal(t, ANSIRed+"-1.5"+ANSIReset, str)
+
+	str, _, err = formatter.FormatCell(ctx, view, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, "2", str)
+
+	_, _, err = formatter.FormatCell(ctx, view, 2, 0)
+	require.True(t, errors.Is(err, errors.ErrUnsupported))
+	_, _, err = formatter.FormatCell(ctx, view, 3, 0)
+	require.True(t, errors.Is(err, errors.ErrUnsupported))
+}
+
+func TestColorScale(t *testing.T) {
+	ctx := context.Background()
+	view := &AnyValuesView{
+		Cols: []string{"A"},
+		Rows: [][]any{{-10}, {0}, {40}, {70}, {100}, {1000}},
+	}
+	formatter := ColorScale(0, 100, ANSIRed, ANSIYellow, ANSIGreen)
+	want := []string{ANSIRed, ANSIRed, ANSIYellow, ANSIGreen, ANSIGreen, ANSIGreen}
+	for row, color := range want {
+		str, _, err := formatter.FormatCell(ctx, view, row, 0)
+		require.NoError(t, err)
+		require.Equal(t, ANSIColorize(StripANSI(str), color), str, "row %d", row)
+	}
+}
@@ -18,11 +18,8 @@ func NewAnyValuesViewFrom(source View) *AnyValuesView {
 		Cols: source.Columns(),
 		Rows: make([][]any, source.NumRows()),
 	}
-	for row := 0; row < source.NumRows(); row++ {
-		view.Rows[row] = make([]any, len(source.Columns()))
-		for col := range view.Rows[row] {
-			view.Rows[row][col] = source.Cell(row, col)
-		}
+	for row, values := range Rows(source) {
+		view.Rows[row] = values
 	}
 	return view
 }
@@ -0,0 +1,12 @@
+module github.com/domonda/go-retable/arrowtable
+
+go 1.23
+
+replace github.com/domonda/go-retable => ..
+
+require github.com/domonda/go-retable v0.0.0-00010101000000-000000000000 // replaced
+
+require (
+	github.com/apache/arrow-go/v18 v18.0.0
+	github.com/stretchr/testify v1.9.0
+)
@@ -0,0 +1,71 @@
+package arrowtable
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/domonda/go-retable"
+)
+
+// WriteIPC writes view as a single record batch
+// in the Arrow IPC stream format to dest.
+func WriteIPC(ctx context.Context, dest io.Writer, view retable.View) (err error) {
+	record, err := RecordFromView(ctx, view, memory.DefaultAllocator)
+	if err != nil {
+		return err
+	}
+	defer record.Release()
+
+	writer := ipc.NewWriter(dest, ipc.WithSchema(record.Schema()))
+	defer func() {
+		err = errors.Join(err, writer.Close())
+	}()
+	return writer.Write(record)
+}
+
+// ReadIPC reads all record batches of an Arrow IPC stream
+// and returns their rows as a single view.
+// The cell values are copied so that the view
+// doesn't depend on the memory of the stream.
+func ReadIPC(ctx context.Context, reader io.Reader) (*retable.AnyValuesView, error) {
+	r, err := ipc.NewReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Release()
+
+	view := &retable.AnyValuesView{}
+	if i := r.Schema().Metadata().FindKey("title"); i >= 0 {
+		view.Tit = r.Schema().Metadata().Values()[i]
+	}
+	for _, field := range r.Schema().Fields() {
+		view.Cols = append(view.Cols, field.Name)
+	}
+	for r.Next() {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		record := r.Record()
+		for row := 0; row < int(record.NumRows()); row++ {
+			values := make([]any, len(view.Cols))
+			for col := range values {
+				values[col] = copyValue(arrayValue(record.Column(col), row))
+			}
+			view.Rows = append(view.Rows, values)
+		}
+	}
+	return view, r.Err()
+}
+
+// copyValue returns a copy of byte slices
+// that reference the memory of an Arrow array.
+func copyValue(value any) any {
+	if b, ok := value.([]byte); ok {
+		return append([]byte(nil), b...)
+	}
+	return value
+}
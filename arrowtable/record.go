@@ -0,0 +1,175 @@
+// Package arrowtable converts between retable.View
+// and Apache Arrow records and the Arrow IPC stream format
+// for the interchange with analytics libraries
+// and Arrow Flight services.
+package arrowtable
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/domonda/go-retable"
+)
+
+var typeOfTime = reflect.TypeFor[time.Time]()
+
+// Schema returns the Arrow schema for the columns of view.
+//
+// The Arrow type of a column is derived from the
+// first non null cell value of the column:
+//
+//   - signed integers as Int64
+//   - unsigned integers as Uint64
+//   - floats as Float64
+//   - bools as Boolean
+//   - time.Time as Timestamp with microseconds in UTC
+//   - []byte as Binary
+//   - strings and all other types as String
+//
+// All fields are nullable and the view title
+// is stored as "title" schema metadata.
+func Schema(view retable.View) *arrow.Schema {
+	reflectView := retable.AsReflectCellView(view)
+	columns := view.Columns()
+	fields := make([]arrow.Field, len(columns))
+	for col, name := range columns {
+		fields[col] = arrow.Field{
+			Name:     name,
+			Type:     columnDataType(reflectView, col),
+			Nullable: true,
+		}
+	}
+	var metadata *arrow.Metadata
+	if title := view.Title(); title != "" {
+		m := arrow.NewMetadata([]string{"title"}, []string{title})
+		metadata = &m
+	}
+	return arrow.NewSchema(fields, metadata)
+}
+
+func columnDataType(view retable.ReflectCellView, col int) arrow.DataType {
+	for row := 0; row < view.NumRows(); row++ {
+		v := view.ReflectCell(row, col)
+		if retable.IsNullLike(v) {
+			continue
+		}
+		if v.Kind() == reflect.Pointer {
+			v = v.Elem()
+		}
+		if v.Type() == typeOfTime {
+			return arrow.FixedWidthTypes.Timestamp_us
+		}
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return arrow.PrimitiveTypes.Int64
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return arrow.PrimitiveTypes.Uint64
+		case reflect.Float32, reflect.Float64:
+			return arrow.PrimitiveTypes.Float64
+		case reflect.Bool:
+			return arrow.FixedWidthTypes.Boolean
+		case reflect.Slice:
+			if v.Type().Elem().Kind() == reflect.Uint8 {
+				return arrow.BinaryTypes.Binary
+			}
+		}
+		return arrow.BinaryTypes.String
+	}
+	return arrow.BinaryTypes.String
+}
+
+// RecordFromView returns an Arrow record with the cells of view
+// using the schema returned by Schema.
+// Null cells are appended as Arrow nulls.
+// The caller has to release the returned record.
+//
+// An error wrapped in a retable.CellError is returned
+// for cells with values that don't match the Arrow type
+// of their column.
+// If mem is nil then memory.DefaultAllocator is used.
+func RecordFromView(ctx context.Context, view retable.View, mem memory.Allocator) (arrow.Record, error) {
+	if mem == nil {
+		mem = memory.DefaultAllocator
+	}
+	builder := array.NewRecordBuilder(mem, Schema(view))
+	defer builder.Release()
+
+	reflectView := retable.AsReflectCellView(view)
+	for row := 0; row < view.NumRows(); row++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		for col := range view.Columns() {
+			err := appendCell(builder.Field(col), reflectView.ReflectCell(row, col))
+			if err != nil {
+				return nil, retable.NewCellError(view, row, col, err)
+			}
+		}
+	}
+	return builder.NewRecord(), nil
+}
+
+func appendCell(builder array.Builder, v reflect.Value) error {
+	if retable.IsNullLike(v) {
+		builder.AppendNull()
+		return nil
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	switch b := builder.(type) {
+	case *array.StringBuilder:
+		if v.Kind() == reflect.String {
+			b.Append(v.String())
+		} else {
+			b.Append(fmt.Sprint(v.Interface()))
+		}
+		return nil
+
+	case *array.Int64Builder:
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			b.Append(v.Int())
+			return nil
+		}
+
+	case *array.Uint64Builder:
+		switch v.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			b.Append(v.Uint())
+			return nil
+		}
+
+	case *array.Float64Builder:
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			b.Append(v.Float())
+			return nil
+		}
+
+	case *array.BooleanBuilder:
+		if v.Kind() == reflect.Bool {
+			b.Append(v.Bool())
+			return nil
+		}
+
+	case *array.BinaryBuilder:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			b.Append(v.Bytes())
+			return nil
+		}
+
+	case *array.TimestampBuilder:
+		if v.Type() == typeOfTime {
+			b.Append(arrow.Timestamp(v.Interface().(time.Time).UnixMicro()))
+			return nil
+		}
+	}
+	return fmt.Errorf("cell value of type %s does not match Arrow type %s", v.Type(), builder.Type())
+}
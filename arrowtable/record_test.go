@@ -0,0 +1,70 @@
+package arrowtable
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-retable"
+)
+
+func TestRecordFromView(t *testing.T) {
+	ctx := context.Background()
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	date := time.Date(2024, 10, 16, 12, 0, 0, 0, time.UTC)
+	view := &retable.AnyValuesView{
+		Tit:  "Table",
+		Cols: []string{"Int", "Float", "String", "Bool", "Time"},
+		Rows: [][]any{
+			{int64(1), 1.5, "a", true, date},
+			{int64(2), nil, "b", false, date.Add(time.Hour)},
+		},
+	}
+
+	record, err := RecordFromView(ctx, view, mem)
+	require.NoError(t, err)
+	defer record.Release()
+	require.Equal(t, int64(2), record.NumRows())
+	require.Equal(t, arrow.PrimitiveTypes.Int64, record.Schema().Field(0).Type)
+	require.Equal(t, arrow.BinaryTypes.String, record.Schema().Field(2).Type)
+
+	recordView := NewRecordView("", record)
+	defer recordView.Release()
+	require.Equal(t, "Table", recordView.Title())
+	require.Equal(t, view.Cols, recordView.Columns())
+	for row := range view.Rows {
+		for col := range view.Cols {
+			require.Equal(t, view.Cell(row, col), recordView.Cell(row, col), "row %d col %d", row, col)
+		}
+	}
+
+	invalid := &retable.AnyValuesView{Cols: []string{"A"}, Rows: [][]any{{1}, {"x"}}}
+	_, err = RecordFromView(ctx, invalid, mem)
+	var cellErr retable.CellError
+	require.ErrorAs(t, err, &cellErr)
+	require.Equal(t, 1, cellErr.Row)
+}
+
+func TestWriteReadIPC(t *testing.T) {
+	ctx := context.Background()
+	view := &retable.AnyValuesView{
+		Tit:  "Table",
+		Cols: []string{"A", "B"},
+		Rows: [][]any{{int64(1), "x"}, {nil, "y"}},
+	}
+
+	var buf bytes.Buffer
+	err := WriteIPC(ctx, &buf, view)
+	require.NoError(t, err)
+
+	read, err := ReadIPC(ctx, &buf)
+	require.NoError(t, err)
+	require.Equal(t, view, read)
+}
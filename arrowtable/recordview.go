@@ -0,0 +1,108 @@
+package arrowtable
+
+import (
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+
+	"github.com/domonda/go-retable"
+)
+
+var _ retable.View = new(RecordView)
+
+// RecordView is a retable.View reading its cells
+// directly from the arrays of an Arrow record
+// without copying the record data.
+//
+// The view retains the record until Release is called.
+type RecordView struct {
+	title   string
+	columns []string
+	record  arrow.Record
+}
+
+// NewRecordView returns a RecordView for record.
+// If title is empty then the "title" schema metadata is used.
+// The record is retained until RecordView.Release is called.
+func NewRecordView(title string, record arrow.Record) *RecordView {
+	schema := record.Schema()
+	if title == "" {
+		if i := schema.Metadata().FindKey("title"); i >= 0 {
+			title = schema.Metadata().Values()[i]
+		}
+	}
+	columns := make([]string, record.NumCols())
+	for col := range columns {
+		columns[col] = record.ColumnName(col)
+	}
+	record.Retain()
+	return &RecordView{title: title, columns: columns, record: record}
+}
+
+func (view *RecordView) Title() string     { return view.title }
+func (view *RecordView) Columns() []string { return view.columns }
+func (view *RecordView) NumRows() int      { return int(view.record.NumRows()) }
+
+// Record returns the wrapped Arrow record.
+func (view *RecordView) Record() arrow.Record { return view.record }
+
+// Release releases the wrapped Arrow record.
+// The view must not be used afterwards.
+func (view *RecordView) Release() { view.record.Release() }
+
+// Cell returns the value of the Arrow array of column col at row
+// as Go value or nil for nulls and out of range positions.
+func (view *RecordView) Cell(row, col int) any {
+	if row < 0 || row >= view.NumRows() || col < 0 || col >= len(view.columns) {
+		return nil
+	}
+	return arrayValue(view.record.Column(col), row)
+}
+
+// arrayValue returns the element i of arr
+// as Go value or nil if the element is null.
+func arrayValue(arr arrow.Array, i int) any {
+	if arr.IsNull(i) {
+		return nil
+	}
+	switch a := arr.(type) {
+	case *array.String:
+		return a.Value(i)
+	case *array.LargeString:
+		return a.Value(i)
+	case *array.Binary:
+		return a.Value(i)
+	case *array.Boolean:
+		return a.Value(i)
+	case *array.Int8:
+		return a.Value(i)
+	case *array.Int16:
+		return a.Value(i)
+	case *array.Int32:
+		return a.Value(i)
+	case *array.Int64:
+		return a.Value(i)
+	case *array.Uint8:
+		return a.Value(i)
+	case *array.Uint16:
+		return a.Value(i)
+	case *array.Uint32:
+		return a.Value(i)
+	case *array.Uint64:
+		return a.Value(i)
+	case *array.Float32:
+		return a.Value(i)
+	case *array.Float64:
+		return a.Value(i)
+	case *array.Timestamp:
+		toTime, err := a.DataType().(*arrow.TimestampType).GetToTimeFunc()
+		if err != nil {
+			return a.GetOneForMarshal(i)
+		}
+		return toTime(a.Value(i))
+	case *array.Date32:
+		return a.Value(i).ToTime()
+	case *array.Date64:
+		return a.Value(i).ToTime()
+	}
+	return arr.GetOneForMarshal(i)
+}
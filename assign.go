@@ -48,6 +48,28 @@ func SmartAssign(dst, src reflect.Value, dstScanner Scanner, srcFormatter Format
 		return nil
 	}
 
+	// Assign numbers exactly to big.Int, big.Float, and big.Rat
+	if srcType == typeOfRawNumber {
+		if assigned, err := assignRawNumber(dst, RawNumber(src.String())); assigned {
+			return err
+		}
+	}
+
+	// Try dstScanner for strings, a nil Parser is passed
+	if srcKind == reflect.String && dstScanner != nil {
+		err := dstScanner.ScanString(dst, src.String(), nil)
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return err // nil or other than errors.ErrUnsupported
+		}
+		// Continue after errors.ErrUnsupported
+	}
+
+	// Scan strings with validation into types like
+	// IBANs or money amounts implementing StringScanner
+	if srcKind == reflect.String && dstType != srcType && dst.CanAddr() && reflect.PointerTo(dstType).Implements(typeOfStringScanner) {
+		return dst.Addr().Interface().(StringScanner).ScanString(src.String(), true)
+	}
+
 	// Convert assigns directly if possible
 	if srcType.ConvertibleTo(dstType) {
 		// Check because conversion can panic
@@ -178,6 +200,11 @@ func SmartAssign(dst, src reflect.Value, dstScanner Scanner, srcFormatter Format
 				dst.SetInt(i)
 				return nil
 			}
+			// Integral values in scientific notation like "1E+5"
+			if n, ok := parseScientificInt(src.String()); ok && n.IsInt64() && !dst.OverflowInt(n.Int64()) {
+				dst.SetInt(n.Int64())
+				return nil
+			}
 		}
 
 	// Convert string to unsigned integers
@@ -187,6 +214,11 @@ func SmartAssign(dst, src reflect.Value, dstScanner Scanner, srcFormatter Format
 				dst.SetUint(i)
 				return nil
 			}
+			// Integral values in scientific notation like "1E+5"
+			if n, ok := parseScientificInt(src.String()); ok && n.IsUint64() && !dst.OverflowUint(n.Uint64()) {
+				dst.SetUint(n.Uint64())
+				return nil
+			}
 		}
 
 	case reflect.Float32, reflect.Float64:
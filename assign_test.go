@@ -3,6 +3,7 @@ package retable
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -42,6 +43,24 @@ func TestSmartAssign(t *testing.T) {
 			src:     reflect.ValueOf(pointerTo(int(1))),
 			wantDst: int(1),
 		},
+		{
+			name:    "scientific string to int",
+			dst:     assignableValue[int](),
+			src:     reflect.ValueOf("1.5E+3"),
+			wantDst: int(1500),
+		},
+		{
+			name:    "scientific string to uint8",
+			dst:     assignableValue[uint8](),
+			src:     reflect.ValueOf("2e2"),
+			wantDst: uint8(200),
+		},
+		{
+			name:    "scientific string to float64",
+			dst:     assignableValue[float64](),
+			src:     reflect.ValueOf("1.2e-3"),
+			wantDst: float64(0.0012),
+		},
 
 		// Error cases
 		{
@@ -56,6 +75,18 @@ func TestSmartAssign(t *testing.T) {
 			src:     reflect.ValueOf(int(1)),
 			wantErr: true,
 		},
+		{
+			name:    "non integral scientific string to int",
+			dst:     assignableValue[int](),
+			src:     reflect.ValueOf("1.5E+0"),
+			wantErr: true,
+		},
+		{
+			name:    "scientific string overflowing uint8",
+			dst:     assignableValue[uint8](),
+			src:     reflect.ValueOf("1E+3"),
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -84,3 +115,32 @@ func assignableValue[T any]() reflect.Value {
 	ptr := new(T)
 	return reflect.ValueOf(ptr).Elem()
 }
+
+func FuzzSmartAssign(f *testing.F) {
+	for _, str := range []string{"", "0", "-1", "1.5", "1e309", "true", "FALSE", "2024-01-02", "2024-01-02T03:04:05Z", "0x10", "NaN", "\x00"} {
+		f.Add(str)
+	}
+	dstTypes := []reflect.Type{
+		reflect.TypeFor[string](),
+		reflect.TypeFor[int](),
+		reflect.TypeFor[int8](),
+		reflect.TypeFor[uint16](),
+		reflect.TypeFor[float32](),
+		reflect.TypeFor[float64](),
+		reflect.TypeFor[bool](),
+		reflect.TypeFor[*int](),
+		reflect.TypeFor[time.Time](),
+		reflect.TypeFor[*time.Time](),
+		reflect.TypeFor[time.Duration](),
+		reflect.TypeFor[[]byte](),
+		reflect.TypeFor[any](),
+	}
+	f.Fuzz(func(t *testing.T, str string) {
+		for _, dstType := range dstTypes {
+			dst := reflect.New(dstType).Elem()
+			// Must not panic, errors are expected for most inputs
+			_ = SmartAssign(dst, reflect.ValueOf(str), nil, nil)
+			_ = SmartAssign(dst, reflect.ValueOf(&str), nil, nil)
+		}
+	})
+}
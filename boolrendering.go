@@ -0,0 +1,51 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+var _ CellFormatter = BoolRendering{}
+
+// BoolRendering defines the strings
+// that writers render for bool values.
+//
+// The CSV and HTML writers use it with WithBoolRendering
+// while the Excel writer always writes native booleans.
+type BoolRendering struct {
+	True  string
+	False string
+}
+
+// Presets of BoolRendering
+var (
+	BoolRenderingYesNo     = BoolRendering{True: "Yes", False: "No"}
+	BoolRenderingCheckmark = BoolRendering{True: "✓", False: "✗"}
+	BoolRenderingTrueFalse = BoolRendering{True: "TRUE", False: "FALSE"}
+	BoolRenderingOneZero   = BoolRendering{True: "1", False: "0"}
+)
+
+// Render returns the string for b.
+func (r BoolRendering) Render(b bool) string {
+	if b {
+		return r.True
+	}
+	return r.False
+}
+
+// FormatCell implements CellFormatter for bool kind cells
+// and returns errors.ErrUnsupported for other cells.
+func (r BoolRendering) FormatCell(ctx context.Context, view View, row, col int) (str string, raw bool, err error) {
+	v := AsReflectCellView(view).ReflectCell(row, col)
+	if IsNullLike(v) {
+		return "", false, errors.ErrUnsupported
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Bool {
+		return "", false, errors.ErrUnsupported
+	}
+	return r.Render(v.Bool()), false, nil
+}
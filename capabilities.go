@@ -0,0 +1,34 @@
+package retable
+
+// WriterCapabilities describes the features of an output format
+// and its writer so that generic export code can select
+// formatters and degrade gracefully without hardcoding
+// knowledge about the formats.
+type WriterCapabilities struct {
+	// Format is the name of the output format
+	// like "csv" or "html" as used for instrumentation
+	Format string
+	// RawHTML is true if raw formatted cells
+	// are written as HTML without escaping
+	RawHTML bool
+	// NativeTypes is true if cell values like numbers,
+	// booleans, and times are written as typed values
+	// of the format instead of formatted strings
+	NativeTypes bool
+	// Styles is true if the writer supports
+	// styling of cells or tables
+	Styles bool
+	// MultiSheet is true if multiple views
+	// can be written to one output
+	MultiSheet bool
+	// Streaming is true if rows are written to the destination
+	// while iterating the view instead of buffering
+	// the whole output in memory
+	Streaming bool
+}
+
+// CapabilitiesWriter is implemented by writers
+// that describe the capabilities of their output format.
+type CapabilitiesWriter interface {
+	Capabilities() WriterCapabilities
+}
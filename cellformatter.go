@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 )
 
 // CellFormatter is an interface for formatting view cells as strings.
@@ -103,9 +104,14 @@ func (rawStr RawCellString) FormatCell(ctx context.Context, view View, row, col
 // interface{ Format(string) string } like time.Time
 // by calling the Format method
 // with the string value of LayoutFormatter.
+// time.Time values are converted to the time location
+// of the context, see WithTimeLocation.
 type LayoutFormatter string
 
 func (f LayoutFormatter) FormatCell(ctx context.Context, view View, row, col int) (str string, raw bool, err error) {
+	if t, ok := view.Cell(row, col).(time.Time); ok {
+		return TimeInContextLocation(ctx, t).Format(string(f)), false, nil
+	}
 	formatter, ok := view.Cell(row, col).(interface{ Format(string) string })
 	if !ok {
 		return "", false, fmt.Errorf("%T does not implement interface{ Format(string) string }", view.Cell(row, col))
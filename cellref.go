@@ -0,0 +1,185 @@
+package retable
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ColumnLetters returns the spreadsheet column letters
+// for the zero based column index col like "A" for 0,
+// "Z" for 25, and "AA" for 26.
+// An empty string is returned for a negative col.
+func ColumnLetters(col int) string {
+	if col < 0 {
+		return ""
+	}
+	var b []byte
+	for col++; col > 0; col = (col - 1) / 26 {
+		b = append(b, byte('A'+(col-1)%26))
+	}
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
+
+// ParseColumnLetters returns the zero based column index
+// for spreadsheet column letters like "A" or "AA".
+// Lower case letters are accepted.
+func ParseColumnLetters(letters string) (col int, err error) {
+	if letters == "" {
+		return 0, errors.New("empty column letters")
+	}
+	if len(letters) > 7 {
+		return 0, fmt.Errorf("too many column letters in %q", letters)
+	}
+	for _, r := range strings.ToUpper(letters) {
+		if r < 'A' || r > 'Z' {
+			return 0, fmt.Errorf("invalid column letters %q", letters)
+		}
+		col = col*26 + int(r-'A') + 1
+	}
+	return col - 1, nil
+}
+
+// CellRef returns the spreadsheet A1 notation
+// for the zero based row and col indices,
+// like "A1" for row 0 and col 0 or "C10" for row 9 and col 2.
+// An empty string is returned for negative indices.
+func CellRef(row, col int) string {
+	if row < 0 || col < 0 {
+		return ""
+	}
+	return ColumnLetters(col) + strconv.Itoa(row+1)
+}
+
+// ParseCellRef parses a cell reference in A1 notation
+// and returns the zero based row and col indices.
+// Absolute references like "$B$2" are accepted.
+func ParseCellRef(ref string) (row, col int, err error) {
+	letters, digits := splitCellRef(ref)
+	if digits == "" {
+		return 0, 0, fmt.Errorf("invalid cell reference %q", ref)
+	}
+	col, err = ParseColumnLetters(letters)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid cell reference %q: %w", ref, err)
+	}
+	row, err = strconv.Atoi(digits)
+	if err != nil || row < 1 {
+		return 0, 0, fmt.Errorf("invalid row in cell reference %q", ref)
+	}
+	return row - 1, col, nil
+}
+
+// splitCellRef splits ref into the column letters
+// and row digits without the $ signs of absolute references.
+func splitCellRef(ref string) (letters, digits string) {
+	ref = strings.TrimPrefix(strings.TrimSpace(ref), "$")
+	i := strings.IndexFunc(ref, func(r rune) bool { return r < 'A' || r > 'z' || (r > 'Z' && r < 'a') })
+	if i == -1 {
+		return ref, ""
+	}
+	return ref[:i], strings.TrimPrefix(ref[i:], "$")
+}
+
+// CellRange is a rectangular range of cells
+// with zero based inclusive indices.
+// LastRow is -1 for ranges of whole columns like "B:D".
+type CellRange struct {
+	FirstRow, FirstCol int
+	LastRow, LastCol   int
+}
+
+// ParseCellRange parses a cell range in A1 notation
+// like "B2:D10", a single cell like "B2",
+// or whole columns like "B:D".
+// The corners of the range can be given in any order.
+func ParseCellRange(ref string) (CellRange, error) {
+	first, last, isRange := strings.Cut(ref, ":")
+	if !isRange {
+		last = first
+	}
+	firstLetters, firstDigits := splitCellRef(first)
+	lastLetters, lastDigits := splitCellRef(last)
+	if (firstDigits == "") != (lastDigits == "") {
+		return CellRange{}, fmt.Errorf("invalid cell range %q", ref)
+	}
+	if firstDigits == "" {
+		if !isRange {
+			return CellRange{}, fmt.Errorf("invalid cell range %q", ref)
+		}
+		firstCol, err := ParseColumnLetters(firstLetters)
+		if err != nil {
+			return CellRange{}, fmt.Errorf("invalid cell range %q: %w", ref, err)
+		}
+		lastCol, err := ParseColumnLetters(lastLetters)
+		if err != nil {
+			return CellRange{}, fmt.Errorf("invalid cell range %q: %w", ref, err)
+		}
+		return CellRange{FirstCol: min(firstCol, lastCol), LastRow: -1, LastCol: max(firstCol, lastCol)}, nil
+	}
+	firstRow, firstCol, err := ParseCellRef(first)
+	if err != nil {
+		return CellRange{}, fmt.Errorf("invalid cell range %q: %w", ref, err)
+	}
+	lastRow, lastCol, err := ParseCellRef(last)
+	if err != nil {
+		return CellRange{}, fmt.Errorf("invalid cell range %q: %w", ref, err)
+	}
+	return CellRange{
+		FirstRow: min(firstRow, lastRow),
+		FirstCol: min(firstCol, lastCol),
+		LastRow:  max(firstRow, lastRow),
+		LastCol:  max(firstCol, lastCol),
+	}, nil
+}
+
+// String returns the range in A1 notation.
+func (r CellRange) String() string {
+	if r.LastRow < 0 {
+		return ColumnLetters(r.FirstCol) + ":" + ColumnLetters(r.LastCol)
+	}
+	if r.FirstRow == r.LastRow && r.FirstCol == r.LastCol {
+		return CellRef(r.FirstRow, r.FirstCol)
+	}
+	return CellRef(r.FirstRow, r.FirstCol) + ":" + CellRef(r.LastRow, r.LastCol)
+}
+
+// RangeView returns a view of the cells of view
+// within a range in A1 notation like "B2:D10".
+//
+// The range refers to view as written to a spreadsheet
+// with the column titles in row 1 and the first view row in row 2.
+// The column titles are always part of the returned view,
+// so a range starting at row 1 selects the same rows
+// as a range starting at row 2.
+// Rows of the range beyond the rows of view are ignored,
+// columns beyond the columns of view result in an error.
+func RangeView(view View, ref string) (*FilteredView, error) {
+	r, err := ParseCellRange(ref)
+	if err != nil {
+		return nil, err
+	}
+	if r.LastCol >= len(view.Columns()) {
+		return nil, fmt.Errorf("cell range %q out of range for %d columns", ref, len(view.Columns()))
+	}
+	columnMapping := make([]int, 0, r.LastCol-r.FirstCol+1)
+	for col := r.FirstCol; col <= r.LastCol; col++ {
+		columnMapping = append(columnMapping, col)
+	}
+	var rowOffset, rowLimit int
+	switch {
+	case r.LastRow < 0:
+		// Whole columns
+	case r.LastRow == 0:
+		// Only the header row
+		rowOffset = view.NumRows()
+	default:
+		rowOffset = max(r.FirstRow-1, 0)
+		rowLimit = r.LastRow - rowOffset
+	}
+	return NewFilteredView(view, rowOffset, rowLimit, columnMapping...)
+}
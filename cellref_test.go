@@ -0,0 +1,129 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnLetters(t *testing.T) {
+	tests := []struct {
+		col     int
+		letters string
+	}{
+		{col: 0, letters: "A"},
+		{col: 25, letters: "Z"},
+		{col: 26, letters: "AA"},
+		{col: 51, letters: "AZ"},
+		{col: 52, letters: "BA"},
+		{col: 701, letters: "ZZ"},
+		{col: 702, letters: "AAA"},
+		{col: 16383, letters: "XFD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.letters, func(t *testing.T) {
+			require.Equal(t, tt.letters, ColumnLetters(tt.col))
+			col, err := ParseColumnLetters(tt.letters)
+			require.NoError(t, err)
+			require.Equal(t, tt.col, col)
+		})
+	}
+	require.Equal(t, "", ColumnLetters(-1))
+	col, err := ParseColumnLetters("ab")
+	require.NoError(t, err)
+	require.Equal(t, 27, col)
+	for _, invalid := range []string{"", "A1", "Ä", "AAAAAAAA"} {
+		_, err := ParseColumnLetters(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestParseCellRef(t *testing.T) {
+	require.Equal(t, "C10", CellRef(9, 2))
+	require.Equal(t, "", CellRef(-1, 0))
+
+	row, col, err := ParseCellRef("C10")
+	require.NoError(t, err)
+	require.Equal(t, []int{9, 2}, []int{row, col})
+	row, col, err = ParseCellRef("$b$2")
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 1}, []int{row, col})
+
+	for _, invalid := range []string{"", "C", "10", "C0", "C-1", "C1x", "1C"} {
+		_, _, err := ParseCellRef(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestParseCellRange(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    CellRange
+		str     string
+		wantErr bool
+	}{
+		{ref: "B2:D10", want: CellRange{FirstRow: 1, FirstCol: 1, LastRow: 9, LastCol: 3}, str: "B2:D10"},
+		{ref: "D10:B2", want: CellRange{FirstRow: 1, FirstCol: 1, LastRow: 9, LastCol: 3}, str: "B2:D10"},
+		{ref: "$A$1:$B$2", want: CellRange{LastRow: 1, LastCol: 1}, str: "A1:B2"},
+		{ref: "C3", want: CellRange{FirstRow: 2, FirstCol: 2, LastRow: 2, LastCol: 2}, str: "C3"},
+		{ref: "B:D", want: CellRange{FirstCol: 1, LastRow: -1, LastCol: 3}, str: "B:D"},
+		{ref: "B", wantErr: true},
+		{ref: "B2:D", wantErr: true},
+		{ref: "B2:", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := ParseCellRange(tt.ref)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.str, got.String())
+		})
+	}
+}
+
+func TestRangeView(t *testing.T) {
+	view := NewStringsView("", [][]string{
+		{"A", "B", "C", "D"},
+		{"a1", "b1", "c1", "d1"},
+		{"a2", "b2", "c2", "d2"},
+		{"a3", "b3", "c3", "d3"},
+	})
+	tests := []struct {
+		ref     string
+		want    [][]string
+		wantErr bool
+	}{
+		{ref: "B2:C3", want: [][]string{{"B", "C"}, {"b1", "c1"}, {"b2", "c2"}}},
+		{ref: "B1:C3", want: [][]string{{"B", "C"}, {"b1", "c1"}, {"b2", "c2"}}},
+		{ref: "D4", want: [][]string{{"D"}, {"d3"}}},
+		{ref: "A3:A100", want: [][]string{{"A"}, {"a2"}, {"a3"}}},
+		{ref: "C1:D1", want: [][]string{{"C", "D"}}},
+		{ref: "C:D", want: [][]string{{"C", "D"}, {"c1", "d1"}, {"c2", "d2"}, {"c3", "d3"}}},
+		{ref: "A10:B20", want: [][]string{{"A", "B"}}},
+		{ref: "D1:E2", wantErr: true},
+		{ref: "invalid", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := RangeView(view, tt.ref)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			rows := [][]string{got.Columns()}
+			for row := range got.NumRows() {
+				var cells []string
+				for col := range got.Columns() {
+					cells = append(cells, got.Cell(row, col).(string))
+				}
+				rows = append(rows, cells)
+			}
+			require.Equal(t, tt.want, rows)
+		})
+	}
+}
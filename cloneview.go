@@ -0,0 +1,145 @@
+package retable
+
+import (
+	"reflect"
+	"slices"
+)
+
+var _ ColumnFormatterView = new(clonedView)
+
+// CloneView returns an independent materialized copy of view
+// that is not affected by later modifications of the source,
+// like mutations of the slice a StructRowsView was created for.
+//
+// The title, columns, and column formatters of the source are kept.
+// All cells are read with ReflectCell and deep copied:
+// pointers, slices, maps, arrays, interfaces and the exported
+// fields of structs are copied recursively.
+// Values only reachable through unexported struct fields,
+// channels, functions, and unsafe pointers are still shared
+// with the source.
+// Strings are immutable in Go and therefore safe to share.
+func CloneView(view View) ReflectCellView {
+	reflectView := AsReflectCellView(view)
+	numCols := len(view.Columns())
+	clone := &clonedView{
+		ReflectValuesView: ReflectValuesView{
+			Tit:  view.Title(),
+			Cols: slices.Clone(view.Columns()),
+			Rows: make([][]reflect.Value, view.NumRows()),
+		},
+	}
+	seen := make(map[seenPointer]reflect.Value)
+	for row := range clone.Rows {
+		clone.Rows[row] = make([]reflect.Value, numCols)
+		for col := range numCols {
+			clone.Rows[row][col] = deepCopyValue(reflectView.ReflectCell(row, col), seen)
+		}
+	}
+	for col := range numCols {
+		if formatter := ViewColumnFormatter(view, col); formatter != nil {
+			if clone.formatters == nil {
+				clone.formatters = make([]CellFormatter, numCols)
+			}
+			clone.formatters[col] = formatter
+		}
+	}
+	return clone
+}
+
+type clonedView struct {
+	ReflectValuesView
+	formatters []CellFormatter
+}
+
+func (view *clonedView) ColumnFormatter(col int) CellFormatter {
+	if col < 0 || col >= len(view.formatters) {
+		return nil
+	}
+	return view.formatters[col]
+}
+
+// seenPointer identifies an already copied pointer target.
+// The type is part of the key because a struct
+// and its first field have the same address.
+type seenPointer struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// deepCopyValue returns a deep copy of v, see CloneView.
+// Pointers already copied are looked up in seen
+// to preserve shared references and cycles.
+func deepCopyValue(v reflect.Value, seen map[seenPointer]reflect.Value) reflect.Value {
+	if !v.IsValid() || !v.CanInterface() {
+		return v
+	}
+	// Start with a copy of the same type
+	// that is not connected to the source anymore
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return deepCopy(c, seen)
+}
+
+func deepCopy(v reflect.Value, seen map[seenPointer]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		key := seenPointer{ptr: v.Pointer(), typ: v.Type()}
+		if c, ok := seen[key]; ok {
+			return c
+		}
+		c := reflect.New(v.Type().Elem())
+		seen[key] = c
+		c.Elem().Set(deepCopy(v.Elem(), seen))
+		return c
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem(), seen))
+		return c
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			c.Index(i).Set(deepCopy(v.Index(i), seen))
+		}
+		return c
+
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			c.Index(i).Set(deepCopy(v.Index(i), seen))
+		}
+		return c
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			c.SetMapIndex(deepCopy(iter.Key(), seen), deepCopy(iter.Value(), seen))
+		}
+		return c
+
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v) // Shallow copy including unexported fields
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				c.Field(i).Set(deepCopy(v.Field(i), seen))
+			}
+		}
+		return c
+	}
+	return v
+}
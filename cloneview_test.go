@@ -0,0 +1,69 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneView(t *testing.T) {
+	type Node struct {
+		Name string
+		Next *Node
+	}
+	type Row struct {
+		Name   string
+		Tags   []string
+		Attrs  map[string]int
+		Node   *Node
+		Any    any
+		Margin float64 `col:"Margin,format=percent:1"`
+		hidden []int
+	}
+	cycle := &Node{Name: "cycle"}
+	cycle.Next = cycle
+	rows := []Row{
+		{
+			Name:   "a",
+			Tags:   []string{"x", "y"},
+			Attrs:  map[string]int{"k": 1},
+			Node:   cycle,
+			Any:    []int{1},
+			Margin: 0.5,
+			hidden: []int{1},
+		},
+		{Name: "b"},
+	}
+	source, err := DefaultStructRowsViewer().NewView("Rows", rows)
+	require.NoError(t, err)
+
+	clone := CloneView(source)
+	require.Equal(t, "Rows", clone.Title())
+	require.Equal(t, source.Columns(), clone.Columns())
+	require.Equal(t, 2, clone.NumRows())
+	require.NotNil(t, ViewColumnFormatter(clone, 5), "column formatter kept")
+
+	// Mutate the source after cloning
+	rows[0].Name = "changed"
+	rows[0].Tags[0] = "changed"
+	rows[0].Attrs["k"] = 2
+	rows[0].Node.Name = "changed"
+	rows[0].Any.([]int)[0] = 2
+	rows[1] = Row{Name: "replaced"}
+
+	require.Equal(t, "a", clone.Cell(0, 0))
+	require.Equal(t, []string{"x", "y"}, clone.Cell(0, 1))
+	require.Equal(t, map[string]int{"k": 1}, clone.Cell(0, 2))
+	node := clone.Cell(0, 3).(*Node)
+	require.Equal(t, "cycle", node.Name)
+	require.Same(t, node, node.Next, "cycle preserved")
+	require.Equal(t, []int{1}, clone.Cell(0, 4))
+	require.Equal(t, 0.5, clone.Cell(0, 5))
+	require.Equal(t, "b", clone.Cell(1, 0))
+	require.Nil(t, clone.Cell(1, 1).([]string), "nil slice kept nil")
+	require.Nil(t, clone.Cell(1, 4), "nil interface")
+
+	// Changes to the columns of the clone don't affect the source
+	clone.Columns()[0] = "Renamed"
+	require.Equal(t, "Name", source.Columns()[0])
+}
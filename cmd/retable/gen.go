@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/domonda/go-retable"
+)
+
+// generateStruct writes Go source code of a struct type
+// with a field per column of view to dest.
+// The field types are derived from the profiled column types
+// and the column titles are used as struct field tags.
+// Columns with empty cells get pointer types
+// except for strings.
+func generateStruct(dest io.Writer, view retable.View, pkg, typeName, tag string) error {
+	profiles := profileColumns(view)
+	usesTime := false
+	for _, p := range profiles {
+		usesTime = usesTime || p.Type == "time"
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	if usesTime {
+		b.WriteString("import \"time\"\n\n")
+	}
+	fmt.Fprintf(&b, "type %s struct {\n", typeName)
+	fieldNames := make(map[string]int)
+	for _, p := range profiles {
+		name := goFieldName(p.Name)
+		if n := fieldNames[name]; n > 0 {
+			fieldNames[name]++
+			name += strconv.Itoa(n + 1)
+		} else {
+			fieldNames[name] = 1
+		}
+		fieldType := goFieldType(p.Type)
+		if p.NonEmpty < view.NumRows() && fieldType != "string" {
+			fieldType = "*" + fieldType
+		}
+		fmt.Fprintf(&b, "\t%s %s `%s:%s`\n", name, fieldType, tag, strconv.Quote(p.Name))
+	}
+	b.WriteString("}\n")
+
+	source, err := format.Source(b.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = dest.Write(source)
+	return err
+}
+
+func goFieldType(profileType string) string {
+	switch profileType {
+	case "int":
+		return "int64"
+	case "float":
+		return "float64"
+	case "bool":
+		return "bool"
+	case "time":
+		return "time.Time"
+	}
+	return "string"
+}
+
+// goFieldName returns an exported Go identifier
+// for a column title like "Invoice No." as InvoiceNo.
+func goFieldName(column string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range column {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	name := b.String()
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) || !token.IsExported(name) {
+		name = "Col" + name
+	}
+	return name
+}
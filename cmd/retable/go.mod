@@ -0,0 +1,36 @@
+module github.com/domonda/go-retable/cmd/retable
+
+go 1.23
+
+replace (
+	github.com/domonda/go-retable => ../..
+	github.com/domonda/go-retable/exceltable => ../../exceltable
+)
+
+require (
+	github.com/domonda/go-retable v0.0.0-00010101000000-000000000000 // replaced
+	github.com/domonda/go-retable/exceltable v0.0.0-00010101000000-000000000000 // replaced
+	github.com/stretchr/testify v1.9.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/domonda/go-types v0.0.0-20241016145418-49737a904fc1 // indirect
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/mohae/deepcopy v0.0.0-20170929034955-c48cc78d4826 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/richardlehane/mscfb v1.0.4 // indirect
+	github.com/richardlehane/msoleps v1.0.4 // indirect
+	github.com/ungerik/go-fs v0.0.0-20240919125757-1b6f933a416d // indirect
+	github.com/xuri/efp v0.0.0-20240408161823-9ad904a10d6d // indirect
+	github.com/xuri/excelize/v2 v2.9.0 // indirect
+	github.com/xuri/nfp v0.0.0-20240318013403-ab9948c2c4a7 // indirect
+	go.opentelemetry.io/otel v1.31.0 // indirect
+	go.opentelemetry.io/otel/metric v1.31.0 // indirect
+	go.opentelemetry.io/otel/trace v1.31.0 // indirect
+	golang.org/x/crypto v0.31.0 // indirect
+	golang.org/x/net v0.33.0 // indirect
+	golang.org/x/text v0.21.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/domonda/go-retable"
+	"github.com/domonda/go-retable/csvtable"
+	"github.com/domonda/go-retable/exceltable"
+)
+
+// formatFromFilename returns the format for the extension of filename
+// or an empty string if the extension is unknown.
+func formatFromFilename(filename string) string {
+	switch ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), ".")); ext {
+	case "csv", "tsv", "xlsx", "json", "html":
+		return ext
+	case "md", "markdown":
+		return "md"
+	case "htm":
+		return "html"
+	}
+	return ""
+}
+
+// detectFormat returns the format of data by its content.
+func detectFormat(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		return "xlsx"
+	case bytes.HasPrefix(trimmed, []byte("[")):
+		return "json"
+	default:
+		// TSV is detected by the CSV parser
+		return "csv"
+	}
+}
+
+func readInput(ctx context.Context, input, format, sheet string, stdin io.Reader) (retable.View, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if input == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(input)
+		if format == "" {
+			format = formatFromFilename(input)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if format == "" {
+		format = detectFormat(data)
+	}
+	title := strings.TrimSuffix(filepath.Base(input), filepath.Ext(input))
+
+	switch format {
+	case "csv", "tsv":
+		rows, _, err := csvtable.ParseDetectFormat(data, nil)
+		if err != nil {
+			return nil, err
+		}
+		rows = csvtable.RemoveEmptyRows(rows)
+		if len(rows) == 0 {
+			return nil, errors.New("no rows in input")
+		}
+		return retable.NewStringsView(title, rows), nil
+
+	case "xlsx":
+		views, err := exceltable.Read(bytes.NewReader(data), false)
+		if err != nil {
+			return nil, err
+		}
+		for _, view := range views {
+			if sheet == "" || view.Title() == sheet {
+				return view, nil
+			}
+		}
+		if sheet != "" {
+			return nil, fmt.Errorf("sheet %q not found", sheet)
+		}
+		return nil, errors.New("no sheets in input")
+
+	case "json":
+		return readJSON(title, data)
+	}
+	return nil, fmt.Errorf("unsupported input format %q", format)
+}
+
+// readJSON reads an array of objects as view
+// with the object keys in the order of their first appearance as columns.
+func readJSON(title string, data []byte) (retable.View, error) {
+	var objects []map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&objects); err != nil {
+		return nil, fmt.Errorf("expected JSON array of objects: %w", err)
+	}
+
+	// Decode again as tokens to get the key order of the objects
+	var (
+		columns  []string
+		colIndex = make(map[string]int)
+		depth    int
+	)
+	dec = json.NewDecoder(bytes.NewReader(data))
+	expectKey := false // Tokens of objects at depth 2 alternate between key and value
+	for {
+		token, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case json.Delim:
+			switch t {
+			case '{', '[':
+				depth++
+				expectKey = depth == 2
+			case '}', ']':
+				depth--
+				// A nested value ended, next is a key again
+				expectKey = depth == 2
+			}
+		default:
+			if depth != 2 {
+				continue
+			}
+			if key, ok := t.(string); ok && expectKey {
+				if _, exists := colIndex[key]; !exists {
+					colIndex[key] = len(columns)
+					columns = append(columns, key)
+				}
+			}
+			expectKey = !expectKey
+		}
+	}
+
+	view := &retable.AnyValuesView{Tit: title, Cols: columns}
+	for _, obj := range objects {
+		row := make([]any, len(columns))
+		for key, val := range obj {
+			row[colIndex[key]] = val
+		}
+		view.Rows = append(view.Rows, row)
+	}
+	return view, nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/domonda/go-retable"
+)
+
+// columnProfile summarizes the values of a column
+type columnProfile struct {
+	Name     string
+	Type     string
+	NonEmpty int
+	Distinct int
+	Example  string
+}
+
+// profileColumns profiles the columns of view
+// by guessing the type of the non empty cell strings.
+func profileColumns(view retable.View) []columnProfile {
+	reflectView := retable.AsReflectCellView(view)
+	profiles := make([]columnProfile, len(view.Columns()))
+	for col, name := range view.Columns() {
+		profile := &profiles[col]
+		profile.Name = name
+		distinct := make(map[string]struct{})
+		for row := 0; row < view.NumRows(); row++ {
+			str := strings.TrimSpace(cellString(reflectView, row, col))
+			if str == "" {
+				continue
+			}
+			profile.NonEmpty++
+			distinct[str] = struct{}{}
+			if profile.Example == "" {
+				profile.Example = str
+			}
+			profile.Type = mergeTypes(profile.Type, guessType(str))
+		}
+		profile.Distinct = len(distinct)
+		if profile.Type == "" {
+			profile.Type = "empty"
+		}
+	}
+	return profiles
+}
+
+func guessType(str string) string {
+	if _, err := strconv.ParseInt(str, 10, 64); err == nil {
+		return "int"
+	}
+	if _, err := strconv.ParseFloat(str, 64); err == nil {
+		return "float"
+	}
+	// Scientific notation with a decimal comma like "1,2E+05"
+	// as displayed by spreadsheet applications in some locales
+	if strings.Count(str, ",") == 1 && retable.IsRawNumber(strings.Replace(str, ",", ".", 1)) {
+		return "float"
+	}
+	if _, err := strconv.ParseBool(str); err == nil {
+		return "bool"
+	}
+	if _, _, err := retable.ParseTime(str); err == nil {
+		return "time"
+	}
+	return "string"
+}
+
+func mergeTypes(a, b string) string {
+	switch {
+	case a == "" || a == b:
+		return b
+	case a == "int" && b == "float" || a == "float" && b == "int":
+		return "float"
+	}
+	return "string"
+}
+
+func inspect(dest io.Writer, view retable.View) error {
+	fmt.Fprintf(dest, "Title:   %s\n", view.Title())
+	fmt.Fprintf(dest, "Rows:    %d\n", view.NumRows())
+	fmt.Fprintf(dest, "Columns: %d\n\n", len(view.Columns()))
+
+	w := tabwriter.NewWriter(dest, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "COLUMN\tTYPE\tNON-EMPTY\tDISTINCT\tEXAMPLE")
+	for _, p := range profileColumns(view) {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\n", p.Name, p.Type, p.NonEmpty, p.Distinct, p.Example)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,133 @@
+// Command retable converts and inspects tabular data files.
+//
+// Usage:
+//
+//	retable convert [flags] INPUT [OUTPUT]
+//	retable inspect [flags] INPUT
+//	retable gen [flags] INPUT
+//
+// INPUT and OUTPUT can be "-" for stdin and stdout.
+// Supported input formats are csv, tsv, xlsx, and json,
+// supported output formats are csv, tsv, xlsx, json, md, and html.
+// Formats are detected from the file extension
+// or the content of the input if not set with -from and -to.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+func main() {
+	err := run(context.Background(), os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			// Usage was already written to stderr
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, "retable:", err)
+		os.Exit(1)
+	}
+}
+
+const usage = `usage:
+  retable convert [flags] INPUT [OUTPUT]
+  retable inspect [flags] INPUT
+  retable gen [flags] INPUT
+
+INPUT and OUTPUT can be "-" for stdin and stdout.
+Input formats:  csv, tsv, xlsx, json
+Output formats: csv, tsv, xlsx, json, md, html
+`
+
+// run executes the command of args writing its output to stdout.
+// Usage and flag errors are written to stderr
+// so they don't mix with output written to stdout
+// and flag.ErrHelp is returned for them.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return flag.ErrHelp
+	}
+	flags := flag.NewFlagSet("retable "+args[0], flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var (
+		from       = flags.String("from", "", "input `format`, detected if empty")
+		sheet      = flags.String("sheet", "", "XLSX input `sheet` name, first sheet if empty")
+		to         = flags.String("to", "", "output `format`, detected from OUTPUT extension or csv")
+		selectCols = flags.String("select", "", "comma separated `columns` to output")
+		sortCol    = flags.String("sort", "", "`column` to sort by, prefix with - for descending order")
+		limit      = flags.Int("limit", 0, "maximum number of `rows` to output")
+		pkg        = flags.String("package", "main", "gen: Go `package` name")
+		typeName   = flags.String("type", "Row", "gen: Go struct `type` name")
+		tag        = flags.String("tag", "col", "gen: struct field `tag` for the column titles")
+	)
+	switch args[0] {
+	case "convert", "inspect", "gen":
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n%s", args[0], usage)
+		return flag.ErrHelp
+	}
+	if err := flags.Parse(args[1:]); err != nil {
+		// The error and usage were written to stderr by flags
+		return flag.ErrHelp
+	}
+	if flags.NArg() < 1 || flags.NArg() > 2 || args[0] != "convert" && flags.NArg() > 1 {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+
+	input := flags.Arg(0)
+	view, err := readInput(ctx, input, *from, *sheet, stdin)
+	if err != nil {
+		return err
+	}
+	if *selectCols != "" {
+		view, err = selectColumns(view, strings.Split(*selectCols, ","))
+		if err != nil {
+			return err
+		}
+	}
+	if *sortCol != "" {
+		view, err = sortRows(view, *sortCol)
+		if err != nil {
+			return err
+		}
+	}
+	if *limit > 0 {
+		view = limitRows(view, *limit)
+	}
+
+	switch args[0] {
+	case "inspect":
+		return inspect(stdout, view)
+	case "gen":
+		return generateStruct(stdout, view, *pkg, *typeName, *tag)
+	}
+
+	output := flags.Arg(1)
+	if output == "" {
+		output = "-"
+	}
+	format := *to
+	if format == "" {
+		format = formatFromFilename(output)
+	}
+	if format == "" {
+		format = "csv"
+	}
+	if output == "-" {
+		return writeOutput(ctx, stdout, view, format)
+	}
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	err = writeOutput(ctx, file, view, format)
+	return errors.Join(err, file.Close())
+}
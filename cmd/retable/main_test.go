@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	const input = "Name;Amount;Date\nB;2.5;2024-01-02\nA;10;2024-01-01\nC;1;\n"
+	tests := []struct {
+		name    string
+		args    []string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "convert to markdown with select, sort, and limit",
+			args:  []string{"convert", "-to", "md", "-select", "Name,Amount", "-sort", "-Amount", "-limit", "2", "-"},
+			input: input,
+			want: "" +
+				"| Name | Amount |\n" +
+				"| --- | --- |\n" +
+				"| A | 10 |\n" +
+				"| B | 2.5 |\n",
+		},
+		{
+			name:  "convert to JSON",
+			args:  []string{"convert", "-to", "json", "-select", "Name", "-sort", "Name", "-"},
+			input: input,
+			want:  "[\n  {\"Name\": \"A\"},\n  {\"Name\": \"B\"},\n  {\"Name\": \"C\"}\n]\n",
+		},
+		{
+			name:  "JSON to TSV keeps key order",
+			args:  []string{"convert", "-to", "tsv", "-"},
+			input: `[{"b": 1, "a": {"x": [1]}}, {"a": null, "c": true}]`,
+			want:  "b\ta\tc\n1\tmap[x:[1]]\t\n\t\ttrue\n",
+		},
+		{
+			name:    "unknown column",
+			args:    []string{"convert", "-select", "Missing", "-"},
+			input:   input,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := run(context.Background(), tt.args, strings.NewReader(tt.input), &out, io.Discard)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestInspect(t *testing.T) {
+	var out bytes.Buffer
+	err := run(context.Background(), []string{"inspect", "-"}, strings.NewReader("Name,Amount,Date\nB,2.5,2024-01-02\nA,10,\n"), &out, io.Discard)
+	require.NoError(t, err)
+	require.Contains(t, out.String(), "Rows:    2\n")
+	require.Regexp(t, `Amount\s+float\s+2\s+2\s+2.5`, out.String())
+	require.Regexp(t, `Date\s+time\s+1\s+1\s+2024-01-02`, out.String())
+}
+
+func TestGuessType(t *testing.T) {
+	for str, want := range map[string]string{
+		"10":         "int",
+		"2.5":        "float",
+		"1E+5":       "float",
+		"1.2e-3":     "float",
+		"1,23E+05":   "float",
+		"1,2,3":      "string",
+		"true":       "bool",
+		"2024-01-02": "time",
+		"E+5":        "string",
+	} {
+		require.Equal(t, want, guessType(str), str)
+	}
+}
+
+func TestGen(t *testing.T) {
+	var out bytes.Buffer
+	input := "Invoice No.,Amount,Date,Paid,1st Note,Note\n1,2.5,2024-01-02,true,x,\n2,10,,false,,y\n"
+	err := run(context.Background(), []string{"gen", "-type", "Invoice", "-"}, strings.NewReader(input), &out, io.Discard)
+	require.NoError(t, err)
+	want := "package main\n\n" +
+		"import \"time\"\n\n" +
+		"type Invoice struct {\n" +
+		"\tInvoiceNo  int64      `col:\"Invoice No.\"`\n" +
+		"\tAmount     float64    `col:\"Amount\"`\n" +
+		"\tDate       *time.Time `col:\"Date\"`\n" +
+		"\tPaid       bool       `col:\"Paid\"`\n" +
+		"\tCol1stNote string     `col:\"1st Note\"`\n" +
+		"\tNote       string     `col:\"Note\"`\n" +
+		"}\n"
+	require.Equal(t, want, out.String())
+}
+
+func TestRun_usage(t *testing.T) {
+	for _, args := range [][]string{
+		nil,
+		{"unknown"},
+		{"convert", "-unknown", "-", "-"},
+		{"convert"},
+	} {
+		var stdout, stderr bytes.Buffer
+		err := run(context.Background(), args, strings.NewReader("A\n1\n"), &stdout, &stderr)
+		require.ErrorIs(t, err, flag.ErrHelp, "args %q", args)
+		require.Empty(t, stdout.String(), "no usage in output data for args %q", args)
+		require.NotEmpty(t, stderr.String(), "usage for args %q", args)
+	}
+}
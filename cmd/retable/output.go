@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/domonda/go-retable"
+	"github.com/domonda/go-retable/csvtable"
+	"github.com/domonda/go-retable/exceltable"
+	"github.com/domonda/go-retable/htmltable"
+	"github.com/domonda/go-retable/mdtable"
+)
+
+func writeOutput(ctx context.Context, dest io.Writer, view retable.View, format string) error {
+	switch format {
+	case "csv":
+		return csvtable.NewWriter[any]().WithHeaderRow(true).WithDelimiter(',').WithNewLine("\n").WriteView(ctx, dest, view)
+	case "tsv":
+		return csvtable.NewWriter[any]().WithHeaderRow(true).WithDelimiter('\t').WithNewLine("\n").WriteView(ctx, dest, view)
+	case "xlsx":
+		return exceltable.NewWriter[any]().WithHeaderRow(true).WithFreezeHeaderRow(true).WriteView(ctx, dest, view)
+	case "html":
+		return htmltable.NewWriter[any]().WithHeaderRow(true).WriteView(ctx, dest, view)
+	case "json":
+		return writeJSON(dest, view)
+	case "md":
+		return mdtable.WriteView(ctx, dest, view)
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
+
+// writeJSON writes the view as array of objects
+// with the columns as keys in column order.
+func writeJSON(dest io.Writer, view retable.View) error {
+	w := bufio.NewWriter(dest)
+	columns := view.Columns()
+	keys := make([][]byte, len(columns))
+	for col, column := range columns {
+		key, err := json.Marshal(column)
+		if err != nil {
+			return err
+		}
+		keys[col] = key
+	}
+	reflectView := retable.AsReflectCellView(view)
+	w.WriteString("[")
+	for row := 0; row < view.NumRows(); row++ {
+		if row > 0 {
+			w.WriteString(",")
+		}
+		w.WriteString("\n  {")
+		for col := range columns {
+			if col > 0 {
+				w.WriteString(", ")
+			}
+			var val any
+			if v := reflectView.ReflectCell(row, col); !retable.IsNullLike(v) {
+				val = v.Interface()
+			}
+			valJSON, err := json.Marshal(val)
+			if err != nil {
+				return err
+			}
+			w.Write(keys[col])
+			w.WriteString(": ")
+			w.Write(valJSON)
+		}
+		w.WriteString("}")
+	}
+	if view.NumRows() > 0 {
+		w.WriteString("\n")
+	}
+	w.WriteString("]\n")
+	return w.Flush()
+}
+
+// cellString returns the string representation of a cell
+// or an empty string for null values.
+func cellString(view retable.ReflectCellView, row, col int) string {
+	v := view.ReflectCell(row, col)
+	if retable.IsNullLike(v) {
+		return ""
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	return fmt.Sprint(v.Interface())
+}
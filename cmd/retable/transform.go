@@ -0,0 +1,78 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/domonda/go-retable"
+)
+
+func selectColumns(view retable.View, columns []string) (retable.View, error) {
+	mapping := make([]int, len(columns))
+	for i, column := range columns {
+		mapping[i] = slices.Index(view.Columns(), strings.TrimSpace(column))
+		if mapping[i] == -1 {
+			return nil, fmt.Errorf("column %q not found", column)
+		}
+	}
+	return retable.NewFilteredView(view, 0, 0, mapping...)
+}
+
+// sortRows sorts the rows by column, numerically if all values are numbers.
+// A "-" prefix sorts in descending order.
+func sortRows(view retable.View, column string) (retable.View, error) {
+	descending := strings.HasPrefix(column, "-")
+	column = strings.TrimPrefix(column, "-")
+	col := slices.Index(view.Columns(), column)
+	if col == -1 {
+		return nil, fmt.Errorf("sort column %q not found", column)
+	}
+
+	reflectView := retable.AsReflectCellView(view)
+	type sortRow struct {
+		index int
+		str   string
+		num   float64
+	}
+	rows := make([]sortRow, view.NumRows())
+	numeric := true
+	for row := range rows {
+		rows[row].index = row
+		rows[row].str = cellString(reflectView, row, col)
+		num, err := strconv.ParseFloat(rows[row].str, 64)
+		if err != nil && rows[row].str != "" {
+			numeric = false
+		}
+		rows[row].num = num
+	}
+	slices.SortStableFunc(rows, func(a, b sortRow) int {
+		c := cmp.Compare(a.str, b.str)
+		if numeric {
+			c = cmp.Compare(a.num, b.num)
+		}
+		if descending {
+			return -c
+		}
+		return c
+	})
+
+	sorted := &retable.AnyValuesView{
+		Tit:  view.Title(),
+		Cols: view.Columns(),
+		Rows: make([][]any, len(rows)),
+	}
+	for i, row := range rows {
+		sorted.Rows[i] = make([]any, len(sorted.Cols))
+		for c := range sorted.Cols {
+			sorted.Rows[i][c] = view.Cell(row.index, c)
+		}
+	}
+	return sorted, nil
+}
+
+func limitRows(view retable.View, limit int) retable.View {
+	return &retable.FilteredView{Source: view, RowLimit: limit}
+}
@@ -0,0 +1,99 @@
+package retable
+
+import (
+	"reflect"
+	"slices"
+)
+
+// DescriptionTag is the struct field tag
+// with the description of the column of the field:
+//
+//	IBAN string `col:"IBAN" desc:"International Bank Account Number"`
+const DescriptionTag = "desc"
+
+// ColumnDescriptionView is a View with descriptions of its columns
+// like the descriptions declared with the DescriptionTag
+// of struct fields.
+//
+// Writers render the descriptions as documentation
+// of the header cells like HTML title attributes
+// or Excel comments.
+type ColumnDescriptionView interface {
+	View
+
+	// ColumnDescription returns the description of the column
+	// or an empty string if the column has no description.
+	ColumnDescription(col int) string
+}
+
+// ViewColumnDescription returns the description of the column
+// if the view implements ColumnDescriptionView or else an empty string.
+func ViewColumnDescription(view View, col int) string {
+	if v, ok := view.(ColumnDescriptionView); ok {
+		return v.ColumnDescription(col)
+	}
+	return ""
+}
+
+// StructFieldDescription returns the value
+// of the DescriptionTag of the struct field.
+func StructFieldDescription(field reflect.StructField) string {
+	return field.Tag.Get(DescriptionTag)
+}
+
+// ViewWithColumnDescriptions returns a ColumnDescriptionView
+// that delegates all View methods to the source View
+// and returns the passed descriptions by column index.
+// Empty or missing descriptions use the description
+// of the source if it implements ColumnDescriptionView.
+//
+// Use Schema.Descriptions to pass the descriptions of a Schema.
+func ViewWithColumnDescriptions(source View, descriptions []string) ColumnDescriptionView {
+	return &columnDescriptionView{
+		source:       AsReflectCellView(source),
+		descriptions: slices.Clone(descriptions),
+	}
+}
+
+var (
+	_ ColumnFormatterView   = new(columnDescriptionView)
+	_ ColumnDescriptionView = new(columnDescriptionView)
+	_ ColumnVisibilityView  = new(columnDescriptionView)
+	_ SparseCellView        = new(columnDescriptionView)
+)
+
+type columnDescriptionView struct {
+	source       ReflectCellView
+	descriptions []string
+}
+
+func (v *columnDescriptionView) Title() string     { return v.source.Title() }
+func (v *columnDescriptionView) Columns() []string { return v.source.Columns() }
+func (v *columnDescriptionView) NumRows() int      { return v.source.NumRows() }
+
+func (v *columnDescriptionView) Cell(row, col int) any {
+	return v.source.Cell(row, col)
+}
+
+func (v *columnDescriptionView) ReflectCell(row, col int) reflect.Value {
+	return v.source.ReflectCell(row, col)
+}
+
+func (v *columnDescriptionView) ColumnDescription(col int) string {
+	if col >= 0 && col < len(v.descriptions) && v.descriptions[col] != "" {
+		return v.descriptions[col]
+	}
+	return ViewColumnDescription(v.source, col)
+}
+
+func (v *columnDescriptionView) ColumnHidden(col int) bool {
+	return ViewColumnHidden(v.source, col)
+}
+
+func (v *columnDescriptionView) ColumnFormatter(col int) CellFormatter {
+	return ViewColumnFormatter(v.source, col)
+}
+
+func (v *columnDescriptionView) CellExists(row, col int) bool {
+	return CellExists(v.source, row, col)
+}
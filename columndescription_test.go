@@ -0,0 +1,36 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewColumnDescription(t *testing.T) {
+	type Row struct {
+		IBAN     string `col:"IBAN" desc:"International Bank Account Number"`
+		Ignored  string `col:"-" desc:"Ignored"`
+		Currency string `col:"Currency"`
+	}
+	view, err := DefaultStructFieldNaming.NewView("", []Row{{IBAN: "AT611904300234573201", Currency: "EUR"}})
+	require.NoError(t, err)
+	require.Equal(t, "International Bank Account Number", ViewColumnDescription(view, 0))
+	require.Equal(t, "", ViewColumnDescription(view, 1))
+	require.Equal(t, "", ViewColumnDescription(view, 2), "out of range")
+
+	// Descriptions are forwarded by wrapping views
+	filtered := &FilteredView{Source: view, ColumnMapping: []int{1, 0}}
+	require.Equal(t, "", ViewColumnDescription(filtered, 0))
+	require.Equal(t, "International Bank Account Number", ViewColumnDescription(filtered, 1))
+	redacted := RedactColumnsView(view, DefaultRedactionPlaceholder, "IBAN")
+	require.Equal(t, "International Bank Account Number", ViewColumnDescription(redacted, 0))
+
+	schema := ViewSchema(view)
+	require.Equal(t, []string{"International Bank Account Number", ""}, schema.Descriptions())
+
+	// Explicit descriptions take precedence over the source
+	described := ViewWithColumnDescriptions(view, []string{"", "ISO 4217 currency code"})
+	require.Equal(t, "International Bank Account Number", ViewColumnDescription(described, 0))
+	require.Equal(t, "ISO 4217 currency code", ViewColumnDescription(described, 1))
+	require.Equal(t, "", ViewColumnDescription(&AnyValuesView{Cols: []string{"A"}}, 0))
+}
@@ -0,0 +1,108 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ColumnFormatterView is a View with cell formatters for columns
+// like the formats declared with the format option
+// of struct field tags, see StructFieldNaming.StructFieldFormat.
+//
+// Writers use the formatter of a column if no column formatter
+// was configured for the column of the writer.
+type ColumnFormatterView interface {
+	View
+
+	// ColumnFormatter returns the formatter for the column
+	// or nil if the column has no formatter.
+	ColumnFormatter(col int) CellFormatter
+}
+
+// ViewColumnFormatter returns the formatter for the column
+// if the view implements ColumnFormatterView or else nil.
+func ViewColumnFormatter(view View, col int) CellFormatter {
+	if v, ok := view.(ColumnFormatterView); ok {
+		return v.ColumnFormatter(col)
+	}
+	return nil
+}
+
+// ParseCellFormat parses a format specification
+// of the form "name" or "name:precision"
+// and returns the corresponding CellFormatter.
+//
+// Supported formats:
+//
+//	percent:2      0.1234 as "12.34%"
+//	bp:1           0.01234 as "123.4 bp" (basis points)
+//	float:2        0.1234 as "0.12"
+//
+// The precision is the number of decimal places
+// and defaults to zero for percent and bp
+// and to the shortest representation for float.
+func ParseCellFormat(spec string) (CellFormatter, error) {
+	name, precisionStr, hasPrecision := strings.Cut(spec, ":")
+	precision := 0
+	if hasPrecision {
+		p, err := strconv.Atoi(precisionStr)
+		if err != nil || p < 0 {
+			return nil, fmt.Errorf("invalid precision in cell format %q", spec)
+		}
+		precision = p
+	}
+	switch name {
+	case "percent":
+		return PercentCellFormatter(precision), nil
+	case "bp":
+		return BasisPointsCellFormatter(precision), nil
+	case "float":
+		if !hasPrecision {
+			precision = -1
+		}
+		return FloatCellFormatter{Precision: precision}, nil
+	}
+	return nil, fmt.Errorf("unknown cell format %q", spec)
+}
+
+// ScaledNumberCellFormatter formats integer and float cells
+// multiplied by Factor with a Suffix like percentages.
+// Null and non numeric cells return errors.ErrUnsupported.
+type ScaledNumberCellFormatter struct {
+	Factor float64
+	Suffix string
+	Float  FloatCellFormatter
+}
+
+func (f ScaledNumberCellFormatter) FormatCell(ctx context.Context, view View, row, col int) (str string, raw bool, err error) {
+	num, _, ok := numericCell(view, row, col)
+	if !ok {
+		return "", false, errors.ErrUnsupported
+	}
+	return f.Float.formatWithContext(ctx, num*f.Factor, 64) + f.Suffix, false, nil
+}
+
+// PercentCellFormatter returns a CellFormatter that formats
+// fractions as percentages with precision decimal places
+// like 0.1234 as "12.34%" for a precision of 2.
+func PercentCellFormatter(precision int) ScaledNumberCellFormatter {
+	return ScaledNumberCellFormatter{
+		Factor: 100,
+		Suffix: "%",
+		Float:  FloatCellFormatter{Precision: precision},
+	}
+}
+
+// BasisPointsCellFormatter returns a CellFormatter that formats
+// fractions as basis points with precision decimal places
+// like 0.0125 as "125 bp" for a precision of 0.
+func BasisPointsCellFormatter(precision int) ScaledNumberCellFormatter {
+	return ScaledNumberCellFormatter{
+		Factor: 10000,
+		Suffix: " bp",
+		Float:  FloatCellFormatter{Precision: precision},
+	}
+}
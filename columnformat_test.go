@@ -0,0 +1,69 @@
+package retable
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCellFormat(t *testing.T) {
+	tests := []struct {
+		spec    string
+		value   any
+		want    string
+		wantErr bool
+	}{
+		{spec: "percent", value: 0.123, want: "12%"},
+		{spec: "percent:2", value: 0.1234, want: "12.34%"},
+		{spec: "percent:1", value: 1, want: "100.0%"},
+		{spec: "bp", value: 0.0125, want: "125 bp"},
+		{spec: "bp:1", value: 0.01234, want: "123.4 bp"},
+		{spec: "float", value: 0.5, want: "0.5"},
+		{spec: "float:2", value: 0.5, want: "0.50"},
+		{spec: "percent:x", wantErr: true},
+		{spec: "percent:-1", wantErr: true},
+		{spec: "unknown", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			formatter, err := ParseCellFormat(tt.spec)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			view := &AnyValuesView{Cols: []string{"A"}, Rows: [][]any{{tt.value}}}
+			str, _, err := formatter.FormatCell(context.Background(), view, 0, 0)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, str)
+		})
+	}
+}
+
+func TestStructRowsViewer_ColumnFormatter(t *testing.T) {
+	type Row struct {
+		Name   string  `col:"Name"`
+		Margin float64 `col:"Margin,format=percent:2"`
+	}
+	naming := &StructFieldNaming{Tag: "col"}
+	view, err := naming.NewView("", []Row{{Name: "A", Margin: 0.1234}})
+	require.NoError(t, err)
+
+	require.Nil(t, ViewColumnFormatter(view, 0))
+	formatter := ViewColumnFormatter(view, 1)
+	require.NotNil(t, formatter)
+	str, _, err := formatter.FormatCell(context.Background(), view, 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, "12.34%", str)
+
+	// Redacted columns are not formatted
+	redacted := RedactColumnsView(view, "***", "Margin")
+	require.Nil(t, ViewColumnFormatter(redacted, 1))
+
+	type InvalidRow struct {
+		Margin float64 `col:"Margin,format=invalid"`
+	}
+	_, err = naming.NewView("", []InvalidRow{})
+	require.Error(t, err)
+}
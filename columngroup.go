@@ -0,0 +1,40 @@
+package retable
+
+import "fmt"
+
+// ColumnGroup is a group of consecutive columns with a common title
+// written by table writers as an additional header row
+// above the column titles.
+// For example "Q1" spanning the columns "Jan", "Feb", and "Mar".
+type ColumnGroup struct {
+	Title string
+	// NumColumns is the number of consecutive columns of the group
+	NumColumns int
+}
+
+// NormalizeColumnGroups returns groups spanning exactly numCols columns.
+// Columns after the last group are added as group with an empty title.
+// Use groups with empty titles for ungrouped columns between groups.
+// An error is returned if a group has less than one column
+// or if the groups span more than numCols columns.
+func NormalizeColumnGroups(groups []ColumnGroup, numCols int) ([]ColumnGroup, error) {
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	spanned := 0
+	for _, group := range groups {
+		if group.NumColumns < 1 {
+			return nil, fmt.Errorf("column group %q has %d columns", group.Title, group.NumColumns)
+		}
+		spanned += group.NumColumns
+	}
+	if spanned > numCols {
+		return nil, fmt.Errorf("column groups span %d columns but view has %d", spanned, numCols)
+	}
+	normalized := make([]ColumnGroup, len(groups), len(groups)+1)
+	copy(normalized, groups)
+	if spanned < numCols {
+		normalized = append(normalized, ColumnGroup{NumColumns: numCols - spanned})
+	}
+	return normalized, nil
+}
@@ -0,0 +1,34 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeColumnGroups(t *testing.T) {
+	tests := []struct {
+		name    string
+		groups  []ColumnGroup
+		numCols int
+		want    []ColumnGroup
+		wantErr bool
+	}{
+		{name: "no groups", groups: nil, numCols: 3, want: nil},
+		{name: "exact", groups: []ColumnGroup{{"Q1", 3}, {"Q2", 3}}, numCols: 6, want: []ColumnGroup{{"Q1", 3}, {"Q2", 3}}},
+		{name: "ungrouped rest", groups: []ColumnGroup{{"", 1}, {"Q1", 2}}, numCols: 5, want: []ColumnGroup{{"", 1}, {"Q1", 2}, {"", 2}}},
+		{name: "too many columns", groups: []ColumnGroup{{"Q1", 3}}, numCols: 2, wantErr: true},
+		{name: "empty group", groups: []ColumnGroup{{"Q1", 0}}, numCols: 2, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeColumnGroups(tt.groups, tt.numCols)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package retable
+
+import (
+	"slices"
+	"strings"
+)
+
+// ColumnOrder defines a deterministic order of columns
+// for viewers of unordered sources like maps
+// so that exports are reproducible across runs.
+//
+// Columns listed in Columns come first in the listed order,
+// followed by all other columns sorted by Compare.
+// A nil *ColumnOrder sorts all columns with strings.Compare.
+type ColumnOrder struct {
+	// Columns are placed first in this order
+	// if they are present.
+	Columns []string
+	// Compare sorts the columns not listed in Columns.
+	// If nil, then strings.Compare is used.
+	Compare func(a, b string) int
+	// OnlyListed drops all columns not listed in Columns.
+	OnlyListed bool
+}
+
+// NewColumnOrder returns a ColumnOrder that places
+// the passed columns first followed by the other
+// columns in lexical order.
+func NewColumnOrder(columns ...string) *ColumnOrder {
+	return &ColumnOrder{Columns: columns}
+}
+
+// Sort returns the passed columns in the defined order
+// as new slice without modifying the passed slice.
+func (o *ColumnOrder) Sort(columns []string) []string {
+	if o == nil {
+		sorted := slices.Clone(columns)
+		slices.Sort(sorted)
+		return sorted
+	}
+	sorted := make([]string, 0, len(columns))
+	var rest []string
+	for _, column := range o.Columns {
+		if slices.Contains(columns, column) && !slices.Contains(sorted, column) {
+			sorted = append(sorted, column)
+		}
+	}
+	if o.OnlyListed {
+		return sorted
+	}
+	for _, column := range columns {
+		if !slices.Contains(o.Columns, column) {
+			rest = append(rest, column)
+		}
+	}
+	compare := o.Compare
+	if compare == nil {
+		compare = strings.Compare
+	}
+	slices.SortStableFunc(rest, compare)
+	return append(sorted, rest...)
+}
+
+// View returns a view with the columns of source
+// in the defined order, for example to write views
+// of sources with unstable column order reproducibly.
+func (o *ColumnOrder) View(source View) *FilteredView {
+	columns := source.Columns()
+	sorted := o.Sort(columns)
+	mapping := make([]int, len(sorted))
+	for i, column := range sorted {
+		mapping[i] = slices.Index(columns, column)
+	}
+	return &FilteredView{Source: source, ColumnMapping: mapping}
+}
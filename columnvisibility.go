@@ -0,0 +1,213 @@
+package retable
+
+import (
+	"reflect"
+	"slices"
+)
+
+// HiddenTagOption is the option of the struct field tag
+// named StructFieldNaming.Tag that marks the column
+// of the field as hidden, see ColumnVisibilityView:
+//
+//	ID uuid.UUID `col:"ID,hidden"`
+const HiddenTagOption = "hidden"
+
+// ColumnVisibilityView is a View with hidden columns
+// like technical IDs that are needed for a re-import
+// but not by human readers.
+//
+// Writers for human readers like htmltable omit hidden columns
+// and data exchange formats like CSV include them by default.
+// Excel includes hidden columns as hidden sheet columns.
+type ColumnVisibilityView interface {
+	View
+
+	// ColumnHidden returns true if the column is hidden.
+	ColumnHidden(col int) bool
+}
+
+// ViewColumnHidden returns if the column is hidden
+// if the view implements ColumnVisibilityView or else false.
+func ViewColumnHidden(view View, col int) bool {
+	if v, ok := view.(ColumnVisibilityView); ok {
+		return v.ColumnHidden(col)
+	}
+	return false
+}
+
+// VisibleColumnsView returns a View of source without
+// the columns hidden by ViewColumnHidden.
+// If no column is hidden, then source is returned unchanged.
+//
+// The returned View forwards the ColumnFormatterView,
+// ColumnDescriptionView, and SparseCellView interfaces
+// of the visible source columns.
+// The row groups of a RowGroupsView source are kept.
+func VisibleColumnsView(source View) View {
+	if _, ok := source.(ColumnVisibilityView); !ok {
+		return source
+	}
+	numCols := len(source.Columns())
+	visible := make([]int, 0, numCols)
+	for col := range numCols {
+		if !ViewColumnHidden(source, col) {
+			visible = append(visible, col)
+		}
+	}
+	if len(visible) == numCols {
+		return source
+	}
+	columns := make([]string, len(visible))
+	for i, col := range visible {
+		columns[i] = source.Columns()[col]
+	}
+	view := &visibleColumnsView{source: AsReflectCellView(source), columns: columns, mapping: visible}
+	if groups, ok := source.(RowGroupsView); ok {
+		return &rowGroupsVisibleColumnsView{visibleColumnsView: view, groups: groups}
+	}
+	return view
+}
+
+var (
+	_ ColumnFormatterView   = new(visibleColumnsView)
+	_ ColumnDescriptionView = new(visibleColumnsView)
+	_ SparseCellView        = new(visibleColumnsView)
+	_ RowGroupsView         = new(rowGroupsVisibleColumnsView)
+)
+
+type visibleColumnsView struct {
+	source  ReflectCellView
+	columns []string
+	mapping []int // source column index of every visible column
+}
+
+func (v *visibleColumnsView) Title() string     { return v.source.Title() }
+func (v *visibleColumnsView) Columns() []string { return v.columns }
+func (v *visibleColumnsView) NumRows() int      { return v.source.NumRows() }
+
+// sourceCol returns the source column index of col or -1
+func (v *visibleColumnsView) sourceCol(col int) int {
+	if col < 0 || col >= len(v.mapping) {
+		return -1
+	}
+	return v.mapping[col]
+}
+
+func (v *visibleColumnsView) Cell(row, col int) any {
+	col = v.sourceCol(col)
+	if col < 0 {
+		return nil
+	}
+	return v.source.Cell(row, col)
+}
+
+func (v *visibleColumnsView) ReflectCell(row, col int) reflect.Value {
+	col = v.sourceCol(col)
+	if col < 0 {
+		return reflect.Value{}
+	}
+	return v.source.ReflectCell(row, col)
+}
+
+func (v *visibleColumnsView) ColumnFormatter(col int) CellFormatter {
+	col = v.sourceCol(col)
+	if col < 0 {
+		return nil
+	}
+	return ViewColumnFormatter(v.source, col)
+}
+
+func (v *visibleColumnsView) ColumnDescription(col int) string {
+	col = v.sourceCol(col)
+	if col < 0 {
+		return ""
+	}
+	return ViewColumnDescription(v.source, col)
+}
+
+func (v *visibleColumnsView) CellExists(row, col int) bool {
+	col = v.sourceCol(col)
+	if col < 0 {
+		return false
+	}
+	return CellExists(v.source, row, col)
+}
+
+type rowGroupsVisibleColumnsView struct {
+	*visibleColumnsView
+	groups RowGroupsView
+}
+
+func (v *rowGroupsVisibleColumnsView) RowGroup(row int) (RowGroup, bool) {
+	return v.groups.RowGroup(row)
+}
+
+// ViewWithHiddenColumns returns a ColumnVisibilityView
+// that delegates all View methods to the source View
+// and hides the columns with the passed titles
+// in addition to the hidden columns of the source.
+// The row groups of a RowGroupsView source are kept.
+func ViewWithHiddenColumns(source View, columns ...string) ColumnVisibilityView {
+	view := &hiddenColumnsView{
+		source:  AsReflectCellView(source),
+		columns: slices.Clone(columns),
+	}
+	if groups, ok := source.(RowGroupsView); ok {
+		return &rowGroupsHiddenColumnsView{hiddenColumnsView: view, groups: groups}
+	}
+	return view
+}
+
+var _ RowGroupsView = new(rowGroupsHiddenColumnsView)
+
+type rowGroupsHiddenColumnsView struct {
+	*hiddenColumnsView
+	groups RowGroupsView
+}
+
+func (v *rowGroupsHiddenColumnsView) RowGroup(row int) (RowGroup, bool) {
+	return v.groups.RowGroup(row)
+}
+
+var (
+	_ ColumnFormatterView   = new(hiddenColumnsView)
+	_ ColumnDescriptionView = new(hiddenColumnsView)
+	_ SparseCellView        = new(hiddenColumnsView)
+)
+
+type hiddenColumnsView struct {
+	source  ReflectCellView
+	columns []string
+}
+
+func (v *hiddenColumnsView) Title() string     { return v.source.Title() }
+func (v *hiddenColumnsView) Columns() []string { return v.source.Columns() }
+func (v *hiddenColumnsView) NumRows() int      { return v.source.NumRows() }
+
+func (v *hiddenColumnsView) Cell(row, col int) any {
+	return v.source.Cell(row, col)
+}
+
+func (v *hiddenColumnsView) ReflectCell(row, col int) reflect.Value {
+	return v.source.ReflectCell(row, col)
+}
+
+func (v *hiddenColumnsView) ColumnHidden(col int) bool {
+	columns := v.source.Columns()
+	if col >= 0 && col < len(columns) && slices.Contains(v.columns, columns[col]) {
+		return true
+	}
+	return ViewColumnHidden(v.source, col)
+}
+
+func (v *hiddenColumnsView) ColumnFormatter(col int) CellFormatter {
+	return ViewColumnFormatter(v.source, col)
+}
+
+func (v *hiddenColumnsView) ColumnDescription(col int) string {
+	return ViewColumnDescription(v.source, col)
+}
+
+func (v *hiddenColumnsView) CellExists(row, col int) bool {
+	return CellExists(v.source, row, col)
+}
@@ -0,0 +1,46 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVisibleColumnsView(t *testing.T) {
+	type Row struct {
+		ID          int    `col:"ID,hidden"`
+		Name        string `col:"Name"`
+		InternalRef string `col:"Ref,format=float:2,hidden"`
+	}
+	view, err := DefaultStructFieldNaming.NewView("", []Row{{ID: 1, Name: "A", InternalRef: "x"}})
+	require.NoError(t, err)
+	require.True(t, ViewColumnHidden(view, 0))
+	require.False(t, ViewColumnHidden(view, 1))
+	require.True(t, ViewColumnHidden(view, 2))
+	require.False(t, ViewColumnHidden(view, 3), "out of range")
+
+	visible := VisibleColumnsView(view)
+	require.Equal(t, []string{"Name"}, visible.Columns())
+	require.Equal(t, "A", visible.Cell(0, 0))
+
+	// Views without hidden columns are returned unchanged
+	plain := &AnyValuesView{Cols: []string{"A"}, Rows: [][]any{{1}}}
+	require.Same(t, plain, VisibleColumnsView(plain))
+	require.Same(t, visible, VisibleColumnsView(visible))
+
+	// Row groups and sparse cells of the source are kept
+	grouped := VisibleColumnsView(ViewWithHiddenColumns(NewGroupedRowsView(view, 1), "ID"))
+	require.Equal(t, []string{"Name"}, grouped.Columns())
+	require.Implements(t, (*RowGroupsView)(nil), grouped)
+	group, isHeaderRow := grouped.(RowGroupsView).RowGroup(0)
+	require.True(t, isHeaderRow)
+	require.Equal(t, "A", group.Key)
+	require.True(t, CellExists(grouped, 0, 0), "key column of group header row")
+	require.False(t, CellExists(grouped, 0, 1), "out of range")
+	require.Equal(t, "A", grouped.Cell(1, 0))
+
+	hidden := ViewWithHiddenColumns(plain, "A")
+	require.True(t, ViewColumnHidden(hidden, 0))
+	require.Empty(t, VisibleColumnsView(hidden).Columns())
+	require.True(t, ViewColumnHidden(RedactColumnsView(hidden, DefaultRedactionPlaceholder, "A"), 0))
+}
@@ -28,14 +28,25 @@ var (
 
 	// SelectViewer selects the best matching Viewer implementation
 	// for the passed table type.
-	// By default it returns a StringsViewer for a [][]string table
+	// By default it returns a StringsViewer for a [][]string
+	// or *[][]string table, a MapRowsViewer with DefaultColumnOrder
+	// for slices of maps with string keys,
 	// and the DefaultStructRowsViewer for all other cases.
 	SelectViewer = func(table any) (Viewer, error) {
-		if _, ok := table.([][]string); ok {
+		switch table.(type) {
+		case [][]string, *[][]string:
 			return new(StringsViewer), nil
 		}
+		if isMapRowsTable(table) {
+			return &MapRowsViewer{ColumnOrder: DefaultColumnOrder}, nil
+		}
 		return &DefaultStructFieldNaming, nil
 	}
+
+	// DefaultColumnOrder is the ColumnOrder of the MapRowsViewer
+	// returned by SelectViewer. The default nil value
+	// sorts the columns lexically.
+	DefaultColumnOrder *ColumnOrder
 )
 
 // DefaultStructRowsViewer returns a StructRowsViewer
@@ -0,0 +1,126 @@
+package retable
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+type contextKey int
+
+const (
+	localeContextKey contextKey = iota
+	timeLocationContextKey
+)
+
+// WithLocale returns a context with the locale
+// used by the bundled formatters to render numbers
+// so that per-request rendering preferences flow
+// through the context argument of CellFormatter.
+//
+// FloatCellFormatter and ScaledNumberCellFormatter use the
+// decimal separator of the locale and MoneyCellFormatter
+// uses its separators if none are configured.
+func WithLocale(ctx context.Context, locale language.Tag) context.Context {
+	return context.WithValue(ctx, localeContextKey, locale)
+}
+
+// LocaleFromContext returns the locale set with WithLocale
+// or language.Und and false if no locale was set.
+func LocaleFromContext(ctx context.Context) (locale language.Tag, ok bool) {
+	locale, ok = ctx.Value(localeContextKey).(language.Tag)
+	return locale, ok
+}
+
+// WithTimeLocation returns a context with the time location
+// used by the bundled formatters to render time.Time values
+// like NewDefaultFormatters and LayoutFormatter.
+// A nil location is ignored.
+func WithTimeLocation(ctx context.Context, loc *time.Location) context.Context {
+	if loc == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, timeLocationContextKey, loc)
+}
+
+// TimeLocationFromContext returns the time location
+// set with WithTimeLocation or nil.
+func TimeLocationFromContext(ctx context.Context) *time.Location {
+	loc, _ := ctx.Value(timeLocationContextKey).(*time.Location)
+	return loc
+}
+
+// TimeInContextLocation returns t in the time location
+// of the context or t unchanged if the context has none.
+func TimeInContextLocation(ctx context.Context, t time.Time) time.Time {
+	if loc := TimeLocationFromContext(ctx); loc != nil {
+		return t.In(loc)
+	}
+	return t
+}
+
+// LocaleNumberSeparators returns the thousands and decimal
+// separators used by the locale for numbers like
+// '.' and ',' for German or ',' and '.' for English.
+func LocaleNumberSeparators(locale language.Tag) (thousandsSep, decimalSep rune) {
+	// Render a number with grouping and decimals as "1,234.5"
+	// in the locale and pick the separators
+	formatted := []rune(message.NewPrinter(locale).Sprint(number.Decimal(1234.5)))
+	if len(formatted) != 7 || string(formatted[2:5]) != "234" {
+		return ',', '.'
+	}
+	return formatted[1], formatted[5]
+}
+
+// contextNumberSeparators returns the separators
+// of the locale of the context if it has one.
+func contextNumberSeparators(ctx context.Context) (thousandsSep, decimalSep rune, ok bool) {
+	locale, ok := LocaleFromContext(ctx)
+	if !ok {
+		return 0, 0, false
+	}
+	thousandsSep, decimalSep = LocaleNumberSeparators(locale)
+	return thousandsSep, decimalSep, true
+}
+
+// LocaleDateLayout returns the numeric date layout
+// commonly used by the locale like "01/02/2006" for American English,
+// "02.01.2006" for German, or time.DateOnly for unknown locales.
+func LocaleDateLayout(locale language.Tag) string {
+	if locale.IsRoot() {
+		return time.DateOnly
+	}
+	base, _ := locale.Base()
+	region, _ := locale.Region()
+	switch base.String() {
+	case "en":
+		if region.String() == "US" {
+			return "01/02/2006"
+		}
+		return "02/01/2006"
+	case "fr", "es", "it", "pt", "el", "vi", "ar", "he":
+		return "02/01/2006"
+	case "de", "ru", "pl", "tr", "cs", "sk", "fi", "da", "nb", "no", "uk", "ro":
+		return "02.01.2006"
+	case "nl":
+		return "02-01-2006"
+	case "ja", "zh":
+		return "2006/01/02"
+	}
+	return time.DateOnly
+}
+
+// LocaleTimeLayout returns the layout for the time of day
+// commonly used by the locale like "3:04:05 PM" for American English
+// or "15:04:05" for other locales.
+func LocaleTimeLayout(locale language.Tag) string {
+	base, _ := locale.Base()
+	region, _ := locale.Region()
+	if !locale.IsRoot() && base.String() == "en" && region.String() == "US" {
+		return "3:04:05 PM"
+	}
+	return "15:04:05"
+}
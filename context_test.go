@@ -0,0 +1,95 @@
+package retable
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestLocaleNumberSeparators(t *testing.T) {
+	tests := []struct {
+		locale           language.Tag
+		wantThousandsSep rune
+		wantDecimalSep   rune
+	}{
+		{locale: language.Und, wantThousandsSep: ',', wantDecimalSep: '.'},
+		{locale: language.English, wantThousandsSep: ',', wantDecimalSep: '.'},
+		{locale: language.German, wantThousandsSep: '.', wantDecimalSep: ','},
+		{locale: language.French, wantThousandsSep: '\u00a0', wantDecimalSep: ','},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale.String(), func(t *testing.T) {
+			thousandsSep, decimalSep := LocaleNumberSeparators(tt.locale)
+			require.Equal(t, string(tt.wantThousandsSep), string(thousandsSep))
+			require.Equal(t, string(tt.wantDecimalSep), string(decimalSep))
+		})
+	}
+}
+
+func TestContextFormatting(t *testing.T) {
+	ctx := WithLocale(context.Background(), language.German)
+	ctx = WithTimeLocation(ctx, time.FixedZone("UTC+2", 2*60*60))
+	view := &AnyValuesView{
+		Cols: []string{"Float", "Percent", "Money", "Time"},
+		Rows: [][]any{{1.5, 0.125, 1234.5, time.Date(2024, 10, 16, 22, 0, 0, 0, time.UTC)}},
+	}
+	formatters := []CellFormatter{
+		FloatCellFormatter{Precision: 2},
+		PercentCellFormatter(1),
+		MoneyCellFormatter{Precision: 2},
+		NewDefaultFormatters(),
+	}
+	for col, want := range []string{"1,50", "12,5%", "1.234,50", "2024-10-17"} {
+		str, _, err := formatters[col].FormatCell(ctx, view, 0, col)
+		require.NoError(t, err)
+		require.Equal(t, want, str)
+	}
+
+	str, _, err := LayoutFormatter(time.DateTime).FormatCell(ctx, view, 0, 3)
+	require.NoError(t, err)
+	require.Equal(t, "2024-10-17 00:00:00", str)
+
+	str, _, err = FloatCellFormatter{Precision: 2}.FormatCell(context.Background(), view, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, "1.50", str, "no locale in context")
+}
+
+func TestLocaleTimeFormatter(t *testing.T) {
+	view := &AnyValuesView{
+		Cols: []string{"Date", "Time"},
+		Rows: [][]any{{
+			time.Date(2024, 10, 16, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 10, 16, 14, 30, 5, 0, time.UTC),
+		}},
+	}
+	tests := []struct {
+		locale   language.Tag
+		wantDate string
+		wantTime string
+	}{
+		{locale: language.Und, wantDate: "2024-10-16", wantTime: "2024-10-16 14:30:05"},
+		{locale: language.AmericanEnglish, wantDate: "10/16/2024", wantTime: "10/16/2024 2:30:05 PM"},
+		{locale: language.BritishEnglish, wantDate: "16/10/2024", wantTime: "16/10/2024 14:30:05"},
+		{locale: language.German, wantDate: "16.10.2024", wantTime: "16.10.2024 14:30:05"},
+		{locale: language.Arabic, wantDate: "16/10/2024", wantTime: "16/10/2024 14:30:05"},
+		{locale: language.Japanese, wantDate: "2024/10/16", wantTime: "2024/10/16 14:30:05"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale.String(), func(t *testing.T) {
+			ctx := WithLocale(context.Background(), tt.locale)
+			str, _, err := LocaleTimeFormatter{}.FormatCell(ctx, view, 0, 0)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantDate, str)
+			str, _, err = LocaleTimeFormatter{}.FormatCell(ctx, view, 0, 1)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantTime, str)
+		})
+	}
+
+	str, _, err := LocaleTimeFormatter{}.FormatCell(context.Background(), view, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, "2024-10-16", str, "no locale in context")
+}
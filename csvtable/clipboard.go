@@ -0,0 +1,34 @@
+package csvtable
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/domonda/go-types/charset"
+)
+
+// ParseClipboard parses text copied from spreadsheet
+// applications like Excel or Google Sheets.
+//
+// The text has tab separated fields and "\r\n" or "\n" newlines.
+// Fields containing tabs, newlines, or quotes are quoted
+// and may span multiple lines.
+// Empty lines and a trailing newline as appended by Excel are ignored.
+func ParseClipboard(text []byte) (rows [][]string, err error) {
+	text = sanitizeUTF8(charset.TrimBOM(text, charset.BOMUTF8))
+	newline := []byte("\n")
+	if bytes.Contains(text, []byte("\r\n")) {
+		newline = []byte("\r\n")
+	}
+	text = bytes.TrimSuffix(text, newline)
+	if len(text) == 0 {
+		return nil, nil
+	}
+	rows, err = readLines(bytes.Split(text, newline), []byte("\t"), "\n")
+	if err != nil {
+		return nil, err
+	}
+	// Remove the rows of lines joined into multi-line fields
+	// and of empty lines
+	return slices.DeleteFunc(rows, func(row []string) bool { return row == nil }), nil
+}
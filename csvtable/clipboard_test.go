@@ -0,0 +1,48 @@
+package csvtable
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-retable"
+)
+
+func TestParseClipboard(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		wantRows [][]string
+	}{
+		{name: "empty", text: "", wantRows: nil},
+		{name: "excel", text: "A\tB\r\n1\t2\r\n", wantRows: [][]string{{"A", "B"}, {"1", "2"}}},
+		{name: "sheets", text: "A\tB\n1\t2", wantRows: [][]string{{"A", "B"}, {"1", "2"}}},
+		{name: "quoted newline", text: "A\tB\r\n\"x\ny\"\t2\r\n", wantRows: [][]string{{"A", "B"}, {"x\ny", "2"}}},
+		{name: "quoted newline LF", text: "A\tB\n\"x\ny\"\t2\n", wantRows: [][]string{{"A", "B"}, {"x\ny", "2"}}},
+		{name: "escaped quotes", text: "\"say \"\"hi\"\"\"\t2", wantRows: [][]string{{`say "hi"`, "2"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := ParseClipboard([]byte(tt.text))
+			require.NoError(t, err)
+			require.Equal(t, tt.wantRows, rows)
+		})
+	}
+}
+
+func TestNewClipboardWriter(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Cols: []string{"Name", "Note"},
+		Rows: [][]any{{"a\tb", "x\ny"}, {`say "hi"`, `5" screen`}},
+	}
+	var dest bytes.Buffer
+	err := NewClipboardWriter[any]().WriteView(context.Background(), &dest, view)
+	require.NoError(t, err)
+	require.Equal(t, "Name\tNote\r\n\"a\tb\"\t\"x\ny\"\r\n\"say \"\"hi\"\"\"\t\"5\"\" screen\"\r\n", dest.String())
+
+	rows, err := ParseClipboard(dest.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"Name", "Note"}, {"a\tb", "x\ny"}, {`say "hi"`, `5" screen`}}, rows)
+}
@@ -165,6 +165,9 @@ func detectFormatAndSplitLines(csv []byte, config *FormatDetectionConfig) (forma
 	}
 
 	if numNonEmptyLines == 0 {
+		// Use the default separator so that
+		// the returned format is valid
+		format.Separator = ","
 		return format, nil, nil
 	}
 
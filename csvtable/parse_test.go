@@ -2,6 +2,7 @@ package csvtable
 
 import (
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -308,3 +309,33 @@ func Test_parseSepHeaderLine(t *testing.T) {
 		})
 	}
 }
+
+func FuzzParseDetectFormat(f *testing.F) {
+	for csv := range testRows {
+		f.Add([]byte(csv))
+	}
+	f.Add([]byte(""))
+	f.Add([]byte("\n\n"))
+	f.Add([]byte("sep=;\nA;B\n1;2\n"))
+	f.Add([]byte("\xef\xbb\xbfA,B\r\n\"1\",\"2\"\r\n"))
+	f.Add([]byte("\"unterminated\n"))
+	f.Fuzz(func(t *testing.T, csv []byte) {
+		rows, format, err := ParseDetectFormat(csv, nil)
+		if err != nil {
+			return
+		}
+		if format == nil {
+			t.Fatal("nil format without error")
+		}
+		if err := format.Validate(); err != nil {
+			t.Fatalf("detected invalid format %#v: %s", format, err)
+		}
+		for _, row := range rows {
+			for _, cell := range row {
+				if !utf8.ValidString(cell) {
+					t.Fatalf("invalid UTF-8 cell %q", cell)
+				}
+			}
+		}
+	})
+}
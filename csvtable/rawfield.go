@@ -0,0 +1,84 @@
+package csvtable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/domonda/go-retable"
+)
+
+// ErrMalformedField is returned for raw CSV fields
+// that would corrupt the row structure.
+var ErrMalformedField = errors.New("malformed CSV field")
+
+// QuoteField returns str as quoted CSV field
+// with quotes escaped by doubling them.
+func QuoteField(str string) string {
+	return `"` + strings.ReplaceAll(str, `"`, `""`) + `"`
+}
+
+// ValidateRawField returns an error wrapping ErrMalformedField
+// if field is not a well formed CSV field for the delimiter.
+//
+// A well formed field is either unquoted without
+// delimiters, quotes, or line breaks,
+// or enclosed in quotes with inner quotes doubled.
+func ValidateRawField(field string, delimiter rune) error {
+	if !strings.HasPrefix(field, `"`) {
+		if strings.ContainsRune(field, delimiter) || strings.ContainsAny(field, "\"\r\n") {
+			return fmt.Errorf("%w: unquoted field %q", ErrMalformedField, field)
+		}
+		return nil
+	}
+	if len(field) < 2 || !strings.HasSuffix(field, `"`) {
+		return fmt.Errorf("%w: unterminated quoted field %q", ErrMalformedField, field)
+	}
+	inner := field[1 : len(field)-1]
+	if strings.Count(strings.ReplaceAll(inner, `""`, ""), `"`) > 0 {
+		return fmt.Errorf("%w: unescaped quote in field %q", ErrMalformedField, field)
+	}
+	return nil
+}
+
+// RawField returns field as raw result for a CellFormatter
+// if it is well formed for the delimiter
+// according to ValidateRawField.
+func RawField(field string, delimiter rune) (str string, raw bool, err error) {
+	if err := ValidateRawField(field, delimiter); err != nil {
+		return "", false, err
+	}
+	return field, true, nil
+}
+
+// RawFieldFormatter returns a CellFormatter that returns
+// the strings of formatter as raw CSV fields
+// after validating them with ValidateRawField
+// so that exact strings like numbers are written unchanged.
+// Malformed fields result in a retable.CellError.
+func RawFieldFormatter(formatter retable.CellFormatter, delimiter rune) retable.CellFormatter {
+	return retable.CellFormatterFunc(func(ctx context.Context, view retable.View, row, col int) (string, bool, error) {
+		str, _, err := formatter.FormatCell(ctx, view, row, col)
+		if err != nil {
+			return "", false, err
+		}
+		str, raw, err := RawField(str, delimiter)
+		if err != nil {
+			return "", false, retable.NewCellError(view, row, col, err)
+		}
+		return str, raw, nil
+	})
+}
+
+// QuotedFieldFormatter returns a CellFormatter that returns
+// the strings of formatter as quoted raw CSV fields.
+func QuotedFieldFormatter(formatter retable.CellFormatter) retable.CellFormatter {
+	return retable.CellFormatterFunc(func(ctx context.Context, view retable.View, row, col int) (string, bool, error) {
+		str, _, err := formatter.FormatCell(ctx, view, row, col)
+		if err != nil {
+			return "", false, err
+		}
+		return QuoteField(str), true, nil
+	})
+}
@@ -0,0 +1,74 @@
+package csvtable
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/domonda/go-retable"
+)
+
+func TestValidateRawField(t *testing.T) {
+	tests := []struct {
+		field   string
+		wantErr bool
+	}{
+		{field: ""},
+		{field: "1.50"},
+		{field: `""`},
+		{field: `"a;b"`},
+		{field: `"say ""hi"""`},
+		{field: "\"multi\nline\""},
+		{field: "a;b", wantErr: true},
+		{field: `a"b`, wantErr: true},
+		{field: "a\nb", wantErr: true},
+		{field: `"`, wantErr: true},
+		{field: `"abc`, wantErr: true},
+		{field: `"a"b"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		err := ValidateRawField(tt.field, ';')
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateRawField(%q) error = %v, wantErr %v", tt.field, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrMalformedField) {
+			t.Errorf("ValidateRawField(%q) error = %v, want ErrMalformedField", tt.field, err)
+		}
+	}
+}
+
+func TestQuoteField(t *testing.T) {
+	for _, str := range []string{"", "a;b", `say "hi"`, "multi\nline"} {
+		if err := ValidateRawField(QuoteField(str), ';'); err != nil {
+			t.Errorf("QuoteField(%q) = %q is malformed: %v", str, QuoteField(str), err)
+		}
+	}
+}
+
+func TestRawFieldFormatter(t *testing.T) {
+	view := &retable.StringsView{
+		Cols: []string{"Amount", "Text"},
+		Rows: [][]string{{"1.50", "a;b"}},
+	}
+	writer := NewWriter[any]().
+		WithNewLine("\n").
+		WithQuoteAllFields(true).
+		WithColumnFormatter(0, RawFieldFormatter(retable.SprintCellFormatter(false), ';')).
+		WithColumnFormatter(1, QuotedFieldFormatter(retable.SprintCellFormatter(false)))
+	var dest bytes.Buffer
+	err := writer.WriteView(context.Background(), &dest, view)
+	if err != nil {
+		t.Fatalf("Writer.WriteView() error = %v", err)
+	}
+	if want := "1.50;\"a;b\"\n"; dest.String() != want {
+		t.Errorf("Writer.WriteView() wrote %q but want %q", dest.String(), want)
+	}
+
+	writer = writer.WithColumnFormatter(1, RawFieldFormatter(retable.SprintCellFormatter(false), ';'))
+	err = writer.WriteView(context.Background(), &dest, view)
+	var cellErr retable.CellError
+	if !errors.As(err, &cellErr) || cellErr.Col != 1 || !errors.Is(err, ErrMalformedField) {
+		t.Errorf("expected CellError with ErrMalformedField for column 1, got %v", err)
+	}
+}
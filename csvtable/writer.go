@@ -7,8 +7,9 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"slices"
 	"strings"
-	"unicode/utf8"
+	"time"
 
 	"github.com/domonda/go-retable"
 )
@@ -47,13 +48,48 @@ type Writer[T any] struct {
 	formatters       *retable.ReflectTypeCellFormatter
 	padding          Padding
 	headerRow        bool
+	repeatHeader     int
 	quoteAllFields   bool
 	quoteEmptyFields bool
+	quoteQuotes      bool
+	trimTrailing     bool
 	escapeQuotes     string
-	nilValue         string
+	nullPolicy       retable.NullPolicy
+	columnNulls      retable.ColumnNullPolicies
+	errorPolicy      retable.ErrorPolicy
 	delimiter        rune
 	newLine          string
 	encoder          Encoder
+	redactedColumns  []string
+	redaction        string
+	hooks            *retable.WriteHooks
+	metrics          retable.MetricsRecorder
+	maxRows          int
+	maxBytes         int64
+	truncationMarker string
+	widthOptions     *retable.WidthOptions
+	provenance       *retable.Provenance
+	titleComment     bool
+	rowTimeout       time.Duration
+	recoverPanics    bool
+	pointerHandling  retable.PointerHandling
+	zeroAsNull       retable.ZeroAsNull
+	includeHidden    bool
+	rawPolicy        retable.RawPolicy
+}
+
+// NewClipboardWriter returns a Writer for text that can be
+// pasted into spreadsheet applications like Excel or Google Sheets:
+// tab separated values with a header row and "\r\n" newlines
+// where fields containing tabs, newlines, or quotes are quoted.
+//
+// Use ParseClipboard to parse text copied from spreadsheets.
+func NewClipboardWriter[T any]() *Writer[T] {
+	return NewWriter[T]().
+		WithHeaderRow(true).
+		WithDelimiter('\t').
+		WithNewLine("\r\n").
+		WithQuoteFieldsWithQuotes(true)
 }
 
 func NewWriter[T any]() *Writer[T] {
@@ -62,13 +98,34 @@ func NewWriter[T any]() *Writer[T] {
 		formatters:       nil, // OK to use nil retable.TypeFormatters
 		padding:          NoPadding,
 		headerRow:        false,
+		repeatHeader:     0,
 		quoteAllFields:   false,
 		quoteEmptyFields: false,
+		quoteQuotes:      false,
+		trimTrailing:     false,
 		escapeQuotes:     `""`,
-		nilValue:         "",
+		nullPolicy:       retable.RenderNullAs(""),
+		columnNulls:      retable.ColumnNullPolicies{},
+		errorPolicy:      retable.ErrorPolicy{},
 		delimiter:        ';',
 		newLine:          "\r\n",
 		encoder:          nil,
+		redactedColumns:  nil,
+		redaction:        retable.DefaultRedactionPlaceholder,
+		hooks:            nil,
+		metrics:          nil,
+		maxRows:          0,
+		maxBytes:         0,
+		truncationMarker: "",
+		widthOptions:     nil,
+		provenance:       nil,
+		titleComment:     false,
+		rowTimeout:       0,
+		recoverPanics:    false,
+		pointerHandling:  retable.PointerKeep,
+		zeroAsNull:       retable.ZeroAsNull{},
+		includeHidden:    true,
+		rawPolicy:        retable.RawTrust,
 	}
 }
 
@@ -78,9 +135,21 @@ func (w *Writer[T]) clone() *Writer[T] {
 	return c
 }
 
-// Write calls WriteView with the result of Viewer.NewView(table)
+// Capabilities returns the capabilities of the CSV writer.
+// Rows are only streamed without padding because
+// padded columns require all rows to be formatted first.
+func (w *Writer[T]) Capabilities() retable.WriterCapabilities {
+	return retable.WriterCapabilities{
+		Format:    "csv",
+		Streaming: w.padding == NoPadding,
+	}
+}
+
+// Write calls WriteView with the result of Viewer.NewView(title, table)
 // using the writer's viewer if not nil or else retable.DefaultViewer.
-func (w *Writer[T]) Write(ctx context.Context, dest io.Writer, table T) error {
+// Multiple title strings are joined with a space.
+// The title is only written if enabled with WithTitleComment.
+func (w *Writer[T]) Write(ctx context.Context, dest io.Writer, table T, title ...string) error {
 	viewer := w.viewer
 	if viewer == nil {
 		var err error
@@ -89,12 +158,13 @@ func (w *Writer[T]) Write(ctx context.Context, dest io.Writer, table T) error {
 			return err
 		}
 	}
-	return w.WriteWithViewer(ctx, dest, viewer, table)
+	return w.WriteWithViewer(ctx, dest, viewer, table, title...)
 }
 
-// WriteWithViewer calls WriteView with the result of viewer.NewView(table).
-func (w *Writer[T]) WriteWithViewer(ctx context.Context, dest io.Writer, viewer retable.Viewer, table T) error {
-	view, err := viewer.NewView("", table)
+// WriteWithViewer calls WriteView with the result of viewer.NewView(title, table).
+// Multiple title strings are joined with a space.
+func (w *Writer[T]) WriteWithViewer(ctx context.Context, dest io.Writer, viewer retable.Viewer, table T, title ...string) error {
+	view, err := viewer.NewView(strings.Join(title, " "), table)
 	if err != nil {
 		return err
 	}
@@ -102,28 +172,146 @@ func (w *Writer[T]) WriteWithViewer(ctx context.Context, dest io.Writer, viewer
 }
 
 // WriteView writes the view to dest as formatted as CSV.
-func (w *Writer[T]) WriteView(ctx context.Context, dest io.Writer, view retable.View) error {
+//
+// An OpenTelemetry span is created for the write
+// and metrics are recorded if a MetricsRecorder is set.
+func (w *Writer[T]) WriteView(ctx context.Context, dest io.Writer, view retable.View) (err error) {
+	ctx, iw := retable.StartInstrumentedWrite(ctx, w.metrics, "csv", view.Title(), dest)
+	defer func() { err = iw.End(ctx, err) }()
+
+	hooks := iw.Hooks(w.hooks)
+	if !w.includeHidden {
+		view = retable.VisibleColumnsView(view)
+	}
+	view = retable.RedactColumnsView(view, w.redaction, w.redactedColumns...)
+	view = w.pointerHandling.View(view)
+	view = w.zeroAsNull.View(view)
+	numRows, truncate, err := w.rowLimit(view)
+	if err != nil {
+		return err
+	}
+
+	out := &retable.MaxBytesWriter{Dest: iw, Max: w.maxBytes}
+	if w.titleComment && view.Title() != "" {
+		err = w.writeComment(out, view.Title())
+		if err != nil {
+			return err
+		}
+	}
+	err = w.writeProvenance(out, numRows)
+	if err != nil {
+		return err
+	}
 	if w.padding != NoPadding {
-		return w.writeViewPadded(ctx, dest, view)
+		err = w.writeViewPadded(ctx, out, view, numRows, hooks)
+	} else {
+		err = w.writeViewRows(ctx, out, view, numRows, hooks)
+	}
+	if errors.As(err, new(retable.ErrMaxOutputBytesExceeded)) && w.truncationMarker != "" {
+		err, truncate = nil, true
 	}
+	if err != nil || !truncate {
+		return err
+	}
+	return w.writeTruncationMarker(iw, len(view.Columns()))
+}
 
-	if w.headerRow {
-		err := w.writeView(ctx, dest, retable.NewHeaderViewFrom(view))
+// rowLimit returns the number of rows of view to write
+// and if the rows are truncated because of maxRows,
+// or ErrMaxRowsExceeded if no truncation marker is set.
+func (w *Writer[T]) rowLimit(view retable.View) (numRows int, truncate bool, err error) {
+	numRows = view.NumRows()
+	if w.maxRows <= 0 || numRows <= w.maxRows {
+		return numRows, false, nil
+	}
+	if w.truncationMarker == "" {
+		return 0, false, retable.ErrMaxRowsExceeded{Max: w.maxRows, NumRows: numRows}
+	}
+	return w.maxRows, true, nil
+}
+
+// writeTruncationMarker writes a row with the truncation marker
+// in the first column and empty other columns.
+func (w *Writer[T]) writeTruncationMarker(dest io.Writer, numCols int) error {
+	row := w.escapeString(w.truncationMarker, false) +
+		strings.Repeat(string(w.delimiter), max(numCols-1, 0)) +
+		w.newLine
+	data := []byte(row)
+	if w.encoder != nil {
+		var err error
+		data, err = w.encoder.Bytes(data)
+		if err != nil {
+			return err
+		}
+	}
+	_, err := dest.Write(data)
+	return err
+}
+
+// writeProvenance writes the provenance fields
+// as comment lines starting with "# ".
+func (w *Writer[T]) writeProvenance(dest io.Writer, numRows int) error {
+	for _, field := range w.provenance.Fields(numRows) {
+		err := w.writeComment(dest, field.String())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeComment writes text as single comment line
+// starting with "# " with line breaks replaced by spaces.
+func (w *Writer[T]) writeComment(dest io.Writer, text string) error {
+	data := []byte("# " + commentLineReplacer.Replace(text) + w.newLine)
+	if w.encoder != nil {
+		var err error
+		data, err = w.encoder.Bytes(data)
 		if err != nil {
 			return err
 		}
 	}
-	return w.writeView(ctx, dest, view)
+	_, err := dest.Write(data)
+	return err
 }
 
-func (w *Writer[T]) writeView(ctx context.Context, dest io.Writer, view retable.View) error {
-	rowBuf := bytes.NewBuffer(make([]byte, 0, 1024))
-	for row, numRows := 0, view.NumRows(); row < numRows; row++ {
-		err := w.writeRow(ctx, rowBuf, view, row)
+var commentLineReplacer = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+
+func (w *Writer[T]) writeViewRows(ctx context.Context, dest io.Writer, view retable.View, numRows int, hooks *retable.WriteHooks) error {
+	if !w.headerRow {
+		return w.writeView(ctx, dest, view, 0, numRows, hooks)
+	}
+	header := retable.NewHeaderViewFrom(view)
+	// At least one chunk to write the header of views without rows
+	chunkRows := max(numRows, 1)
+	if w.repeatHeader > 0 {
+		chunkRows = w.repeatHeader
+	}
+	for firstRow := 0; firstRow == 0 || firstRow < numRows; firstRow += chunkRows {
+		err := w.writeView(ctx, dest, header, 0, 1, nil)
 		if err != nil {
 			return err
 		}
-		_, err = dest.Write(rowBuf.Bytes())
+		err = w.writeView(ctx, dest, view, firstRow, min(firstRow+chunkRows, numRows), hooks)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeView writes the rows from firstRow to endRow exclusive
+// of view to dest calling hooks that are nil for header views.
+func (w *Writer[T]) writeView(ctx context.Context, dest io.Writer, view retable.View, firstRow, endRow int, hooks *retable.WriteHooks) error {
+	rowBuf := retable.GetRowBuffer()
+	defer retable.PutRowBuffer(rowBuf)
+	for row := firstRow; row < endRow; row++ {
+		hooks.RowStart(ctx, view, row)
+		err := w.writeRow(ctx, rowBuf, view, row, hooks)
+		if err == nil {
+			_, err = dest.Write(rowBuf.Bytes())
+		}
+		hooks.RowEnd(ctx, view, row, err)
 		if err != nil {
 			return err
 		}
@@ -132,7 +320,17 @@ func (w *Writer[T]) writeView(ctx context.Context, dest io.Writer, view retable.
 	return nil
 }
 
-func (w *Writer[T]) writeRow(ctx context.Context, rowBuf *bytes.Buffer, view retable.View, row int) error {
+func (w *Writer[T]) writeRow(ctx context.Context, rowBuf *bytes.Buffer, view retable.View, row int, hooks *retable.WriteHooks) error {
+	cellString := func(col int) (string, error) {
+		return w.hookedCellString(ctx, view, row, col, hooks)
+	}
+	if w.rowTimeout > 0 {
+		rowStrs, err := w.timedRowStrings(ctx, view, row, hooks)
+		if err != nil {
+			return err
+		}
+		cellString = func(col int) (string, error) { return rowStrs[col], nil }
+	}
 	for col := range view.Columns() {
 		if col > 0 {
 			_, err := rowBuf.WriteRune(w.delimiter)
@@ -140,7 +338,7 @@ func (w *Writer[T]) writeRow(ctx context.Context, rowBuf *bytes.Buffer, view ret
 				return err
 			}
 		}
-		str, err := w.cellString(ctx, view, row, col)
+		str, err := cellString(col)
 		if err != nil {
 			return err
 		}
@@ -168,100 +366,123 @@ func (w *Writer[T]) writeRow(ctx context.Context, rowBuf *bytes.Buffer, view ret
 	return err
 }
 
-func (w *Writer[T]) writeViewPadded(ctx context.Context, dest io.Writer, view retable.View) error {
-	rows, err := w.ViewStrings(ctx, view)
+func (w *Writer[T]) writeViewPadded(ctx context.Context, dest io.Writer, view retable.View, numRows int, hooks *retable.WriteHooks) error {
+	rows, err := w.viewStrings(ctx, view, numRows, hooks)
 	if err != nil {
 		return err
 	}
 
 	// Collect column widths
-	colRuneCount := retable.StringColumnWidths(rows, len(view.Columns()))
+	colWidths := retable.MeasureColumnWidths(rows, len(view.Columns()), w.widthOptions)
 
-	rowBuf := bytes.NewBuffer(make([]byte, 0, 1024))
+	rowBuf := retable.GetRowBuffer()
+	defer retable.PutRowBuffer(rowBuf)
 	for row := range rows {
-		for col, str := range rows[row] {
-			if col > 0 {
-				_, err := rowBuf.WriteRune(w.delimiter)
-				if err != nil {
-					return err
-				}
-			}
-			var (
-				padTotal = colRuneCount[col] - utf8.RuneCountInString(str)
-				padLeft  = 0
-				padRight = 0
-			)
-			switch w.padding {
-			case AlignLeft:
-				padRight = padTotal
-			case AlignRight:
-				padLeft = padTotal
-			case AlignCenter:
-				padLeft = padTotal / 2
-				padRight = (padTotal + 1) / 2
-			}
-			for i := 0; i < padLeft; i++ {
-				err := rowBuf.WriteByte(' ')
-				if err != nil {
-					return err
-				}
-			}
-			_, err := rowBuf.WriteString(str)
+		if w.headerRow && w.repeatHeader > 0 && row > 1 && (row-1)%w.repeatHeader == 0 {
+			// Repeat the header row before every repeatHeader data rows
+			err = w.writePaddedRow(dest, rowBuf, rows[0], colWidths)
 			if err != nil {
 				return err
 			}
-			for i := 0; i < padRight; i++ {
-				err = rowBuf.WriteByte(' ')
-				if err != nil {
-					return err
-				}
-			}
 		}
-		_, err := rowBuf.WriteString(w.newLine)
+		err = w.writePaddedRow(dest, rowBuf, rows[row], colWidths)
 		if err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		if w.encoder != nil {
-			// Read, encode, and write back the buffered row
-			encoded, err := w.encoder.Bytes(rowBuf.Bytes())
+// writePaddedRow writes the padded cell strings
+// of a row to dest using rowBuf as buffer.
+func (w *Writer[T]) writePaddedRow(dest io.Writer, rowBuf *bytes.Buffer, rowStrs []string, colWidths []int) error {
+	defer rowBuf.Reset()
+	for col, str := range rowStrs {
+		if col > 0 {
+			_, err := rowBuf.WriteRune(w.delimiter)
+			if err != nil {
+				return err
+			}
+		}
+		var (
+			padTotal = colWidths[col] - retable.StringWidth(str, w.widthOptions)
+			padLeft  = 0
+			padRight = 0
+		)
+		switch w.padding {
+		case AlignLeft:
+			padRight = padTotal
+		case AlignRight:
+			padLeft = padTotal
+		case AlignCenter:
+			padLeft = padTotal / 2
+			padRight = (padTotal + 1) / 2
+		}
+		for i := 0; i < padLeft; i++ {
+			err := rowBuf.WriteByte(' ')
 			if err != nil {
 				return err
 			}
-			rowBuf.Reset()
-			_, err = rowBuf.Write(encoded)
+		}
+		_, err := rowBuf.WriteString(str)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < padRight; i++ {
+			err = rowBuf.WriteByte(' ')
 			if err != nil {
 				return err
 			}
 		}
+	}
+	_, err := rowBuf.WriteString(w.newLine)
+	if err != nil {
+		return err
+	}
 
-		_, err = dest.Write(rowBuf.Bytes())
+	if w.encoder != nil {
+		// Read, encode, and write back the buffered row
+		encoded, err := w.encoder.Bytes(rowBuf.Bytes())
 		if err != nil {
 			return err
 		}
 		rowBuf.Reset()
+		_, err = rowBuf.Write(encoded)
+		if err != nil {
+			return err
+		}
 	}
 
-	return nil
+	_, err = dest.Write(rowBuf.Bytes())
+	return err
 }
 
 // ViewStrings returns the view formatted as a slice of string slices.
 func (w *Writer[T]) ViewStrings(ctx context.Context, view retable.View) ([][]string, error) {
-	var (
-		numRows = view.NumRows()
-		rows    = make([][]string, 0, numRows+1)
-	)
+	if !w.includeHidden {
+		view = retable.VisibleColumnsView(view)
+	}
+	view = retable.RedactColumnsView(view, w.redaction, w.redactedColumns...)
+	view = w.pointerHandling.View(view)
+	view = w.zeroAsNull.View(view)
+	return w.viewStrings(ctx, view, view.NumRows(), w.hooks)
+}
+
+func (w *Writer[T]) viewStrings(ctx context.Context, view retable.View, numRows int, hooks *retable.WriteHooks) ([][]string, error) {
+	rows := make([][]string, 0, numRows+1)
 	if w.headerRow {
 		// view.Columns() already returns a string slice,
 		// but use HeaderView for any potential formatting
-		rowStrs, err := w.rowStrings(ctx, retable.NewHeaderViewFrom(view), 0)
+		rowStrs, err := w.rowStrings(ctx, retable.NewHeaderViewFrom(view), 0, nil)
 		if err != nil {
 			return nil, err
 		}
 		rows = append(rows, rowStrs)
 	}
 	for row := 0; row < numRows; row++ {
-		rowStrs, err := w.rowStrings(ctx, view, row)
+		hooks.RowStart(ctx, view, row)
+		rowStrs, err := w.timedRowStrings(ctx, view, row, hooks)
+		hooks.RowEnd(ctx, view, row, err)
 		if err != nil {
 			return nil, err
 		}
@@ -270,12 +491,32 @@ func (w *Writer[T]) ViewStrings(ctx context.Context, view retable.View) ([][]str
 	return rows, nil
 }
 
-func (w *Writer[T]) rowStrings(ctx context.Context, view retable.View, row int) ([]string, error) {
+// timedRowStrings returns the result of rowStrings
+// called with the row timeout of the writer.
+func (w *Writer[T]) timedRowStrings(ctx context.Context, view retable.View, row int, hooks *retable.WriteHooks) ([]string, error) {
+	if w.rowTimeout <= 0 {
+		return w.rowStrings(ctx, view, row, hooks)
+	}
+	var rowStrs []string
+	err := retable.CallWithRowTimeout(ctx, row, w.rowTimeout, func(ctx context.Context) (err error) {
+		rowStrs, err = w.rowStrings(ctx, view, row, hooks)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rowStrs, nil
+}
+
+func (w *Writer[T]) rowStrings(ctx context.Context, view retable.View, row int, hooks *retable.WriteHooks) ([]string, error) {
 	columns := view.Columns()
 	rowStrs := make([]string, len(columns))
 	for col := range columns {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		var err error
-		rowStrs[col], err = w.cellString(ctx, view, row, col)
+		rowStrs[col], err = w.hookedCellString(ctx, view, row, col, hooks)
 		if err != nil {
 			return nil, err
 		}
@@ -283,12 +524,45 @@ func (w *Writer[T]) rowStrings(ctx context.Context, view retable.View, row int)
 	return rowStrs, nil
 }
 
+// hookedCellString calls cellString reporting its latency to hooks.
+// If cellString fails and the error policy aborts,
+// then the error is passed to hooks.CellError
+// and an empty cell string is returned if it returns nil.
+func (w *Writer[T]) hookedCellString(ctx context.Context, view retable.View, row, col int, hooks *retable.WriteHooks) (string, error) {
+	start := hooks.CellStart()
+	str, err := w.cellString(ctx, view, row, col)
+	hooks.CellEnd(ctx, view, row, col, start)
+	if err != nil {
+		str, isRaw, policyErr := w.errorPolicy.FormatError(view, row, col, err)
+		if policyErr == nil {
+			return w.escapeString(str, isRaw), nil
+		}
+		if err = hooks.CellError(ctx, view, row, col, err); err != nil {
+			return "", retable.NewCellError(view, row, col, err)
+		}
+		return w.escapeString("", false), nil
+	}
+	return str, nil
+}
+
 func (w *Writer[T]) cellString(ctx context.Context, view retable.View, row, col int) (string, error) {
 	if ctx.Err() != nil {
 		return "", ctx.Err()
 	}
+	if !retable.CellExists(view, row, col) {
+		return w.escapeString("", false), nil
+	}
 
-	if colFormatter, ok := w.columnFormatters[col]; ok {
+	colFormatter, ok := w.columnFormatters[col]
+	if !ok {
+		colFormatter = retable.ViewColumnFormatter(view, col)
+		ok = colFormatter != nil
+	}
+	colFormatter = w.rawPolicy.WrapFormatter(colFormatter)
+	if ok && w.recoverPanics {
+		colFormatter = retable.SafeCellFormatter(colFormatter)
+	}
+	if ok {
 		str, isRaw, err := colFormatter.FormatCell(ctx, view, row, col)
 		if err == nil {
 			return w.escapeString(str, isRaw), nil
@@ -299,7 +573,11 @@ func (w *Writer[T]) cellString(ctx context.Context, view retable.View, row, col
 		// Continue after errors.ErrUnsupported
 	}
 
-	str, isRaw, err := w.formatters.FormatCell(ctx, view, row, col)
+	typeFormatter := w.rawPolicy.WrapFormatter(w.formatters)
+	if w.recoverPanics {
+		typeFormatter = retable.SafeCellFormatter(typeFormatter)
+	}
+	str, isRaw, err := typeFormatter.FormatCell(ctx, view, row, col)
 	if err == nil {
 		return w.escapeString(str, isRaw), nil
 	}
@@ -311,7 +589,18 @@ func (w *Writer[T]) cellString(ctx context.Context, view retable.View, row, col
 	// Use fallback methods for formatting
 	v := retable.AsReflectCellView(view).ReflectCell(row, col)
 	if retable.IsNullLike(v) {
-		return w.escapeString(w.nilValue, false), nil
+		str, isRaw, err := w.columnNulls.Policy(view, col, w.nullPolicy).FormatNull(view, row, col)
+		if err != nil {
+			return "", err
+		}
+		return w.escapeString(str, isRaw), nil
+	}
+	if cellErr := retable.CellErrorValue(view, row, col); cellErr != nil {
+		str, isRaw, err := w.errorPolicy.FormatError(view, row, col, cellErr)
+		if err != nil {
+			return "", err
+		}
+		return w.escapeString(str, isRaw), nil
 	}
 	if v.Kind() == reflect.Pointer {
 		v = v.Elem()
@@ -320,6 +609,11 @@ func (w *Writer[T]) cellString(ctx context.Context, view retable.View, row, col
 }
 
 func (w *Writer[T]) escapeString(str string, isRaw bool) string {
+	// Terminal colors of formatters are not valid in CSV
+	str = retable.StripANSI(str)
+	if w.trimTrailing {
+		str = strings.TrimRight(str, " \t")
+	}
 	if isRaw {
 		return str
 	}
@@ -327,7 +621,8 @@ func (w *Writer[T]) escapeString(str string, isRaw bool) string {
 	// \n alone is valid within quotes
 	str = strings.ReplaceAll(str, "\r", "")
 	switch {
-	case w.quoteAllFields || strings.ContainsRune(str, w.delimiter) || strings.ContainsRune(str, '\n'):
+	case w.quoteAllFields || strings.ContainsRune(str, w.delimiter) || strings.ContainsRune(str, '\n'),
+		w.quoteQuotes && strings.ContainsRune(str, '"'):
 		return `"` + strings.ReplaceAll(str, `"`, w.escapeQuotes) + `"`
 	case w.quoteEmptyFields && str == "":
 		return `""`
@@ -341,6 +636,89 @@ func (w *Writer[T]) WithHeaderRow(headerRow bool) *Writer[T] {
 	return mod
 }
 
+// WithCanonicalOutput returns a new writer with a fixed
+// output format that can be hashed and compared reliably,
+// for example in tests and audit trails:
+//
+//   - comma delimiter and "\n" newlines
+//   - UTF-8 without byte order mark (no encoder)
+//   - fields quoted only if they contain the delimiter,
+//     newlines, or quotes, with quotes escaped as ""
+//   - no padding and trailing spaces of fields trimmed
+//   - time.Time formatted in UTC as RFC 3339 with nanoseconds,
+//     see retable.CanonicalCellString
+//
+// The header row setting and formatters are kept.
+func (w *Writer[T]) WithCanonicalOutput() *Writer[T] {
+	mod := w.clone()
+	mod.delimiter = ','
+	mod.newLine = "\n"
+	mod.encoder = nil
+	mod.padding = NoPadding
+	mod.quoteAllFields = false
+	mod.quoteEmptyFields = false
+	mod.quoteQuotes = true
+	mod.escapeQuotes = `""`
+	mod.trimTrailing = true
+	mod.formatters = w.formatters.
+		WithTypeFormatter(typeOfTime, retable.CellFormatterFunc(formatCanonicalTime)).
+		WithTypeFormatter(reflect.PointerTo(typeOfTime), retable.CellFormatterFunc(formatCanonicalTime))
+	return mod
+}
+
+// WithTrimTrailingSpace returns a new writer that
+// removes trailing spaces and tabs from fields.
+func (w *Writer[T]) WithTrimTrailingSpace(trim bool) *Writer[T] {
+	mod := w.clone()
+	mod.trimTrailing = trim
+	return mod
+}
+
+// WithProvenance returns a new writer that writes
+// the fields of provenance as comment lines
+// starting with "# " before the CSV rows.
+// Readers must skip those lines.
+// Pass nil to write no provenance.
+func (w *Writer[T]) WithProvenance(provenance *retable.Provenance) *Writer[T] {
+	mod := w.clone()
+	mod.provenance = provenance
+	return mod
+}
+
+// WithTitleComment returns a new writer that writes
+// a non empty view title as comment line
+// starting with "# " before the CSV rows.
+// Readers must skip that line.
+func (w *Writer[T]) WithTitleComment(titleComment bool) *Writer[T] {
+	mod := w.clone()
+	mod.titleComment = titleComment
+	return mod
+}
+
+// WithRowTimeout returns a new writer that aborts writing
+// with a retable.TimeoutError if formatting a row
+// takes longer than timeout, protecting exports
+// from formatters that hang on specific cells.
+// The formatters get a context with the deadline of the row
+// and no further cells of the row are formatted after the deadline,
+// see retable.CallWithRowTimeout.
+// Values <= 0 don't limit the formatting time.
+func (w *Writer[T]) WithRowTimeout(timeout time.Duration) *Writer[T] {
+	mod := w.clone()
+	mod.rowTimeout = timeout
+	return mod
+}
+
+// WithRepeatHeader returns a new writer that repeats the header row
+// before every everyNumRows data rows for printer friendly long listings.
+// Only used if the writer has a header row.
+// Values <= 0 write the header row only once.
+func (w *Writer[T]) WithRepeatHeader(everyNumRows int) *Writer[T] {
+	mod := w.clone()
+	mod.repeatHeader = everyNumRows
+	return mod
+}
+
 func (w *Writer[T]) WithTableViewer(viewer retable.Viewer) *Writer[T] {
 	mod := w.clone()
 	mod.viewer = viewer
@@ -369,6 +747,55 @@ func (w *Writer[T]) WithColumnFormatterFunc(columnIndex int, formatterFunc retab
 	return w.WithColumnFormatter(columnIndex, formatterFunc)
 }
 
+// WithRecoverPanics returns a new writer that recovers
+// panics of column and type formatters and returns them
+// as errors with the coordinates of the formatted cell,
+// see retable.SafeCellFormatter.
+func (w *Writer[T]) WithRecoverPanics(recoverPanics bool) *Writer[T] {
+	mod := w.clone()
+	mod.recoverPanics = recoverPanics
+	return mod
+}
+
+// WithRawPolicy returns a new writer that handles
+// raw strings returned by column and type formatters
+// according to policy, see retable.RawPolicy.
+func (w *Writer[T]) WithRawPolicy(policy retable.RawPolicy) *Writer[T] {
+	mod := w.clone()
+	mod.rawPolicy = policy
+	return mod
+}
+
+// WithPointerHandling returns a new writer that formats
+// cells with pointer values according to handling,
+// see retable.PointerHandling.
+func (w *Writer[T]) WithPointerHandling(handling retable.PointerHandling) *Writer[T] {
+	mod := w.clone()
+	mod.pointerHandling = handling
+	return mod
+}
+
+// WithZeroAsNull returns a new writer that handles
+// the zero values of the cells selected by zeroAsNull
+// like null values using the null policy,
+// for example to render 0 amounts as empty cells.
+func (w *Writer[T]) WithZeroAsNull(zeroAsNull retable.ZeroAsNull) *Writer[T] {
+	mod := w.clone()
+	mod.zeroAsNull = zeroAsNull
+	return mod
+}
+
+// WithIncludeHiddenColumns returns a new writer that writes
+// or omits the hidden columns of a retable.ColumnVisibilityView
+// like IDs needed for a re-import.
+// By default hidden columns are written.
+// Column indices of the writer refer to the written columns.
+func (w *Writer[T]) WithIncludeHiddenColumns(include bool) *Writer[T] {
+	mod := w.clone()
+	mod.includeHidden = include
+	return mod
+}
+
 func (w *Writer[T]) WithTypeFormatters(formatter *retable.ReflectTypeCellFormatter) *Writer[T] {
 	mod := w.clone()
 	mod.formatters = formatter
@@ -421,6 +848,43 @@ func (w *Writer[T]) WithKindFormatter(kind reflect.Kind, fmt retable.CellFormatt
 	return mod
 }
 
+// WithFloatFormatter returns a new writer that formats
+// float32 and float64 cells with fmt, for example:
+//
+//	WithFloatFormatter(retable.FloatCellFormatter{Precision: 2, TrimZeros: true})
+func (w *Writer[T]) WithFloatFormatter(fmt retable.CellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.formatters = w.formatters.WithFloatFormatter(fmt)
+	return mod
+}
+
+// WithMoneyFormatter returns a new writer that formats
+// money amounts implementing retable.AmountFormatter
+// like go-types money.Amount with fmt.
+func (w *Writer[T]) WithMoneyFormatter(fmt retable.MoneyCellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.formatters = w.formatters.WithMoneyFormatter(fmt)
+	return mod
+}
+
+// WithBoolRendering returns a new writer that renders
+// bool values with the passed rendering
+// like retable.BoolRenderingYesNo.
+func (w *Writer[T]) WithBoolRendering(rendering retable.BoolRendering) *Writer[T] {
+	mod := w.clone()
+	mod.formatters = w.formatters.WithKindFormatter(reflect.Bool, rendering)
+	return mod
+}
+
+// WithDefaults returns a new writer that uses
+// the formatters of retable.NewDefaultFormatters
+// for types and kinds without a type formatter of the writer.
+func (w *Writer[T]) WithDefaults() *Writer[T] {
+	mod := w.clone()
+	mod.formatters = retable.NewDefaultFormatters().WithFormatters(w.formatters)
+	return mod
+}
+
 func (w *Writer[T]) WithKindFormatterFunc(kind reflect.Kind, fmt retable.CellFormatterFunc) *Writer[T] {
 	mod := w.clone()
 	mod.formatters = w.formatters.WithKindFormatter(kind, fmt)
@@ -433,6 +897,17 @@ func (w *Writer[T]) WithPadding(padding Padding) *Writer[T] {
 	return mod
 }
 
+// WithWidthOptions returns a new writer that measures
+// the width of cells for padding with options.
+// Pass retable.DisplayWidthOptions to align East Asian wide runes
+// and ignore ANSI escape sequences.
+// The default nil options count UTF-8 runes.
+func (w *Writer[T]) WithWidthOptions(options *retable.WidthOptions) *Writer[T] {
+	mod := w.clone()
+	mod.widthOptions = options
+	return mod
+}
+
 func (w *Writer[T]) WithQuoteAllFields(quoteAllFields bool) *Writer[T] {
 	mod := w.clone()
 	mod.quoteAllFields = quoteAllFields
@@ -445,9 +920,54 @@ func (w *Writer[T]) WithQuoteEmptyFields(quoteEmptyFields bool) *Writer[T] {
 	return mod
 }
 
+// WithQuoteFieldsWithQuotes returns a new writer
+// that quotes fields containing quote characters.
+// Else quotes are only escaped within unquoted fields.
+func (w *Writer[T]) WithQuoteFieldsWithQuotes(quoteQuotes bool) *Writer[T] {
+	mod := w.clone()
+	mod.quoteQuotes = quoteQuotes
+	return mod
+}
+
+// WithNilValue returns a new writer that renders
+// null-like values as the passed nilValue.
+// It is a shortcut for WithNullPolicy(retable.RenderNullAs(nilValue)).
 func (w *Writer[T]) WithNilValue(nilValue string) *Writer[T] {
+	return w.WithNullPolicy(retable.RenderNullAs(nilValue))
+}
+
+// WithNullPolicy returns a new writer that handles
+// null-like values using the passed policy.
+func (w *Writer[T]) WithNullPolicy(policy retable.NullPolicy) *Writer[T] {
+	mod := w.clone()
+	mod.nullPolicy = policy
+	return mod
+}
+
+// WithColumnNilValue returns a new writer that renders
+// null-like values of the column with columnIndex
+// as the passed nilValue instead of using the null policy of the writer.
+func (w *Writer[T]) WithColumnNilValue(columnIndex int, nilValue string) *Writer[T] {
+	mod := w.clone()
+	mod.columnNulls = w.columnNulls.WithIndex(columnIndex, retable.RenderNullAs(nilValue))
+	return mod
+}
+
+// WithColumnTitleNilValue returns a new writer that renders
+// null-like values of the columns with the passed title
+// as nilValue instead of using the null policy of the writer.
+func (w *Writer[T]) WithColumnTitleNilValue(title string, nilValue string) *Writer[T] {
+	mod := w.clone()
+	mod.columnNulls = w.columnNulls.WithTitle(title, retable.RenderNullAs(nilValue))
+	return mod
+}
+
+// WithColumnNullPolicies returns a new writer that handles
+// null-like values of the columns selected by policies
+// with their own null policy.
+func (w *Writer[T]) WithColumnNullPolicies(policies retable.ColumnNullPolicies) *Writer[T] {
 	mod := w.clone()
-	mod.nilValue = nilValue
+	mod.columnNulls = policies
 	return mod
 }
 
@@ -475,6 +995,93 @@ func (w *Writer[T]) WithEncoder(encoder Encoder) *Writer[T] {
 	return mod
 }
 
+// WithRedactedColumns returns a new writer that replaces the non null values
+// of the columns with the passed titles with a placeholder
+// while keeping the column headers.
+// The placeholder can be set with WithRedactionPlaceholder
+// and defaults to retable.DefaultRedactionPlaceholder.
+func (w *Writer[T]) WithRedactedColumns(columns ...string) *Writer[T] {
+	mod := w.clone()
+	mod.redactedColumns = slices.Clone(columns)
+	return mod
+}
+
+// WithRedactionPlaceholder returns a new writer that uses
+// the passed placeholder for the values of redacted columns.
+func (w *Writer[T]) WithRedactionPlaceholder(placeholder string) *Writer[T] {
+	mod := w.clone()
+	mod.redaction = placeholder
+	return mod
+}
+
+// WithWriteHooks returns a new writer that calls hooks
+// while writing the data rows of a view.
+func (w *Writer[T]) WithWriteHooks(hooks *retable.WriteHooks) *Writer[T] {
+	mod := w.clone()
+	mod.hooks = hooks
+	return mod
+}
+
+// WithMetricsRecorder returns a new writer that records
+// the statistics of every written view with recorder.
+func (w *Writer[T]) WithMetricsRecorder(recorder retable.MetricsRecorder) *Writer[T] {
+	mod := w.clone()
+	mod.metrics = recorder
+	return mod
+}
+
+// EstimateSize estimates the output size of WriteView for view
+// by writing retable.DefaultEstimateSampleRows sample rows
+// without row and output size limits, hooks, and metrics.
+func (w *Writer[T]) EstimateSize(ctx context.Context, view retable.View) (*retable.SizeEstimate, error) {
+	mod := w.clone()
+	mod.hooks = nil
+	mod.metrics = nil
+	mod.maxRows = 0
+	mod.maxBytes = 0
+	return retable.EstimateSize(ctx, view, retable.DefaultEstimateSampleRows, mod.WriteView)
+}
+
+// WithMaxRows returns a new writer that writes views
+// with at most maxRows data rows.
+// Views with more rows are not written at all and
+// retable.ErrMaxRowsExceeded is returned,
+// or if a truncation marker is set, then only the first
+// maxRows rows are written followed by a marker row.
+// Values <= 0 don't limit the number of rows.
+func (w *Writer[T]) WithMaxRows(maxRows int) *Writer[T] {
+	mod := w.clone()
+	mod.maxRows = maxRows
+	return mod
+}
+
+// WithMaxOutputBytes returns a new writer that stops writing
+// before the row that would exceed maxBytes output bytes
+// and returns retable.ErrMaxOutputBytesExceeded,
+// or if a truncation marker is set, then a marker row
+// is written instead that is not counted for the limit.
+// Values <= 0 don't limit the output size.
+func (w *Writer[T]) WithMaxOutputBytes(maxBytes int64) *Writer[T] {
+	mod := w.clone()
+	mod.maxBytes = maxBytes
+	return mod
+}
+
+// WithTruncationMarker returns a new writer that truncates
+// views exceeding the limits of WithMaxRows and WithMaxOutputBytes
+// and writes the marker in the first column of a final row.
+// An empty marker returns an error instead of truncating.
+func (w *Writer[T]) WithTruncationMarker(marker string) *Writer[T] {
+	mod := w.clone()
+	mod.truncationMarker = marker
+	return mod
+}
+
+// RedactedColumns returns the titles of the redacted columns.
+func (w *Writer[T]) RedactedColumns() []string {
+	return w.redactedColumns
+}
+
 func (w *Writer[T]) QuoteAllFields() bool {
 	return w.quoteAllFields
 }
@@ -491,8 +1098,34 @@ func (w *Writer[T]) EscapeQuotes() string {
 	return w.escapeQuotes
 }
 
+// NilValue returns the value written for null-like values
+// by the null policy of the writer.
+// It is empty for the actions retable.NullOmit that writes an empty cell
+// and retable.NullError that aborts writing.
 func (w *Writer[T]) NilValue() string {
-	return w.nilValue
+	if w.nullPolicy.Action != retable.NullRender {
+		return ""
+	}
+	return w.nullPolicy.Value
+}
+
+// WithErrorPolicy returns a new writer that handles
+// cells with error values and cells whose formatting failed
+// with the passed policy.
+// The default policy aborts writing with a retable.CellError.
+func (w *Writer[T]) WithErrorPolicy(policy retable.ErrorPolicy) *Writer[T] {
+	mod := w.clone()
+	mod.errorPolicy = policy
+	return mod
+}
+
+// ErrorPolicy returns the error policy of the writer.
+func (w *Writer[T]) ErrorPolicy() retable.ErrorPolicy {
+	return w.errorPolicy
+}
+
+func (w *Writer[T]) NullPolicy() retable.NullPolicy {
+	return w.nullPolicy
 }
 
 func (w *Writer[T]) NewLine() string {
@@ -502,3 +1135,15 @@ func (w *Writer[T]) NewLine() string {
 func (w *Writer[T]) Encoder() Encoder {
 	return w.encoder
 }
+
+var typeOfTime = reflect.TypeFor[time.Time]()
+
+// formatCanonicalTime formats non null time.Time cells
+// with retable.CanonicalCellString.
+func formatCanonicalTime(ctx context.Context, view retable.View, row, col int) (str string, raw bool, err error) {
+	v := retable.AsReflectCellView(view).ReflectCell(row, col)
+	if retable.IsNullLike(v) {
+		return "", false, errors.ErrUnsupported
+	}
+	return retable.CanonicalCellString(v), false, nil
+}
@@ -3,7 +3,13 @@ package csvtable
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/domonda/go-retable"
 )
@@ -23,6 +29,18 @@ func TestWriter_WriteView(t *testing.T) {
 			view:     &retable.AnyValuesView{},
 			wantDest: ``,
 		},
+		{
+			name:     "empty view with header row",
+			writer:   NewWriter[any]().WithHeaderRow(true),
+			view:     &retable.AnyValuesView{Cols: []string{"A", "B"}},
+			wantDest: "A;B\r\n",
+		},
+		{
+			name:     "empty view with repeated header row",
+			writer:   NewWriter[any]().WithHeaderRow(true).WithRepeatHeader(2),
+			view:     &retable.AnyValuesView{Cols: []string{"A", "B"}},
+			wantDest: "A;B\r\n",
+		},
 		{
 			name: "simple",
 			writer: NewWriter[any]().
@@ -127,6 +145,202 @@ func TestWriter_WriteView(t *testing.T) {
 				`"1","Hello",""` + "\r\n" +
 				`"2","world!","0"` + "\r\n",
 		},
+		{
+			name: "null policy render",
+			writer: NewWriter[any]().
+				WithNullPolicy(retable.RenderNullAs("NULL")),
+			view: &retable.AnyValuesView{
+				Cols: []string{"A", "B"},
+				Rows: [][]any{
+					{1, nil},
+					{(*int)(nil), "x"},
+				},
+			},
+			wantDest: "" +
+				`1;NULL` + "\r\n" +
+				`NULL;x` + "\r\n",
+		},
+		{
+			name: "null policy error",
+			writer: NewWriter[any]().
+				WithNullPolicy(retable.NullPolicy{Action: retable.NullError}),
+			view: &retable.AnyValuesView{
+				Cols: []string{"A", "B"},
+				Rows: [][]any{
+					{1, nil},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sparse view missing vs null",
+			writer: NewWriter[any]().
+				WithNullPolicy(retable.RenderNullAs("NULL")),
+			view: &retable.SparseView{
+				Cols: []string{"A", "B", "C"},
+				Rows: []map[int]any{
+					{0: 1, 1: nil},
+					{2: "x"},
+				},
+			},
+			wantDest: "" +
+				`1;NULL;` + "\r\n" +
+				`;;x` + "\r\n",
+		},
+		{
+			name: "redacted columns",
+			writer: NewWriter[any]().
+				WithHeaderRow(true).
+				WithRedactedColumns("IBAN", "Salary").
+				WithNullPolicy(retable.RenderNullAs("NULL")),
+			view: &retable.AnyValuesView{
+				Cols: []string{"Name", "IBAN", "Salary"},
+				Rows: [][]any{
+					{"John", "AT611904300234573201", 5000},
+					{"Jane", nil, 6000},
+				},
+			},
+			wantDest: "" +
+				`Name;IBAN;Salary` + "\r\n" +
+				`John;***;***` + "\r\n" +
+				`Jane;NULL;***` + "\r\n",
+		},
+		{
+			name: "max rows exceeded",
+			writer: NewWriter[any]().
+				WithHeaderRow(true).
+				WithMaxRows(1),
+			view: &retable.AnyValuesView{
+				Cols: []string{"A", "B"},
+				Rows: [][]any{{1, 2}, {3, 4}},
+			},
+			wantDest: ``,
+			wantErr:  true,
+		},
+		{
+			name: "max rows truncated",
+			writer: NewWriter[any]().
+				WithHeaderRow(true).
+				WithMaxRows(1).
+				WithTruncationMarker("..."),
+			view: &retable.AnyValuesView{
+				Cols: []string{"A", "B"},
+				Rows: [][]any{{1, 2}, {3, 4}},
+			},
+			wantDest: "" +
+				`A;B` + "\r\n" +
+				`1;2` + "\r\n" +
+				`...;` + "\r\n",
+		},
+		{
+			name: "max output bytes exceeded",
+			writer: NewWriter[any]().
+				WithHeaderRow(true).
+				WithMaxOutputBytes(12),
+			view: &retable.AnyValuesView{
+				Cols: []string{"A", "B"},
+				Rows: [][]any{{1, 2}, {3, 4}},
+			},
+			wantDest: "" +
+				`A;B` + "\r\n" +
+				`1;2` + "\r\n",
+			wantErr: true,
+		},
+		{
+			name: "max output bytes truncated",
+			writer: NewWriter[any]().
+				WithHeaderRow(true).
+				WithMaxOutputBytes(12).
+				WithTruncationMarker("..."),
+			view: &retable.AnyValuesView{
+				Cols: []string{"A", "B"},
+				Rows: [][]any{{1, 2}, {3, 4}},
+			},
+			wantDest: "" +
+				`A;B` + "\r\n" +
+				`1;2` + "\r\n" +
+				`...;` + "\r\n",
+		},
+		{
+			name: "ANSI colors stripped",
+			writer: NewWriter[any]().
+				WithColumnFormatter(0, retable.ColorizeNegativeNumbers(retable.ANSIRed)),
+			view: &retable.AnyValuesView{
+				Cols: []string{"A"},
+				Rows: [][]any{{-1}, {2}},
+			},
+			wantDest: "" +
+				`-1` + "\r\n" +
+				`2` + "\r\n",
+		},
+		{
+			name: "float formatter",
+			writer: NewWriter[any]().
+				WithFloatFormatter(retable.FloatCellFormatter{Precision: 2, TrimZeros: true}),
+			view: &retable.AnyValuesView{
+				Cols: []string{"A", "B"},
+				Rows: [][]any{{0.30000000000000004, 1}, {float32(2.5), 1.005}},
+			},
+			wantDest: "" +
+				`0.3;1` + "\r\n" +
+				`2.5;1.01` + "\r\n",
+		},
+		{
+			name:   "struct tag format",
+			writer: NewWriter[any](),
+			view: func() retable.View {
+				view, _ := (&retable.StructFieldNaming{Tag: "col"}).NewView("", []struct {
+					Margin float64 `col:"Margin,format=percent:1"`
+				}{{Margin: 0.1234}})
+				return view
+			}(),
+			wantDest: "" +
+				`12.3%` + "\r\n",
+		},
+		{
+			name: "bool rendering",
+			writer: NewWriter[any]().
+				WithBoolRendering(retable.BoolRenderingYesNo),
+			view: &retable.AnyValuesView{
+				Cols: []string{"A", "B"},
+				Rows: [][]any{{true, new(bool)}, {false, nil}},
+			},
+			wantDest: "" +
+				`Yes;No` + "\r\n" +
+				`No;` + "\r\n",
+		},
+		{
+			name: "defaults",
+			writer: NewWriter[any]().
+				WithBoolRendering(retable.BoolRenderingOneZero).
+				WithDefaults(),
+			view: &retable.AnyValuesView{
+				Cols: []string{"A", "B", "C"},
+				Rows: [][]any{{0.30000000000000004, true, time.Date(2024, 10, 16, 0, 0, 0, 0, time.UTC)}},
+			},
+			wantDest: "" +
+				`0.3;1;2024-10-16` + "\r\n",
+		},
+		{
+			name: "error policy",
+			writer: NewWriter[any]().
+				WithErrorPolicy(retable.RenderErrorAs("#ERR")),
+			view: &retable.AnyValuesView{
+				Cols: []string{"A", "B"},
+				Rows: [][]any{{1, errors.New("failed")}},
+			},
+			wantDest: "" +
+				`1;#ERR` + "\r\n",
+		},
+		{
+			name:   "error value aborts",
+			writer: NewWriter[any](),
+			view: &retable.AnyValuesView{
+				Cols: []string{"A", "B"},
+				Rows: [][]any{{1, errors.New("failed")}},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -141,3 +355,512 @@ func TestWriter_WriteView(t *testing.T) {
 		})
 	}
 }
+
+func TestWriter_WithWriteHooks(t *testing.T) {
+	ctx := context.Background()
+	var (
+		events     []string
+		cellErrors []string
+	)
+	hooks := &retable.WriteHooks{
+		OnRowStart: func(ctx context.Context, view retable.View, row int) {
+			events = append(events, fmt.Sprintf("start %d", row))
+		},
+		OnRowEnd: func(ctx context.Context, view retable.View, row int, err error) {
+			events = append(events, fmt.Sprintf("end %d %v", row, err))
+		},
+		OnCellError: func(ctx context.Context, view retable.View, row, col int, err error) error {
+			cellErrors = append(cellErrors, fmt.Sprintf("%d/%d: %s", row, col, err))
+			return nil
+		},
+	}
+	writer := NewWriter[any]().
+		WithHeaderRow(true).
+		WithColumnFormatterFunc(1, func(ctx context.Context, view retable.View, row, col int) (string, bool, error) {
+			if row == 1 {
+				return "", false, errors.New("invalid")
+			}
+			return fmt.Sprint(view.Cell(row, col)), false, nil
+		}).
+		WithWriteHooks(hooks)
+	view := &retable.AnyValuesView{
+		Cols: []string{"A", "B"},
+		Rows: [][]any{{1, 2}, {3, 4}},
+	}
+
+	var dest bytes.Buffer
+	err := writer.WriteView(ctx, &dest, view)
+	if err != nil {
+		t.Fatalf("Writer.WriteView() error = %v", err)
+	}
+	if want := "A;B\r\n1;2\r\n3;\r\n"; dest.String() != want {
+		t.Errorf("Writer.WriteView() wrote:\n%s\nbut want:\n%s", dest.String(), want)
+	}
+	if want := []string{"start 0", "end 0 <nil>", "start 1", "end 1 <nil>"}; !slices.Equal(events, want) {
+		t.Errorf("row events %v, want %v", events, want)
+	}
+	if want := []string{"1/1: invalid"}; !slices.Equal(cellErrors, want) {
+		t.Errorf("cell errors %v, want %v", cellErrors, want)
+	}
+
+	// Without OnCellError the cell error aborts writing
+	hooks.OnCellError = nil
+	events = nil
+	err = writer.WriteView(ctx, new(bytes.Buffer), view)
+	if err == nil {
+		t.Fatal("Writer.WriteView() expected error")
+	}
+	if want := []string{"start 0", "end 0 <nil>", "start 1", `end 1 cell at row 1 column 1 "B": invalid`}; !slices.Equal(events, want) {
+		t.Errorf("row events %v, want %v", events, want)
+	}
+}
+
+func TestWriter_WithRepeatHeader(t *testing.T) {
+	view := &retable.StringsView{
+		Cols: []string{"Name", "N"},
+		Rows: [][]string{{"a", "1"}, {"bb", "2"}, {"c", "3"}},
+	}
+	tests := []struct {
+		name   string
+		writer *Writer[any]
+		view   retable.View
+		want   string
+	}{
+		{
+			name:   "every 2 rows",
+			writer: NewWriter[any]().WithHeaderRow(true).WithNewLine("\n").WithRepeatHeader(2),
+			view:   view,
+			want:   "Name;N\na;1\nbb;2\nName;N\nc;3\n",
+		},
+		{
+			name:   "more than rows",
+			writer: NewWriter[any]().WithHeaderRow(true).WithNewLine("\n").WithRepeatHeader(3),
+			view:   view,
+			want:   "Name;N\na;1\nbb;2\nc;3\n",
+		},
+		{
+			name:   "no rows",
+			writer: NewWriter[any]().WithHeaderRow(true).WithNewLine("\n").WithRepeatHeader(2),
+			view:   &retable.StringsView{Cols: []string{"Name", "N"}},
+			want:   "Name;N\n",
+		},
+		{
+			name:   "without header row",
+			writer: NewWriter[any]().WithNewLine("\n").WithRepeatHeader(1),
+			view:   view,
+			want:   "a;1\nbb;2\nc;3\n",
+		},
+		{
+			name:   "padded",
+			writer: NewWriter[any]().WithHeaderRow(true).WithNewLine("\n").WithPadding(AlignLeft).WithDelimiter('|').WithRepeatHeader(1),
+			view:   view,
+			want:   "Name|N\na   |1\nName|N\nbb  |2\nName|N\nc   |3\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dest bytes.Buffer
+			err := tt.writer.WriteView(context.Background(), &dest, tt.view)
+			if err != nil {
+				t.Fatalf("Writer.WriteView() error = %v", err)
+			}
+			if dest.String() != tt.want {
+				t.Errorf("Writer.WriteView() wrote:\n%q\nbut want:\n%q", dest.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriter_WithCanonicalOutput(t *testing.T) {
+	type Row struct {
+		Name string
+		Time time.Time
+		Ptr  *time.Time
+		Note string
+	}
+	vienna := time.FixedZone("CET", 3600)
+	at := time.Date(2024, 1, 2, 13, 4, 5, 600, vienna)
+	rows := []Row{
+		{Name: "a, b  ", Time: at, Ptr: &at, Note: `say "hi"`},
+		{Name: "c\t", Note: "line1\r\nline2 "},
+	}
+	writer := NewWriter[[]Row]().
+		WithHeaderRow(true).
+		WithPadding(AlignLeft).
+		WithQuoteAllFields(true).
+		WithEncoder(PassthroughEncoder()).
+		WithCanonicalOutput()
+
+	var dest bytes.Buffer
+	err := writer.Write(context.Background(), &dest, rows)
+	if err != nil {
+		t.Fatalf("Writer.Write() error = %v", err)
+	}
+	want := "Name,Time,Ptr,Note\n" +
+		`"a, b",2024-01-02T12:04:05.0000006Z,2024-01-02T12:04:05.0000006Z,"say ""hi"""` + "\n" +
+		`c,,,"line1` + "\n" + `line2"` + "\n"
+	if dest.String() != want {
+		t.Errorf("Writer.Write() wrote:\n%q\nbut want:\n%q", dest.String(), want)
+	}
+}
+
+func TestWriter_WithProvenance(t *testing.T) {
+	provenance := &retable.Provenance{
+		GeneratedAt: time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC),
+		Source:      "multi\nline",
+	}
+	view := &retable.StringsView{
+		Cols: []string{"A"},
+		Rows: [][]string{{"1"}, {"2"}},
+	}
+	writer := NewWriter[any]().WithHeaderRow(true).WithNewLine("\n").WithProvenance(provenance)
+
+	var dest bytes.Buffer
+	err := writer.WriteView(context.Background(), &dest, view)
+	if err != nil {
+		t.Fatalf("Writer.WriteView() error = %v", err)
+	}
+	want := "# Generated At: 2024-05-06T07:08:09Z\n# Source: multi line\n# Rows: 2\nA\n1\n2\n"
+	if dest.String() != want {
+		t.Errorf("Writer.WriteView() wrote:\n%q\nbut want:\n%q", dest.String(), want)
+	}
+}
+
+func TestWriter_StringsViewRaggedRows(t *testing.T) {
+	view := &retable.StringsView{
+		Cols: []string{"A", "B"},
+		Rows: [][]string{{"1", "2"}, {"3"}, {"4", ""}},
+	}
+	// Missing cells of ragged rows are written empty
+	// without calling the column formatter
+	writer := NewWriter[any]().
+		WithNewLine("\n").
+		WithColumnFormatter(1, retable.CellFormatterFunc(func(ctx context.Context, view retable.View, row, col int) (string, bool, error) {
+			return "<" + view.Cell(row, col).(string) + ">", false, nil
+		}))
+
+	var dest bytes.Buffer
+	err := writer.WriteView(context.Background(), &dest, view)
+	if err != nil {
+		t.Fatalf("Writer.WriteView() error = %v", err)
+	}
+	want := "1;<2>\n3;\n4;<>\n"
+	if dest.String() != want {
+		t.Errorf("Writer.WriteView() wrote:\n%q\nbut want:\n%q", dest.String(), want)
+	}
+}
+
+func TestWriter_NilValue(t *testing.T) {
+	tests := []struct {
+		policy retable.NullPolicy
+		want   string
+	}{
+		{policy: retable.RenderNullAs("NULL"), want: "NULL"},
+		{policy: retable.NullPolicy{Action: retable.NullOmit, Value: "NULL"}, want: ""},
+		{policy: retable.NullPolicy{Action: retable.NullError, Value: "NULL"}, want: ""},
+	}
+	for _, tt := range tests {
+		writer := NewWriter[any]().WithNullPolicy(tt.policy)
+		if got := writer.NilValue(); got != tt.want {
+			t.Errorf("Writer.NilValue() with %s = %q, want %q", tt.policy.Action, got, tt.want)
+		}
+	}
+
+	// NullOmit writes an empty cell keeping the following columns
+	view := &retable.AnyValuesView{
+		Cols: []string{"A", "B", "C"},
+		Rows: [][]any{{1, nil, 3}},
+	}
+	var dest bytes.Buffer
+	err := NewWriter[any]().
+		WithNewLine("\n").
+		WithNullPolicy(retable.NullPolicy{Action: retable.NullOmit, Value: "NULL"}).
+		WriteView(context.Background(), &dest, view)
+	if err != nil {
+		t.Fatalf("Writer.WriteView() error = %v", err)
+	}
+	if want := "1;;3\n"; dest.String() != want {
+		t.Errorf("Writer.WriteView() wrote:\n%q\nbut want:\n%q", dest.String(), want)
+	}
+}
+
+// failingWriter fails writing after limit bytes
+type failingWriter struct {
+	dest  *bytes.Buffer
+	limit int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.dest.Len()+len(p) > w.limit {
+		return 0, errors.New("connection lost")
+	}
+	return w.dest.Write(p)
+}
+
+func TestWriter_ResumableWrite(t *testing.T) {
+	view := &retable.StringsView{
+		Cols: []string{"A"},
+		Rows: [][]string{{"1"}, {"2"}, {"3"}},
+	}
+	writer := NewWriter[any]().WithNewLine("\n")
+	resumable := retable.NewResumableWrite(view)
+	write := func(ctx context.Context, dest io.Writer, view retable.View, hooks *retable.WriteHooks) error {
+		return writer.WithHeaderRow(resumable.NextRow == 0).WithWriteHooks(hooks).WriteView(ctx, dest, view)
+	}
+
+	var dest bytes.Buffer
+	err := resumable.Write(context.Background(), &failingWriter{dest: &dest, limit: 5}, write)
+	var partial retable.ErrPartialWrite
+	if !errors.As(err, &partial) || partial.NextRow != 1 {
+		t.Fatalf("expected ErrPartialWrite at row 1, got %v", err)
+	}
+	err = resumable.Write(context.Background(), &dest, write)
+	if err != nil {
+		t.Fatalf("ResumableWrite.Write() error = %v", err)
+	}
+	if want := "A\n1\n2\n3\n"; dest.String() != want {
+		t.Errorf("wrote:\n%q\nbut want:\n%q", dest.String(), want)
+	}
+}
+
+func TestWriter_WithRowTimeout(t *testing.T) {
+	view := &retable.StringsView{
+		Cols: []string{"A"},
+		Rows: [][]string{{"fast"}, {"slow"}},
+	}
+	slowFormatter := retable.CellFormatterFunc(func(ctx context.Context, view retable.View, row, col int) (string, bool, error) {
+		if row == 1 {
+			time.Sleep(50 * time.Millisecond) // Ignores the context deadline
+		}
+		return view.Cell(row, col).(string), false, nil
+	})
+	for _, padding := range []Padding{NoPadding, AlignLeft} {
+		writer := NewWriter[any]().
+			WithPadding(padding).
+			WithColumnFormatter(0, slowFormatter).
+			WithRowTimeout(10 * time.Millisecond)
+		err := writer.WriteView(context.Background(), io.Discard, view)
+		var timeoutErr retable.TimeoutError
+		if !errors.As(err, &timeoutErr) || timeoutErr.Row != 1 {
+			t.Errorf("padding %d: expected TimeoutError for row 1, got %v", padding, err)
+		}
+	}
+
+	var dest bytes.Buffer
+	err := NewWriter[any]().WithNewLine("\n").WithRowTimeout(time.Second).WriteView(context.Background(), &dest, view)
+	if err != nil || dest.String() != "fast\nslow\n" {
+		t.Errorf("Writer.WriteView() = %q, %v", dest.String(), err)
+	}
+}
+
+func TestWriter_WithRecoverPanics(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Cols: []string{"A", "B"},
+		Rows: [][]any{{true, "not a bool"}},
+	}
+	writer := NewWriter[any]().
+		WithColumnFormatter(1, retable.StringIfTrue("yes")).
+		WithRecoverPanics(true)
+	err := writer.WriteView(context.Background(), io.Discard, view)
+	var cellErr retable.CellError
+	if !errors.As(err, &cellErr) || cellErr.Row != 0 || cellErr.Col != 1 {
+		t.Errorf("expected CellError for row 0 column 1, got %v", err)
+	}
+	var panicErr retable.FormatterPanicError
+	if !errors.As(err, &panicErr) {
+		t.Errorf("expected FormatterPanicError, got %v", err)
+	}
+}
+
+func TestWriter_WithTitleComment(t *testing.T) {
+	table := [][]string{{"A"}, {"1"}}
+	tests := []struct {
+		name   string
+		writer *Writer[[][]string]
+		title  []string
+		want   string
+	}{
+		{name: "title ignored", writer: NewWriter[[][]string](), title: []string{"Title"}, want: "A\n1\n"},
+		{name: "no title", writer: NewWriter[[][]string]().WithTitleComment(true), want: "A\n1\n"},
+		{name: "title", writer: NewWriter[[][]string]().WithTitleComment(true), title: []string{"Sales", "2024"}, want: "# Sales 2024\nA\n1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dest bytes.Buffer
+			err := tt.writer.WithHeaderRow(true).WithNewLine("\n").Write(context.Background(), &dest, table, tt.title...)
+			if err != nil {
+				t.Fatalf("Writer.Write() error = %v", err)
+			}
+			if dest.String() != tt.want {
+				t.Errorf("Writer.Write() wrote %q but want %q", dest.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriter_Capabilities(t *testing.T) {
+	var writer retable.CapabilitiesWriter = NewWriter[any]()
+	if caps := writer.Capabilities(); caps.Format != "csv" || !caps.Streaming || caps.RawHTML || caps.NativeTypes {
+		t.Errorf("Writer.Capabilities() = %+v", caps)
+	}
+	if caps := NewWriter[any]().WithPadding(AlignLeft).Capabilities(); caps.Streaming {
+		t.Errorf("padded Writer.Capabilities() = %+v", caps)
+	}
+}
+
+func TestWriter_WithRawPolicy(t *testing.T) {
+	view := &retable.StringsView{
+		Cols: []string{"A", "B"},
+		Rows: [][]string{{"a;b", "c"}},
+	}
+	writer := NewWriter[any]().WithNewLine("\n").WithColumnFormatter(0, retable.SprintCellFormatter(true))
+	tests := []struct {
+		policy  retable.RawPolicy
+		want    string
+		wantErr error
+	}{
+		{policy: retable.RawTrust, want: "a;b;c\n"},
+		{policy: retable.RawEscape, want: "\"a;b\";c\n"},
+		{policy: retable.RawReject, wantErr: retable.ErrRawRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			var dest bytes.Buffer
+			err := writer.WithRawPolicy(tt.policy).WriteView(context.Background(), &dest, view)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Writer.WriteView() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && dest.String() != tt.want {
+				t.Errorf("Writer.WriteView() wrote %q but want %q", dest.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriter_WithColumnNilValue(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Cols: []string{"Name", "Amount", "Note"},
+		Rows: [][]any{{nil, nil, nil}},
+	}
+	writer := NewWriter[any]().
+		WithNewLine("\n").
+		WithNilValue("-").
+		WithColumnNilValue(0, "").
+		WithColumnTitleNilValue("Amount", "0.00")
+	var dest bytes.Buffer
+	err := writer.WriteView(context.Background(), &dest, view)
+	if err != nil {
+		t.Fatalf("Writer.WriteView() error = %v", err)
+	}
+	if want := ";0.00;-\n"; dest.String() != want {
+		t.Errorf("Writer.WriteView() wrote %q but want %q", dest.String(), want)
+	}
+}
+
+func TestWriter_WithPointerHandling(t *testing.T) {
+	var (
+		amount    = 5
+		nilAmount *int
+	)
+	view := &retable.AnyValuesView{
+		Cols: []string{"Amount"},
+		Rows: [][]any{{&amount}, {nilAmount}},
+	}
+	typeFormatter := retable.CellFormatterFunc(func(ctx context.Context, view retable.View, row, col int) (string, bool, error) {
+		if p := view.Cell(row, col).(*int); p != nil {
+			return fmt.Sprintf("ptr %d", *p), false, nil
+		}
+		return "nil ptr", false, nil
+	})
+	writer := NewWriter[any]().WithNewLine("\n").WithNilValue("null").WithTypeFormatter(reflect.TypeFor[*int](), typeFormatter)
+	tests := []struct {
+		handling retable.PointerHandling
+		want     string
+	}{
+		{handling: retable.PointerKeep, want: "ptr 5\nnil ptr\n"},
+		{handling: retable.PointerDeref, want: "5\nnull\n"},
+		{handling: retable.PointerNilAsNull, want: "ptr 5\nnull\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.handling.String(), func(t *testing.T) {
+			var dest bytes.Buffer
+			err := writer.WithPointerHandling(tt.handling).WriteView(context.Background(), &dest, view)
+			if err != nil {
+				t.Fatalf("Writer.WriteView() error = %v", err)
+			}
+			if dest.String() != tt.want {
+				t.Errorf("Writer.WriteView() wrote %q but want %q", dest.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriter_WithZeroAsNull(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Cols: []string{"Name", "Amount", "Count"},
+		Rows: [][]any{{"a", 0.0, 0}, {"b", 1.5, 2}},
+	}
+	zeroAsNull := retable.ZeroAsNull{}.WithTypes(reflect.TypeFor[float64]())
+	var dest bytes.Buffer
+	err := NewWriter[any]().WithNewLine("\n").WithZeroAsNull(zeroAsNull).WriteView(context.Background(), &dest, view)
+	if err != nil {
+		t.Fatalf("Writer.WriteView() error = %v", err)
+	}
+	if want := "a;;0\nb;1.5;2\n"; dest.String() != want {
+		t.Errorf("Writer.WriteView() wrote %q but want %q", dest.String(), want)
+	}
+}
+
+func TestWriter_WithIncludeHiddenColumns(t *testing.T) {
+	type Row struct {
+		ID   int    `col:"ID,hidden"`
+		Name string `col:"Name"`
+	}
+	table := []Row{{ID: 1, Name: "A"}}
+	tests := []struct {
+		name   string
+		writer *Writer[[]Row]
+		want   string
+	}{
+		{name: "default", writer: NewWriter[[]Row](), want: "ID;Name\n1;A\n"},
+		{name: "omit hidden", writer: NewWriter[[]Row]().WithIncludeHiddenColumns(false), want: "Name\nA\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dest bytes.Buffer
+			err := tt.writer.WithHeaderRow(true).WithNewLine("\n").Write(context.Background(), &dest, table)
+			if err != nil {
+				t.Fatalf("Writer.Write() error = %v", err)
+			}
+			if dest.String() != tt.want {
+				t.Errorf("Writer.Write() wrote %q but want %q", dest.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriter_EstimateSize(t *testing.T) {
+	rows := make([][]any, 1000)
+	for i := range rows {
+		rows[i] = []any{"abc", 12345}
+	}
+	for _, view := range []*retable.AnyValuesView{
+		{Cols: []string{"A", "B"}},
+		{Cols: []string{"A", "B"}, Rows: rows[:1]},
+		{Cols: []string{"A", "B"}, Rows: rows},
+	} {
+		writer := NewWriter[any]().WithHeaderRow(true)
+		var dest bytes.Buffer
+		err := writer.WriteView(context.Background(), &dest, view)
+		if err != nil {
+			t.Fatalf("Writer.WriteView() error = %v", err)
+		}
+		estimate, err := writer.EstimateSize(context.Background(), view)
+		if err != nil {
+			t.Fatalf("Writer.EstimateSize() error = %v", err)
+		}
+		if estimate.Rows != len(view.Rows) || estimate.Bytes != int64(dest.Len()) {
+			t.Errorf("Writer.EstimateSize() = %d rows, %d bytes, want %d rows, %d bytes", estimate.Rows, estimate.Bytes, len(view.Rows), dest.Len())
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// DefaultTimeLayout is the time layout used by NewDefaultFormatters
+const DefaultTimeLayout = time.DateTime
+
+// NewDefaultFormatters returns a ReflectTypeCellFormatter
+// with sensible formatters for common types:
+//
+//   - time.Time and *time.Time with DefaultTimeLayout
+//     or time.DateOnly for times at midnight
+//     in the time location of the context, see WithTimeLocation
+//   - time.Duration with its String method
+//   - floats with 15 significant digits so that
+//     0.1+0.2 renders as 0.3 instead of 0.30000000000000004
+//   - bools as TRUE and FALSE
+//   - types implementing fmt.Stringer with their String method
+//
+// Null values are not formatted so that
+// the null policy of writers applies.
+func NewDefaultFormatters() *ReflectTypeCellFormatter {
+	return NewReflectTypeCellFormatter().
+		WithTypeFormatter(typeOfTime, CellFormatterFunc(formatDefaultTime)).
+		WithTypeFormatter(reflect.PointerTo(typeOfTime), CellFormatterFunc(formatDefaultTime)).
+		WithTypeFormatter(typeOfDuration, CellFormatterFunc(formatStringer)).
+		WithFloatFormatter(FloatCellFormatter{Precision: -1, SignificantDigits: 15, TrimZeros: true}).
+		WithKindFormatter(reflect.Bool, BoolRenderingTrueFalse).
+		WithInterfaceTypeFormatter(typeOfStringer, CellFormatterFunc(formatStringer))
+}
+
+var (
+	typeOfDuration = reflect.TypeFor[time.Duration]()
+	typeOfStringer = reflect.TypeFor[fmt.Stringer]()
+)
+
+func formatDefaultTime(ctx context.Context, view View, row, col int) (str string, raw bool, err error) {
+	v := AsReflectCellView(view).ReflectCell(row, col)
+	if IsNullLike(v) {
+		return "", false, errors.ErrUnsupported
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	t, ok := v.Interface().(time.Time)
+	if !ok {
+		return "", false, errors.ErrUnsupported
+	}
+	t = TimeInContextLocation(ctx, t)
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.Format(time.DateOnly), false, nil
+	}
+	return t.Format(DefaultTimeLayout), false, nil
+}
+
+// LocaleTimeFormatter formats time.Time and *time.Time values
+// in the time location of the context, see WithTimeLocation,
+// using the LocaleDateLayout of the locale of the context
+// followed by its LocaleTimeLayout for times not at midnight.
+// Without a locale in the context the layouts
+// of NewDefaultFormatters are used.
+//
+// Null values are not formatted so that
+// the null policy of writers applies.
+type LocaleTimeFormatter struct{}
+
+func (LocaleTimeFormatter) FormatCell(ctx context.Context, view View, row, col int) (str string, raw bool, err error) {
+	locale, ok := LocaleFromContext(ctx)
+	if !ok {
+		return formatDefaultTime(ctx, view, row, col)
+	}
+	v := AsReflectCellView(view).ReflectCell(row, col)
+	if IsNullLike(v) {
+		return "", false, errors.ErrUnsupported
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	t, ok := v.Interface().(time.Time)
+	if !ok {
+		return "", false, errors.ErrUnsupported
+	}
+	t = TimeInContextLocation(ctx, t)
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.Format(LocaleDateLayout(locale)), false, nil
+	}
+	return t.Format(LocaleDateLayout(locale) + " " + LocaleTimeLayout(locale)), false, nil
+}
+
+func formatStringer(ctx context.Context, view View, row, col int) (str string, raw bool, err error) {
+	v := AsReflectCellView(view).ReflectCell(row, col)
+	if IsNullLike(v) || !v.CanInterface() {
+		return "", false, errors.ErrUnsupported
+	}
+	stringer, ok := v.Interface().(fmt.Stringer)
+	if !ok {
+		return "", false, errors.ErrUnsupported
+	}
+	return stringer.String(), false, nil
+}
@@ -0,0 +1,48 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDefaultFormatters(t *testing.T) {
+	var (
+		point1, point2 = 0.1, 0.2
+		dateTime       = time.Date(2024, 10, 16, 14, 30, 0, 0, time.UTC)
+		date           = time.Date(2024, 10, 16, 0, 0, 0, 0, time.UTC)
+	)
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{name: "time", value: dateTime, want: "2024-10-16 14:30:00"},
+		{name: "time pointer", value: &dateTime, want: "2024-10-16 14:30:00"},
+		{name: "date", value: date, want: "2024-10-16"},
+		{name: "duration", value: 90 * time.Second, want: "1m30s"},
+		{name: "float", value: point1 + point2, want: "0.3"},
+		{name: "float32", value: float32(0.1), want: "0.1"},
+		{name: "bool", value: true, want: "TRUE"},
+		{name: "stringer", value: &url.URL{Scheme: "https", Host: "example.com"}, want: "https://example.com"},
+	}
+	formatters := NewDefaultFormatters()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := &AnyValuesView{Cols: []string{"A"}, Rows: [][]any{{tt.value}}}
+			str, _, err := formatters.FormatCell(context.Background(), view, 0, 0)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, str)
+		})
+	}
+
+	view := &AnyValuesView{Cols: []string{"A"}, Rows: [][]any{{(*time.Time)(nil)}, {nil}}}
+	for row := range view.NumRows() {
+		_, _, err := formatters.FormatCell(context.Background(), view, row, 0)
+		require.ErrorIs(t, err, errors.ErrUnsupported, "null values are not formatted")
+	}
+}
@@ -3,10 +3,15 @@ package retable
 import "reflect"
 
 // DerefView returns a View that dereferences
-// every value returned by the source View
-// by calling reflect.Value.Elem()
-// wich might panic if the contained value
-// does not support calling the Elem method.
+// the pointer and interface values returned by the source View
+// so they can be formatted like values of the pointed to types.
+//
+// Only one level of indirection is removed.
+// Nil pointers and interfaces result in nil cells,
+// all other values are returned unchanged.
+//
+// See also PointerHandling for dereferencing
+// the cells of views passed to writers.
 func DerefView(source View) ReflectCellView {
 	return derefView{source: AsReflectCellView(source)}
 }
@@ -20,9 +25,18 @@ func (v derefView) Columns() []string { return v.source.Columns() }
 func (v derefView) NumRows() int      { return v.source.NumRows() }
 
 func (v derefView) Cell(row, col int) any {
-	return v.source.ReflectCell(row, col).Elem().Interface()
+	val := v.ReflectCell(row, col)
+	if !val.IsValid() || !val.CanInterface() {
+		return nil
+	}
+	return val.Interface()
 }
 
 func (v derefView) ReflectCell(row, col int) reflect.Value {
-	return v.source.ReflectCell(row, col).Elem()
+	val := v.source.ReflectCell(row, col)
+	switch val.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return val.Elem()
+	}
+	return val
 }
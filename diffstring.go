@@ -0,0 +1,114 @@
+package retable
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DiffString returns a human readable textual diff of the
+// cells of the views a and b formatted as strings,
+// or an empty string if the views are equal.
+//
+// Rows and columns are compared by index.
+// The header row is compared like a data row.
+// Every row is prefixed with a marker:
+//   - "  " for equal rows
+//   - "~ " for rows with changed cells marked as "a → b"
+//   - "- " for rows only in a
+//   - "+ " for rows only in b
+//
+// The columns are aligned so that the result can be
+// used directly in test failure messages and logs:
+//
+//	if diff := retable.DiffString(expected, actual); diff != "" {
+//		t.Errorf("views differ:\n%s", diff)
+//	}
+func DiffString(a, b View) string {
+	rowsA, err := FormatViewAsStrings(context.Background(), a, nil, OptionAddHeaderRow)
+	if err != nil {
+		return fmt.Sprintf("can't format view a: %s", err)
+	}
+	rowsB, err := FormatViewAsStrings(context.Background(), b, nil, OptionAddHeaderRow)
+	if err != nil {
+		return fmt.Sprintf("can't format view b: %s", err)
+	}
+
+	var (
+		diffRows = make([][]string, max(len(rowsA), len(rowsB)))
+		markers  = make([]string, len(diffRows))
+		equal    = a.Title() == b.Title()
+	)
+	for row := range diffRows {
+		switch {
+		case row >= len(rowsB):
+			markers[row] = "- "
+			diffRows[row] = rowsA[row]
+			equal = false
+		case row >= len(rowsA):
+			markers[row] = "+ "
+			diffRows[row] = rowsB[row]
+			equal = false
+		default:
+			markers[row] = "  "
+			numCols := max(len(rowsA[row]), len(rowsB[row]))
+			diffRows[row] = make([]string, numCols)
+			for col := range numCols {
+				cellA, okA := diffCell(rowsA[row], col)
+				cellB, okB := diffCell(rowsB[row], col)
+				switch {
+				case okA && okB && cellA == cellB:
+					diffRows[row][col] = cellA
+				case !okB:
+					diffRows[row][col] = cellA + " → ∅"
+				case !okA:
+					diffRows[row][col] = "∅ → " + cellB
+				default:
+					diffRows[row][col] = cellA + " → " + cellB
+				}
+				if !okA || !okB || cellA != cellB {
+					markers[row] = "~ "
+					equal = false
+				}
+			}
+		}
+	}
+	if equal {
+		return ""
+	}
+
+	var out strings.Builder
+	if a.Title() != b.Title() {
+		fmt.Fprintf(&out, "~ title: %q → %q\n", a.Title(), b.Title())
+	} else if a.Title() != "" {
+		fmt.Fprintf(&out, "  title: %q\n", a.Title())
+	}
+	colWidths := MeasureColumnWidths(diffRows, -1, DisplayWidthOptions)
+	for row, rowStrs := range diffRows {
+		out.WriteString(markers[row])
+		for col, colWidth := range colWidths {
+			if col == 0 {
+				out.WriteString("| ")
+			} else {
+				out.WriteString(" | ")
+			}
+			str := ""
+			if col < len(rowStrs) {
+				str = rowStrs[col]
+			}
+			out.WriteString(str)
+			out.WriteString(strings.Repeat(" ", colWidth-StringWidth(str, DisplayWidthOptions)))
+		}
+		out.WriteString(" |\n")
+	}
+	return out.String()
+}
+
+// diffCell returns the cell of row at col
+// or false if the row has no such column.
+func diffCell(row []string, col int) (string, bool) {
+	if col >= len(row) {
+		return "", false
+	}
+	return row[col], true
+}
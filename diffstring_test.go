@@ -0,0 +1,57 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffString(t *testing.T) {
+	tests := []struct {
+		name string
+		a    View
+		b    View
+		want string
+	}{
+		{
+			name: "equal",
+			a:    NewStringsView("", [][]string{{"A", "B"}, {"1", "2"}}),
+			b:    NewStringsView("", [][]string{{"A", "B"}, {"1", "2"}}),
+			want: "",
+		},
+		{
+			name: "changed cell",
+			a:    NewStringsView("T", [][]string{{"A", "B"}, {"1", "2"}, {"3", "4"}}),
+			b:    NewStringsView("T", [][]string{{"A", "B"}, {"1", "x"}, {"3", "4"}}),
+			want: "" +
+				"  title: \"T\"\n" +
+				"  | A | B     |\n" +
+				"~ | 1 | 2 → x |\n" +
+				"  | 3 | 4     |\n",
+		},
+		{
+			name: "added and removed rows",
+			a:    NewStringsView("", [][]string{{"A"}, {"1"}, {"2"}}),
+			b:    NewStringsView("", [][]string{{"A"}, {"1"}}),
+			want: "" +
+				"  | A |\n" +
+				"  | 1 |\n" +
+				"- | 2 |\n",
+		},
+		{
+			name: "added column and title",
+			a:    NewStringsView("a", [][]string{{"A"}, {"1"}}),
+			b:    NewStringsView("b", [][]string{{"A", "B"}, {"1", "2"}, {"3", "4"}}),
+			want: "" +
+				"~ title: \"a\" → \"b\"\n" +
+				"~ | A | ∅ → B |\n" +
+				"~ | 1 | ∅ → 2 |\n" +
+				"+ | 3 | 4     |\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, DiffString(tt.a, tt.b))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package retable
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// DuplicateColumnPolicy defines how viewers handle
+// multiple columns with the same title
+// that break the matching of columns by title
+// when reading views into structs or querying them with SQL.
+type DuplicateColumnPolicy int
+
+const (
+	// DuplicateColumnsAllowed keeps duplicate column titles unchanged
+	DuplicateColumnsAllowed DuplicateColumnPolicy = iota
+	// DuplicateColumnsError returns an ErrDuplicateColumn
+	DuplicateColumnsError
+	// DuplicateColumnsSuffix appends the number of the occurrence
+	// to duplicate titles like "Total (2)"
+	DuplicateColumnsSuffix
+	// DuplicateColumnsMerge merges duplicate columns into
+	// the first column with the title by dropping the others
+	DuplicateColumnsMerge
+)
+
+func (p DuplicateColumnPolicy) String() string {
+	switch p {
+	case DuplicateColumnsAllowed:
+		return "DuplicateColumnsAllowed"
+	case DuplicateColumnsError:
+		return "DuplicateColumnsError"
+	case DuplicateColumnsSuffix:
+		return "DuplicateColumnsSuffix"
+	case DuplicateColumnsMerge:
+		return "DuplicateColumnsMerge"
+	}
+	return fmt.Sprintf("DuplicateColumnPolicy(%d)", int(p))
+}
+
+// ErrDuplicateColumn is returned for duplicate
+// column titles with DuplicateColumnsError.
+type ErrDuplicateColumn struct {
+	Column string
+	// Indices of the columns with the title
+	Indices []int
+}
+
+func (e ErrDuplicateColumn) Error() string {
+	return fmt.Sprintf("duplicate column %q at indices %v", e.Column, e.Indices)
+}
+
+// DuplicateColumns returns the titles that are used
+// for more than one column in the order of their first use.
+func DuplicateColumns(columns []string) []string {
+	count := make(map[string]int, len(columns))
+	var duplicates []string
+	for _, column := range columns {
+		count[column]++
+		if count[column] == 2 {
+			duplicates = append(duplicates, column)
+		}
+	}
+	return duplicates
+}
+
+// Apply applies the policy to columns and returns
+// the resulting titles with the same length as columns
+// and if the columns are kept or dropped by DuplicateColumnsMerge.
+func (p DuplicateColumnPolicy) Apply(columns []string) (titles []string, keep []bool, err error) {
+	titles = make([]string, len(columns))
+	keep = make([]bool, len(columns))
+	count := make(map[string]int, len(columns))
+	used := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		used[column] = true
+	}
+	for i, column := range columns {
+		titles[i] = column
+		keep[i] = true
+		count[column]++
+		if count[column] == 1 {
+			continue
+		}
+		switch p {
+		case DuplicateColumnsAllowed:
+		case DuplicateColumnsError:
+			var indices []int
+			for j, c := range columns {
+				if c == column {
+					indices = append(indices, j)
+				}
+			}
+			return nil, nil, ErrDuplicateColumn{Column: column, Indices: indices}
+		case DuplicateColumnsSuffix:
+			// Skip suffixes that are already used as titles
+			title := column + " (" + strconv.Itoa(count[column]) + ")"
+			for used[title] {
+				count[column]++
+				title = column + " (" + strconv.Itoa(count[column]) + ")"
+			}
+			used[title] = true
+			titles[i] = title
+		case DuplicateColumnsMerge:
+			keep[i] = false
+		default:
+			return nil, nil, fmt.Errorf("invalid %s", p)
+		}
+	}
+	return titles, keep, nil
+}
@@ -0,0 +1,97 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateColumnPolicy_Apply(t *testing.T) {
+	columns := []string{"A", "B", "A", "A (2)", "A"}
+	tests := []struct {
+		policy    DuplicateColumnPolicy
+		wantTitle []string
+		wantKeep  []bool
+		wantErr   error
+	}{
+		{
+			policy:    DuplicateColumnsAllowed,
+			wantTitle: columns,
+			wantKeep:  []bool{true, true, true, true, true},
+		},
+		{
+			policy:  DuplicateColumnsError,
+			wantErr: ErrDuplicateColumn{Column: "A", Indices: []int{0, 2, 4}},
+		},
+		{
+			policy:    DuplicateColumnsSuffix,
+			wantTitle: []string{"A", "B", "A (3)", "A (2)", "A (4)"},
+			wantKeep:  []bool{true, true, true, true, true},
+		},
+		{
+			policy:    DuplicateColumnsMerge,
+			wantTitle: columns,
+			wantKeep:  []bool{true, true, false, true, false},
+		},
+		{
+			policy:  DuplicateColumnPolicy(99),
+			wantErr: ErrDuplicateColumn{}, // Only checked for non nil
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			titles, keep, err := tt.policy.Apply(columns)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				if tt.policy == DuplicateColumnsError {
+					require.Equal(t, tt.wantErr, err)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantTitle, titles)
+			require.Equal(t, tt.wantKeep, keep)
+		})
+	}
+	require.Equal(t, []string{"A"}, DuplicateColumns(columns))
+	require.Nil(t, DuplicateColumns([]string{"A", "B"}))
+}
+
+func TestStructRowsViewer_DuplicateColumns(t *testing.T) {
+	type Row struct {
+		Net   float64 `col:"Amount"`
+		Gross float64 `col:"Amount"`
+		A     string  `col:"-"`
+		B     string  `col:"-"`
+	}
+	rows := []Row{{Net: 1, Gross: 2}}
+
+	view, err := DefaultStructRowsViewer().NewView("", rows)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Amount", "Amount"}, view.Columns())
+
+	_, err = DefaultStructRowsViewer().WithDuplicateColumns(DuplicateColumnsError).NewView("", rows)
+	require.ErrorAs(t, err, new(ErrDuplicateColumn))
+
+	view, err = DefaultStructRowsViewer().WithDuplicateColumns(DuplicateColumnsSuffix).NewView("", rows)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Amount", "Amount (2)"}, view.Columns())
+	require.Equal(t, 2.0, view.Cell(0, 1))
+
+	view, err = DefaultStructRowsViewer().WithDuplicateColumns(DuplicateColumnsMerge).NewView("", rows)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Amount"}, view.Columns())
+	require.Equal(t, 1.0, view.Cell(0, 0))
+}
+
+func TestStringsViewer_DuplicateColumns(t *testing.T) {
+	table := [][]string{{"A", "B", "A"}, {"1", "2", "3"}, {"4"}}
+
+	_, err := StringsViewer{DuplicateColumns: DuplicateColumnsError}.NewView("", table)
+	require.ErrorAs(t, err, new(ErrDuplicateColumn))
+
+	view, err := StringsViewer{DuplicateColumns: DuplicateColumnsMerge}.NewView("", table)
+	require.NoError(t, err)
+	require.Equal(t, []string{"A", "B"}, view.Columns())
+	require.Equal(t, &StringsView{Cols: []string{"A", "B"}, Rows: [][]string{{"1", "2"}, {"4"}}}, view)
+}
@@ -0,0 +1,125 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+var (
+	_ CellFormatter = new(EnumMapper)
+	_ Scanner       = new(EnumMapper)
+)
+
+// EnumMapper maps enum values like status codes to labels.
+//
+// It is a CellFormatter that renders values as labels
+// and a Scanner that parses labels back to values.
+// Use it as column formatter of a writer
+// and with LabelsToValuesView for the columns of a read view
+// to configure it per column.
+type EnumMapper struct {
+	labels map[any]string
+	values map[string]any
+}
+
+// NewEnumMapper returns an EnumMapper for the passed labels of values.
+// An error is returned if a label is used for more than one value
+// because labels could not be parsed back to values.
+func NewEnumMapper(labels map[any]string) (*EnumMapper, error) {
+	m := &EnumMapper{
+		labels: make(map[any]string, len(labels)),
+		values: make(map[string]any, len(labels)),
+	}
+	for value, label := range labels {
+		if other, exists := m.values[label]; exists {
+			return nil, fmt.Errorf("enum label %q used for %#v and %#v", label, other, value)
+		}
+		m.labels[value] = label
+		m.values[label] = value
+	}
+	return m, nil
+}
+
+// Label returns the label of value.
+func (m *EnumMapper) Label(value any) (label string, ok bool) {
+	if value == nil || !reflect.TypeOf(value).Comparable() {
+		return "", false
+	}
+	label, ok = m.labels[value]
+	return label, ok
+}
+
+// Value returns the value of label.
+func (m *EnumMapper) Value(label string) (value any, ok bool) {
+	value, ok = m.values[label]
+	return value, ok
+}
+
+// FormatCell implements CellFormatter by returning the label
+// of the cell value or errors.ErrUnsupported for values without label.
+func (m *EnumMapper) FormatCell(ctx context.Context, view View, row, col int) (str string, raw bool, err error) {
+	v := AsReflectCellView(view).ReflectCell(row, col)
+	if IsNullLike(v) || !v.CanInterface() {
+		return "", false, errors.ErrUnsupported
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	label, ok := m.Label(v.Interface())
+	if !ok {
+		return "", false, fmt.Errorf("%w: no enum label for %#v", errors.ErrUnsupported, v.Interface())
+	}
+	return label, false, nil
+}
+
+// ScanString implements Scanner by assigning the value
+// of the label str to dest using SmartAssign.
+// Strings that are no labels return errors.ErrUnsupported.
+func (m *EnumMapper) ScanString(dest reflect.Value, str string, parser Parser) error {
+	value, ok := m.Value(str)
+	if !ok {
+		return fmt.Errorf("%w: no enum value for label %q", errors.ErrUnsupported, str)
+	}
+	return SmartAssign(dest, reflect.ValueOf(value), nil, nil)
+}
+
+// LabelsToValuesView returns a view that replaces the labels
+// of the mapper in the cells of the passed columns
+// of the source view with their values
+// so that they can be read into structs with ViewToStructSlice.
+// Cells that are no labels are not changed.
+func (m *EnumMapper) LabelsToValuesView(source View, columns ...string) ReflectCellView {
+	mapped := make([]bool, len(source.Columns()))
+	for col, title := range source.Columns() {
+		mapped[col] = slices.Contains(columns, title)
+	}
+	return &enumValuesView{ReflectCellView: AsReflectCellView(source), mapper: m, mapped: mapped}
+}
+
+type enumValuesView struct {
+	ReflectCellView
+	mapper *EnumMapper
+	mapped []bool
+}
+
+func (view *enumValuesView) Cell(row, col int) any {
+	v := view.ReflectCell(row, col)
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func (view *enumValuesView) ReflectCell(row, col int) reflect.Value {
+	v := view.ReflectCellView.ReflectCell(row, col)
+	if col < 0 || col >= len(view.mapped) || !view.mapped[col] || v.Kind() != reflect.String {
+		return v
+	}
+	if value, ok := view.mapper.Value(v.String()); ok {
+		return reflect.ValueOf(value)
+	}
+	return v
+}
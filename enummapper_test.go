@@ -0,0 +1,76 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testStatus int
+
+const (
+	testStatusOpen testStatus = iota + 1
+	testStatusClosed
+)
+
+func TestEnumMapper(t *testing.T) {
+	mapper, err := NewEnumMapper(map[any]string{
+		testStatusOpen:   "Open",
+		testStatusClosed: "Closed",
+	})
+	require.NoError(t, err)
+
+	view := &AnyValuesView{
+		Cols: []string{"Status"},
+		Rows: [][]any{{testStatusOpen}, {testStatusClosed}, {testStatus(99)}, {nil}, {[]int{1}}},
+	}
+	for row, want := range []string{"Open", "Closed"} {
+		str, _, err := mapper.FormatCell(context.Background(), view, row, 0)
+		require.NoError(t, err)
+		require.Equal(t, want, str)
+	}
+	for row := 2; row < view.NumRows(); row++ {
+		_, _, err := mapper.FormatCell(context.Background(), view, row, 0)
+		require.ErrorIs(t, err, errors.ErrUnsupported, "row %d", row)
+	}
+
+	var status testStatus
+	err = mapper.ScanString(reflect.ValueOf(&status).Elem(), "Closed", nil)
+	require.NoError(t, err)
+	require.Equal(t, testStatusClosed, status)
+	err = mapper.ScanString(reflect.ValueOf(&status).Elem(), "Unknown", nil)
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+
+	_, err = NewEnumMapper(map[any]string{1: "Same", 2: "Same"})
+	require.Error(t, err)
+}
+
+func TestEnumMapper_read(t *testing.T) {
+	mapper, err := NewEnumMapper(map[any]string{
+		testStatusOpen:   "Open",
+		testStatusClosed: "Closed",
+	})
+	require.NoError(t, err)
+
+	type Row struct {
+		Name   string
+		Status testStatus
+	}
+	view := &StringsView{
+		Cols: []string{"Name", "Status"},
+		Rows: [][]string{{"A", "Closed"}, {"B", "Open"}},
+	}
+
+	// As scanner for all columns
+	rows, err := ViewToStructSlice[Row](view, nil, mapper, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Row{{"A", testStatusClosed}, {"B", testStatusOpen}}, rows)
+
+	// Per column
+	rows, err = ViewToStructSlice[Row](mapper.LabelsToValuesView(view, "Status"), nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Row{{"A", testStatusClosed}, {"B", testStatusOpen}}, rows)
+}
@@ -0,0 +1,143 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrorAction defines what a writer does with cells
+// that have error values or whose formatting failed.
+type ErrorAction int
+
+const (
+	// ErrorAbort aborts writing with a CellError
+	ErrorAbort ErrorAction = iota
+	// ErrorRenderMessage renders the error message
+	ErrorRenderMessage
+	// ErrorRenderPlaceholder renders the ErrorPolicy.Placeholder
+	ErrorRenderPlaceholder
+)
+
+func (a ErrorAction) String() string {
+	switch a {
+	case ErrorAbort:
+		return "ErrorAbort"
+	case ErrorRenderMessage:
+		return "ErrorRenderMessage"
+	case ErrorRenderPlaceholder:
+		return "ErrorRenderPlaceholder"
+	}
+	return fmt.Sprintf("ErrorAction(%d)", int(a))
+}
+
+// CellError is the error returned by writers
+// for a cell that could not be written
+// with the position of the cell.
+type CellError struct {
+	Row    int
+	Col    int
+	Column string
+	Err    error
+}
+
+// NewCellError returns a CellError for the cell
+// at row and col of view wrapping err.
+// If err already is a CellError, then it is returned unchanged.
+func NewCellError(view View, row, col int, err error) error {
+	var cellErr CellError
+	if errors.As(err, &cellErr) {
+		return err
+	}
+	var column string
+	if columns := view.Columns(); col >= 0 && col < len(columns) {
+		column = columns[col]
+	}
+	return CellError{Row: row, Col: col, Column: column, Err: err}
+}
+
+func (e CellError) Error() string {
+	return fmt.Sprintf("cell at row %d column %d %q: %s", e.Row, e.Col, e.Column, e.Err)
+}
+
+func (e CellError) Unwrap() error {
+	return e.Err
+}
+
+var _ CellFormatter = ErrorPolicy{}
+
+// ErrorPolicy defines how writers handle cells
+// with values implementing the error interface
+// and cells whose formatters returned an error.
+//
+// The zero value aborts writing with a CellError.
+// Errors of canceled contexts and ErrNullValue
+// errors of a NullPolicy always abort.
+//
+// ErrorPolicy implements CellFormatter returning
+// errors.ErrUnsupported for non error values.
+type ErrorPolicy struct {
+	// Action for errors
+	Action ErrorAction
+	// Placeholder is rendered for errors
+	// if Action is ErrorRenderPlaceholder
+	Placeholder string
+}
+
+// RenderErrorMessage returns an ErrorPolicy
+// that renders the error message.
+func RenderErrorMessage() ErrorPolicy {
+	return ErrorPolicy{Action: ErrorRenderMessage}
+}
+
+// RenderErrorAs returns an ErrorPolicy
+// that renders errors as the passed placeholder.
+func RenderErrorAs(placeholder string) ErrorPolicy {
+	return ErrorPolicy{Action: ErrorRenderPlaceholder, Placeholder: placeholder}
+}
+
+// FormatCell implements CellFormatter.
+// It returns errors.ErrUnsupported if the cell value is not an error.
+func (p ErrorPolicy) FormatCell(ctx context.Context, view View, row, col int) (str string, raw bool, err error) {
+	cellErr := CellErrorValue(view, row, col)
+	if cellErr == nil {
+		return "", false, errors.ErrUnsupported
+	}
+	return p.FormatError(view, row, col, cellErr)
+}
+
+// FormatError returns the string for the cell at the
+// passed row and column of a View that failed with err
+// or a CellError if the error aborts writing.
+func (p ErrorPolicy) FormatError(view View, row, col int, err error) (str string, raw bool, e error) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return "", false, err
+	}
+	if errors.Is(err, ErrNullValue) {
+		return "", false, NewCellError(view, row, col, err)
+	}
+	switch p.Action {
+	case ErrorAbort:
+		return "", false, NewCellError(view, row, col, err)
+	case ErrorRenderMessage:
+		var cellErr CellError
+		if errors.As(err, &cellErr) {
+			return cellErr.Err.Error(), false, nil
+		}
+		return err.Error(), false, nil
+	case ErrorRenderPlaceholder:
+		return p.Placeholder, false, nil
+	}
+	return "", false, fmt.Errorf("invalid %s", p.Action)
+}
+
+// CellErrorValue returns the value of a cell
+// if it is a non nil value implementing the error interface
+// or else nil.
+func CellErrorValue(view View, row, col int) error {
+	v := AsReflectCellView(view).ReflectCell(row, col)
+	if IsNullLike(v) || !v.CanInterface() || !v.Type().Implements(typeOfError) {
+		return nil
+	}
+	return v.Interface().(error)
+}
@@ -0,0 +1,56 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorPolicy_FormatCell(t *testing.T) {
+	view := &AnyValuesView{
+		Cols: []string{"A", "B"},
+		Rows: [][]any{{1, errors.New("failed")}},
+	}
+	tests := []struct {
+		name    string
+		policy  ErrorPolicy
+		col     int
+		want    string
+		wantErr error
+	}{
+		{name: "no error value", policy: RenderErrorMessage(), col: 0, wantErr: errors.ErrUnsupported},
+		{name: "abort", policy: ErrorPolicy{}, col: 1, wantErr: CellError{Row: 0, Col: 1, Column: "B", Err: view.Rows[0][1].(error)}},
+		{name: "message", policy: RenderErrorMessage(), col: 1, want: "failed"},
+		{name: "placeholder", policy: RenderErrorAs("#ERR"), col: 1, want: "#ERR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			str, _, err := tt.policy.FormatCell(context.Background(), view, 0, tt.col)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, str)
+		})
+	}
+}
+
+func TestErrorPolicy_FormatError(t *testing.T) {
+	view := &AnyValuesView{Cols: []string{"A"}, Rows: [][]any{{1}}}
+
+	_, _, err := RenderErrorAs("#ERR").FormatError(view, 0, 0, context.Canceled)
+	require.Equal(t, context.Canceled, err, "canceled context always aborts unwrapped")
+
+	_, _, err = RenderErrorAs("#ERR").FormatError(view, 0, 0, ErrNullValue)
+	require.ErrorIs(t, err, ErrNullValue)
+	var cellErr CellError
+	require.ErrorAs(t, err, &cellErr)
+	require.Equal(t, `cell at row 0 column 0 "A": null value`, cellErr.Error())
+
+	str, _, err := RenderErrorMessage().FormatError(view, 0, 0, NewCellError(view, 0, 0, errors.New("wrapped")))
+	require.NoError(t, err)
+	require.Equal(t, "wrapped", str)
+}
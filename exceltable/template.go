@@ -0,0 +1,248 @@
+package exceltable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/domonda/go-retable"
+)
+
+// TemplatePlaceholder returns the placeholder marker "{{name}}"
+// that can be put into a cell of a template workbook
+// to mark where the rows of the view with name are inserted.
+func TemplatePlaceholder(name string) string {
+	return "{{" + name + "}}"
+}
+
+// FillTemplate reads a template workbook, injects the rows
+// of the passed views, and writes the resulting workbook to dest.
+// See Writer.FillWorkbook for how the views are located in the template.
+func FillTemplate(ctx context.Context, dest io.Writer, template io.Reader, views map[string]retable.View) error {
+	return NewWriter[any]().FillTemplate(ctx, dest, template, views)
+}
+
+// FillTemplate reads a template workbook, injects the rows
+// of the passed views, and writes the resulting workbook to dest.
+// See FillWorkbook for how the views are located in the template.
+//
+// An OpenTelemetry span is created for the write
+// and metrics are recorded if a MetricsRecorder is set.
+func (w *Writer[T]) FillTemplate(ctx context.Context, dest io.Writer, template io.Reader, views map[string]retable.View) (err error) {
+	names := sortedViewNames(views)
+	ctx, iw := retable.StartInstrumentedWrite(ctx, w.metrics, "xlsx", viewTitles(views, names), dest)
+	defer func() { err = iw.End(ctx, err) }()
+
+	wb, err := OpenWorkbook(template)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, wb.Close())
+	}()
+
+	err = w.fillWorkbook(ctx, wb.File(), views, names, iw.Hooks(w.hooks))
+	if err != nil {
+		return err
+	}
+	return wb.Write(&retable.MaxBytesWriter{Dest: iw, Max: w.maxBytes})
+}
+
+// FillWorkbook injects the rows of the passed views into the workbook f.
+//
+// The map key of a view is the name of a defined name (named range)
+// of the workbook or of a placeholder cell with the value "{{name}}".
+// The first row of the view is written at the top left cell
+// of the named range or placeholder, the following rows below it.
+// If a view has more rows than the named range or placeholder
+// then the last row of the range is duplicated for the additional rows
+// so that the rows have the same formatting and the content below is moved down.
+//
+// A column header row is only written when enabled with WithHeaderRow,
+// because the template usually owns the layout including the headers.
+//
+// An OpenTelemetry span is created for the write
+// and metrics are recorded if a MetricsRecorder is set.
+// No bytes are counted because the file is written by the caller.
+func (w *Writer[T]) FillWorkbook(ctx context.Context, f *excelize.File, views map[string]retable.View) (err error) {
+	names := sortedViewNames(views)
+	ctx, iw := retable.StartInstrumentedWrite(ctx, w.metrics, "xlsx", viewTitles(views, names), io.Discard)
+	defer func() { err = iw.End(ctx, err) }()
+
+	return w.fillWorkbook(ctx, f, views, names, iw.Hooks(w.hooks))
+}
+
+// sortedViewNames returns the sorted names of views
+// for deterministic results when rows are inserted.
+func sortedViewNames(views map[string]retable.View) []string {
+	names := make([]string, 0, len(views))
+	for name := range views {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// viewTitles returns the titles of the views with names
+// joined with commas for the instrumentation of a write.
+func viewTitles(views map[string]retable.View, names []string) string {
+	titles := make([]string, len(names))
+	for i, name := range names {
+		titles[i] = views[name].Title()
+	}
+	return strings.Join(titles, ", ")
+}
+
+func (w *Writer[T]) fillWorkbook(ctx context.Context, f *excelize.File, views map[string]retable.View, names []string, hooks *retable.WriteHooks) error {
+	for _, name := range names {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		sheet, col, row, numRangeRows, err := findTemplateRange(f, name)
+		if err != nil {
+			return err
+		}
+		err = w.fillTemplateRange(ctx, f, sheet, col, row, numRangeRows, views[name], hooks)
+		if err != nil {
+			return fmt.Errorf("can't fill template range %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (w *Writer[T]) fillTemplateRange(ctx context.Context, f *excelize.File, sheet string, firstCol, firstRow, numRangeRows int, view retable.View, hooks *retable.WriteHooks) error {
+	view = w.transformView(view)
+	numCols := len(view.Columns())
+	numDataRows := view.NumRows()
+	truncate := w.maxRows > 0 && numDataRows > w.maxRows
+	if truncate {
+		if w.truncationMarker == "" {
+			return retable.ErrMaxRowsExceeded{Max: w.maxRows, NumRows: numDataRows}
+		}
+		numDataRows = w.maxRows
+	}
+	numRows := numDataRows
+	if w.headerRow {
+		numRows++
+	}
+	if truncate {
+		numRows++ // Marker row
+	}
+	lastRangeRow := firstRow + numRangeRows - 1
+	for i := numRangeRows; i < numRows; i++ {
+		err := f.DuplicateRow(sheet, lastRangeRow)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Clear the placeholder and duplicated values
+	// but keep the cell styles
+	for row := firstRow; row < firstRow+max(numRows, numRangeRows); row++ {
+		for col := firstCol; col < firstCol+max(numCols, 1); col++ {
+			cell, err := excelize.CoordinatesToCellName(col, row)
+			if err != nil {
+				return err
+			}
+			err = f.SetCellValue(sheet, cell, nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	rowOffset := firstRow
+	if w.headerRow {
+		for col, title := range view.Columns() {
+			cell, err := excelize.CoordinatesToCellName(firstCol+col, rowOffset)
+			if err != nil {
+				return err
+			}
+			err = f.SetCellStr(sheet, cell, title)
+			if err != nil {
+				return err
+			}
+			err = addDescriptionComment(f, sheet, cell, view, col)
+			if err != nil {
+				return err
+			}
+		}
+		rowOffset++
+	}
+	for row := 0; row < numDataRows; row++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		hooks.RowStart(ctx, view, row)
+		err := w.writeRow(ctx, f, sheet, view, row, firstCol, rowOffset+row, numCols, hooks)
+		hooks.RowEnd(ctx, view, row, err)
+		if err != nil {
+			return err
+		}
+	}
+	if truncate {
+		cell, err := excelize.CoordinatesToCellName(firstCol, rowOffset+numDataRows)
+		if err != nil {
+			return err
+		}
+		return f.SetCellStr(sheet, cell, w.truncationMarker)
+	}
+	return nil
+}
+
+// findTemplateRange returns the sheet, the 1 based top left coordinates,
+// and the number of rows of the defined name or placeholder cell with name.
+func findTemplateRange(f *excelize.File, name string) (sheet string, col, row, numRows int, err error) {
+	for _, definedName := range f.GetDefinedName() {
+		if definedName.Name == name {
+			return parseRangeRef(definedName.RefersTo)
+		}
+	}
+	placeholder := TemplatePlaceholder(name)
+	for _, sheet := range f.GetSheetList() {
+		cells, err := f.SearchSheet(sheet, placeholder)
+		if err != nil {
+			return "", 0, 0, 0, err
+		}
+		if len(cells) > 0 {
+			col, row, err := excelize.CellNameToCoordinates(cells[0])
+			if err != nil {
+				return "", 0, 0, 0, err
+			}
+			return sheet, col, row, 1, nil
+		}
+	}
+	return "", 0, 0, 0, fmt.Errorf("no defined name or placeholder %s found in template", placeholder)
+}
+
+// parseRangeRef parses a range reference like "'My Sheet'!$B$2:$D$4"
+// into the sheet name, the top left coordinates, and the number of rows.
+func parseRangeRef(ref string) (sheet string, col, row, numRows int, err error) {
+	sep := strings.LastIndexByte(ref, '!')
+	if sep == -1 {
+		return "", 0, 0, 0, fmt.Errorf("range reference %q has no sheet", ref)
+	}
+	sheet = ref[:sep]
+	if strings.HasPrefix(sheet, "'") && strings.HasSuffix(sheet, "'") && len(sheet) > 1 {
+		sheet = strings.ReplaceAll(sheet[1:len(sheet)-1], "''", "'")
+	}
+	topLeft, bottomRight, _ := strings.Cut(strings.ReplaceAll(ref[sep+1:], "$", ""), ":")
+	col, row, err = excelize.CellNameToCoordinates(topLeft)
+	if err != nil {
+		return "", 0, 0, 0, err
+	}
+	numRows = 1
+	if bottomRight != "" {
+		_, lastRow, err := excelize.CellNameToCoordinates(bottomRight)
+		if err != nil {
+			return "", 0, 0, 0, err
+		}
+		numRows = max(lastRow-row+1, 1)
+	}
+	return sheet, col, row, numRows, nil
+}
@@ -0,0 +1,100 @@
+package exceltable
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/domonda/go-retable"
+)
+
+func TestFillTemplate(t *testing.T) {
+	tmpl := excelize.NewFile()
+	require.NoError(t, tmpl.SetSheetRow("Sheet1", "A1", &[]any{"Item", "Amount"}))
+	require.NoError(t, tmpl.SetCellStr("Sheet1", "A2", TemplatePlaceholder("items")))
+	require.NoError(t, tmpl.SetCellStr("Sheet1", "A3", "Total"))
+	require.NoError(t, tmpl.SetSheetRow("Sheet1", "A5", &[]any{"Name", "Count"}))
+	require.NoError(t, tmpl.SetDefinedName(&excelize.DefinedName{Name: "Counts", RefersTo: "Sheet1!$A$6:$B$6"}))
+	var template bytes.Buffer
+	require.NoError(t, tmpl.Write(&template))
+	require.NoError(t, tmpl.Close())
+
+	views := map[string]retable.View{
+		"items": &retable.AnyValuesView{
+			Cols: []string{"Item", "Amount"},
+			Rows: [][]any{{"Apples", 1.5}, {"Pears", 2}, {"Plums", 3}},
+		},
+		"Counts": &retable.AnyValuesView{
+			Cols: []string{"Name", "Count"},
+			Rows: [][]any{{"A", 1}},
+		},
+	}
+	var buf bytes.Buffer
+	err := FillTemplate(context.Background(), &buf, &template, views)
+	require.NoError(t, err)
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	defer f.Close()
+	rows, err := f.GetRows("Sheet1")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"Item", "Amount"},
+		{"Apples", "1.5"},
+		{"Pears", "2"},
+		{"Plums", "3"},
+		{"Total"},
+		nil,
+		{"Name", "Count"},
+		{"A", "1"},
+	}, rows)
+
+	err = FillTemplate(context.Background(), &buf, bytes.NewReader(template.Bytes()), map[string]retable.View{"missing": views["items"]})
+	require.Error(t, err)
+}
+
+func TestWriter_FillTemplate_options(t *testing.T) {
+	tmpl := excelize.NewFile()
+	require.NoError(t, tmpl.SetCellStr("Sheet1", "A1", TemplatePlaceholder("items")))
+	require.NoError(t, tmpl.SetCellStr("Sheet1", "A2", "Total"))
+	var template bytes.Buffer
+	require.NoError(t, tmpl.Write(&template))
+	require.NoError(t, tmpl.Close())
+
+	views := map[string]retable.View{
+		"items": &retable.AnyValuesView{
+			Cols: []string{"Item", "Amount"},
+			Rows: [][]any{{"Apples", 0}, {"Pears", 2}, {"Plums", 3}},
+		},
+	}
+	fill := func(t *testing.T, w *Writer[any]) ([][]string, error) {
+		t.Helper()
+		var buf bytes.Buffer
+		err := w.FillTemplate(context.Background(), &buf, bytes.NewReader(template.Bytes()), views)
+		if err != nil {
+			return nil, err
+		}
+		f, err := excelize.OpenReader(&buf)
+		require.NoError(t, err)
+		t.Cleanup(func() { f.Close() })
+		rows, err := f.GetRows("Sheet1")
+		require.NoError(t, err)
+		return rows, nil
+	}
+
+	rows, err := fill(t, NewWriter[any]().
+		WithZeroAsNull(retable.ZeroAsNull{}.WithIndices(1)).
+		WithNullPolicy(retable.RenderNullAs("-")))
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"Apples", "-"}, {"Pears", "2"}, {"Plums", "3"}, {"Total"}}, rows)
+
+	rows, err = fill(t, NewWriter[any]().WithMaxRows(2).WithTruncationMarker("..."))
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"Apples", "0"}, {"Pears", "2"}, {"..."}, {"Total"}}, rows)
+
+	_, err = fill(t, NewWriter[any]().WithMaxRows(2))
+	require.ErrorAs(t, err, new(retable.ErrMaxRowsExceeded))
+}
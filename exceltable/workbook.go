@@ -0,0 +1,225 @@
+package exceltable
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/domonda/go-retable"
+)
+
+// Workbook keeps an Excel workbook open
+// to read sheets as views, modify cell values,
+// and write the workbook back with all existing
+// formatting, formulas, and other sheets preserved.
+//
+// This is the typical workflow for filling in template workbooks:
+//
+//	wb, err := exceltable.OpenWorkbookFile("template.xlsx")
+//	...
+//	defer wb.Close()
+//	sheet, err := wb.Sheet("Invoices", false)
+//	...
+//	err = sheet.SetCell(0, 2, 99.5)
+//	...
+//	err = wb.SaveAs("filled.xlsx")
+type Workbook struct {
+	file *excelize.File
+}
+
+// NewWorkbook returns a Workbook for an already opened excelize.File.
+func NewWorkbook(file *excelize.File) *Workbook {
+	return &Workbook{file: file}
+}
+
+// OpenWorkbook reads a workbook from reader.
+// The returned Workbook must be closed after usage.
+func OpenWorkbook(reader io.Reader) (*Workbook, error) {
+	f, err := excelize.OpenReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	return &Workbook{file: f}, nil
+}
+
+// OpenWorkbookFile opens the workbook file with filename.
+// The returned Workbook must be closed after usage.
+func OpenWorkbookFile(filename string) (*Workbook, error) {
+	f, err := excelize.OpenFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &Workbook{file: f}, nil
+}
+
+// File returns the underlying excelize.File
+// for modifications not covered by Workbook.
+func (wb *Workbook) File() *excelize.File {
+	return wb.file
+}
+
+// SheetNames returns the names of all sheets in the workbook.
+func (wb *Workbook) SheetNames() []string {
+	return wb.file.GetSheetList()
+}
+
+// Sheet reads the sheet with the passed name into a SheetView.
+// The first non empty row of the sheet is used as column titles.
+// If rawCellStrings is true then the cell strings are read
+// without applying the number format of the cells.
+func (wb *Workbook) Sheet(name string, rawCellStrings bool) (*SheetView, error) {
+	opts := excelize.Options{RawCellValue: rawCellStrings}
+	rows, err := wb.file.GetRows(name, opts)
+	if err != nil {
+		return nil, err
+	}
+	headerRow := slices.IndexFunc(rows, func(row []string) bool { return !retable.IsStringRowEmpty(row) })
+	if headerRow == -1 {
+		return nil, ErrEmptySheet
+	}
+	for len(rows) > headerRow+1 && retable.IsStringRowEmpty(rows[len(rows)-1]) {
+		rows = rows[:len(rows)-1]
+	}
+	numCols := 0
+	for _, row := range rows[headerRow:] {
+		numCols = max(numCols, len(row))
+	}
+	columns := make([]string, numCols)
+	copy(columns, rows[headerRow])
+	return &SheetView{
+		workbook:  wb,
+		sheet:     name,
+		opts:      opts,
+		headerRow: headerRow,
+		columns:   columns,
+		rows:      rows[headerRow+1:],
+	}, nil
+}
+
+// Write writes the workbook in XLSX format to dest.
+func (wb *Workbook) Write(dest io.Writer) error {
+	return wb.file.Write(dest)
+}
+
+// SaveAs saves the workbook in XLSX format to filename.
+func (wb *Workbook) SaveAs(filename string) error {
+	return wb.file.SaveAs(filename)
+}
+
+// Close closes the workbook and removes temporary files.
+func (wb *Workbook) Close() error {
+	return wb.file.Close()
+}
+
+var _ retable.ReflectCellView = new(SheetView)
+
+// SheetView is a View of a Workbook sheet
+// that writes modified cell values back to the workbook.
+//
+// Empty rows and columns within the sheet are kept
+// so that every view cell maps directly to a sheet cell.
+// Cells of the view are the cell strings as read from the workbook.
+type SheetView struct {
+	workbook  *Workbook
+	sheet     string
+	opts      excelize.Options
+	headerRow int // Zero based index of the header row in the sheet
+	columns   []string
+	rows      [][]string
+}
+
+func (view *SheetView) Title() string     { return view.sheet }
+func (view *SheetView) Columns() []string { return view.columns }
+func (view *SheetView) NumRows() int      { return len(view.rows) }
+
+func (view *SheetView) Cell(row, col int) any {
+	if row < 0 || col < 0 || row >= len(view.rows) || col >= len(view.columns) {
+		return nil
+	}
+	if col >= len(view.rows[row]) {
+		return ""
+	}
+	return view.rows[row][col]
+}
+
+func (view *SheetView) ReflectCell(row, col int) reflect.Value {
+	if row < 0 || col < 0 || row >= len(view.rows) || col >= len(view.columns) {
+		return reflect.Value{}
+	}
+	return reflect.ValueOf(view.Cell(row, col))
+}
+
+// CellName returns the Excel cell name like "B3"
+// of the view cell at row and col.
+func (view *SheetView) CellName(row, col int) (string, error) {
+	return excelize.CoordinatesToCellName(col+1, view.headerRow+row+2)
+}
+
+// SetCell sets the value of the cell at row and col
+// in the workbook and re-reads the cell string for the view.
+// The existing style of the workbook cell is preserved.
+// A nil value clears the cell and FormulaCell values
+// are written as formulas.
+func (view *SheetView) SetCell(row, col int, value any) error {
+	if row < 0 || col < 0 || row >= len(view.rows) || col >= len(view.columns) {
+		return fmt.Errorf("cell row %d, column %d out of range for sheet %q with %d rows and %d columns", row, col, view.sheet, len(view.rows), len(view.columns))
+	}
+	cell, err := view.CellName(row, col)
+	if err != nil {
+		return err
+	}
+	f := view.workbook.file
+	v := reflect.ValueOf(value)
+	if retable.IsNullLike(v) {
+		err = f.SetCellValue(view.sheet, cell, nil)
+	} else {
+		if v.Kind() == reflect.Pointer {
+			v = v.Elem()
+		}
+		err = setCellValue(f, view.sheet, cell, v)
+	}
+	if err != nil {
+		return err
+	}
+	str, err := f.GetCellValue(view.sheet, cell, view.opts)
+	if err != nil {
+		return err
+	}
+	if col >= len(view.rows[row]) {
+		view.rows[row] = append(view.rows[row], make([]string, col+1-len(view.rows[row]))...)
+	}
+	view.rows[row][col] = str
+	return nil
+}
+
+// AppendRow appends a row with the passed values below the last row of the view.
+// If the view has rows then the last row is duplicated first
+// so that the new row gets the same formatting.
+// Values beyond the number of columns result in an error.
+func (view *SheetView) AppendRow(values ...any) (err error) {
+	if len(values) > len(view.columns) {
+		return fmt.Errorf("%d values for sheet %q with %d columns", len(values), view.sheet, len(view.columns))
+	}
+	numRows := len(view.rows)
+	if numRows > 0 {
+		// Excel row numbers are 1 based and the header row comes before the data rows
+		lastRow := view.headerRow + numRows + 1
+		err = view.workbook.file.DuplicateRow(view.sheet, lastRow)
+		if err != nil {
+			return err
+		}
+	}
+	view.rows = append(view.rows, nil)
+	for col := range view.columns {
+		var value any
+		if col < len(values) {
+			value = values[col]
+		}
+		err = errors.Join(err, view.SetCell(numRows, col, value))
+	}
+	return err
+}
@@ -0,0 +1,53 @@
+package exceltable
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestWorkbook_FillTemplate(t *testing.T) {
+	// Create a template with a styled data row
+	tmpl := excelize.NewFile()
+	require.NoError(t, tmpl.SetSheetRow("Sheet1", "A2", &[]any{"Item", "Amount"}))
+	require.NoError(t, tmpl.SetSheetRow("Sheet1", "A3", &[]any{"Apples", 1.5}))
+	style, err := tmpl.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	require.NoError(t, err)
+	require.NoError(t, tmpl.SetCellStyle("Sheet1", "B3", "B3", style))
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Write(&buf))
+	require.NoError(t, tmpl.Close())
+
+	wb, err := OpenWorkbook(&buf)
+	require.NoError(t, err)
+	defer wb.Close()
+
+	sheet, err := wb.Sheet("Sheet1", true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Item", "Amount"}, sheet.Columns())
+	require.Equal(t, 1, sheet.NumRows())
+	require.Equal(t, "1.5", sheet.Cell(0, 1))
+
+	require.NoError(t, sheet.SetCell(0, 1, 2.5))
+	require.Equal(t, "2.5", sheet.Cell(0, 1))
+	require.NoError(t, sheet.AppendRow("Pears", 3))
+	require.Equal(t, 2, sheet.NumRows())
+	require.Error(t, sheet.SetCell(2, 0, "out of range"))
+
+	buf.Reset()
+	require.NoError(t, wb.Write(&buf))
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	defer f.Close()
+
+	rows, err := f.GetRows("Sheet1")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{nil, {"Item", "Amount"}, {"Apples", "2.5"}, {"Pears", "3"}}, rows)
+	for _, cell := range []string{"B3", "B4"} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		require.NoError(t, err)
+		require.Equal(t, style, styleID, "style of %s preserved", cell)
+	}
+}
@@ -0,0 +1,1084 @@
+package exceltable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/domonda/go-retable"
+)
+
+// FormulaCell is a cell value that is written
+// as Excel formula like "=SUM(B2:B10)".
+// The leading "=" is optional.
+type FormulaCell string
+
+// Writer writes views as sheets of an Excel XLSX workbook.
+//
+// Numbers, bools, and time values are written as native Excel values,
+// other values are formatted as strings.
+//
+// Strings returned as raw by a cell formatter that start
+// with "=" are written as formulas, so formatters can
+// emit computed cells like totals that recalc inside Excel.
+type Writer[T any] struct {
+	viewer           retable.Viewer
+	columnFormatters map[int]retable.CellFormatter
+	typeFormatters   *retable.ReflectTypeCellFormatter
+	nullPolicy       retable.NullPolicy
+	columnNulls      retable.ColumnNullPolicies
+	errorPolicy      retable.ErrorPolicy
+	headerRow        bool
+	freezeHeaderRow  bool
+	autoFilter       bool
+	tableStyle       string
+	hiddenColumns    []int
+	protectedColumns []int
+	password         string
+	redactedColumns  []string
+	redaction        string
+	hooks            *retable.WriteHooks
+	metrics          retable.MetricsRecorder
+	maxRows          int
+	maxBytes         int64
+	truncationMarker string
+	autoColumnWidth  bool
+	columnGroups     []retable.ColumnGroup
+	provenance       *retable.Provenance
+	recoverPanics    bool
+	rowTimeout       time.Duration
+	pointerHandling  retable.PointerHandling
+	zeroAsNull       retable.ZeroAsNull
+	includeHidden    bool
+	rawPolicy        retable.RawPolicy
+}
+
+func NewWriter[T any]() *Writer[T] {
+	return &Writer[T]{
+		viewer:           nil,
+		columnFormatters: make(map[int]retable.CellFormatter),
+		typeFormatters:   nil, // OK to use nil retable.TypeFormatters
+		nullPolicy:       retable.RenderNullAs(""),
+		columnNulls:      retable.ColumnNullPolicies{},
+		errorPolicy:      retable.ErrorPolicy{},
+		headerRow:        false,
+		freezeHeaderRow:  false,
+		autoFilter:       false,
+		tableStyle:       "",
+		hiddenColumns:    nil,
+		protectedColumns: nil,
+		password:         "",
+		redactedColumns:  nil,
+		redaction:        retable.DefaultRedactionPlaceholder,
+		hooks:            nil,
+		metrics:          nil,
+		maxRows:          0,
+		maxBytes:         0,
+		truncationMarker: "",
+		autoColumnWidth:  false,
+		columnGroups:     nil,
+		provenance:       nil,
+		recoverPanics:    false,
+		rowTimeout:       0,
+		pointerHandling:  retable.PointerKeep,
+		zeroAsNull:       retable.ZeroAsNull{},
+		includeHidden:    true,
+		rawPolicy:        retable.RawTrust,
+	}
+}
+
+func (w *Writer[T]) clone() *Writer[T] {
+	c := new(Writer[T])
+	*c = *w
+	return c
+}
+
+// Capabilities returns the capabilities of the Excel writer.
+func (w *Writer[T]) Capabilities() retable.WriterCapabilities {
+	return retable.WriterCapabilities{
+		Format:      "xlsx",
+		NativeTypes: true,
+		Styles:      true,
+		MultiSheet:  true,
+	}
+}
+
+// Write calls WriteView with the result of Viewer.NewView(table)
+// using the writer's viewer if not nil or else retable.SelectViewer.
+// The optional sheetName is used as title of the view
+// and as name of the written sheet.
+func (w *Writer[T]) Write(ctx context.Context, dest io.Writer, table T, sheetName ...string) error {
+	viewer := w.viewer
+	if viewer == nil {
+		var err error
+		viewer, err = retable.SelectViewer(table)
+		if err != nil {
+			return err
+		}
+	}
+	return w.WriteWithViewer(ctx, dest, viewer, table, sheetName...)
+}
+
+// WriteWithViewer calls WriteView with the result of viewer.NewView(table).
+func (w *Writer[T]) WriteWithViewer(ctx context.Context, dest io.Writer, viewer retable.Viewer, table T, sheetName ...string) error {
+	view, err := viewer.NewView(strings.Join(sheetName, " "), table)
+	if err != nil {
+		return err
+	}
+	return w.WriteView(ctx, dest, view)
+}
+
+// WriteView writes the view as the single sheet of an XLSX workbook to dest.
+// The title of the view is used as sheet name.
+func (w *Writer[T]) WriteView(ctx context.Context, dest io.Writer, view retable.View) error {
+	return w.WriteViews(ctx, dest, view)
+}
+
+// WriteViews writes every view as a sheet of an XLSX workbook to dest.
+// The retable.MetadataSheetName metadata or else the titles
+// of the views are used as sheet names, see SheetName.
+//
+// An OpenTelemetry span is created for the write
+// and metrics are recorded if a MetricsRecorder is set.
+func (w *Writer[T]) WriteViews(ctx context.Context, dest io.Writer, views ...retable.View) (err error) {
+	if len(views) == 0 {
+		return errors.New("no views to write")
+	}
+	titles := make([]string, len(views))
+	for i, view := range views {
+		titles[i] = view.Title()
+	}
+	ctx, iw := retable.StartInstrumentedWrite(ctx, w.metrics, "xlsx", strings.Join(titles, ", "), dest)
+	defer func() { err = iw.End(ctx, err) }()
+	hooks := iw.Hooks(w.hooks)
+
+	f := excelize.NewFile()
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+
+	defaultSheet := f.GetSheetName(0)
+	for i, view := range views {
+		sheet := SheetName(sheetTitle(view), i)
+		if i == 0 {
+			err = f.SetSheetName(defaultSheet, sheet)
+		} else {
+			// Titles can be equal or equal after truncation
+			sheet = UniqueSheetName(f, sheet)
+			_, err = f.NewSheet(sheet)
+		}
+		if err != nil {
+			return err
+		}
+		err = w.writeSheet(ctx, f, sheet, view, hooks)
+		if err != nil {
+			return err
+		}
+	}
+	err = w.setProvenance(f, views)
+	if err != nil {
+		return err
+	}
+	return f.Write(&retable.MaxBytesWriter{Dest: iw, Max: w.maxBytes})
+}
+
+// setProvenance sets the document properties of f
+// from the provenance of the writer if not nil:
+// the creation time and the provenance fields
+// with the number of written rows of all views as description.
+func (w *Writer[T]) setProvenance(f *excelize.File, views []retable.View) error {
+	if w.provenance == nil {
+		return nil
+	}
+	numRows := 0
+	for _, view := range views {
+		if w.maxRows > 0 {
+			numRows += min(view.NumRows(), w.maxRows)
+		} else {
+			numRows += view.NumRows()
+		}
+	}
+	var description strings.Builder
+	for i, field := range w.provenance.Fields(numRows) {
+		if i > 0 {
+			description.WriteByte('\n')
+		}
+		description.WriteString(field.String())
+	}
+	return f.SetDocProps(&excelize.DocProperties{
+		Created:     w.provenance.GeneratedAtOrNow().Format(time.RFC3339),
+		Description: description.String(),
+	})
+}
+
+// WriteSheet writes the view to an existing sheet of the passed file
+// starting at the top left cell A1.
+//
+// An OpenTelemetry span is created for the write
+// and metrics are recorded if a MetricsRecorder is set.
+// No bytes are counted because the file is written by the caller.
+func (w *Writer[T]) WriteSheet(ctx context.Context, f *excelize.File, sheet string, view retable.View) (err error) {
+	ctx, iw := retable.StartInstrumentedWrite(ctx, w.metrics, "xlsx", view.Title(), io.Discard)
+	defer func() { err = iw.End(ctx, err) }()
+
+	return w.writeSheet(ctx, f, sheet, view, iw.Hooks(w.hooks))
+}
+
+// transformView applies the column visibility, redaction,
+// pointer handling, and zero as null options of the writer to view.
+func (w *Writer[T]) transformView(view retable.View) retable.View {
+	if !w.includeHidden {
+		view = retable.VisibleColumnsView(view)
+	}
+	view = retable.RedactColumnsView(view, w.redaction, w.redactedColumns...)
+	view = w.pointerHandling.View(view)
+	return w.zeroAsNull.View(view)
+}
+
+func (w *Writer[T]) writeSheet(ctx context.Context, f *excelize.File, sheet string, view retable.View, hooks *retable.WriteHooks) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	view = w.transformView(view)
+	numRows := view.NumRows()
+	truncate := w.maxRows > 0 && numRows > w.maxRows
+	if truncate {
+		if w.truncationMarker == "" {
+			return retable.ErrMaxRowsExceeded{Max: w.maxRows, NumRows: numRows}
+		}
+		numRows = w.maxRows
+	}
+	rowOffset := 1 // Excel rows start at 1
+	if w.headerRow {
+		written, err := w.writeColumnGroups(f, sheet, len(view.Columns()))
+		if err != nil {
+			return err
+		}
+		if written {
+			rowOffset++
+		}
+		for col, title := range view.Columns() {
+			cell, err := excelize.CoordinatesToCellName(col+1, rowOffset)
+			if err != nil {
+				return err
+			}
+			err = f.SetCellStr(sheet, cell, title)
+			if err != nil {
+				return err
+			}
+			err = addDescriptionComment(f, sheet, cell, view, col)
+			if err != nil {
+				return err
+			}
+		}
+		rowOffset++
+	}
+	numCols := len(view.Columns())
+	for row := 0; row < numRows; row++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		hooks.RowStart(ctx, view, row)
+		err := w.writeRow(ctx, f, sheet, view, row, 1, row+rowOffset, numCols, hooks)
+		hooks.RowEnd(ctx, view, row, err)
+		if err != nil {
+			return err
+		}
+	}
+	err := outlineRowGroups(f, sheet, view, rowOffset, numRows)
+	if err != nil {
+		return err
+	}
+	if truncate && numCols > 0 {
+		cell, err := excelize.CoordinatesToCellName(1, numRows+rowOffset)
+		if err != nil {
+			return err
+		}
+		err = f.SetCellStr(sheet, cell, w.truncationMarker)
+		if err != nil {
+			return err
+		}
+	}
+	return w.formatSheet(f, sheet, view, rowOffset-1, numRows)
+}
+
+// addDescriptionComment adds the description of the column
+// as comment to the header cell if the view has one,
+// see retable.ColumnDescriptionView.
+func addDescriptionComment(f *excelize.File, sheet, cell string, view retable.View, col int) error {
+	description := retable.ViewColumnDescription(view, col)
+	if description == "" {
+		return nil
+	}
+	return f.AddComment(sheet, excelize.Comment{Cell: cell, Text: description})
+}
+
+// outlineRowGroups sets the outline level of the data rows
+// of the groups of a retable.RowGroupsView to 1
+// so that the groups can be collapsed below their header rows.
+// The first view row is written at the 1 based sheet row rowOffset.
+func outlineRowGroups(f *excelize.File, sheet string, view retable.View, rowOffset, numRows int) error {
+	groups, ok := view.(retable.RowGroupsView)
+	if !ok {
+		return nil
+	}
+	for row := 0; row < numRows; row++ {
+		if _, isHeaderRow := groups.RowGroup(row); isHeaderRow {
+			continue
+		}
+		err := f.SetRowOutlineLevel(sheet, row+rowOffset, 1)
+		if err != nil {
+			return err
+		}
+	}
+	// Group header rows are above their rows
+	summaryBelow := false
+	return f.SetSheetProps(sheet, &excelize.SheetPropsOptions{OutlineSummaryBelow: &summaryBelow})
+}
+
+// writeColumnGroups writes the column groups as first row
+// of the sheet with merged cells for groups of multiple columns
+// and returns if the row was written.
+func (w *Writer[T]) writeColumnGroups(f *excelize.File, sheet string, numCols int) (written bool, err error) {
+	groups, err := retable.NormalizeColumnGroups(w.columnGroups, numCols)
+	if err != nil || len(groups) == 0 {
+		return false, err
+	}
+	firstCol := 1
+	for _, group := range groups {
+		topLeft, err := excelize.CoordinatesToCellName(firstCol, 1)
+		if err != nil {
+			return false, err
+		}
+		err = f.SetCellStr(sheet, topLeft, group.Title)
+		if err != nil {
+			return false, err
+		}
+		if group.NumColumns > 1 {
+			bottomRight, err := excelize.CoordinatesToCellName(firstCol+group.NumColumns-1, 1)
+			if err != nil {
+				return false, err
+			}
+			err = f.MergeCell(sheet, topLeft, bottomRight)
+			if err != nil {
+				return false, err
+			}
+		}
+		firstCol += group.NumColumns
+	}
+	return true, nil
+}
+
+// formatSheet applies the sheet level formatting
+// after the header row at the 1 based headerSheetRow
+// and numRows rows of the view have been written.
+func (w *Writer[T]) formatSheet(f *excelize.File, sheet string, view retable.View, headerSheetRow, numRows int) error {
+	if w.autoColumnWidth {
+		err := autoColumnWidths(f, sheet, len(view.Columns()))
+		if err != nil {
+			return err
+		}
+	}
+	err := w.formatColumns(f, sheet, view)
+	if err != nil {
+		return err
+	}
+	if !w.headerRow || len(view.Columns()) == 0 {
+		// Panes, filters, and tables require a header row
+		return nil
+	}
+	if w.freezeHeaderRow {
+		err := f.SetPanes(sheet, &excelize.Panes{
+			Freeze:      true,
+			YSplit:      headerSheetRow,
+			TopLeftCell: fmt.Sprintf("A%d", headerSheetRow+1),
+			ActivePane:  "bottomLeft",
+		})
+		if err != nil {
+			return err
+		}
+	}
+	// Excel tables need at least one data row below the header row
+	rangeRef, err := RangeRef(1, headerSheetRow, len(view.Columns()), headerSheetRow+max(numRows, 1))
+	if err != nil {
+		return err
+	}
+	switch {
+	case w.tableStyle != "":
+		// Tables have their own auto filter
+		return f.AddTable(sheet, &excelize.Table{
+			Range:     rangeRef,
+			StyleName: w.tableStyle,
+		})
+	case w.autoFilter:
+		return f.AutoFilter(sheet, rangeRef, nil)
+	}
+	return nil
+}
+
+// autoColumnWidths sets the width of the first numCols columns
+// of the sheet to fit their formatted cell values
+// measured with retable.DisplayWidthOptions
+// so that East Asian wide characters fit.
+func autoColumnWidths(f *excelize.File, sheet string, numCols int) error {
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return err
+	}
+	for col, width := range retable.MeasureColumnWidths(rows, numCols, retable.DisplayWidthOptions) {
+		if width == 0 {
+			continue
+		}
+		colName, err := excelize.ColumnNumberToName(col + 1)
+		if err != nil {
+			return err
+		}
+		// Add some space for the cell margins
+		err = f.SetColWidth(sheet, colName, colName, min(float64(width)+2, excelize.MaxColumnWidth))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatColumns hides and protects the configured columns
+// and hides the hidden columns of a retable.ColumnVisibilityView.
+// Column indices outside of the view columns are ignored.
+func (w *Writer[T]) formatColumns(f *excelize.File, sheet string, view retable.View) error {
+	numCols := len(view.Columns())
+	for col := range numCols {
+		if !slices.Contains(w.hiddenColumns, col) && !retable.ViewColumnHidden(view, col) {
+			continue
+		}
+		colName, err := excelize.ColumnNumberToName(col + 1)
+		if err != nil {
+			return err
+		}
+		err = f.SetColVisible(sheet, colName, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(w.protectedColumns) == 0 {
+		return nil
+	}
+	// All cells are locked by default when a sheet is protected,
+	// so unlock the cells of all columns that are not protected
+	unlocked, err := f.NewStyle(&excelize.Style{Protection: &excelize.Protection{Locked: false}})
+	if err != nil {
+		return err
+	}
+	for col := 0; col < numCols; col++ {
+		if slices.Contains(w.protectedColumns, col) {
+			continue
+		}
+		colName, err := excelize.ColumnNumberToName(col + 1)
+		if err != nil {
+			return err
+		}
+		err = f.SetColStyle(sheet, colName, unlocked)
+		if err != nil {
+			return err
+		}
+	}
+	return f.ProtectSheet(sheet, &excelize.SheetProtectionOptions{
+		Password:            w.password,
+		AutoFilter:          true,
+		Sort:                true,
+		FormatColumns:       true,
+		SelectLockedCells:   true,
+		SelectUnlockedCells: true,
+	})
+}
+
+// RangeRef returns an Excel range reference like "A1:C10"
+// for the passed 1 based column and row coordinates.
+func RangeRef(firstCol, firstRow, lastCol, lastRow int) (string, error) {
+	topLeft, err := excelize.CoordinatesToCellName(firstCol, firstRow)
+	if err != nil {
+		return "", err
+	}
+	bottomRight, err := excelize.CoordinatesToCellName(lastCol, lastRow)
+	if err != nil {
+		return "", err
+	}
+	return topLeft + ":" + bottomRight, nil
+}
+
+// writeRow writes the row of view to the sheet
+// starting at the 1 based coordinates firstCol and sheetRow
+// within the row timeout of the writer.
+func (w *Writer[T]) writeRow(ctx context.Context, f *excelize.File, sheet string, view retable.View, row, firstCol, sheetRow, numCols int, hooks *retable.WriteHooks) error {
+	return retable.CallWithRowTimeout(ctx, row, w.rowTimeout, func(ctx context.Context) error {
+		return w.writeRowCells(ctx, f, sheet, view, row, firstCol, sheetRow, numCols, hooks)
+	})
+}
+
+func (w *Writer[T]) writeRowCells(ctx context.Context, f *excelize.File, sheet string, view retable.View, row, firstCol, sheetRow, numCols int, hooks *retable.WriteHooks) error {
+	for col := 0; col < numCols; col++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		cell, err := excelize.CoordinatesToCellName(firstCol+col, sheetRow)
+		if err != nil {
+			return err
+		}
+		start := hooks.CellStart()
+		err = w.writeCell(ctx, f, sheet, cell, view, row, col)
+		hooks.CellEnd(ctx, view, row, col, start)
+		if err != nil {
+			str, _, policyErr := w.errorPolicy.FormatError(view, row, col, err)
+			if policyErr == nil {
+				if str != "" {
+					err = f.SetCellStr(sheet, cell, str)
+					if err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			err = hooks.CellError(ctx, view, row, col, err)
+			if err != nil {
+				return retable.NewCellError(view, row, col, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (w *Writer[T]) writeCell(ctx context.Context, f *excelize.File, sheet, cell string, view retable.View, row, col int) error {
+	if !retable.CellExists(view, row, col) {
+		return nil
+	}
+
+	colFormatter, ok := w.columnFormatters[col]
+	if !ok {
+		colFormatter = retable.ViewColumnFormatter(view, col)
+		ok = colFormatter != nil
+	}
+	colFormatter = w.rawPolicy.WrapFormatter(colFormatter)
+	if ok && w.recoverPanics {
+		colFormatter = retable.SafeCellFormatter(colFormatter)
+	}
+	if ok {
+		str, isRaw, err := colFormatter.FormatCell(ctx, view, row, col)
+		if err == nil {
+			return setCellString(f, sheet, cell, str, isRaw)
+		}
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return err
+		}
+		// Continue after errors.ErrUnsupported
+	}
+
+	typeFormatter := w.rawPolicy.WrapFormatter(w.typeFormatters)
+	if w.recoverPanics {
+		typeFormatter = retable.SafeCellFormatter(typeFormatter)
+	}
+	str, isRaw, err := typeFormatter.FormatCell(ctx, view, row, col)
+	if err == nil {
+		return setCellString(f, sheet, cell, str, isRaw)
+	}
+	if !errors.Is(err, errors.ErrUnsupported) {
+		return err
+	}
+	// Continue after errors.ErrUnsupported
+
+	// Use fallback methods for writing
+	v := retable.AsReflectCellView(view).ReflectCell(row, col)
+	if retable.IsNullLike(v) {
+		str, isRaw, err := w.columnNulls.Policy(view, col, w.nullPolicy).FormatNull(view, row, col)
+		if err != nil {
+			return err
+		}
+		if str == "" {
+			return nil
+		}
+		return setCellString(f, sheet, cell, str, isRaw)
+	}
+	if cellErr := retable.CellErrorValue(view, row, col); cellErr != nil {
+		str, _, err := w.errorPolicy.FormatError(view, row, col, cellErr)
+		if err != nil || str == "" {
+			return err
+		}
+		return f.SetCellStr(sheet, cell, str)
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	return setCellValue(f, sheet, cell, v)
+}
+
+func setCellString(f *excelize.File, sheet, cell, str string, isRaw bool) error {
+	// Terminal colors of formatters are not valid in Excel
+	str = retable.StripANSI(str)
+	if isRaw && strings.HasPrefix(str, "=") {
+		return setCellFormula(f, sheet, cell, str)
+	}
+	return f.SetCellStr(sheet, cell, str)
+}
+
+// setCellFormula sets the formula without a leading "="
+// as it's stored in the XLSX format.
+func setCellFormula(f *excelize.File, sheet, cell, formula string) error {
+	return f.SetCellFormula(sheet, cell, strings.TrimPrefix(formula, "="))
+}
+
+func setCellValue(f *excelize.File, sheet, cell string, v reflect.Value) error {
+	switch x := v.Interface().(type) {
+	case FormulaCell:
+		return setCellFormula(f, sheet, cell, string(x))
+	case retable.RawNumber:
+		// Written as number cell with the exact digits
+		return f.SetCellDefault(sheet, cell, string(x))
+	case time.Time, time.Duration, bool, string, []byte,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return f.SetCellValue(sheet, cell, x)
+	case fmt.Stringer:
+		return f.SetCellStr(sheet, cell, x.String())
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return f.SetCellBool(sheet, cell, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return f.SetCellValue(sheet, cell, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return f.SetCellValue(sheet, cell, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return f.SetCellFloat(sheet, cell, v.Float(), -1, 64)
+	case reflect.String:
+		return f.SetCellStr(sheet, cell, v.String())
+	}
+	return f.SetCellStr(sheet, cell, fmt.Sprint(v.Interface()))
+}
+
+// sheetTitle returns the retable.MetadataSheetName
+// metadata of view or else its title.
+func sheetTitle(view retable.View) string {
+	if name, ok := retable.ViewMetadataString(view, retable.MetadataSheetName); ok {
+		return name
+	}
+	return view.Title()
+}
+
+// maxSheetNameLen is the maximum number of characters of a sheet name
+const maxSheetNameLen = 31
+
+// SheetName returns a valid Excel sheet name for a view title
+// by replacing the characters :\/?*[] with underscores,
+// trimming leading and trailing apostrophes,
+// and truncating it to 31 characters.
+// A title that results in an empty name
+// is named "Sheet" followed by index+1.
+func SheetName(title string, index int) string {
+	title = strings.Map(
+		func(r rune) rune {
+			if strings.ContainsRune(`:\/?*[]`, r) {
+				return '_'
+			}
+			return r
+		},
+		title,
+	)
+	title = truncateSheetName(strings.Trim(title, "'"), maxSheetNameLen)
+	if title == "" {
+		return fmt.Sprintf("Sheet%d", index+1)
+	}
+	return title
+}
+
+// UniqueSheetName returns name if no sheet of f has that name
+// or else name with a numeric suffix like " (2)"
+// within the 31 characters of a sheet name.
+// Excel compares sheet names case-insensitively.
+func UniqueSheetName(f *excelize.File, name string) string {
+	exists := func(name string) bool {
+		return slices.ContainsFunc(f.GetSheetList(), func(sheet string) bool {
+			return strings.EqualFold(sheet, name)
+		})
+	}
+	unique := name
+	for n := 2; exists(unique); n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		unique = truncateSheetName(name, maxSheetNameLen-utf8.RuneCountInString(suffix)) + suffix
+	}
+	return unique
+}
+
+// truncateSheetName truncates name to maxLen characters
+// without leaving a trailing apostrophe.
+func truncateSheetName(name string, maxLen int) string {
+	if utf8.RuneCountInString(name) > maxLen {
+		name = strings.TrimRight(string([]rune(name)[:maxLen]), "'")
+	}
+	return name
+}
+
+func (w *Writer[T]) WithHeaderRow(headerRow bool) *Writer[T] {
+	mod := w.clone()
+	mod.headerRow = headerRow
+	return mod
+}
+
+// WithColumnGroups returns a new writer that writes
+// the passed groups of consecutive columns as merged cells
+// in an additional first row above the column titles
+// if the header row is enabled.
+// Columns after the last group are left ungrouped.
+func (w *Writer[T]) WithColumnGroups(groups ...retable.ColumnGroup) *Writer[T] {
+	mod := w.clone()
+	mod.columnGroups = slices.Clone(groups)
+	return mod
+}
+
+// WithFreezeHeaderRow returns a new writer that freezes the header row
+// so that it stays visible when scrolling.
+// Only used when a header row is written.
+func (w *Writer[T]) WithFreezeHeaderRow(freeze bool) *Writer[T] {
+	mod := w.clone()
+	mod.freezeHeaderRow = freeze
+	return mod
+}
+
+// WithAutoFilter returns a new writer that adds an auto filter
+// to the header row for filtering and sorting the rows.
+// Only used when a header row is written and no
+// Excel table style is set because tables have their own filter.
+func (w *Writer[T]) WithAutoFilter(autoFilter bool) *Writer[T] {
+	mod := w.clone()
+	mod.autoFilter = autoFilter
+	return mod
+}
+
+// WithExcelTableStyle returns a new writer that formats the written cells
+// as Excel table with the passed style name like "TableStyleMedium2".
+// An empty style name disables the table formatting.
+// Only used when a header row is written.
+func (w *Writer[T]) WithExcelTableStyle(styleName string) *Writer[T] {
+	mod := w.clone()
+	mod.tableStyle = styleName
+	return mod
+}
+
+// WithProvenance returns a new writer that sets
+// the document properties of written workbooks
+// from the fields of provenance.
+// Pass nil to set no provenance.
+func (w *Writer[T]) WithProvenance(provenance *retable.Provenance) *Writer[T] {
+	mod := w.clone()
+	mod.provenance = provenance
+	return mod
+}
+
+// WithAutoColumnWidth returns a new writer that sets
+// the width of the columns to fit their content.
+func (w *Writer[T]) WithAutoColumnWidth(autoColumnWidth bool) *Writer[T] {
+	mod := w.clone()
+	mod.autoColumnWidth = autoColumnWidth
+	return mod
+}
+
+// WithHiddenColumns returns a new writer that hides
+// the columns with the passed indices in the written sheets.
+// Hidden columns are still part of the workbook,
+// for example to keep internal IDs for a later re-import.
+func (w *Writer[T]) WithHiddenColumns(columnIndices ...int) *Writer[T] {
+	mod := w.clone()
+	mod.hiddenColumns = slices.Clone(columnIndices)
+	return mod
+}
+
+// WithProtectedColumns returns a new writer that protects the written sheets
+// and makes the columns with the passed indices read-only
+// while the cells of all other columns stay editable.
+// Passing no column indices disables the sheet protection.
+//
+// Use WithProtectionPassword to require a password
+// for removing the sheet protection in Excel.
+func (w *Writer[T]) WithProtectedColumns(columnIndices ...int) *Writer[T] {
+	mod := w.clone()
+	mod.protectedColumns = slices.Clone(columnIndices)
+	return mod
+}
+
+// WithProtectionPassword returns a new writer that uses the passed password
+// for the sheet protection enabled by WithProtectedColumns.
+// Note that the password only prevents accidental changes
+// in Excel and is not a security feature.
+func (w *Writer[T]) WithProtectionPassword(password string) *Writer[T] {
+	mod := w.clone()
+	mod.password = password
+	return mod
+}
+
+// WithRedactedColumns returns a new writer that replaces the non null values
+// of the columns with the passed titles with a placeholder
+// while keeping the column headers.
+// The placeholder can be set with WithRedactionPlaceholder
+// and defaults to retable.DefaultRedactionPlaceholder.
+func (w *Writer[T]) WithRedactedColumns(columns ...string) *Writer[T] {
+	mod := w.clone()
+	mod.redactedColumns = slices.Clone(columns)
+	return mod
+}
+
+// WithRedactionPlaceholder returns a new writer that uses
+// the passed placeholder for the values of redacted columns.
+func (w *Writer[T]) WithRedactionPlaceholder(placeholder string) *Writer[T] {
+	mod := w.clone()
+	mod.redaction = placeholder
+	return mod
+}
+
+// WithWriteHooks returns a new writer that calls hooks
+// while writing the data rows of a view.
+func (w *Writer[T]) WithWriteHooks(hooks *retable.WriteHooks) *Writer[T] {
+	mod := w.clone()
+	mod.hooks = hooks
+	return mod
+}
+
+// WithMetricsRecorder returns a new writer that records
+// the statistics of every written workbook with recorder.
+func (w *Writer[T]) WithMetricsRecorder(recorder retable.MetricsRecorder) *Writer[T] {
+	mod := w.clone()
+	mod.metrics = recorder
+	return mod
+}
+
+// EstimateSize estimates the output size of WriteView for view
+// by writing retable.DefaultEstimateSampleRows sample rows
+// without row and output size limits, hooks, and metrics.
+// The compressed XLSX output size is not linear to the number
+// of rows, so the estimate is only a rough approximation.
+func (w *Writer[T]) EstimateSize(ctx context.Context, view retable.View) (*retable.SizeEstimate, error) {
+	mod := w.clone()
+	mod.hooks = nil
+	mod.metrics = nil
+	mod.maxRows = 0
+	mod.maxBytes = 0
+	return retable.EstimateSize(ctx, view, retable.DefaultEstimateSampleRows, mod.WriteView)
+}
+
+// WithMaxRows returns a new writer that writes views
+// with at most maxRows data rows per sheet.
+// Views with more rows are not written at all and
+// retable.ErrMaxRowsExceeded is returned,
+// or if a truncation marker is set, then only the first
+// maxRows rows are written followed by a marker row.
+// Values <= 0 don't limit the number of rows.
+func (w *Writer[T]) WithMaxRows(maxRows int) *Writer[T] {
+	mod := w.clone()
+	mod.maxRows = maxRows
+	return mod
+}
+
+// WithMaxOutputBytes returns a new writer that fails with
+// retable.ErrMaxOutputBytesExceeded when the compressed XLSX
+// output would exceed maxBytes.
+// The workbook is written as a whole, so it can't be truncated
+// and the truncation marker is not used for this limit.
+// Values <= 0 don't limit the output size.
+func (w *Writer[T]) WithMaxOutputBytes(maxBytes int64) *Writer[T] {
+	mod := w.clone()
+	mod.maxBytes = maxBytes
+	return mod
+}
+
+// WithTruncationMarker returns a new writer that truncates
+// views exceeding the limit of WithMaxRows
+// and writes the marker in the first column of a final row.
+// An empty marker returns an error instead of truncating.
+func (w *Writer[T]) WithTruncationMarker(marker string) *Writer[T] {
+	mod := w.clone()
+	mod.truncationMarker = marker
+	return mod
+}
+
+// RedactedColumns returns the titles of the redacted columns.
+func (w *Writer[T]) RedactedColumns() []string {
+	return w.redactedColumns
+}
+
+func (w *Writer[T]) WithTableViewer(viewer retable.Viewer) *Writer[T] {
+	mod := w.clone()
+	mod.viewer = viewer
+	return mod
+}
+
+// WithColumnFormatter returns a new writer with the passed formatter registered for columnIndex.
+// If nil is passed as formatter, then a previous registered column formatter is removed.
+func (w *Writer[T]) WithColumnFormatter(columnIndex int, formatter retable.CellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.columnFormatters = make(map[int]retable.CellFormatter)
+	for key, val := range w.columnFormatters {
+		mod.columnFormatters[key] = val
+	}
+	if formatter != nil {
+		mod.columnFormatters[columnIndex] = formatter
+	} else {
+		delete(mod.columnFormatters, columnIndex)
+	}
+	return mod
+}
+
+// WithColumnFormatterFunc returns a new writer with the passed formatterFunc registered for columnIndex.
+// If nil is passed as formatterFunc, then a previous registered column formatter is removed.
+func (w *Writer[T]) WithColumnFormatterFunc(columnIndex int, formatterFunc retable.CellFormatterFunc) *Writer[T] {
+	return w.WithColumnFormatter(columnIndex, formatterFunc)
+}
+
+// WithRecoverPanics returns a new writer that recovers
+// panics of column and type formatters and returns them
+// as errors with the coordinates of the formatted cell,
+// see retable.SafeCellFormatter.
+func (w *Writer[T]) WithRecoverPanics(recoverPanics bool) *Writer[T] {
+	mod := w.clone()
+	mod.recoverPanics = recoverPanics
+	return mod
+}
+
+// WithRowTimeout returns a new writer that aborts writing
+// with a retable.TimeoutError if formatting a row
+// takes longer than timeout, protecting exports
+// from formatters that hang on specific cells.
+// The formatters get a context with the deadline of the row
+// and no further cells of the row are formatted after the deadline,
+// see retable.CallWithRowTimeout.
+// Values <= 0 don't limit the formatting time.
+func (w *Writer[T]) WithRowTimeout(timeout time.Duration) *Writer[T] {
+	mod := w.clone()
+	mod.rowTimeout = timeout
+	return mod
+}
+
+// WithRawPolicy returns a new writer that handles
+// raw strings returned by column and type formatters
+// according to policy, see retable.RawPolicy.
+func (w *Writer[T]) WithRawPolicy(policy retable.RawPolicy) *Writer[T] {
+	mod := w.clone()
+	mod.rawPolicy = policy
+	return mod
+}
+
+// WithPointerHandling returns a new writer that formats
+// cells with pointer values according to handling,
+// see retable.PointerHandling.
+func (w *Writer[T]) WithPointerHandling(handling retable.PointerHandling) *Writer[T] {
+	mod := w.clone()
+	mod.pointerHandling = handling
+	return mod
+}
+
+// WithZeroAsNull returns a new writer that handles
+// the zero values of the cells selected by zeroAsNull
+// like null values using the null policy,
+// for example to render 0 amounts as empty cells.
+func (w *Writer[T]) WithZeroAsNull(zeroAsNull retable.ZeroAsNull) *Writer[T] {
+	mod := w.clone()
+	mod.zeroAsNull = zeroAsNull
+	return mod
+}
+
+// WithIncludeHiddenColumns returns a new writer that writes
+// the hidden columns of a retable.ColumnVisibilityView
+// as hidden sheet columns or omits them.
+// By default hidden columns are written as hidden sheet columns
+// so that they are available for a re-import of the workbook.
+// Column indices of the writer refer to the written columns.
+func (w *Writer[T]) WithIncludeHiddenColumns(include bool) *Writer[T] {
+	mod := w.clone()
+	mod.includeHidden = include
+	return mod
+}
+
+func (w *Writer[T]) WithTypeFormatters(formatter *retable.ReflectTypeCellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.typeFormatters = formatter
+	return mod
+}
+
+func (w *Writer[T]) WithTypeFormatter(typ reflect.Type, fmt retable.CellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.typeFormatters = w.typeFormatters.WithTypeFormatter(typ, fmt)
+	return mod
+}
+
+func (w *Writer[T]) WithInterfaceTypeFormatter(typ reflect.Type, fmt retable.CellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.typeFormatters = w.typeFormatters.WithInterfaceTypeFormatter(typ, fmt)
+	return mod
+}
+
+func (w *Writer[T]) WithKindFormatter(kind reflect.Kind, fmt retable.CellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.typeFormatters = w.typeFormatters.WithKindFormatter(kind, fmt)
+	return mod
+}
+
+// WithNullPolicy returns a new writer that handles
+// null-like values using the passed policy.
+// Null values rendered as empty strings result in empty cells.
+func (w *Writer[T]) WithNullPolicy(policy retable.NullPolicy) *Writer[T] {
+	mod := w.clone()
+	mod.nullPolicy = policy
+	return mod
+}
+
+// WithColumnNilValue returns a new writer that renders
+// null-like values of the column with columnIndex
+// as the passed nilValue instead of using the null policy of the writer.
+func (w *Writer[T]) WithColumnNilValue(columnIndex int, nilValue string) *Writer[T] {
+	mod := w.clone()
+	mod.columnNulls = w.columnNulls.WithIndex(columnIndex, retable.RenderNullAs(nilValue))
+	return mod
+}
+
+// WithColumnTitleNilValue returns a new writer that renders
+// null-like values of the columns with the passed title
+// as nilValue instead of using the null policy of the writer.
+func (w *Writer[T]) WithColumnTitleNilValue(title string, nilValue string) *Writer[T] {
+	mod := w.clone()
+	mod.columnNulls = w.columnNulls.WithTitle(title, retable.RenderNullAs(nilValue))
+	return mod
+}
+
+// WithColumnNullPolicies returns a new writer that handles
+// null-like values of the columns selected by policies
+// with their own null policy.
+func (w *Writer[T]) WithColumnNullPolicies(policies retable.ColumnNullPolicies) *Writer[T] {
+	mod := w.clone()
+	mod.columnNulls = policies
+	return mod
+}
+
+// WithErrorPolicy returns a new writer that handles
+// cells with error values and cells whose formatting failed
+// with the passed policy.
+// The default policy aborts writing with a retable.CellError.
+func (w *Writer[T]) WithErrorPolicy(policy retable.ErrorPolicy) *Writer[T] {
+	mod := w.clone()
+	mod.errorPolicy = policy
+	return mod
+}
+
+// ErrorPolicy returns the error policy of the writer.
+func (w *Writer[T]) ErrorPolicy() retable.ErrorPolicy {
+	return w.errorPolicy
+}
+
+func (w *Writer[T]) NullPolicy() retable.NullPolicy {
+	return w.nullPolicy
+}
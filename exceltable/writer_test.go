@@ -0,0 +1,370 @@
+package exceltable
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/domonda/go-retable"
+)
+
+func TestWriter_WriteView(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Tit:  "Sales: 2024/Q1",
+		Cols: []string{"Product", "Amount", "Paid"},
+		Rows: [][]any{
+			{"A", 1.5, true},
+			{"B", 2, nil},
+			{"Total", FormulaCell("=SUM(B2:B3)"), nil},
+		},
+	}
+	var buf bytes.Buffer
+	err := NewWriter[any]().
+		WithHeaderRow(true).
+		WithNullPolicy(retable.RenderNullAs("-")).
+		WriteView(context.Background(), &buf, view)
+	require.NoError(t, err)
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+
+	sheet := "Sales_ 2024_Q1"
+	require.Equal(t, []string{sheet}, f.GetSheetList())
+	rows, err := f.GetRows(sheet)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Product", "Amount", "Paid"}, rows[0])
+	require.Equal(t, []string{"A", "1.5", "TRUE"}, rows[1])
+	require.Equal(t, []string{"B", "2", "-"}, rows[2])
+
+	formula, err := f.GetCellFormula(sheet, "B4")
+	require.NoError(t, err)
+	require.Equal(t, "SUM(B2:B3)", formula)
+}
+
+func TestWriter_WithProvenance(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Tit:  "Sales",
+		Cols: []string{"Product"},
+		Rows: [][]any{{"A"}, {"B"}},
+	}
+	provenance := &retable.Provenance{
+		GeneratedAt: time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC),
+		Source:      "Shop",
+	}
+	var buf bytes.Buffer
+	err := NewWriter[any]().
+		WithProvenance(provenance).
+		WriteViews(context.Background(), &buf, view, view)
+	require.NoError(t, err)
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+
+	props, err := f.GetDocProps()
+	require.NoError(t, err)
+	require.Equal(t, "2024-05-06T07:08:09Z", props.Created)
+	require.Equal(t, "Generated At: 2024-05-06T07:08:09Z\nSource: Shop\nRows: 4", props.Description)
+}
+
+func TestSheetName(t *testing.T) {
+	require.Equal(t, "Sheet3", SheetName("", 2))
+	require.Equal(t, "a_b_c", SheetName("a/b?c", 0))
+	require.Equal(t, "1234567890123456789012345678901", SheetName("12345678901234567890123456789012345", 0))
+	require.Equal(t, "Sheet2", SheetName("''", 1), "only apostrophes")
+}
+
+func TestWriter_WriteViews_uniqueSheetNames(t *testing.T) {
+	longTitle := strings.Repeat("x", 31)
+	views := []retable.View{
+		&retable.AnyValuesView{Tit: "Data", Cols: []string{"A"}, Rows: [][]any{{1}}},
+		&retable.AnyValuesView{Tit: "data", Cols: []string{"A"}, Rows: [][]any{{2}}},
+		&retable.AnyValuesView{Tit: longTitle + "1", Cols: []string{"A"}, Rows: [][]any{{3}}},
+		&retable.AnyValuesView{Tit: longTitle + "2", Cols: []string{"A"}, Rows: [][]any{{4}}},
+		&retable.AnyValuesView{Tit: "''", Cols: []string{"A"}, Rows: [][]any{{5}}},
+	}
+	var buf bytes.Buffer
+	err := NewWriter[any]().WriteViews(context.Background(), &buf, views...)
+	require.NoError(t, err)
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	require.Equal(t, []string{"Data", "data (2)", longTitle, strings.Repeat("x", 27) + " (2)", "Sheet5"}, f.GetSheetList())
+	for i, sheet := range f.GetSheetList() {
+		value, err := f.GetCellValue(sheet, "A1")
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprint(i+1), value, sheet)
+	}
+}
+
+func TestWriter_SheetFormatting(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Cols: []string{"A", "B"},
+		Rows: [][]any{{1, 2}, {3, 4}},
+	}
+	for _, writer := range []*Writer[any]{
+		NewWriter[any]().WithHeaderRow(true).WithFreezeHeaderRow(true).WithAutoFilter(true),
+		NewWriter[any]().WithHeaderRow(true).WithExcelTableStyle("TableStyleMedium2"),
+	} {
+		var buf bytes.Buffer
+		err := writer.WriteView(context.Background(), &buf, view)
+		require.NoError(t, err)
+
+		f, err := excelize.OpenReader(&buf)
+		require.NoError(t, err)
+		rows, err := f.GetRows("Sheet1")
+		require.NoError(t, err)
+		require.Equal(t, [][]string{{"A", "B"}, {"1", "2"}, {"3", "4"}}, rows)
+		require.NoError(t, f.Close())
+	}
+}
+
+func TestWriter_HiddenAndProtectedColumns(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Cols: []string{"ID", "Name"},
+		Rows: [][]any{{1, "A"}, {2, "B"}},
+	}
+	writer := NewWriter[any]().
+		WithHeaderRow(true).
+		WithHiddenColumns(0).
+		WithProtectedColumns(0).
+		WithProtectionPassword("secret")
+	var buf bytes.Buffer
+	err := writer.WriteView(context.Background(), &buf, view)
+	require.NoError(t, err)
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	defer f.Close()
+
+	visible, err := f.GetColVisible("Sheet1", "A")
+	require.NoError(t, err)
+	require.False(t, visible, "ID column hidden")
+	visible, err = f.GetColVisible("Sheet1", "B")
+	require.NoError(t, err)
+	require.True(t, visible, "Name column visible")
+
+	for cell, locked := range map[string]bool{"A2": true, "B2": false} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		require.NoError(t, err)
+		style, err := f.GetStyle(styleID)
+		require.NoError(t, err)
+		require.Equal(t, locked, style.Protection == nil || style.Protection.Locked, cell)
+	}
+}
+
+func TestWriter_WithColumnGroups(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Cols: []string{"Product", "Jan", "Feb", "Mar"},
+		Rows: [][]any{{"A", 1, 2, 3}},
+	}
+	var buf bytes.Buffer
+	err := NewWriter[any]().
+		WithHeaderRow(true).
+		WithFreezeHeaderRow(true).
+		WithAutoFilter(true).
+		WithColumnGroups(
+			retable.ColumnGroup{Title: "", NumColumns: 1},
+			retable.ColumnGroup{Title: "Q1", NumColumns: 3},
+		).
+		WriteView(context.Background(), &buf, view)
+	require.NoError(t, err)
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	defer f.Close()
+
+	rows, err := f.GetRows("Sheet1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Product", "Jan", "Feb", "Mar"}, rows[1])
+	require.Equal(t, []string{"A", "1", "2", "3"}, rows[2])
+
+	merged, err := f.GetMergeCells("Sheet1")
+	require.NoError(t, err)
+	require.Len(t, merged, 1)
+	require.Equal(t, "B1", merged[0].GetStartAxis())
+	require.Equal(t, "D1", merged[0].GetEndAxis())
+	require.Equal(t, "Q1", merged[0].GetCellValue())
+
+	err = NewWriter[any]().
+		WithHeaderRow(true).
+		WithColumnGroups(retable.ColumnGroup{Title: "Too many", NumColumns: 5}).
+		WriteView(context.Background(), &buf, view)
+	require.Error(t, err)
+}
+
+func TestWriter_RowGroupsOutline(t *testing.T) {
+	view := retable.NewGroupedRowsView(
+		&retable.AnyValuesView{
+			Cols: []string{"Region", "City"},
+			Rows: [][]any{{"North", "Oslo"}, {"North", "Helsinki"}, {"South", "Rome"}},
+		},
+		0,
+	)
+	writers := map[string]*Writer[any]{
+		"default":    NewWriter[any](),
+		"ZeroAsNull": NewWriter[any]().WithZeroAsNull(retable.ZeroAsNull{}.WithIndices(1)),
+	}
+	for name, writer := range writers {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := writer.WithHeaderRow(true).WriteView(context.Background(), &buf, view)
+			require.NoError(t, err)
+
+			f, err := excelize.OpenReader(&buf)
+			require.NoError(t, err)
+			defer f.Close()
+
+			for sheetRow, want := range map[int]uint8{1: 0, 2: 0, 3: 1, 4: 1, 5: 0, 6: 1} {
+				level, err := f.GetRowOutlineLevel("Sheet1", sheetRow)
+				require.NoError(t, err)
+				require.Equal(t, want, level, "sheet row %d", sheetRow)
+			}
+		})
+	}
+}
+
+func TestWriter_RawNumber(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Cols: []string{"Amount"},
+		Rows: [][]any{{retable.RawNumber("0.1000000000000000055")}},
+	}
+	var buf bytes.Buffer
+	err := NewWriter[any]().WriteView(context.Background(), &buf, view)
+	require.NoError(t, err)
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+
+	cellType, err := f.GetCellType("Sheet1", "A1")
+	require.NoError(t, err)
+	require.Equal(t, excelize.CellTypeUnset, cellType, "number cell")
+	value, err := f.GetCellValue("Sheet1", "A1", excelize.Options{RawCellValue: true})
+	require.NoError(t, err)
+	require.Equal(t, "0.1000000000000000055", value)
+}
+
+func TestWriter_ColumnDescriptions(t *testing.T) {
+	type Row struct {
+		IBAN   string  `col:"IBAN" desc:"International Bank Account Number"`
+		Amount float64 `col:"Amount"`
+	}
+	var buf bytes.Buffer
+	err := NewWriter[[]Row]().
+		WithHeaderRow(true).
+		Write(context.Background(), &buf, []Row{{IBAN: "AT611904300234573201", Amount: 1}})
+	require.NoError(t, err)
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+
+	comments, err := f.GetComments("Sheet1")
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	require.Equal(t, "A1", comments[0].Cell)
+	require.Equal(t, "International Bank Account Number", comments[0].Text)
+}
+
+func TestWriter_HiddenViewColumns(t *testing.T) {
+	view := retable.ViewWithHiddenColumns(&retable.AnyValuesView{
+		Cols: []string{"ID", "Name"},
+		Rows: [][]any{{1, "A"}},
+	}, "ID")
+
+	var buf bytes.Buffer
+	err := NewWriter[any]().WithHeaderRow(true).WriteView(context.Background(), &buf, view)
+	require.NoError(t, err)
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	visible, err := f.GetColVisible("Sheet1", "A")
+	require.NoError(t, err)
+	require.False(t, visible, "ID column hidden")
+	value, err := f.GetCellValue("Sheet1", "A2")
+	require.NoError(t, err)
+	require.Equal(t, "1", value, "ID written for re-import")
+
+	buf.Reset()
+	err = NewWriter[any]().WithHeaderRow(true).WithIncludeHiddenColumns(false).WriteView(context.Background(), &buf, view)
+	require.NoError(t, err)
+	f, err = excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	rows, err := f.GetRows("Sheet1")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"Name"}, {"A"}}, rows)
+}
+
+func TestWriter_WriteSheet_options(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Cols: []string{"Item", "Amount"},
+		Rows: [][]any{{"Apples", 0}, {"Pears", 2}},
+	}
+	f := excelize.NewFile()
+	t.Cleanup(func() { f.Close() })
+	err := NewWriter[any]().
+		WithZeroAsNull(retable.ZeroAsNull{}.WithIndices(1)).
+		WithNullPolicy(retable.RenderNullAs("-")).
+		WriteSheet(context.Background(), f, "Sheet1", view)
+	require.NoError(t, err)
+
+	rows, err := f.GetRows("Sheet1")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"Apples", "-"}, {"Pears", "2"}}, rows)
+}
+
+func TestWriter_WithRowTimeout(t *testing.T) {
+	view := &retable.StringsView{
+		Cols: []string{"A", "B"},
+		Rows: [][]string{{"fast", "fast"}, {"slow", "next"}},
+	}
+	var formatted []string
+	slowFormatter := retable.CellFormatterFunc(func(ctx context.Context, view retable.View, row, col int) (string, bool, error) {
+		str := view.Cell(row, col).(string)
+		if str == "slow" {
+			time.Sleep(50 * time.Millisecond) // Ignores the context deadline
+		}
+		formatted = append(formatted, str)
+		return str, false, nil
+	})
+	err := NewWriter[any]().
+		WithColumnFormatter(0, slowFormatter).
+		WithColumnFormatter(1, slowFormatter).
+		WithRowTimeout(10*time.Millisecond).
+		WriteView(context.Background(), new(bytes.Buffer), view)
+	var timeoutErr retable.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	require.Equal(t, 1, timeoutErr.Row)
+	require.Equal(t, []string{"fast", "fast", "slow"}, formatted, "no cells formatted after the timeout")
+
+	err = NewWriter[any]().WithRowTimeout(time.Second).WriteView(context.Background(), new(bytes.Buffer), view)
+	require.NoError(t, err)
+}
+
+func TestWriter_WriteViews_metadataSheetName(t *testing.T) {
+	views := []retable.View{
+		retable.ViewWithMetadata(
+			&retable.AnyValuesView{Tit: "Title", Cols: []string{"A"}, Rows: [][]any{{1}}},
+			map[string]any{retable.MetadataSheetName: "Sheet: A"},
+		),
+		&retable.AnyValuesView{Tit: "Title", Cols: []string{"A"}, Rows: [][]any{{2}}},
+	}
+	var buf bytes.Buffer
+	err := NewWriter[any]().WriteViews(context.Background(), &buf, views...)
+	require.NoError(t, err)
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	require.Equal(t, []string{"Sheet_ A", "Title"}, f.GetSheetList())
+}
@@ -1,7 +1,23 @@
 package retable
 
-var _ View = ExtraRowView(nil)
+import (
+	"reflect"
+	"sort"
+)
 
+var (
+	_ ReflectCellView = ExtraRowView(nil)
+	_ ReflectCellView = new(ConcatView)
+	_ SparseCellView  = ExtraRowView(nil)
+	_ SparseCellView  = new(ConcatView)
+)
+
+// ExtraRowView is a View that concatenates the rows
+// of multiple views.
+// The title and columns are taken from the first view.
+//
+// Every cell access iterates the views to find the one
+// containing the row, use NewConcatView for a large number of views.
 type ExtraRowView []View
 
 func (e ExtraRowView) Title() string {
@@ -26,18 +42,113 @@ func (e ExtraRowView) NumRows() int {
 	return numRows
 }
 
-func (e ExtraRowView) Cell(row, col int) any {
+func (e ExtraRowView) viewRow(row, col int) (View, int) {
 	if row < 0 || col < 0 || col >= len(e.Columns()) {
-		return nil
+		return nil, 0
 	}
 	rowTop := 0
 	for _, view := range e {
 		numRows := view.NumRows()
 		rowBottom := rowTop + numRows
 		if row < rowBottom {
-			return view.Cell(row-rowTop, col)
+			return view, row - rowTop
 		}
 		rowTop = rowBottom
 	}
-	return nil
+	return nil, 0
+}
+
+func (e ExtraRowView) CellExists(row, col int) bool {
+	view, viewRow := e.viewRow(row, col)
+	return view != nil && CellExists(view, viewRow, col)
+}
+
+func (e ExtraRowView) Cell(row, col int) any {
+	view, viewRow := e.viewRow(row, col)
+	if view == nil {
+		return nil
+	}
+	return view.Cell(viewRow, col)
+}
+
+func (e ExtraRowView) ReflectCell(row, col int) reflect.Value {
+	view, viewRow := e.viewRow(row, col)
+	if view == nil {
+		return reflect.Value{}
+	}
+	return AsReflectCellView(view).ReflectCell(viewRow, col)
+}
+
+// ConcatView is a compiled form of ExtraRowView
+// that precomputes the row offsets of the concatenated views
+// at creation to find the view of a row by binary search.
+//
+// The number of rows of the views must not change
+// after the ConcatView was created.
+type ConcatView struct {
+	views []View
+	// rowOffsets[i] is the index of the first row of views[i],
+	// the last element is the total number of rows
+	rowOffsets []int
+}
+
+// NewConcatView returns a ConcatView for the passed views.
+// The title and columns are taken from the first view.
+func NewConcatView(views ...View) *ConcatView {
+	rowOffsets := make([]int, len(views)+1)
+	for i, view := range views {
+		rowOffsets[i+1] = rowOffsets[i] + view.NumRows()
+	}
+	return &ConcatView{views: views, rowOffsets: rowOffsets}
+}
+
+func (c *ConcatView) Title() string {
+	if len(c.views) == 0 {
+		return ""
+	}
+	return c.views[0].Title()
+}
+
+func (c *ConcatView) Columns() []string {
+	if len(c.views) == 0 {
+		return nil
+	}
+	return c.views[0].Columns()
+}
+
+func (c *ConcatView) NumRows() int {
+	return c.rowOffsets[len(c.views)]
+}
+
+func (c *ConcatView) viewRow(row, col int) (View, int) {
+	if row < 0 || col < 0 || row >= c.NumRows() || col >= len(c.Columns()) {
+		return nil, 0
+	}
+	// Find the first view with a row offset greater than row,
+	// the view before it contains the row.
+	// Views without rows have the same offset as the following view
+	// so the search skips them.
+	i := sort.SearchInts(c.rowOffsets, row+1) - 1
+	return c.views[i], row - c.rowOffsets[i]
+}
+
+func (c *ConcatView) CellExists(row, col int) bool {
+	view, viewRow := c.viewRow(row, col)
+	return view != nil && CellExists(view, viewRow, col)
+}
+
+func (c *ConcatView) Cell(row, col int) any {
+	view, viewRow := c.viewRow(row, col)
+	if view == nil {
+		return nil
+	}
+	return view.Cell(viewRow, col)
+}
+
+func (c *ConcatView) ReflectCell(row, col int) reflect.Value {
+	view, viewRow := c.viewRow(row, col)
+	if view == nil {
+		return reflect.Value{}
+	}
+	return AsReflectCellView(view).ReflectCell(viewRow, col)
 }
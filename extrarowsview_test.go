@@ -0,0 +1,33 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcatView(t *testing.T) {
+	views := []View{
+		&StringsView{Tit: "First", Cols: []string{"A", "B"}, Rows: [][]string{{"a0", "b0"}, {"a1", "b1"}}},
+		&StringsView{Cols: []string{"A", "B"}},
+		&StringsView{Cols: []string{"A", "B"}, Rows: [][]string{{"a2", "b2"}}},
+		&StringsView{Cols: []string{"A", "B"}, Rows: [][]string{{"a3", "b3"}, {"a4", "b4"}}},
+	}
+	extra := ExtraRowView(views)
+	concat := NewConcatView(views...)
+
+	require.Equal(t, "First", concat.Title())
+	require.Equal(t, []string{"A", "B"}, concat.Columns())
+	require.Equal(t, extra.NumRows(), concat.NumRows())
+	for row := -1; row <= concat.NumRows(); row++ {
+		for col := -1; col <= 2; col++ {
+			require.Equal(t, extra.Cell(row, col), concat.Cell(row, col), "row %d, col %d", row, col)
+			require.Equal(t, extra.ReflectCell(row, col).IsValid(), concat.ReflectCell(row, col).IsValid(), "row %d, col %d", row, col)
+		}
+	}
+	require.Equal(t, "b3", concat.Cell(3, 1))
+
+	empty := NewConcatView()
+	require.Equal(t, 0, empty.NumRows())
+	require.Nil(t, empty.Cell(0, 0))
+}
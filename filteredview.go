@@ -1,7 +1,19 @@
 package retable
 
-var _ View = new(FilteredView)
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
 
+var _ ReflectCellView = new(FilteredView)
+
+// FilteredView is a View that selects a range of rows
+// and a mapping of columns from a Source View.
+//
+// Invalid ColumnMapping indices don't cause panics,
+// the mapped columns will have empty titles and nil cells.
+// Use NewFilteredView to validate the ColumnMapping up front.
 type FilteredView struct {
 	Source View
 	// Offset index of the first row from Source, must be positive.
@@ -15,6 +27,30 @@ type FilteredView struct {
 	ColumnMapping []int
 }
 
+// NewFilteredView returns a FilteredView for the passed source View
+// or an error if rowOffset is negative or if columnMapping
+// contains indices that are out of range for the source columns.
+func NewFilteredView(source View, rowOffset, rowLimit int, columnMapping ...int) (*FilteredView, error) {
+	if source == nil {
+		return nil, errors.New("source view is nil")
+	}
+	if rowOffset < 0 {
+		return nil, fmt.Errorf("negative row offset %d", rowOffset)
+	}
+	numSourceCols := len(source.Columns())
+	for i, index := range columnMapping {
+		if index < 0 || index >= numSourceCols {
+			return nil, fmt.Errorf("column mapping index %d at position %d out of range for %d source columns", index, i, numSourceCols)
+		}
+	}
+	return &FilteredView{
+		Source:        source,
+		RowOffset:     rowOffset,
+		RowLimit:      rowLimit,
+		ColumnMapping: columnMapping,
+	}, nil
+}
+
 func (view *FilteredView) Title() string {
 	return view.Source.Title()
 }
@@ -26,7 +62,9 @@ func (view *FilteredView) Columns() []string {
 	}
 	mappedCols := make([]string, len(view.ColumnMapping))
 	for i, iSource := range view.ColumnMapping {
-		mappedCols[i] = sourceCols[iSource]
+		if iSource >= 0 && iSource < len(sourceCols) {
+			mappedCols[i] = sourceCols[iSource]
+		}
 	}
 	return mappedCols
 }
@@ -49,15 +87,77 @@ func (view *FilteredView) NumRows() int {
 	return n
 }
 
-func (view *FilteredView) Cell(row, col int) any {
-	numRows := view.NumRows()
-	numCols := view.NumCols()
-	if row < 0 || col < 0 || row >= numRows || col >= numCols {
-		return nil
+// sourceRowCol returns the row and column in the Source view
+// or false if row or col are out of bounds.
+func (view *FilteredView) sourceRowCol(row, col int) (int, int, bool) {
+	if row < 0 || col < 0 || row >= view.NumRows() || col >= view.NumCols() {
+		return 0, 0, false
 	}
 	row += max(view.RowOffset, 0)
 	if view.ColumnMapping != nil {
 		col = view.ColumnMapping[col]
+		if col < 0 || col >= len(view.Source.Columns()) {
+			return 0, 0, false
+		}
+	}
+	return row, col, true
+}
+
+// ColumnFormatter implements ColumnFormatterView
+// by returning the formatter of the mapped Source column.
+func (view *FilteredView) ColumnFormatter(col int) CellFormatter {
+	if col < 0 || col >= view.NumCols() {
+		return nil
+	}
+	if view.ColumnMapping != nil {
+		col = view.ColumnMapping[col]
+	}
+	return ViewColumnFormatter(view.Source, col)
+}
+
+// ColumnDescription implements ColumnDescriptionView
+// by returning the description of the mapped Source column.
+func (view *FilteredView) ColumnDescription(col int) string {
+	if col < 0 || col >= view.NumCols() {
+		return ""
+	}
+	if view.ColumnMapping != nil {
+		col = view.ColumnMapping[col]
+	}
+	return ViewColumnDescription(view.Source, col)
+}
+
+// ColumnHidden implements ColumnVisibilityView
+// by returning if the mapped Source column is hidden.
+func (view *FilteredView) ColumnHidden(col int) bool {
+	if col < 0 || col >= view.NumCols() {
+		return false
+	}
+	if view.ColumnMapping != nil {
+		col = view.ColumnMapping[col]
+	}
+	return ViewColumnHidden(view.Source, col)
+}
+
+// CellExists implements SparseCellView
+// by returning if the mapped Source cell exists.
+func (view *FilteredView) CellExists(row, col int) bool {
+	row, col, ok := view.sourceRowCol(row, col)
+	return ok && CellExists(view.Source, row, col)
+}
+
+func (view *FilteredView) Cell(row, col int) any {
+	row, col, ok := view.sourceRowCol(row, col)
+	if !ok {
+		return nil
 	}
 	return view.Source.Cell(row, col)
 }
+
+func (view *FilteredView) ReflectCell(row, col int) reflect.Value {
+	row, col, ok := view.sourceRowCol(row, col)
+	if !ok {
+		return reflect.Value{}
+	}
+	return AsReflectCellView(view.Source).ReflectCell(row, col)
+}
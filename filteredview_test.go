@@ -0,0 +1,40 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilteredView(t *testing.T) {
+	source := &AnyValuesView{
+		Cols: []string{"A", "B", "C"},
+		Rows: [][]any{
+			{1, "one", true},
+			{2, "two", false},
+			{3, "three", true},
+		},
+	}
+
+	view, err := NewFilteredView(source, 1, 1, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{"C", "A"}, view.Columns())
+	require.Equal(t, 1, view.NumRows())
+	require.Equal(t, false, view.Cell(0, 0))
+	require.Equal(t, 2, view.ReflectCell(0, 1).Interface())
+	require.Nil(t, view.Cell(1, 0))
+	require.False(t, view.ReflectCell(0, 2).IsValid())
+
+	_, err = NewFilteredView(source, 0, 0, 3)
+	require.Error(t, err)
+	_, err = NewFilteredView(source, -1, 0)
+	require.Error(t, err)
+
+	// Invalid mapping must not panic
+	invalid := &FilteredView{Source: source, ColumnMapping: []int{-1, 5, 1}}
+	require.Equal(t, []string{"", "", "B"}, invalid.Columns())
+	require.Nil(t, invalid.Cell(0, 0))
+	require.Nil(t, invalid.Cell(0, 1))
+	require.False(t, invalid.ReflectCell(0, 1).IsValid())
+	require.Equal(t, "one", invalid.Cell(0, 2))
+}
@@ -0,0 +1,202 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode defines how numbers are rounded
+// to the digits of a FloatCellFormatter.
+type RoundingMode int
+
+const (
+	// RoundHalfAwayFromZero rounds ties away from zero like math.Round
+	RoundHalfAwayFromZero RoundingMode = iota
+	// RoundHalfEven rounds ties to the nearest even digit
+	// like bankers rounding
+	RoundHalfEven
+	// RoundTowardZero truncates digits
+	RoundTowardZero
+	// RoundAwayFromZero rounds up the absolute value
+	RoundAwayFromZero
+	// RoundFloor rounds toward negative infinity
+	RoundFloor
+	// RoundCeiling rounds toward positive infinity
+	RoundCeiling
+)
+
+// FloatKinds are the reflect.Kind values of float types.
+var FloatKinds = []reflect.Kind{reflect.Float32, reflect.Float64}
+
+var _ CellFormatter = FloatCellFormatter{}
+
+// FloatCellFormatter formats float32 and float64 cells
+// with a fixed number of decimal places or significant digits
+// so that floats don't render like 0.30000000000000004.
+//
+// Rounding is done on the shortest decimal representation
+// of the float, so 1.005 rounds to 1.01 with Precision 2.
+// Other cells return errors.ErrUnsupported.
+//
+// Example:
+//
+//	FloatCellFormatter{Precision: 2, TrimZeros: true}
+type FloatCellFormatter struct {
+	// Precision is the number of decimal places,
+	// a negative Precision uses the smallest number
+	// of digits necessary to represent the value.
+	Precision int
+	// SignificantDigits rounds to the number
+	// of significant digits if greater than zero
+	// and if that results in less decimal places than Precision.
+	SignificantDigits int
+	// TrimZeros removes trailing zeros of the decimal places
+	// and the decimal point if no decimal places are left.
+	TrimZeros bool
+	// RoundingMode is used to round to the digits
+	RoundingMode RoundingMode
+}
+
+func (f FloatCellFormatter) FormatCell(ctx context.Context, view View, row, col int) (str string, raw bool, err error) {
+	v := AsReflectCellView(view).ReflectCell(row, col)
+	if IsNullLike(v) {
+		return "", false, errors.ErrUnsupported
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Float32:
+		return f.formatWithContext(ctx, v.Float(), 32), false, nil
+	case reflect.Float64:
+		return f.formatWithContext(ctx, v.Float(), 64), false, nil
+	}
+	return "", false, errors.ErrUnsupported
+}
+
+// formatWithContext formats num with the decimal separator
+// of the locale of the context, see WithLocale.
+func (f FloatCellFormatter) formatWithContext(ctx context.Context, num float64, bitSize int) string {
+	str := f.Format(num, bitSize)
+	if _, decimalSep, ok := contextNumberSeparators(ctx); ok && decimalSep != '.' {
+		str = strings.Replace(str, ".", string(decimalSep), 1)
+	}
+	return str
+}
+
+// Format formats num with the passed bitSize of 32 or 64
+// as configured by the formatter.
+func (f FloatCellFormatter) Format(num float64, bitSize int) string {
+	if math.IsNaN(num) || math.IsInf(num, 0) {
+		return strconv.FormatFloat(num, 'f', -1, bitSize)
+	}
+	neg := num < 0
+	intPart, fracPart, _ := strings.Cut(strconv.FormatFloat(math.Abs(num), 'f', -1, bitSize), ".")
+
+	places := len(fracPart)
+	if f.Precision >= 0 {
+		places = f.Precision
+	}
+	if f.SignificantDigits > 0 {
+		places = min(places, significantPlaces(intPart, fracPart, f.SignificantDigits))
+	}
+	intPart, fracPart = roundDecimal(intPart, fracPart, places, f.RoundingMode, neg)
+
+	if f.Precision > len(fracPart) {
+		fracPart += strings.Repeat("0", f.Precision-len(fracPart))
+	}
+	if f.TrimZeros {
+		fracPart = strings.TrimRight(fracPart, "0")
+	}
+	str := intPart
+	if fracPart != "" {
+		str += "." + fracPart
+	}
+	if neg && strings.Trim(str, "0.") != "" {
+		str = "-" + str
+	}
+	return str
+}
+
+// significantPlaces returns the number of decimal places
+// for the number of significant digits of the decimal number
+// with the passed integer and fraction digits.
+// The result is negative for rounding integer digits.
+func significantPlaces(intPart, fracPart string, digits int) int {
+	if intPart != "0" {
+		return digits - len(intPart)
+	}
+	leadingZeros := len(fracPart) - len(strings.TrimLeft(fracPart, "0"))
+	if leadingZeros == len(fracPart) {
+		// Zero has no significant digits
+		return 0
+	}
+	return digits + leadingZeros
+}
+
+// roundDecimal rounds the absolute decimal number
+// with the passed integer and fraction digits
+// to places decimal places with mode.
+// Negative places round integer digits to zeros.
+// neg is the sign of the number needed for RoundFloor and RoundCeiling.
+func roundDecimal(intPart, fracPart string, places int, mode RoundingMode, neg bool) (string, string) {
+	digits := intPart + fracPart
+	point := len(intPart)
+	cut := point + places
+	if cut >= len(digits) {
+		return intPart, fracPart
+	}
+	if cut < 0 {
+		digits = strings.Repeat("0", -cut) + digits
+		point -= cut
+		cut = 0
+	}
+	keep, rest := []byte(digits[:cut]), digits[cut:]
+
+	restNonZero := strings.Trim(rest, "0") != ""
+	var roundUp bool
+	switch mode {
+	case RoundHalfEven:
+		lastKept := byte('0')
+		if cut > 0 {
+			lastKept = keep[cut-1]
+		}
+		roundUp = rest[0] > '5' ||
+			(rest[0] == '5' && (strings.Trim(rest[1:], "0") != "" || (lastKept-'0')%2 == 1))
+	case RoundTowardZero:
+		roundUp = false
+	case RoundAwayFromZero:
+		roundUp = restNonZero
+	case RoundFloor:
+		roundUp = neg && restNonZero
+	case RoundCeiling:
+		roundUp = !neg && restNonZero
+	default:
+		roundUp = rest[0] >= '5'
+	}
+	if roundUp {
+		i := len(keep) - 1
+		for ; i >= 0 && keep[i] == '9'; i-- {
+			keep[i] = '0'
+		}
+		if i >= 0 {
+			keep[i]++
+		} else {
+			keep = append([]byte{'1'}, keep...)
+			point++
+			cut++
+		}
+	}
+
+	// Rounded integer digits become zeros
+	result := string(keep) + strings.Repeat("0", max(point-cut, 0))
+	intPart = strings.TrimLeft(result[:point], "0")
+	if intPart == "" {
+		intPart = "0"
+	}
+	return intPart, result[point:]
+}
@@ -0,0 +1,67 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFloatCellFormatter_Format(t *testing.T) {
+	// Variables to prevent exact constant arithmetic
+	point1, point2 := 0.1, 0.2
+	tests := []struct {
+		name      string
+		formatter FloatCellFormatter
+		num       float64
+		want      string
+	}{
+		{name: "zero precision", formatter: FloatCellFormatter{}, num: 2.5, want: "3"},
+		{name: "shortest", formatter: FloatCellFormatter{Precision: -1}, num: point1 + point2, want: "0.30000000000000004"},
+		{name: "precision", formatter: FloatCellFormatter{Precision: 2}, num: point1 + point2, want: "0.30"},
+		{name: "trim zeros", formatter: FloatCellFormatter{Precision: 2, TrimZeros: true}, num: point1 + point2, want: "0.3"},
+		{name: "trim point", formatter: FloatCellFormatter{Precision: 2, TrimZeros: true}, num: 3, want: "3"},
+		{name: "decimal tie", formatter: FloatCellFormatter{Precision: 2}, num: 1.005, want: "1.01"},
+		{name: "carry", formatter: FloatCellFormatter{Precision: 2}, num: 9.999, want: "10.00"},
+		{name: "negative", formatter: FloatCellFormatter{Precision: 1}, num: -1.25, want: "-1.3"},
+		{name: "negative zero", formatter: FloatCellFormatter{Precision: 1}, num: -0.01, want: "0.0"},
+		{name: "half even down", formatter: FloatCellFormatter{Precision: 1, RoundingMode: RoundHalfEven}, num: 0.25, want: "0.2"},
+		{name: "half even up", formatter: FloatCellFormatter{Precision: 1, RoundingMode: RoundHalfEven}, num: 0.35, want: "0.4"},
+		{name: "half even integer", formatter: FloatCellFormatter{RoundingMode: RoundHalfEven}, num: 2.5, want: "2"},
+		{name: "toward zero", formatter: FloatCellFormatter{Precision: 1, RoundingMode: RoundTowardZero}, num: -1.29, want: "-1.2"},
+		{name: "away from zero", formatter: FloatCellFormatter{Precision: 1, RoundingMode: RoundAwayFromZero}, num: 1.21, want: "1.3"},
+		{name: "floor", formatter: FloatCellFormatter{Precision: 1, RoundingMode: RoundFloor}, num: -1.21, want: "-1.3"},
+		{name: "ceiling", formatter: FloatCellFormatter{Precision: 1, RoundingMode: RoundCeiling}, num: -1.29, want: "-1.2"},
+		{name: "significant fraction", formatter: FloatCellFormatter{Precision: -1, SignificantDigits: 3}, num: 0.00123456, want: "0.00123"},
+		{name: "significant integer", formatter: FloatCellFormatter{Precision: -1, SignificantDigits: 2}, num: 12345, want: "12000"},
+		{name: "significant limited by precision", formatter: FloatCellFormatter{Precision: 2, SignificantDigits: 6}, num: 1.23456, want: "1.23"},
+		{name: "significant carry", formatter: FloatCellFormatter{Precision: -1, SignificantDigits: 1}, num: 96, want: "100"},
+		{name: "NaN", formatter: FloatCellFormatter{Precision: 2}, num: math.NaN(), want: "NaN"},
+		{name: "Inf", formatter: FloatCellFormatter{Precision: 2}, num: math.Inf(-1), want: "-Inf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.formatter.Format(tt.num, 64))
+		})
+	}
+}
+
+func TestFloatCellFormatter_FormatCell(t *testing.T) {
+	view := &AnyValuesView{
+		Cols: []string{"A"},
+		Rows: [][]any{{float32(0.1)}, {1}, {nil}},
+	}
+	formatter := FloatCellFormatter{Precision: -1}
+
+	str, raw, err := formatter.FormatCell(context.Background(), view, 0, 0)
+	require.NoError(t, err)
+	require.False(t, raw)
+	require.Equal(t, "0.1", str)
+
+	_, _, err = formatter.FormatCell(context.Background(), view, 1, 0)
+	require.True(t, errors.Is(err, errors.ErrUnsupported))
+	_, _, err = formatter.FormatCell(context.Background(), view, 2, 0)
+	require.True(t, errors.Is(err, errors.ErrUnsupported))
+}
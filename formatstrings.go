@@ -2,9 +2,16 @@ package retable
 
 import (
 	"context"
+	"slices"
 )
 
 func FormatTableAsStrings(ctx context.Context, table any, formatter CellFormatter, options ...Option) (rows [][]string, err error) {
+	return FormatTableAsStringsWithOptions(ctx, table, NewFormatOptions(formatter, options...))
+}
+
+// FormatTableAsStringsWithOptions creates a View for the table
+// using SelectViewer and formats it using FormatViewAsStringsWithOptions.
+func FormatTableAsStringsWithOptions(ctx context.Context, table any, options *FormatOptions) (rows [][]string, err error) {
 	viewer, err := SelectViewer(table)
 	if err != nil {
 		return nil, err
@@ -13,21 +20,38 @@ func FormatTableAsStrings(ctx context.Context, table any, formatter CellFormatte
 	if err != nil {
 		return nil, err
 	}
-	return FormatViewAsStrings(ctx, view, formatter, options...)
+	return FormatViewAsStringsWithOptions(ctx, view, options)
 }
 
 func FormatViewAsStrings(ctx context.Context, view View, formatter CellFormatter, options ...Option) (rows [][]string, err error) {
-	formatter = TryFormattersOrSprint(formatter)
+	return FormatViewAsStringsWithOptions(ctx, view, NewFormatOptions(formatter, options...))
+}
+
+// FormatViewAsStringsWithOptions formats the cells of a View as strings
+// configured by the passed options.
+// A nil options argument is valid and equal to the zero value.
+func FormatViewAsStringsWithOptions(ctx context.Context, view View, options *FormatOptions) (rows [][]string, err error) {
+	if options == nil {
+		options = new(FormatOptions)
+	}
 	numRows := view.NumRows()
 	numCols := len(view.Columns())
+	if options.MaxRows > 0 {
+		numRows = min(numRows, options.MaxRows)
+	}
 
-	if HasOption(options, OptionAddHeaderRow) {
+	if options.HeaderRow {
 		// view.Columns() would already returns a string slice,
 		// but use formatter for any additional formatting of strings
 		headerView := NewHeaderViewFrom(view)
+		headerFormatters := options.Formatters
+		if options.HeaderFormatter != nil {
+			headerFormatters = []CellFormatter{options.HeaderFormatter}
+		}
+		headerFormatter := formatStringsFormatter(headerFormatters, options.NilString)
 		rowStrings := make([]string, numCols)
 		for col := 0; col < numCols; col++ {
-			rowStrings[col], _, err = formatter.FormatCell(ctx, headerView, 0, col)
+			rowStrings[col], _, err = headerFormatter.FormatCell(ctx, headerView, 0, col)
 			if err != nil {
 				return nil, err
 			}
@@ -35,6 +59,7 @@ func FormatViewAsStrings(ctx context.Context, view View, formatter CellFormatter
 		rows = append(rows, rowStrings)
 	}
 
+	formatter := formatStringsFormatter(options.Formatters, options.NilString)
 	for row := 0; row < numRows; row++ {
 		rowStrings := make([]string, numCols)
 		for col := 0; col < numCols; col++ {
@@ -48,3 +73,11 @@ func FormatViewAsStrings(ctx context.Context, view View, formatter CellFormatter
 
 	return rows, nil
 }
+
+// formatStringsFormatter returns TryFormattersOrSprint for formatters
+// with RenderNullAs(nilString) as last formatter
+// so null-like values not formatted by one of the formatters
+// are formatted as nilString instead of an empty string.
+func formatStringsFormatter(formatters []CellFormatter, nilString string) CellFormatter {
+	return TryFormattersOrSprint(append(slices.Clip(formatters), RenderNullAs(nilString))...)
+}
@@ -77,3 +77,31 @@ func TestFormatTableAsStrings(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatViewAsStringsWithOptions(t *testing.T) {
+	view := &AnyValuesView{
+		Cols: []string{"A", "B"},
+		Rows: [][]any{
+			{1, nil},
+			{2, "two"},
+			{3, "three"},
+		},
+	}
+	options := new(FormatOptions).
+		WithHeaderRow(true).
+		WithHeaderFormatter(PrintfCellFormatter("[%s]")).
+		WithNilString("NULL").
+		WithMaxRows(2)
+	gotRows, err := FormatViewAsStringsWithOptions(context.Background(), view, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantRows := [][]string{
+		{"[A]", "[B]"},
+		{"1", "NULL"},
+		{"2", "two"},
+	}
+	if !reflect.DeepEqual(gotRows, wantRows) {
+		t.Errorf("FormatViewAsStringsWithOptions() = %v, want %v", gotRows, wantRows)
+	}
+}
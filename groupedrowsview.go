@@ -0,0 +1,152 @@
+package retable
+
+import (
+	"reflect"
+	"sort"
+)
+
+var (
+	_ RowGroupsView   = new(GroupedRowsView)
+	_ ReflectCellView = new(GroupedRowsView)
+	_ SparseCellView  = new(GroupedRowsView)
+)
+
+// RowGroupsView is a View with rows grouped into sections
+// that start with a group header row.
+//
+// The HTML writer renders every group as <tbody> section
+// and the Excel writer uses row outline levels
+// so that groups can be collapsed.
+type RowGroupsView interface {
+	View
+
+	// RowGroup returns the group of a row
+	// and if the row is the header row of the group.
+	RowGroup(row int) (group RowGroup, isHeaderRow bool)
+}
+
+// RowGroup describes a group of a RowGroupsView.
+type RowGroup struct {
+	// Index of the group
+	Index int
+	// Key is the value of the key column of the rows of the group
+	Key any
+	// HeaderRow is the row index of the group header row
+	HeaderRow int
+	// NumRows is the number of data rows
+	// of the group without the header row
+	NumRows int
+}
+
+// LastRow returns the row index of the last row of the group.
+func (g RowGroup) LastRow() int {
+	return g.HeaderRow + g.NumRows
+}
+
+// GroupedRowsView is a RowGroupsView that groups
+// consecutive rows of a source view with equal values
+// in a key column and inserts a group header row
+// before the rows of every group.
+//
+// Group header rows only have a cell in the key column
+// with the key of the group, all other cells don't exist.
+// The source should be sorted by the key column
+// because only consecutive rows are grouped.
+//
+// The source must not change after the GroupedRowsView was created.
+type GroupedRowsView struct {
+	source    ReflectCellView
+	keyColumn int
+	groups    []RowGroup
+	// sourceRows maps view rows to source rows, -1 for header rows
+	sourceRows []int
+}
+
+// NewGroupedRowsView returns a GroupedRowsView
+// that groups the rows of source by the column keyColumn.
+func NewGroupedRowsView(source View, keyColumn int) *GroupedRowsView {
+	view := &GroupedRowsView{
+		source:    AsReflectCellView(source),
+		keyColumn: keyColumn,
+	}
+	for row := 0; row < source.NumRows(); row++ {
+		key := source.Cell(row, keyColumn)
+		if len(view.groups) == 0 || !reflect.DeepEqual(view.groups[len(view.groups)-1].Key, key) {
+			view.groups = append(view.groups, RowGroup{
+				Index:     len(view.groups),
+				Key:       key,
+				HeaderRow: len(view.sourceRows),
+			})
+			view.sourceRows = append(view.sourceRows, -1)
+		}
+		view.groups[len(view.groups)-1].NumRows++
+		view.sourceRows = append(view.sourceRows, row)
+	}
+	return view
+}
+
+func (view *GroupedRowsView) Title() string     { return view.source.Title() }
+func (view *GroupedRowsView) Columns() []string { return view.source.Columns() }
+func (view *GroupedRowsView) NumRows() int      { return len(view.sourceRows) }
+
+// KeyColumn returns the index of the column the rows are grouped by.
+func (view *GroupedRowsView) KeyColumn() int { return view.keyColumn }
+
+// Groups returns the groups of the view.
+func (view *GroupedRowsView) Groups() []RowGroup { return view.groups }
+
+func (view *GroupedRowsView) RowGroup(row int) (group RowGroup, isHeaderRow bool) {
+	if row < 0 || row >= len(view.sourceRows) {
+		return RowGroup{Index: -1, HeaderRow: -1}, false
+	}
+	i := sort.Search(len(view.groups), func(i int) bool {
+		return view.groups[i].LastRow() >= row
+	})
+	group = view.groups[i]
+	return group, group.HeaderRow == row
+}
+
+// ColumnFormatter implements ColumnFormatterView
+// by returning the formatter of the source column.
+func (view *GroupedRowsView) ColumnFormatter(col int) CellFormatter {
+	return ViewColumnFormatter(view.source, col)
+}
+
+func (view *GroupedRowsView) ColumnDescription(col int) string {
+	return ViewColumnDescription(view.source, col)
+}
+
+func (view *GroupedRowsView) ColumnHidden(col int) bool {
+	return ViewColumnHidden(view.source, col)
+}
+
+func (view *GroupedRowsView) CellExists(row, col int) bool {
+	if row < 0 || row >= len(view.sourceRows) || col < 0 || col >= len(view.Columns()) {
+		return false
+	}
+	if view.sourceRows[row] == -1 {
+		return col == view.keyColumn
+	}
+	return CellExists(view.source, view.sourceRows[row], col)
+}
+
+func (view *GroupedRowsView) Cell(row, col int) any {
+	if !view.CellExists(row, col) {
+		return nil
+	}
+	if view.sourceRows[row] == -1 {
+		group, _ := view.RowGroup(row)
+		return group.Key
+	}
+	return view.source.Cell(view.sourceRows[row], col)
+}
+
+func (view *GroupedRowsView) ReflectCell(row, col int) reflect.Value {
+	if !view.CellExists(row, col) {
+		return reflect.Value{}
+	}
+	if view.sourceRows[row] == -1 {
+		return view.source.ReflectCell(view.sourceRows[row+1], col)
+	}
+	return view.source.ReflectCell(view.sourceRows[row], col)
+}
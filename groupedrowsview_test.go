@@ -0,0 +1,54 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupedRowsView(t *testing.T) {
+	source := &AnyValuesView{
+		Cols: []string{"Region", "City"},
+		Rows: [][]any{
+			{"North", "Oslo"},
+			{"North", "Helsinki"},
+			{"South", "Rome"},
+		},
+	}
+	view := NewGroupedRowsView(source, 0)
+
+	require.Equal(t, 5, view.NumRows())
+	require.Equal(t, []RowGroup{
+		{Index: 0, Key: "North", HeaderRow: 0, NumRows: 2},
+		{Index: 1, Key: "South", HeaderRow: 3, NumRows: 1},
+	}, view.Groups())
+
+	tests := []struct {
+		row         int
+		wantGroup   int
+		wantHeader  bool
+		wantCells   []any
+		wantExists1 bool
+	}{
+		{row: 0, wantGroup: 0, wantHeader: true, wantCells: []any{"North", nil}},
+		{row: 1, wantGroup: 0, wantHeader: false, wantCells: []any{"North", "Oslo"}, wantExists1: true},
+		{row: 2, wantGroup: 0, wantHeader: false, wantCells: []any{"North", "Helsinki"}, wantExists1: true},
+		{row: 3, wantGroup: 1, wantHeader: true, wantCells: []any{"South", nil}},
+		{row: 4, wantGroup: 1, wantHeader: false, wantCells: []any{"South", "Rome"}, wantExists1: true},
+	}
+	for _, tt := range tests {
+		group, isHeader := view.RowGroup(tt.row)
+		require.Equal(t, tt.wantGroup, group.Index, "row %d", tt.row)
+		require.Equal(t, tt.wantHeader, isHeader, "row %d", tt.row)
+		require.Equal(t, tt.wantCells, []any{view.Cell(tt.row, 0), view.Cell(tt.row, 1)}, "row %d", tt.row)
+		require.Equal(t, tt.wantExists1, view.CellExists(tt.row, 1), "row %d", tt.row)
+		require.Equal(t, tt.wantCells[0], view.ReflectCell(tt.row, 0).Interface(), "row %d", tt.row)
+	}
+
+	group, _ := view.RowGroup(5)
+	require.Equal(t, -1, group.Index)
+
+	masked := RedactColumnsView(view, "***", "City")
+	_, ok := masked.(RowGroupsView)
+	require.True(t, ok, "redacted view keeps row groups")
+}
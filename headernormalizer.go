@@ -0,0 +1,139 @@
+package retable
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// UnicodeNormalization is the Unicode normalization form
+// applied by a HeaderNormalizer.
+type UnicodeNormalization int
+
+const (
+	// NoUnicodeNormalization does not normalize
+	NoUnicodeNormalization UnicodeNormalization = iota
+	// NFC is the canonical composition normalization form
+	NFC
+	// NFKC is the compatibility composition normalization form
+	// that also maps compatibility characters
+	// like ligatures or full width letters
+	NFKC
+)
+
+// HeaderNormalizer normalizes column titles for lenient matching
+// of the columns of read views with struct fields and SQL columns,
+// so that for example "Straße " matches "strasse".
+//
+// nil is a valid value for *HeaderNormalizer
+// that does not normalize.
+type HeaderNormalizer struct {
+	// Unicode normalization form
+	Unicode UnicodeNormalization
+	// Trim leading and trailing whitespace
+	Trim bool
+	// CollapseSpaces replaces sequences of whitespace with a single space
+	CollapseSpaces bool
+	// CaseFold applies Unicode case folding
+	// like "Straße" to "strasse"
+	CaseFold bool
+	// StripAccents removes diacritical marks
+	// like "Café" to "Cafe"
+	StripAccents bool
+}
+
+// LenientHeaderNormalizer returns a HeaderNormalizer
+// with all normalization options enabled.
+func LenientHeaderNormalizer() *HeaderNormalizer {
+	return &HeaderNormalizer{
+		Unicode:        NFKC,
+		Trim:           true,
+		CollapseSpaces: true,
+		CaseFold:       true,
+		StripAccents:   true,
+	}
+}
+
+// Normalize returns the normalized header.
+//
+// Valid to call with nil receiver.
+func (n *HeaderNormalizer) Normalize(header string) string {
+	if n == nil {
+		return header
+	}
+	if n.StripAccents {
+		// Decompose to remove the combining marks
+		header, _, _ = transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), header)
+	}
+	switch n.Unicode {
+	case NFC:
+		header = norm.NFC.String(header)
+	case NFKC:
+		header = norm.NFKC.String(header)
+	}
+	if n.CaseFold {
+		header = cases.Fold().String(header)
+	}
+	if n.CollapseSpaces {
+		header = collapseSpaces(header)
+	}
+	if n.Trim {
+		header = strings.TrimSpace(header)
+	}
+	return header
+}
+
+// Equal returns if the headers a and b are equal after normalization.
+//
+// Valid to call with nil receiver.
+func (n *HeaderNormalizer) Equal(a, b string) bool {
+	if a == b {
+		return true
+	}
+	return n != nil && n.Normalize(a) == n.Normalize(b)
+}
+
+// Index returns the index of the first of columns that
+// equals column after normalization or -1 if there is none.
+//
+// Valid to call with nil receiver.
+func (n *HeaderNormalizer) Index(columns []string, column string) int {
+	for i, c := range columns {
+		if c == column {
+			return i
+		}
+	}
+	if n == nil {
+		return -1
+	}
+	normalized := n.Normalize(column)
+	for i, c := range columns {
+		if n.Normalize(c) == normalized {
+			return i
+		}
+	}
+	return -1
+}
+
+// collapseSpaces replaces sequences of whitespace with a single space
+func collapseSpaces(str string) string {
+	var b strings.Builder
+	b.Grow(len(str))
+	lastWasSpace := false
+	for _, r := range str {
+		if unicode.IsSpace(r) {
+			if !lastWasSpace {
+				b.WriteByte(' ')
+			}
+			lastWasSpace = true
+			continue
+		}
+		b.WriteRune(r)
+		lastWasSpace = false
+	}
+	return b.String()
+}
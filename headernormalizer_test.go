@@ -0,0 +1,69 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderNormalizer_Normalize(t *testing.T) {
+	tests := []struct {
+		name       string
+		normalizer *HeaderNormalizer
+		header     string
+		want       string
+	}{
+		{name: "nil", normalizer: nil, header: " Straße ", want: " Straße "},
+		{name: "zero", normalizer: &HeaderNormalizer{}, header: " Straße ", want: " Straße "},
+		{name: "trim", normalizer: &HeaderNormalizer{Trim: true}, header: " \tStraße\n", want: "Straße"},
+		{name: "collapse spaces", normalizer: &HeaderNormalizer{CollapseSpaces: true}, header: "Invoice \t Number ", want: "Invoice Number "},
+		{name: "case fold", normalizer: &HeaderNormalizer{CaseFold: true}, header: "Straße", want: "strasse"},
+		{name: "strip accents", normalizer: &HeaderNormalizer{StripAccents: true}, header: "Café Crème", want: "Cafe Creme"},
+		{name: "NFC", normalizer: &HeaderNormalizer{Unicode: NFC}, header: "Café", want: "Café"},
+		{name: "NFKC", normalizer: &HeaderNormalizer{Unicode: NFKC}, header: "ﬁle Ｎｏ", want: "file No"},
+		{name: "lenient", normalizer: LenientHeaderNormalizer(), header: "  Straße  Nr.  ", want: "strasse nr."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.normalizer.Normalize(tt.header))
+		})
+	}
+}
+
+func TestHeaderNormalizer_Index(t *testing.T) {
+	columns := []string{"Name", "Straße ", "PLZ"}
+	var exact *HeaderNormalizer
+	require.Equal(t, 1, exact.Index(columns, "Straße "))
+	require.Equal(t, -1, exact.Index(columns, "strasse"))
+	require.True(t, exact.Equal("PLZ", "PLZ"))
+	require.False(t, exact.Equal("PLZ", "plz"))
+
+	lenient := LenientHeaderNormalizer()
+	require.Equal(t, 1, lenient.Index(columns, "strasse"))
+	require.Equal(t, 2, lenient.Index(columns, "plz"))
+	require.Equal(t, -1, lenient.Index(columns, "City"))
+	require.True(t, lenient.Equal("Straße ", "STRASSE"))
+}
+
+func TestViewToStructSlice_HeaderNormalizer(t *testing.T) {
+	type Address struct {
+		Street string `col:"strasse"`
+		Zip    string `col:"plz"`
+	}
+	view := NewStringsView("", [][]string{
+		{"Straße ", " PLZ"},
+		{"Hauptstraße 1", "1010"},
+	})
+
+	rows, err := ViewToStructSlice[Address](view, &DefaultStructFieldNaming, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Address{{}}, rows, "no exact matches")
+
+	_, err = ViewToStructSlice[Address](view, &DefaultStructFieldNaming, nil, nil, nil, "strasse")
+	require.Error(t, err)
+
+	naming := DefaultStructFieldNaming.WithHeaderNormalizer(LenientHeaderNormalizer())
+	rows, err = ViewToStructSlice[Address](view, naming, nil, nil, nil, "strasse", "plz")
+	require.NoError(t, err)
+	require.Equal(t, []Address{{Street: "Hauptstraße 1", Zip: "1010"}}, rows)
+}
@@ -0,0 +1,144 @@
+package htmltable
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/domonda/go-retable"
+)
+
+// Page describes a range of rows of a view
+// written by Writer.WriteViewPage or Writer.WriteRowsFragment
+// with the metadata needed for pagination
+// or "load more" navigation.
+type Page struct {
+	// Offset is the index of the first row of the page
+	Offset int
+	// Limit is the maximum number of rows of the page
+	Limit int
+	// TotalRows is the number of rows of the view
+	TotalRows int
+}
+
+// NewPage returns a Page with up to limit rows
+// starting at offset for a view with totalRows rows.
+func NewPage(offset, limit, totalRows int) (*Page, error) {
+	if offset < 0 {
+		return nil, fmt.Errorf("negative page offset %d", offset)
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("invalid page limit %d", limit)
+	}
+	return &Page{Offset: offset, Limit: limit, TotalRows: totalRows}, nil
+}
+
+// NumRows returns the number of rows of the page
+// which can be less than Limit for the last page.
+func (p *Page) NumRows() int {
+	return max(min(p.Limit, p.TotalRows-p.Offset), 0)
+}
+
+// HasNext returns if there are rows after the page.
+func (p *Page) HasNext() bool {
+	return p.NextOffset() < p.TotalRows
+}
+
+// NextOffset returns the offset of the row after the page.
+func (p *Page) NextOffset() int {
+	return p.Offset + p.NumRows()
+}
+
+// HasPrev returns if there are rows before the page.
+func (p *Page) HasPrev() bool {
+	return p.Offset > 0
+}
+
+// PrevOffset returns the offset of the previous page.
+func (p *Page) PrevOffset() int {
+	return max(p.Offset-p.Limit, 0)
+}
+
+// WriteViewPage writes an HTML table with up to limit rows
+// of the view starting at the row offset.
+//
+// The returned Page is passed as TemplateContext.Page to the templates
+// and the default HeaderTemplate writes it as data-offset,
+// data-total-rows, and data-next-offset attributes of the table.
+// The max rows limit of the writer is not used for pages.
+func (w *Writer[T]) WriteViewPage(ctx context.Context, dest io.Writer, view retable.View, offset, limit int) (page *Page, err error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	page, err = NewPage(offset, limit, view.NumRows())
+	if err != nil {
+		return nil, err
+	}
+	ctx, iw := retable.StartInstrumentedWrite(ctx, w.metrics, "html", view.Title(), dest)
+	defer func() { err = iw.End(ctx, err) }()
+
+	caption := w.viewCaption(view)
+	if !w.includeHidden {
+		view = retable.VisibleColumnsView(view)
+	}
+	view = retable.RedactColumnsView(view, w.redaction, w.redactedColumns...)
+	view = w.pointerHandling.View(view)
+	view = w.zeroAsNull.View(view)
+	err = w.writeView(ctx, iw, view, caption, page.Offset, page.NumRows(), false, page, iw.Hooks(w.hooks))
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// WriteRowsFragment writes only the rows of a page of the view
+// using the row template without table header, header row, and footer.
+// The fragment can be returned by endpoints for infinite scrolling
+// or HTMX "load more" requests that append rows to an existing table.
+// The returned Page has the offset for the next request.
+func (w *Writer[T]) WriteRowsFragment(ctx context.Context, dest io.Writer, view retable.View, offset, limit int) (page *Page, err error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	page, err = NewPage(offset, limit, view.NumRows())
+	if err != nil {
+		return nil, err
+	}
+	ctx, iw := retable.StartInstrumentedWrite(ctx, w.metrics, "html", view.Title(), dest)
+	defer func() { err = iw.End(ctx, err) }()
+
+	caption := w.viewCaption(view)
+	if !w.includeHidden {
+		view = retable.VisibleColumnsView(view)
+	}
+	view = retable.RedactColumnsView(view, w.redaction, w.redactedColumns...)
+	view = w.pointerHandling.View(view)
+	view = w.zeroAsNull.View(view)
+	var (
+		hooks       = iw.Hooks(w.hooks)
+		templData   = w.newRowTemplateContext(ctx, view, caption, page, page.Offset)
+		reflectView = retable.AsReflectCellView(view)
+		rowBuf      = bytes.NewBuffer(make([]byte, 0, 1024))
+		out         = &retable.MaxBytesWriter{Dest: iw, Max: w.maxBytes}
+	)
+	if w.headerRow {
+		// Continue the row indices of WriteViewPage
+		templData.RowIndex++
+	}
+	for row := page.Offset; row < page.NextOffset(); row++ {
+		hooks.RowStart(ctx, view, row)
+		templData.Group = rowGroup(view, row, page.Offset, page.NextOffset()-1)
+		err = w.writeRow(ctx, rowBuf, view, reflectView, row, templData, hooks)
+		if err == nil {
+			_, err = out.Write(rowBuf.Bytes())
+		}
+		hooks.RowEnd(ctx, view, row, err)
+		if err != nil {
+			return nil, err
+		}
+		rowBuf.Reset()
+		templData.RowIndex++
+	}
+	return page, nil
+}
@@ -0,0 +1,31 @@
+package htmltable
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/domonda/go-retable"
+)
+
+func ExampleWriter_WriteViewPage() {
+	view := retable.NewStringsView("", [][]string{
+		{"A", "B"},
+		{"1", "2"},
+		{"3", "4"},
+		{"5", "6"},
+	})
+	writer := NewWriter[any]().WithHeaderRow(true)
+
+	page, _ := writer.WriteViewPage(context.Background(), os.Stdout, view, 0, 2)
+	fmt.Println()
+	writer.WriteRowsFragment(context.Background(), os.Stdout, view, page.NextOffset(), page.Limit)
+
+	// Output:
+	// <table data-offset='0' data-total-rows='3' data-next-offset='2'>
+	//   <tr><th scope='col'>A</th><th scope='col'>B</th></tr>
+	//   <tr><td>1</td><td>2</td></tr>
+	//   <tr><td>3</td><td>4</td></tr>
+	// </table>
+	//   <tr><td>5</td><td>6</td></tr>
+}
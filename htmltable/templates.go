@@ -1,29 +1,75 @@
 package htmltable
 
-import "html/template"
+import (
+	"html/template"
+
+	"golang.org/x/text/language"
+
+	"github.com/domonda/go-retable"
+)
 
 var (
 	HeaderTemplate = template.Must(template.New("header").Parse(
-		"<table{{if .TableClass}} class='{{.TableClass}}'{{end}}>\n" +
-			"{{if .Caption}}  <caption>{{.Caption}}</caption>\n{{end}}",
+		"<table{{if .TableID}} id='{{.TableID}}'{{end}}{{if .TableClass}} class='{{.TableClass}}'{{end}}" +
+			"{{if .Lang}} lang='{{.Lang}}'{{end}}{{if .Dir}} dir='{{.Dir}}'{{end}}" +
+			"{{with .Styles.table}} style='{{.}}'{{end}}" +
+			"{{with .Page}} data-offset='{{.Offset}}' data-total-rows='{{.TotalRows}}'" +
+			"{{if .HasNext}} data-next-offset='{{.NextOffset}}'{{end}}{{end}}>\n" +
+			"{{if .Caption}}  <caption{{with .Styles.caption}} style='{{.}}'{{end}}>{{.Caption}}</caption>\n{{end}}" +
+			"{{if .ColumnGroups}}  <tr{{with .Styles.tr}} style='{{.}}'{{end}}>{{range .ColumnGroups}}<th scope='colgroup'" +
+			"{{if gt .NumColumns 1}} colspan='{{.NumColumns}}'{{end}}{{with $.Styles.th}} style='{{.}}'{{end}}>{{.Title}}</th>{{end}}</tr>\n{{end}}",
 	))
 
 	RowTemplate = template.Must(template.New("row").Parse("" +
+		"{{with .Group}}{{if .IsFirstRow}}  <tbody data-group='{{.Index}}' data-group-key='{{.Key}}' data-group-rows='{{.NumRows}}'>\n{{end}}{{end}}" +
 		"{{if .IsHeaderRow}}" +
-		"  <tr>{{range $cell := .RawCells}}<th>{{$cell}}</th>{{end}}</tr>\n" +
+		"  <tr{{with .Styles.tr}} style='{{.}}'{{end}}>{{range $i, $cell := .RawCells}}<th scope='col'" +
+		"{{with index $.Columns $i}}{{if .ID}} id='{{.ID}}'{{end}}{{if .AriaSort}} aria-sort='{{.AriaSort}}'{{end}}" +
+		"{{if .Description}} title='{{.Description}}'{{end}}{{end}}" +
+		"{{with $.Styles.th}} style='{{.}}'{{end}}>{{$cell}}</th>{{end}}</tr>\n" +
+		"{{else if and .Group .Group.IsHeaderRow}}" +
+		"  <tr class='group-header'{{with .Styles.tr}} style='{{.}}'{{end}}><th scope='rowgroup' colspan='{{len .RawCells}}'" +
+		"{{with .Styles.th}} style='{{.}}'{{end}}>{{range .RawCells}}{{.}}{{end}}</th></tr>\n" +
 		"{{else}}" +
-		"  <tr>{{range $cell := .RawCells}}<td>{{$cell}}</td>{{end}}</tr>\n" +
-		"{{end}}",
+		"  <tr{{with .Styles.tr}} style='{{.}}'{{end}}>{{range $cell := .RawCells}}<td{{with $.Styles.td}} style='{{.}}'{{end}}>{{$cell}}</td>{{end}}</tr>\n" +
+		"{{end}}" +
+		"{{with .Group}}{{if .IsLastRow}}  </tbody>\n{{end}}{{end}}",
 	))
 
 	FooterTemplate = template.Must(template.New("footer").Parse(
-		"</table>",
+		"{{with .Provenance}}  <tfoot class='provenance'><tr><td colspan='{{$.NumColumns}}'>" +
+			"{{range $i, $f := .}}{{if $i}}<br>{{end}}{{$f.Name}}: {{$f.Value}}{{end}}</td></tr></tfoot>\n{{end}}" +
+			"</table>",
 	))
 )
 
 type TemplateContext struct {
+	// TableID is the id attribute of the table
+	// and prefix of the column header ids
+	TableID    string
 	TableClass string
 	Caption    string
+	// Lang is the lang attribute of the table,
+	// see Writer.WithLang
+	Lang string
+	// Dir is the dir attribute of the table,
+	// see Writer.WithDir
+	Dir Dir
+	// Page is the written page of rows
+	// or nil if all rows are written
+	Page *Page
+	// Styles are inline CSS styles by element name,
+	// see Writer.WithInlineStyles
+	Styles map[string]template.CSS
+	// ColumnGroups are written as header row
+	// above the column titles if not empty,
+	// see Writer.WithColumnGroups
+	ColumnGroups []retable.ColumnGroup
+	// NumColumns is the number of columns of the table
+	NumColumns int
+	// Provenance fields are written as table footer note
+	// if not empty, see Writer.WithProvenance
+	Provenance []retable.ProvenanceField
 }
 
 type RowTemplateContext struct {
@@ -32,4 +78,94 @@ type RowTemplateContext struct {
 	IsHeaderRow bool
 	RowIndex    int
 	RawCells    []template.HTML
+	// Columns has the accessibility attributes
+	// for the header cells of every column
+	Columns []ColumnTemplateContext
+	// Values are the unformatted cell values of a data row
+	// with nil for null values, nil for the header row
+	Values []any
+	// Group of the row if the view implements
+	// retable.RowGroupsView or else nil
+	Group *RowGroupTemplateContext
+}
+
+// RowGroupTemplateContext describes the group of a row.
+// The default RowTemplate writes every group
+// as <tbody> section with data attributes
+// so that groups can be collapsed by scripts or styles.
+type RowGroupTemplateContext struct {
+	Index int
+	// Key of the group formatted with fmt.Sprint
+	Key string
+	// NumRows of the group without the group header row
+	NumRows int
+	// IsHeaderRow is true for the group header row
+	// that only has a cell for the key column
+	IsHeaderRow bool
+	// IsFirstRow is true if the row starts the section of the group
+	IsFirstRow bool
+	// IsLastRow is true if the row ends the section of the group
+	IsLastRow bool
+}
+
+// Value returns the unformatted value of the cell
+// of the current data row in the column with the passed title
+// or nil if there is no such column.
+// Can be used in templates like {{if lt (.Value "Amount") 0.0}}
+func (c *RowTemplateContext) Value(column string) any {
+	if c.Values == nil {
+		return nil
+	}
+	for i, col := range c.Columns {
+		if col.Title == column {
+			return c.Values[i]
+		}
+	}
+	return nil
+}
+
+// ColumnTemplateContext has the accessibility attributes
+// of a column header cell.
+type ColumnTemplateContext struct {
+	Title string
+	// ID of the header cell if the table has an ID
+	ID string
+	// AriaSort of the column if it is sorted
+	AriaSort AriaSort
+	// Description of the column written as title attribute
+	// of the header cell, see retable.ColumnDescriptionView
+	Description string
+}
+
+// AriaSort is the value of the aria-sort attribute
+// of the header cell of a sorted column.
+type AriaSort string
+
+const (
+	AriaSortNone       AriaSort = ""
+	AriaSortAscending  AriaSort = "ascending"
+	AriaSortDescending AriaSort = "descending"
+)
+
+// Dir is the value of the dir attribute of the table
+// that sets the text direction of its content.
+type Dir string
+
+const (
+	DirNone Dir = ""
+	DirLTR  Dir = "ltr"
+	DirRTL  Dir = "rtl"
+	DirAuto Dir = "auto"
+)
+
+// LocaleDir returns DirRTL for locales
+// written in a right-to-left script like Arabic or Hebrew
+// and DirLTR for all other locales.
+func LocaleDir(locale language.Tag) Dir {
+	script, _ := locale.Script()
+	switch script.String() {
+	case "Arab", "Hebr", "Syrc", "Thaa", "Nkoo", "Adlm", "Mand", "Samr", "Rohg":
+		return DirRTL
+	}
+	return DirLTR
 }
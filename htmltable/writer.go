@@ -6,35 +6,90 @@ import (
 	"fmt"
 	"html/template"
 	"io"
+	"io/fs"
+	"maps"
+	"path"
 	"reflect"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/domonda/go-retable"
 )
 
 type Writer[T any] struct {
+	tableID          string
 	tableClass       string
 	viewer           retable.Viewer
 	columnFormatters map[int]retable.CellFormatter
 	typeFormatters   *retable.ReflectTypeCellFormatter
-	nilValue         template.HTML
+	nullPolicy       retable.NullPolicy
+	columnNulls      retable.ColumnNullPolicies
+	errorPolicy      retable.ErrorPolicy
 	headerRow        bool
 	headerTemplate   *template.Template
 	rowTemplate      *template.Template
 	footerTemplate   *template.Template
+	redactedColumns  []string
+	redaction        string
+	hooks            *retable.WriteHooks
+	metrics          retable.MetricsRecorder
+	maxRows          int
+	maxBytes         int64
+	truncationMarker string
+	caption          *string
+	lang             string
+	dir              Dir
+	sortedColumn     int
+	ariaSort         AriaSort
+	styles           map[string]template.CSS
+	templateFuncs    template.FuncMap
+	columnGroups     []retable.ColumnGroup
+	provenance       *retable.Provenance
+	recoverPanics    bool
+	rowTimeout       time.Duration
+	pointerHandling  retable.PointerHandling
+	zeroAsNull       retable.ZeroAsNull
+	includeHidden    bool
+	rawPolicy        retable.RawPolicy
 }
 
 func NewWriter[T any]() *Writer[T] {
 	return &Writer[T]{
+		tableID:          "",
 		tableClass:       "",
 		viewer:           nil,
 		columnFormatters: make(map[int]retable.CellFormatter),
 		typeFormatters:   nil, // OK to use nil retable.TypeFormatters
-		nilValue:         "",
+		nullPolicy:       retable.RenderNullAsRaw(""),
+		columnNulls:      retable.ColumnNullPolicies{},
+		errorPolicy:      retable.ErrorPolicy{},
 		headerRow:        false,
 		headerTemplate:   HeaderTemplate,
 		rowTemplate:      RowTemplate,
 		footerTemplate:   FooterTemplate,
+		redactedColumns:  nil,
+		redaction:        retable.DefaultRedactionPlaceholder,
+		hooks:            nil,
+		metrics:          nil,
+		maxRows:          0,
+		maxBytes:         0,
+		truncationMarker: "",
+		caption:          nil,
+		lang:             "",
+		dir:              DirNone,
+		sortedColumn:     -1,
+		ariaSort:         AriaSortNone,
+		styles:           nil,
+		templateFuncs:    nil,
+		columnGroups:     nil,
+		provenance:       nil,
+		recoverPanics:    false,
+		rowTimeout:       0,
+		pointerHandling:  retable.PointerKeep,
+		zeroAsNull:       retable.ZeroAsNull{},
+		includeHidden:    false,
+		rawPolicy:        retable.RawTrust,
 	}
 }
 
@@ -60,92 +115,325 @@ func (w *Writer[T]) WriteWithViewer(ctx context.Context, dest io.Writer, viewer
 	return w.WriteView(ctx, dest, view)
 }
 
-func (w *Writer[T]) WriteView(ctx context.Context, dest io.Writer, view retable.View) error {
+// WriteView writes the view to dest as HTML table.
+//
+// An OpenTelemetry span is created for the write
+// and metrics are recorded if a MetricsRecorder is set.
+func (w *Writer[T]) WriteView(ctx context.Context, dest io.Writer, view retable.View) (err error) {
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
+	ctx, iw := retable.StartInstrumentedWrite(ctx, w.metrics, "html", view.Title(), dest)
+	defer func() { err = iw.End(ctx, err) }()
 
+	caption := w.viewCaption(view)
+	if !w.includeHidden {
+		view = retable.VisibleColumnsView(view)
+	}
+	view = retable.RedactColumnsView(view, w.redaction, w.redactedColumns...)
+	view = w.pointerHandling.View(view)
+	view = w.zeroAsNull.View(view)
+	numRows, truncate, err := w.rowLimit(view)
+	if err != nil {
+		return err
+	}
+	return w.writeView(ctx, iw, view, caption, 0, numRows, truncate, nil, iw.Hooks(w.hooks))
+}
+
+// rowLimit returns the number of rows of view to write
+// and if the rows are truncated because of maxRows,
+// or ErrMaxRowsExceeded if no truncation marker is set.
+func (w *Writer[T]) rowLimit(view retable.View) (numRows int, truncate bool, err error) {
+	numRows = view.NumRows()
+	if w.maxRows <= 0 || numRows <= w.maxRows {
+		return numRows, false, nil
+	}
+	if w.truncationMarker == "" {
+		return 0, false, retable.ErrMaxRowsExceeded{Max: w.maxRows, NumRows: numRows}
+	}
+	return w.maxRows, true, nil
+}
+
+// writeView writes numRows rows of view starting at firstRow to dest.
+// Every row is buffered to write only complete rows
+// within the limit of maxBytes.
+// The page is passed to the templates and can be nil.
+func (w *Writer[T]) writeView(ctx context.Context, dest io.Writer, view retable.View, caption string, firstRow, numRows int, truncate bool, page *Page, hooks *retable.WriteHooks) error {
 	var (
-		columns   = view.Columns()
-		numCols   = len(columns)
-		templData = &RowTemplateContext{
-			TemplateContext: TemplateContext{
-				TableClass: w.tableClass,
-				Caption:    view.Title(),
-			},
-			RawCells: make([]template.HTML, numCols),
-		}
+		columns     = view.Columns()
+		numCols     = len(columns)
+		templData   = w.newRowTemplateContext(ctx, view, caption, page, firstRow)
 		reflectView = retable.AsReflectCellView(view)
+		rowBuf      = retable.GetRowBuffer()
+		out         = &retable.MaxBytesWriter{Dest: dest, Max: w.maxBytes}
 	)
+	defer retable.PutRowBuffer(rowBuf)
+	templData.Provenance = w.provenance.Fields(numRows)
+
+	if w.headerRow {
+		groups, err := retable.NormalizeColumnGroups(w.columnGroups, numCols)
+		if err != nil {
+			return err
+		}
+		templData.ColumnGroups = groups
+	}
 
-	err := w.headerTemplate.Execute(dest, templData.TemplateContext)
+	err := w.headerTemplate.Execute(rowBuf, templData.TemplateContext)
 	if err != nil {
 		return err
 	}
 
 	if w.headerRow {
 		templData.IsHeaderRow = true
+		values := templData.Values
+		templData.Values = nil
 		for i := range columns {
 			templData.RawCells[i] = template.HTML(template.HTMLEscapeString(columns[i])) //#nosec G203
 		}
-		err = w.rowTemplate.Execute(dest, templData)
+		err = w.rowTemplate.Execute(rowBuf, templData)
 		if err != nil {
 			return err
 		}
 		templData.IsHeaderRow = false
+		templData.Values = values
 		templData.RowIndex++
 	}
+	_, err = out.Write(rowBuf.Bytes())
+	if err != nil {
+		return err
+	}
+	rowBuf.Reset()
 
-	for row, numRows := 0, view.NumRows(); row < numRows; row++ {
-		for col := 0; col < numCols; col++ {
+	var openGroup *RowGroupTemplateContext
+	for row := firstRow; row < firstRow+numRows; row++ {
+		hooks.RowStart(ctx, view, row)
+		templData.Group = rowGroup(view, row, firstRow, firstRow+numRows-1)
+		err = w.writeRow(ctx, rowBuf, view, reflectView, row, templData, hooks)
+		if err == nil {
+			_, err = out.Write(rowBuf.Bytes())
+		}
+		hooks.RowEnd(ctx, view, row, err)
+		if errors.As(err, new(retable.ErrMaxOutputBytesExceeded)) && w.truncationMarker != "" {
+			truncate = true
+			break
+		}
+		if err != nil {
+			return err
+		}
+		rowBuf.Reset()
+		templData.RowIndex++
+		openGroup = templData.Group
+		if openGroup != nil && openGroup.IsLastRow {
+			openGroup = nil
+		}
+	}
 
-			if colFormatter, ok := w.columnFormatters[col]; ok {
-				str, isRaw, err := colFormatter.FormatCell(ctx, view, row, col)
-				if err != nil && !errors.Is(err, errors.ErrUnsupported) {
-					return err
-				}
-				if err == nil {
-					if !isRaw {
-						str = template.HTMLEscapeString(str)
-					}
-					templData.RawCells[col] = template.HTML(str) //#nosec G203
-					continue                                     // next column cell
-				}
+	if truncate {
+		// The marker row is not counted for the output limit
+		clear(templData.RawCells)
+		clear(templData.Values)
+		templData.Group = nil
+		if openGroup != nil {
+			// Close the section of the group of the last written row
+			templData.Group = &RowGroupTemplateContext{
+				Index:     openGroup.Index,
+				Key:       openGroup.Key,
+				NumRows:   openGroup.NumRows,
+				IsLastRow: true,
 			}
+		}
+		if numCols > 0 {
+			templData.RawCells[0] = template.HTML(template.HTMLEscapeString(w.truncationMarker)) //#nosec G203
+		}
+		err = w.rowTemplate.Execute(dest, templData)
+		if err != nil {
+			return err
+		}
+	}
 
-			str, isRaw, err := w.typeFormatters.FormatCell(ctx, view, row, col)
-			if err != nil {
-				if !errors.Is(err, errors.ErrUnsupported) {
-					return err
-				}
-				// In case of errors.ErrUnsupported
-				// use fallback method of formatting
-				v := reflectView.ReflectCell(row, col)
-				if retable.IsNullLike(v) {
-					templData.RawCells[col] = w.nilValue
-					continue // next column cell
-				}
-				if v.Kind() == reflect.Pointer {
-					v = v.Elem()
+	return w.footerTemplate.Execute(dest, templData.TemplateContext)
+}
+
+// viewCaption returns the caption of the writer if set,
+// the retable.MetadataCaption metadata of view,
+// or else the title of view.
+// It has to be called with the view passed to the writer
+// because the options of the writer wrap it without metadata.
+func (w *Writer[T]) viewCaption(view retable.View) string {
+	if w.caption != nil {
+		return *w.caption
+	}
+	if caption, ok := retable.ViewMetadataString(view, retable.MetadataCaption); ok {
+		return caption
+	}
+	return view.Title()
+}
+
+// newRowTemplateContext returns the template context for view
+// with the accessibility attributes and descriptions of the columns
+// and the language attributes of the table.
+func (w *Writer[T]) newRowTemplateContext(ctx context.Context, view retable.View, caption string, page *Page, firstRow int) *RowTemplateContext {
+	lang, dir := w.lang, w.dir
+	if locale, ok := retable.LocaleFromContext(ctx); ok && !locale.IsRoot() {
+		if lang == "" {
+			lang = locale.String()
+		}
+		if dir == DirNone {
+			dir = LocaleDir(locale)
+		}
+	}
+	columns := make([]ColumnTemplateContext, len(view.Columns()))
+	for col, title := range view.Columns() {
+		columns[col].Title = title
+		columns[col].Description = retable.ViewColumnDescription(view, col)
+		if w.tableID != "" {
+			columns[col].ID = fmt.Sprintf("%s-col-%d", w.tableID, col)
+		}
+		if col == w.sortedColumn {
+			columns[col].AriaSort = w.ariaSort
+		}
+	}
+	return &RowTemplateContext{
+		TemplateContext: TemplateContext{
+			TableID:    w.tableID,
+			TableClass: w.tableClass,
+			Caption:    caption,
+			Lang:       lang,
+			Dir:        dir,
+			Page:       page,
+			Styles:     w.styles,
+			NumColumns: len(columns),
+		},
+		RowIndex: firstRow,
+		RawCells: make([]template.HTML, len(columns)),
+		Columns:  columns,
+		Values:   make([]any, len(columns)),
+	}
+}
+
+// rowGroup returns the group template context of row
+// if view implements retable.RowGroupsView or else nil.
+// The first and the last written row of a group
+// open and close the section of the group,
+// even if not all rows of the group are written.
+func rowGroup(view retable.View, row, firstRow, lastRow int) *RowGroupTemplateContext {
+	groups, ok := view.(retable.RowGroupsView)
+	if !ok {
+		return nil
+	}
+	group, isHeaderRow := groups.RowGroup(row)
+	if group.Index < 0 {
+		return nil
+	}
+	return &RowGroupTemplateContext{
+		Index:       group.Index,
+		Key:         fmt.Sprint(group.Key),
+		NumRows:     group.NumRows,
+		IsHeaderRow: isHeaderRow,
+		IsFirstRow:  isHeaderRow || row == firstRow,
+		IsLastRow:   row == group.LastRow() || row == lastRow,
+	}
+}
+
+// writeRow writes the row of view to dest
+// within the row timeout of the writer.
+func (w *Writer[T]) writeRow(ctx context.Context, dest io.Writer, view retable.View, reflectView retable.ReflectCellView, row int, templData *RowTemplateContext, hooks *retable.WriteHooks) error {
+	return retable.CallWithRowTimeout(ctx, row, w.rowTimeout, func(ctx context.Context) error {
+		return w.writeRowCells(ctx, dest, view, reflectView, row, templData, hooks)
+	})
+}
+
+func (w *Writer[T]) writeRowCells(ctx context.Context, dest io.Writer, view retable.View, reflectView retable.ReflectCellView, row int, templData *RowTemplateContext, hooks *retable.WriteHooks) error {
+	for col := range templData.RawCells {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		start := hooks.CellStart()
+		cell, err := w.cellHTML(ctx, view, reflectView, row, col)
+		hooks.CellEnd(ctx, view, row, col, start)
+		if err != nil {
+			str, _, policyErr := w.errorPolicy.FormatError(view, row, col, err)
+			if policyErr != nil {
+				err = hooks.CellError(ctx, view, row, col, err)
+				if err != nil {
+					return retable.NewCellError(view, row, col, err)
 				}
-				str, isRaw = fmt.Sprint(v.Interface()), false
 			}
+			cell = template.HTML(template.HTMLEscapeString(str)) //#nosec G203
+		}
+		templData.RawCells[col] = cell
+		templData.Values[col] = nil
+		if v := reflectView.ReflectCell(row, col); !retable.IsNullLike(v) && v.CanInterface() {
+			templData.Values[col] = v.Interface()
+		}
+	}
+	return w.rowTemplate.Execute(dest, templData)
+}
+
+func (w *Writer[T]) cellHTML(ctx context.Context, view retable.View, reflectView retable.ReflectCellView, row, col int) (template.HTML, error) {
+	if !retable.CellExists(view, row, col) {
+		return "", nil
+	}
 
+	colFormatter, ok := w.columnFormatters[col]
+	if !ok {
+		colFormatter = retable.ViewColumnFormatter(view, col)
+		ok = colFormatter != nil
+	}
+	colFormatter = w.rawPolicy.WrapFormatter(colFormatter)
+	if ok && w.recoverPanics {
+		colFormatter = retable.SafeCellFormatter(colFormatter)
+	}
+	if ok {
+		str, isRaw, err := colFormatter.FormatCell(ctx, view, row, col)
+		if err != nil && !errors.Is(err, errors.ErrUnsupported) {
+			return "", err
+		}
+		if err == nil {
+			str = retable.StripANSI(str)
 			if !isRaw {
 				str = template.HTMLEscapeString(str)
 			}
-			templData.RawCells[col] = template.HTML(str) //#nosec G203
+			return template.HTML(str), nil //#nosec G203
 		}
+	}
 
-		err = w.rowTemplate.Execute(dest, templData)
-		if err != nil {
-			return err
+	typeFormatter := w.rawPolicy.WrapFormatter(w.typeFormatters)
+	if w.recoverPanics {
+		typeFormatter = retable.SafeCellFormatter(typeFormatter)
+	}
+	str, isRaw, err := typeFormatter.FormatCell(ctx, view, row, col)
+	if err != nil {
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return "", err
+		}
+		// In case of errors.ErrUnsupported
+		// use fallback method of formatting
+		v := reflectView.ReflectCell(row, col)
+		if retable.IsNullLike(v) {
+			str, isRaw, err = w.columnNulls.Policy(view, col, w.nullPolicy).FormatNull(view, row, col)
+			if err != nil {
+				return "", err
+			}
+		} else if cellErr := retable.CellErrorValue(view, row, col); cellErr != nil {
+			str, isRaw, err = w.errorPolicy.FormatError(view, row, col, cellErr)
+			if err != nil {
+				return "", err
+			}
+		} else {
+			if v.Kind() == reflect.Pointer {
+				v = v.Elem()
+			}
+			str, isRaw = fmt.Sprint(v.Interface()), false
 		}
-
-		templData.RowIndex++
 	}
 
-	return w.footerTemplate.Execute(dest, templData.TemplateContext)
+	// Terminal colors of formatters are not valid in HTML
+	str = retable.StripANSI(str)
+	if !isRaw {
+		str = template.HTMLEscapeString(str)
+	}
+	return template.HTML(str), nil //#nosec G203
 }
 
 func (w *Writer[T]) clone() *Writer[T] {
@@ -154,6 +442,16 @@ func (w *Writer[T]) clone() *Writer[T] {
 	return c
 }
 
+// Capabilities returns the capabilities of the HTML writer.
+func (w *Writer[T]) Capabilities() retable.WriterCapabilities {
+	return retable.WriterCapabilities{
+		Format:    "html",
+		RawHTML:   true,
+		Styles:    true,
+		Streaming: true,
+	}
+}
+
 func (w *Writer[T]) WithHeaderRow(headerRow bool) *Writer[T] {
 	mod := w.clone()
 	mod.headerRow = headerRow
@@ -166,6 +464,151 @@ func (w *Writer[T]) WithTableClass(tableClass string) *Writer[T] {
 	return mod
 }
 
+// WithTableID returns a new writer that writes tableID
+// as id attribute of the table and uses it as prefix
+// for the ids of the column header cells like "tableID-col-0".
+func (w *Writer[T]) WithTableID(tableID string) *Writer[T] {
+	mod := w.clone()
+	mod.tableID = tableID
+	return mod
+}
+
+// WithCaption returns a new writer that writes caption
+// as table caption instead of the view title.
+// The caption describes the table for screen readers.
+func (w *Writer[T]) WithCaption(caption string) *Writer[T] {
+	mod := w.clone()
+	mod.caption = &caption
+	return mod
+}
+
+// WithLang returns a new writer that writes lang
+// as lang attribute of the table like "de-AT".
+// If lang is empty and the context passed to the write methods
+// has a locale, see retable.WithLocale,
+// then the locale is used as lang attribute.
+func (w *Writer[T]) WithLang(lang string) *Writer[T] {
+	mod := w.clone()
+	mod.lang = lang
+	return mod
+}
+
+// WithDir returns a new writer that writes dir
+// as dir attribute of the table, like DirRTL for
+// right-to-left languages.
+// If dir is DirNone and the context passed to the write methods
+// has a locale, see retable.WithLocale,
+// then the LocaleDir of the locale is used.
+func (w *Writer[T]) WithDir(dir Dir) *Writer[T] {
+	mod := w.clone()
+	mod.dir = dir
+	return mod
+}
+
+// WithLocaleFormatting returns a new writer that renders
+// floats with the decimal separator and times with the
+// date layout of the locale of the context passed to the write methods,
+// see retable.WithLocale, retable.FloatCellFormatter,
+// and retable.LocaleTimeFormatter.
+// Type formatters of the writer take precedence.
+func (w *Writer[T]) WithLocaleFormatting() *Writer[T] {
+	mod := w.clone()
+	mod.typeFormatters = retable.NewReflectTypeCellFormatter().
+		WithTypeFormatter(reflect.TypeFor[time.Time](), retable.LocaleTimeFormatter{}).
+		WithTypeFormatter(reflect.TypeFor[*time.Time](), retable.LocaleTimeFormatter{}).
+		WithFloatFormatter(retable.FloatCellFormatter{Precision: -1}).
+		WithFormatters(w.typeFormatters)
+	return mod
+}
+
+// WithProvenance returns a new writer that writes
+// the fields of provenance as footer note of the table.
+// Pass nil to write no provenance.
+func (w *Writer[T]) WithProvenance(provenance *retable.Provenance) *Writer[T] {
+	mod := w.clone()
+	mod.provenance = provenance
+	return mod
+}
+
+// WithInlineStyles returns a new writer that writes
+// the CSS declarations of styles as style attributes
+// of the elements with the names used as map keys:
+// "table", "caption", "tr", "th", and "td".
+//
+// HTML email clients ignore stylesheets and classes,
+// so inline styles are needed for tables in emails:
+//
+//	writer.WithInlineStyles(map[string]string{
+//		"table": "border-collapse: collapse",
+//		"th":    "border: 1px solid #ccc; background: #eee",
+//		"td":    "border: 1px solid #ccc; padding: 4px",
+//	})
+//
+// The styles must be trusted CSS because they are not sanitized.
+func (w *Writer[T]) WithInlineStyles(styles map[string]string) *Writer[T] {
+	mod := w.clone()
+	mod.styles = nil
+	if len(styles) > 0 {
+		mod.styles = make(map[string]template.CSS, len(styles))
+		for element, style := range styles {
+			mod.styles[element] = template.CSS(style) //#nosec G203
+		}
+	}
+	return mod
+}
+
+// WithColumnGroups returns a new writer that writes
+// the passed groups of consecutive columns as an additional
+// header row with colspans above the column titles
+// if the header row is enabled.
+// Columns after the last group are left ungrouped.
+func (w *Writer[T]) WithColumnGroups(groups ...retable.ColumnGroup) *Writer[T] {
+	mod := w.clone()
+	mod.columnGroups = slices.Clone(groups)
+	return mod
+}
+
+// WithTemplateFuncs returns a new writer that adds funcs
+// to the functions available in templates parsed
+// by WithRowTemplateText.
+func (w *Writer[T]) WithTemplateFuncs(funcs template.FuncMap) *Writer[T] {
+	mod := w.clone()
+	mod.templateFuncs = make(template.FuncMap, len(w.templateFuncs)+len(funcs))
+	maps.Copy(mod.templateFuncs, w.templateFuncs)
+	maps.Copy(mod.templateFuncs, funcs)
+	return mod
+}
+
+// WithRowTemplateText returns a new writer that uses
+// text parsed with the functions of WithTemplateFuncs
+// as template for the rows with RowTemplateContext as data.
+//
+// Besides the formatted RawCells, the template can make
+// layout decisions based on the unformatted cell Values
+// or the Value method:
+//
+//	{{if .IsHeaderRow}}...{{else}}<tr{{if isNegative (.Value "Amount")}} class='neg'{{end}}>...{{end}}
+func (w *Writer[T]) WithRowTemplateText(text string) (*Writer[T], error) {
+	rowTemplate, err := template.New("row").Funcs(w.templateFuncs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	mod := w.clone()
+	mod.rowTemplate = rowTemplate
+	return mod, nil
+}
+
+// WithSortedColumn returns a new writer that marks
+// the header cell of the column with columnIndex
+// with an aria-sort attribute for screen readers.
+// The view is not sorted by the writer.
+func (w *Writer[T]) WithSortedColumn(columnIndex int, sort AriaSort) *Writer[T] {
+	mod := w.clone()
+	mod.sortedColumn = columnIndex
+	mod.ariaSort = sort
+	return mod
+}
+
 func (w *Writer[T]) WithTableViewer(viewer retable.Viewer) *Writer[T] {
 	mod := w.clone()
 	mod.viewer = viewer
@@ -200,6 +643,69 @@ func (w *Writer[T]) WithRawColumn(columnIndex int) *Writer[T] {
 	return w.WithColumnFormatter(columnIndex, retable.SprintCellFormatter(true))
 }
 
+// WithRecoverPanics returns a new writer that recovers
+// panics of column and type formatters and returns them
+// as errors with the coordinates of the formatted cell,
+// see retable.SafeCellFormatter.
+func (w *Writer[T]) WithRecoverPanics(recoverPanics bool) *Writer[T] {
+	mod := w.clone()
+	mod.recoverPanics = recoverPanics
+	return mod
+}
+
+// WithRowTimeout returns a new writer that aborts writing
+// with a retable.TimeoutError if formatting a row
+// takes longer than timeout, protecting exports
+// from formatters that hang on specific cells.
+// The formatters get a context with the deadline of the row
+// and no further cells of the row are formatted after the deadline,
+// see retable.CallWithRowTimeout.
+// Values <= 0 don't limit the formatting time.
+func (w *Writer[T]) WithRowTimeout(timeout time.Duration) *Writer[T] {
+	mod := w.clone()
+	mod.rowTimeout = timeout
+	return mod
+}
+
+// WithRawPolicy returns a new writer that handles
+// raw strings returned by column and type formatters
+// according to policy, see retable.RawPolicy.
+func (w *Writer[T]) WithRawPolicy(policy retable.RawPolicy) *Writer[T] {
+	mod := w.clone()
+	mod.rawPolicy = policy
+	return mod
+}
+
+// WithPointerHandling returns a new writer that formats
+// cells with pointer values according to handling,
+// see retable.PointerHandling.
+func (w *Writer[T]) WithPointerHandling(handling retable.PointerHandling) *Writer[T] {
+	mod := w.clone()
+	mod.pointerHandling = handling
+	return mod
+}
+
+// WithZeroAsNull returns a new writer that handles
+// the zero values of the cells selected by zeroAsNull
+// like null values using the null policy,
+// for example to render 0 amounts as empty cells.
+func (w *Writer[T]) WithZeroAsNull(zeroAsNull retable.ZeroAsNull) *Writer[T] {
+	mod := w.clone()
+	mod.zeroAsNull = zeroAsNull
+	return mod
+}
+
+// WithIncludeHiddenColumns returns a new writer that writes
+// or omits the hidden columns of a retable.ColumnVisibilityView
+// like technical IDs that are not meant for human readers.
+// By default hidden columns are omitted.
+// Column indices of the writer refer to the written columns.
+func (w *Writer[T]) WithIncludeHiddenColumns(include bool) *Writer[T] {
+	mod := w.clone()
+	mod.includeHidden = include
+	return mod
+}
+
 func (w *Writer[T]) WithTypeFormatters(formatter *retable.ReflectTypeCellFormatter) *Writer[T] {
 	mod := w.clone()
 	mod.typeFormatters = formatter
@@ -252,30 +758,277 @@ func (w *Writer[T]) WithKindFormatter(kind reflect.Kind, fmt retable.CellFormatt
 	return mod
 }
 
+// WithFloatFormatter returns a new writer that formats
+// float32 and float64 cells with fmt, for example:
+//
+//	WithFloatFormatter(retable.FloatCellFormatter{Precision: 2, TrimZeros: true})
+func (w *Writer[T]) WithFloatFormatter(fmt retable.CellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.typeFormatters = w.typeFormatters.WithFloatFormatter(fmt)
+	return mod
+}
+
+// WithMoneyFormatter returns a new writer that formats
+// money amounts implementing retable.AmountFormatter
+// like go-types money.Amount with fmt.
+func (w *Writer[T]) WithMoneyFormatter(fmt retable.MoneyCellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.typeFormatters = w.typeFormatters.WithMoneyFormatter(fmt)
+	return mod
+}
+
+// WithBoolRendering returns a new writer that renders
+// bool values with the passed rendering
+// like retable.BoolRenderingYesNo.
+func (w *Writer[T]) WithBoolRendering(rendering retable.BoolRendering) *Writer[T] {
+	mod := w.clone()
+	mod.typeFormatters = w.typeFormatters.WithKindFormatter(reflect.Bool, rendering)
+	return mod
+}
+
+// WithDefaults returns a new writer that uses
+// the formatters of retable.NewDefaultFormatters
+// for types and kinds without a type formatter of the writer.
+func (w *Writer[T]) WithDefaults() *Writer[T] {
+	mod := w.clone()
+	mod.typeFormatters = retable.NewDefaultFormatters().WithFormatters(w.typeFormatters)
+	return mod
+}
+
 func (w *Writer[T]) WithKindFormatterFunc(kind reflect.Kind, fmt retable.CellFormatterFunc) *Writer[T] {
 	mod := w.clone()
 	mod.typeFormatters = w.typeFormatters.WithKindFormatter(kind, fmt)
 	return mod
 }
 
+// WithNilValue returns a new writer that renders
+// null-like values as the passed raw HTML.
+// It is a shortcut for WithNullPolicy(retable.RenderNullAsRaw(string(nilValue))).
 func (w *Writer[T]) WithNilValue(nilValue template.HTML) *Writer[T] {
+	return w.WithNullPolicy(retable.RenderNullAsRaw(string(nilValue)))
+}
+
+// WithNullPolicy returns a new writer that handles
+// null-like values using the passed policy.
+// A non-raw policy value will be HTML escaped.
+func (w *Writer[T]) WithNullPolicy(policy retable.NullPolicy) *Writer[T] {
 	mod := w.clone()
-	mod.nilValue = nilValue
+	mod.nullPolicy = policy
 	return mod
 }
 
-func (w *Writer[T]) WithTemplate(tableTemplate, rowTemplate, footerTemplate *template.Template) *Writer[T] {
+// WithColumnNilValue returns a new writer that renders
+// null-like values of the column with columnIndex
+// as the passed nilValue instead of using the null policy of the writer.
+func (w *Writer[T]) WithColumnNilValue(columnIndex int, nilValue template.HTML) *Writer[T] {
 	mod := w.clone()
-	mod.headerTemplate = tableTemplate
-	mod.rowTemplate = rowTemplate
-	mod.footerTemplate = footerTemplate
-	return w
+	mod.columnNulls = w.columnNulls.WithIndex(columnIndex, retable.RenderNullAsRaw(string(nilValue)))
+	return mod
+}
+
+// WithColumnTitleNilValue returns a new writer that renders
+// null-like values of the columns with the passed title
+// as nilValue instead of using the null policy of the writer.
+func (w *Writer[T]) WithColumnTitleNilValue(title string, nilValue template.HTML) *Writer[T] {
+	mod := w.clone()
+	mod.columnNulls = w.columnNulls.WithTitle(title, retable.RenderNullAsRaw(string(nilValue)))
+	return mod
+}
+
+// WithColumnNullPolicies returns a new writer that handles
+// null-like values of the columns selected by policies
+// with their own null policy.
+func (w *Writer[T]) WithColumnNullPolicies(policies retable.ColumnNullPolicies) *Writer[T] {
+	mod := w.clone()
+	mod.columnNulls = policies
+	return mod
+}
+
+// WithTemplate returns a new writer that uses the passed templates
+// for the table header, the rows, and the table footer.
+// A nil template keeps the current template
+// so that only the row template can be overridden
+// while keeping the default header and footer templates.
+//
+// The header and footer templates are executed with TemplateContext
+// and the row template with RowTemplateContext as data.
+func (w *Writer[T]) WithTemplate(headerTemplate, rowTemplate, footerTemplate *template.Template) *Writer[T] {
+	mod := w.clone()
+	if headerTemplate != nil {
+		mod.headerTemplate = headerTemplate
+	}
+	if rowTemplate != nil {
+		mod.rowTemplate = rowTemplate
+	}
+	if footerTemplate != nil {
+		mod.footerTemplate = footerTemplate
+	}
+	return mod
+}
+
+// WithTemplatesFS returns a new writer that uses the templates
+// named "header", "row", and "footer" parsed from the files
+// of fsys matching the patterns, for example from an embed.FS.
+//
+// A template can be named by its file name without extension
+// like "row.html" or by a {{define "row"}} action.
+// Templates that are not found keep the current template.
+// The functions of WithTemplateFuncs are available in the templates.
+func (w *Writer[T]) WithTemplatesFS(fsys fs.FS, patterns ...string) (*Writer[T], error) {
+	parsed, err := template.New("").Funcs(w.templateFuncs).ParseFS(fsys, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		headerTemplate = lookupTemplate(parsed, "header")
+		rowTemplate    = lookupTemplate(parsed, "row")
+		footerTemplate = lookupTemplate(parsed, "footer")
+	)
+	if headerTemplate == nil && rowTemplate == nil && footerTemplate == nil {
+		return nil, fmt.Errorf("no header, row, or footer template found in %s", strings.Join(patterns, ", "))
+	}
+	return w.WithTemplate(headerTemplate, rowTemplate, footerTemplate), nil
+}
+
+// lookupTemplate returns the template with the passed name
+// or file name without extension, or nil if there is none.
+func lookupTemplate(t *template.Template, name string) *template.Template {
+	if found := t.Lookup(name); found != nil {
+		return found
+	}
+	for _, found := range t.Templates() {
+		if strings.TrimSuffix(found.Name(), path.Ext(found.Name())) == name {
+			return found
+		}
+	}
+	return nil
+}
+
+// WithRedactedColumns returns a new writer that replaces the non null values
+// of the columns with the passed titles with a placeholder
+// while keeping the column headers.
+// The placeholder can be set with WithRedactionPlaceholder
+// and defaults to retable.DefaultRedactionPlaceholder.
+func (w *Writer[T]) WithRedactedColumns(columns ...string) *Writer[T] {
+	mod := w.clone()
+	mod.redactedColumns = slices.Clone(columns)
+	return mod
+}
+
+// WithRedactionPlaceholder returns a new writer that uses
+// the passed placeholder for the values of redacted columns.
+func (w *Writer[T]) WithRedactionPlaceholder(placeholder string) *Writer[T] {
+	mod := w.clone()
+	mod.redaction = placeholder
+	return mod
+}
+
+// WithWriteHooks returns a new writer that calls hooks
+// while writing the data rows of a view.
+func (w *Writer[T]) WithWriteHooks(hooks *retable.WriteHooks) *Writer[T] {
+	mod := w.clone()
+	mod.hooks = hooks
+	return mod
+}
+
+// WithMetricsRecorder returns a new writer that records
+// the statistics of every written view with recorder.
+func (w *Writer[T]) WithMetricsRecorder(recorder retable.MetricsRecorder) *Writer[T] {
+	mod := w.clone()
+	mod.metrics = recorder
+	return mod
+}
+
+// EstimateSize estimates the output size of WriteView for view
+// by writing retable.DefaultEstimateSampleRows sample rows
+// without row and output size limits, hooks, and metrics.
+func (w *Writer[T]) EstimateSize(ctx context.Context, view retable.View) (*retable.SizeEstimate, error) {
+	mod := w.clone()
+	mod.hooks = nil
+	mod.metrics = nil
+	mod.maxRows = 0
+	mod.maxBytes = 0
+	return retable.EstimateSize(ctx, view, retable.DefaultEstimateSampleRows, mod.WriteView)
+}
+
+// WithMaxRows returns a new writer that writes views
+// with at most maxRows data rows.
+// Views with more rows are not written at all and
+// retable.ErrMaxRowsExceeded is returned,
+// or if a truncation marker is set, then only the first
+// maxRows rows are written followed by a marker row.
+// Values <= 0 don't limit the number of rows.
+func (w *Writer[T]) WithMaxRows(maxRows int) *Writer[T] {
+	mod := w.clone()
+	mod.maxRows = maxRows
+	return mod
+}
+
+// WithMaxOutputBytes returns a new writer that stops writing
+// before the row that would exceed maxBytes output bytes
+// and returns retable.ErrMaxOutputBytesExceeded,
+// or if a truncation marker is set, then a marker row
+// and the table footer are written instead
+// that are not counted for the limit.
+// Values <= 0 don't limit the output size.
+func (w *Writer[T]) WithMaxOutputBytes(maxBytes int64) *Writer[T] {
+	mod := w.clone()
+	mod.maxBytes = maxBytes
+	return mod
+}
+
+// WithTruncationMarker returns a new writer that truncates
+// views exceeding the limits of WithMaxRows and WithMaxOutputBytes
+// and writes the marker in the first column of a final row.
+// An empty marker returns an error instead of truncating.
+func (w *Writer[T]) WithTruncationMarker(marker string) *Writer[T] {
+	mod := w.clone()
+	mod.truncationMarker = marker
+	return mod
+}
+
+// RedactedColumns returns the titles of the redacted columns.
+func (w *Writer[T]) RedactedColumns() []string {
+	return w.redactedColumns
+}
+
+func (w *Writer[T]) TableID() string {
+	return w.tableID
 }
 
 func (w *Writer[T]) TableClass() string {
 	return w.tableClass
 }
 
+// NilValue returns the value rendered for null-like values
+// as HTML, escaping it if the null policy value is not raw.
+// It is empty for the actions retable.NullOmit that renders an empty cell
+// and retable.NullError that aborts writing.
 func (w *Writer[T]) NilValue() template.HTML {
-	return w.nilValue
+	if w.nullPolicy.Action != retable.NullRender {
+		return ""
+	}
+	if w.nullPolicy.Raw {
+		return template.HTML(w.nullPolicy.Value) //#nosec G203
+	}
+	return template.HTML(template.HTMLEscapeString(w.nullPolicy.Value)) //#nosec G203
+}
+
+// WithErrorPolicy returns a new writer that handles
+// cells with error values and cells whose formatting failed
+// with the passed policy.
+// The default policy aborts writing with a retable.CellError.
+func (w *Writer[T]) WithErrorPolicy(policy retable.ErrorPolicy) *Writer[T] {
+	mod := w.clone()
+	mod.errorPolicy = policy
+	return mod
+}
+
+// ErrorPolicy returns the error policy of the writer.
+func (w *Writer[T]) ErrorPolicy() retable.ErrorPolicy {
+	return w.errorPolicy
+}
+
+func (w *Writer[T]) NullPolicy() retable.NullPolicy {
+	return w.nullPolicy
 }
@@ -1,10 +1,21 @@
 package htmltable
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"html/template"
 	"os"
 	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/domonda/go-retable"
 )
 
 func ExampleWriter() {
@@ -27,8 +38,422 @@ func ExampleWriter() {
 	// Output:
 	// <table>
 	//   <caption>Table Title</caption>
-	//   <tr><th>Status</th><th>Company</th><th>Company ID</th></tr>
+	//   <tr><th scope='col'>Status</th><th scope='col'>Company</th><th scope='col'>Company ID</th></tr>
 	//   <tr><td></td><td>Company 1</td><td>1</td></tr>
 	//   <tr><td><pre>{"ok":true}</pre></td><td>Company 2</td><td>2</td></tr>
 	// </table>
 }
+
+func ExampleWriter_WithMaxRows() {
+	table := [][]string{
+		{"A", "B"},
+		{"1", "2"},
+		{"3", "4"},
+	}
+
+	NewWriter[[][]string]().
+		WithHeaderRow(true).
+		WithMaxRows(1).
+		WithTruncationMarker("truncated").
+		Write(context.Background(), os.Stdout, table)
+
+	// Output:
+	// <table>
+	//   <tr><th scope='col'>A</th><th scope='col'>B</th></tr>
+	//   <tr><td>1</td><td>2</td></tr>
+	//   <tr><td>truncated</td><td></td></tr>
+	// </table>
+}
+
+func ExampleWriter_WithSortedColumn() {
+	table := [][]string{
+		{"Name", "Amount"},
+		{"A", "1"},
+	}
+
+	NewWriter[[][]string]().
+		WithHeaderRow(true).
+		WithTableID("amounts").
+		WithCaption("Amounts by name").
+		WithSortedColumn(1, AriaSortDescending).
+		Write(context.Background(), os.Stdout, table)
+
+	// Output:
+	// <table id='amounts'>
+	//   <caption>Amounts by name</caption>
+	//   <tr><th scope='col' id='amounts-col-0'>Name</th><th scope='col' id='amounts-col-1' aria-sort='descending'>Amount</th></tr>
+	//   <tr><td>A</td><td>1</td></tr>
+	// </table>
+}
+
+func ExampleWriter_WithInlineStyles() {
+	table := [][]string{
+		{"Name", "Amount"},
+		{"A", "1"},
+	}
+
+	NewWriter[[][]string]().
+		WithHeaderRow(true).
+		WithInlineStyles(map[string]string{
+			"table": "border-collapse: collapse",
+			"th":    "background: #eee",
+			"td":    "padding: 4px",
+		}).
+		Write(context.Background(), os.Stdout, table)
+
+	// Output:
+	// <table style='border-collapse: collapse'>
+	//   <tr><th scope='col' style='background: #eee'>Name</th><th scope='col' style='background: #eee'>Amount</th></tr>
+	//   <tr><td style='padding: 4px'>A</td><td style='padding: 4px'>1</td></tr>
+	// </table>
+}
+
+func ExampleWriter_WithTemplateFuncs() {
+	type Row struct {
+		Name   string
+		Amount float64
+	}
+	rows := []Row{
+		{Name: "A", Amount: 1.5},
+		{Name: "B", Amount: -2},
+	}
+
+	writer, err := NewWriter[[]Row]().
+		WithTemplateFuncs(template.FuncMap{
+			"isNegative": func(v any) bool {
+				f, ok := v.(float64)
+				return ok && f < 0
+			},
+		}).
+		WithRowTemplateText("" +
+			"{{if not .IsHeaderRow}}" +
+			"  <tr{{if isNegative (.Value `Amount`)}} class='negative'{{end}}>" +
+			"{{range .RawCells}}<td>{{.}}</td>{{end}}</tr>\n" +
+			"{{end}}",
+		)
+	if err != nil {
+		panic(err)
+	}
+	writer.Write(context.Background(), os.Stdout, rows)
+
+	// Output:
+	// <table>
+	//   <tr><td>A</td><td>1.5</td></tr>
+	//   <tr class='negative'><td>B</td><td>-2</td></tr>
+	// </table>
+}
+
+func ExampleWriter_WithTemplate() {
+	table := [][]string{
+		{"Name", "Amount"},
+		{"A", "1"},
+	}
+
+	rowTemplate := template.Must(template.New("row").Parse(
+		"  <tr>{{range .RawCells}}<td>{{.}}</td>{{end}}</tr>\n",
+	))
+	NewWriter[[][]string]().
+		WithHeaderRow(true).
+		WithTemplate(nil, rowTemplate, nil).
+		Write(context.Background(), os.Stdout, table)
+
+	// Output:
+	// <table>
+	//   <tr><td>Name</td><td>Amount</td></tr>
+	//   <tr><td>A</td><td>1</td></tr>
+	// </table>
+}
+
+func ExampleWriter_WithTemplatesFS() {
+	table := [][]string{
+		{"Name", "Amount"},
+		{"A", "1"},
+	}
+
+	// Usually an embed.FS
+	templates := fstest.MapFS{
+		"templates/row.html": {Data: []byte(
+			"  <tr class='row-{{.RowIndex}}'>{{range .RawCells}}<td>{{.}}</td>{{end}}</tr>\n",
+		)},
+		"templates/footer.html": {Data: []byte(
+			"</table>\n<p>{{.Caption}}</p>",
+		)},
+	}
+
+	writer, err := NewWriter[[][]string]().WithTemplatesFS(templates, "templates/*.html")
+	if err != nil {
+		panic(err)
+	}
+	writer.
+		WithHeaderRow(true).
+		WithCaption("Amounts").
+		Write(context.Background(), os.Stdout, table)
+
+	// Output:
+	// <table>
+	//   <caption>Amounts</caption>
+	//   <tr class='row-0'><td>Name</td><td>Amount</td></tr>
+	//   <tr class='row-1'><td>A</td><td>1</td></tr>
+	// </table>
+	// <p>Amounts</p>
+}
+
+func ExampleWriter_WithColumnGroups() {
+	table := [][]string{
+		{"Product", "Jan", "Feb", "Mar", "Apr"},
+		{"A", "1", "2", "3", "4"},
+	}
+
+	NewWriter[[][]string]().
+		WithHeaderRow(true).
+		WithColumnGroups(
+			retable.ColumnGroup{Title: "", NumColumns: 1},
+			retable.ColumnGroup{Title: "Q1", NumColumns: 3},
+		).
+		Write(context.Background(), os.Stdout, table)
+
+	// Output:
+	// <table>
+	//   <tr><th scope='colgroup'></th><th scope='colgroup' colspan='3'>Q1</th><th scope='colgroup'></th></tr>
+	//   <tr><th scope='col'>Product</th><th scope='col'>Jan</th><th scope='col'>Feb</th><th scope='col'>Mar</th><th scope='col'>Apr</th></tr>
+	//   <tr><td>A</td><td>1</td><td>2</td><td>3</td><td>4</td></tr>
+	// </table>
+}
+
+func ExampleWriter_groupedRows() {
+	view := retable.NewGroupedRowsView(
+		&retable.AnyValuesView{
+			Cols: []string{"Region", "City"},
+			Rows: [][]any{
+				{"North", "Oslo"},
+				{"North", "Helsinki"},
+				{"South", "Rome"},
+			},
+		},
+		0,
+	)
+
+	NewWriter[retable.View]().
+		WithHeaderRow(true).
+		WriteView(context.Background(), os.Stdout, view)
+
+	// Output:
+	// <table>
+	//   <tr><th scope='col'>Region</th><th scope='col'>City</th></tr>
+	//   <tbody data-group='0' data-group-key='North' data-group-rows='2'>
+	//   <tr class='group-header'><th scope='rowgroup' colspan='2'>North</th></tr>
+	//   <tr><td>North</td><td>Oslo</td></tr>
+	//   <tr><td>North</td><td>Helsinki</td></tr>
+	//   </tbody>
+	//   <tbody data-group='1' data-group-key='South' data-group-rows='1'>
+	//   <tr class='group-header'><th scope='rowgroup' colspan='2'>South</th></tr>
+	//   <tr><td>South</td><td>Rome</td></tr>
+	//   </tbody>
+	// </table>
+}
+
+func ExampleWriter_WithBoolRendering() {
+	view := &retable.AnyValuesView{
+		Cols: []string{"Task", "Done"},
+		Rows: [][]any{{"A", true}, {"B", false}},
+	}
+
+	NewWriter[retable.View]().
+		WithHeaderRow(true).
+		WithBoolRendering(retable.BoolRenderingCheckmark).
+		WriteView(context.Background(), os.Stdout, view)
+
+	// Output:
+	// <table>
+	//   <tr><th scope='col'>Task</th><th scope='col'>Done</th></tr>
+	//   <tr><td>A</td><td>✓</td></tr>
+	//   <tr><td>B</td><td>✗</td></tr>
+	// </table>
+}
+
+func ExampleWriter_WithProvenance() {
+	table := [][]string{
+		{"Name", "Amount"},
+		{"A", "1"},
+	}
+	provenance := &retable.Provenance{
+		GeneratedAt: time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC),
+		Source:      "Accounting",
+	}
+
+	NewWriter[[][]string]().
+		WithHeaderRow(true).
+		WithProvenance(provenance).
+		Write(context.Background(), os.Stdout, table)
+
+	// Output:
+	// <table>
+	//   <tr><th scope='col'>Name</th><th scope='col'>Amount</th></tr>
+	//   <tr><td>A</td><td>1</td></tr>
+	//   <tfoot class='provenance'><tr><td colspan='2'>Generated At: 2024-05-06T07:08:09Z<br>Source: Accounting<br>Rows: 1</td></tr></tfoot>
+	// </table>
+}
+
+func ExampleWriter_WithLocaleFormatting() {
+	view := &retable.AnyValuesView{
+		Cols: []string{"التاريخ", "المبلغ"},
+		Rows: [][]any{{time.Date(2024, 10, 16, 0, 0, 0, 0, time.UTC), 1234.5}},
+	}
+	ctx := retable.WithLocale(context.Background(), language.Arabic)
+
+	NewWriter[retable.View]().
+		WithHeaderRow(true).
+		WithLocaleFormatting().
+		WriteView(ctx, os.Stdout, view)
+
+	NewWriter[retable.View]().
+		WithLang("de").
+		WithDir(DirLTR).
+		WithLocaleFormatting().
+		WriteView(retable.WithLocale(context.Background(), language.German), os.Stdout, view)
+
+	// Output:
+	// <table lang='ar' dir='rtl'>
+	//   <tr><th scope='col'>التاريخ</th><th scope='col'>المبلغ</th></tr>
+	//   <tr><td>16/10/2024</td><td>1234.5</td></tr>
+	// </table><table lang='de' dir='ltr'>
+	//   <tr><td>16.10.2024</td><td>1234,5</td></tr>
+	// </table>
+}
+
+func ExampleWriter_columnDescriptions() {
+	type Row struct {
+		IBAN   string  `col:"IBAN" desc:"International Bank Account Number"`
+		Amount float64 `col:"Amount"`
+	}
+
+	NewWriter[[]Row]().
+		WithHeaderRow(true).
+		Write(context.Background(), os.Stdout, []Row{{IBAN: "AT611904300234573201", Amount: 1}})
+
+	// Output:
+	// <table>
+	//   <tr><th scope='col' title='International Bank Account Number'>IBAN</th><th scope='col'>Amount</th></tr>
+	//   <tr><td>AT611904300234573201</td><td>1</td></tr>
+	// </table>
+}
+
+func ExampleWriter_WithIncludeHiddenColumns() {
+	type Row struct {
+		ID   int    `col:"ID,hidden"`
+		Name string `col:"Name"`
+	}
+	table := []Row{{ID: 1, Name: "A"}}
+
+	NewWriter[[]Row]().
+		WithHeaderRow(true).
+		Write(context.Background(), os.Stdout, table)
+
+	NewWriter[[]Row]().
+		WithHeaderRow(true).
+		WithIncludeHiddenColumns(true).
+		Write(context.Background(), os.Stdout, table)
+
+	// Output:
+	// <table>
+	//   <tr><th scope='col'>Name</th></tr>
+	//   <tr><td>A</td></tr>
+	// </table><table>
+	//   <tr><th scope='col'>ID</th><th scope='col'>Name</th></tr>
+	//   <tr><td>1</td><td>A</td></tr>
+	// </table>
+}
+
+func TestWriter_WriteView_keepsRowGroups(t *testing.T) {
+	amount := 1.5
+	view := retable.NewGroupedRowsView(
+		&retable.AnyValuesView{
+			Cols: []string{"Region", "Amount"},
+			Rows: [][]any{
+				{"North", &amount},
+				{"South", &amount},
+			},
+		},
+		0,
+	)
+	tests := []struct {
+		name   string
+		writer *Writer[retable.View]
+		view   retable.View
+	}{
+		{name: "default", writer: NewWriter[retable.View]()},
+		{name: "PointerDeref", writer: NewWriter[retable.View]().WithPointerHandling(retable.PointerDeref)},
+		{name: "ZeroAsNull", writer: NewWriter[retable.View]().WithZeroAsNull(retable.ZeroAsNull{}.WithIndices(1))},
+		{name: "hidden column", writer: NewWriter[retable.View](), view: retable.ViewWithHiddenColumns(view, "Amount")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.view
+			if v == nil {
+				v = view
+			}
+			var buf bytes.Buffer
+			err := tt.writer.WithHeaderRow(true).WriteView(context.Background(), &buf, v)
+			require.NoError(t, err)
+			require.Equal(t, 2, strings.Count(buf.String(), "<tbody"), buf.String())
+		})
+	}
+}
+
+func TestWriter_WithRowTimeout(t *testing.T) {
+	view := &retable.StringsView{
+		Cols: []string{"A", "B"},
+		Rows: [][]string{{"fast", "fast"}, {"slow", "next"}},
+	}
+	var formatted []string
+	slowFormatter := retable.CellFormatterFunc(func(ctx context.Context, view retable.View, row, col int) (string, bool, error) {
+		str := view.Cell(row, col).(string)
+		if str == "slow" {
+			time.Sleep(50 * time.Millisecond) // Ignores the context deadline
+		}
+		formatted = append(formatted, str)
+		return str, false, nil
+	})
+	err := NewWriter[retable.View]().
+		WithTypeFormatter(reflect.TypeFor[string](), slowFormatter).
+		WithRowTimeout(10*time.Millisecond).
+		WriteView(context.Background(), new(bytes.Buffer), view)
+	var timeoutErr retable.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	require.Equal(t, 1, timeoutErr.Row)
+	require.Equal(t, []string{"fast", "fast", "slow"}, formatted, "no cells formatted after the timeout")
+
+	err = NewWriter[retable.View]().WithRowTimeout(time.Second).WriteView(context.Background(), new(bytes.Buffer), view)
+	require.NoError(t, err)
+}
+
+func TestWriter_WriteView_metadataCaption(t *testing.T) {
+	view := retable.ViewWithMetadata(
+		&retable.StringsView{Tit: "Title", Cols: []string{"A", "B"}, Rows: [][]string{{"a", "b"}}},
+		map[string]any{retable.MetadataCaption: "Caption"},
+	)
+	tests := []struct {
+		name   string
+		writer *Writer[retable.View]
+		want   string
+	}{
+		{name: "metadata", writer: NewWriter[retable.View](), want: "<caption>Caption</caption>"},
+		{name: "metadata with redaction", writer: NewWriter[retable.View]().WithRedactedColumns("B"), want: "<caption>Caption</caption>"},
+		{name: "writer caption", writer: NewWriter[retable.View]().WithCaption("Writer"), want: "<caption>Writer</caption>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := tt.writer.WriteView(context.Background(), &buf, view)
+			require.NoError(t, err)
+			require.Contains(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestWriter_NilValue(t *testing.T) {
+	require.Equal(t, template.HTML("&lt;null&gt;"), NewWriter[retable.View]().WithNullPolicy(retable.RenderNullAs("<null>")).NilValue())
+	require.Equal(t, template.HTML("<i>null</i>"), NewWriter[retable.View]().WithNullPolicy(retable.RenderNullAsRaw("<i>null</i>")).NilValue())
+	require.Equal(t, template.HTML(""), NewWriter[retable.View]().WithNullPolicy(retable.NullPolicy{Action: retable.NullOmit, Value: "null"}).NilValue())
+	require.Equal(t, template.HTML(""), NewWriter[retable.View]().WithNullPolicy(retable.NullPolicy{Action: retable.NullError, Value: "null"}).NilValue())
+}
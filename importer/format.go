@@ -0,0 +1,49 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/domonda/go-retable"
+	"github.com/domonda/go-retable/csvtable"
+)
+
+// Format of imported data
+type Format string
+
+const (
+	FormatUnknown Format = ""
+	FormatCSV     Format = "csv"
+	FormatXLSX    Format = "xlsx"
+)
+
+// DetectFormat detects the format of data by its content.
+// XLSX files are ZIP archives and detected by the ZIP file signature,
+// everything else is treated as CSV.
+func DetectFormat(data []byte) Format {
+	switch {
+	case len(data) == 0:
+		return FormatUnknown
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		return FormatXLSX
+	default:
+		return FormatCSV
+	}
+}
+
+// ReadFunc reads data of a Format as View
+// where the column titles are the headers of the data.
+type ReadFunc func(ctx context.Context, data []byte) (retable.View, error)
+
+// ReadCSV returns a ReadFunc that parses CSV data with the format
+// detected using the passed config or csvtable.NewDefaultFormatDetectionConfig if nil.
+// Empty rows are removed and the first row is used as header.
+func ReadCSV(config *csvtable.FormatDetectionConfig) ReadFunc {
+	return func(ctx context.Context, data []byte) (retable.View, error) {
+		rows, _, err := csvtable.ParseDetectFormat(data, config)
+		if err != nil {
+			return nil, err
+		}
+		return retable.NewStringsView("", csvtable.RemoveEmptyRows(rows)), nil
+	}
+}
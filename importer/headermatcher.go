@@ -0,0 +1,181 @@
+package importer
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/domonda/go-retable"
+)
+
+// DefaultMinHeaderScore is the minimum similarity score
+// for a header to be suggested for a column.
+const DefaultMinHeaderScore = 0.6
+
+// HeaderMatcher fuzzy matches the headers of imported data
+// with the target columns to suggest a header mapping
+// for "map your columns" user interfaces.
+//
+// Headers and columns are normalized before comparison
+// by lower casing, replacing German umlauts,
+// and removing spaces and punctuation.
+// The similarity is scored between 0 and 1
+// using the Levenshtein distance of the normalized strings.
+type HeaderMatcher struct {
+	// Columns are the target column titles
+	Columns []string
+	// MinScore is the minimum score for a suggestion,
+	// if zero then DefaultMinHeaderScore is used.
+	MinScore float64
+}
+
+// NewHeaderMatcher returns a HeaderMatcher for the passed target columns.
+func NewHeaderMatcher(columns ...string) *HeaderMatcher {
+	return &HeaderMatcher{Columns: columns}
+}
+
+// NewHeaderMatcherFor returns a HeaderMatcher for the columns
+// of the struct type T using naming.
+func NewHeaderMatcherFor[T any](naming *retable.StructFieldNaming) *HeaderMatcher {
+	return NewHeaderMatcher(naming.Columns(new(T))...)
+}
+
+// HeaderSuggestion suggests to map Header to Column
+type HeaderSuggestion struct {
+	Header string
+	Column string
+	Score  float64
+}
+
+// HeaderMatch is the result of HeaderMatcher.Match
+type HeaderMatch struct {
+	// Suggestions has the ranked suggestions for every header,
+	// the best suggestion first
+	Suggestions map[string][]HeaderSuggestion
+	// Mapping is a unique mapping of headers to columns
+	// using the best available suggestions
+	// that can be passed to Pipeline.WithHeaderMapping
+	Mapping map[string]string
+	// UnmatchedHeaders are the headers not in Mapping
+	UnmatchedHeaders []string
+	// UnmatchedColumns are the columns not in Mapping
+	UnmatchedColumns []string
+}
+
+// Match scores all headers against all columns
+// and returns the ranked suggestions and a unique mapping.
+func (m *HeaderMatcher) Match(headers []string) *HeaderMatch {
+	minScore := m.MinScore
+	if minScore == 0 {
+		minScore = DefaultMinHeaderScore
+	}
+	normColumns := make([]string, len(m.Columns))
+	for i, column := range m.Columns {
+		normColumns[i] = NormalizeHeader(column)
+	}
+
+	match := &HeaderMatch{
+		Suggestions: make(map[string][]HeaderSuggestion),
+		Mapping:     make(map[string]string),
+	}
+	var all []HeaderSuggestion
+	for _, header := range headers {
+		normHeader := NormalizeHeader(header)
+		var suggestions []HeaderSuggestion
+		for i, column := range m.Columns {
+			score := HeaderSimilarity(normHeader, normColumns[i])
+			if score >= minScore {
+				suggestions = append(suggestions, HeaderSuggestion{Header: header, Column: column, Score: score})
+			}
+		}
+		slices.SortStableFunc(suggestions, compareSuggestions)
+		if len(suggestions) > 0 {
+			match.Suggestions[header] = suggestions
+		}
+		all = append(all, suggestions...)
+	}
+
+	// Greedy assignment of the best scores
+	slices.SortStableFunc(all, compareSuggestions)
+	mappedColumns := make(map[string]bool)
+	for _, s := range all {
+		if _, ok := match.Mapping[s.Header]; ok || mappedColumns[s.Column] {
+			continue
+		}
+		match.Mapping[s.Header] = s.Column
+		mappedColumns[s.Column] = true
+	}
+	for _, header := range headers {
+		if _, ok := match.Mapping[header]; !ok {
+			match.UnmatchedHeaders = append(match.UnmatchedHeaders, header)
+		}
+	}
+	for _, column := range m.Columns {
+		if !mappedColumns[column] {
+			match.UnmatchedColumns = append(match.UnmatchedColumns, column)
+		}
+	}
+	return match
+}
+
+func compareSuggestions(a, b HeaderSuggestion) int {
+	return cmp.Compare(b.Score, a.Score)
+}
+
+var umlautReplacer = strings.NewReplacer(
+	"ä", "ae",
+	"ö", "oe",
+	"ü", "ue",
+	"ß", "ss",
+)
+
+// NormalizeHeader lower cases header, replaces German umlauts,
+// and removes all characters that are not letters or digits.
+func NormalizeHeader(header string) string {
+	header = umlautReplacer.Replace(strings.ToLower(header))
+	return strings.Map(
+		func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		},
+		header,
+	)
+}
+
+// HeaderSimilarity returns a score between 0 and 1
+// for the similarity of two normalized headers
+// based on their Levenshtein distance.
+func HeaderSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	maxLen := max(len(ra), len(rb))
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(maxLen)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,39 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderMatcher_Match(t *testing.T) {
+	matcher := NewHeaderMatcher("invoice_number", "amount", "due_date", "Empfänger")
+	match := matcher.Match([]string{"Invoice Number", "Amout", "EMPFAENGER", "Comment"})
+
+	require.Equal(t, map[string]string{
+		"Invoice Number": "invoice_number",
+		"Amout":          "amount",
+		"EMPFAENGER":     "Empfänger",
+	}, match.Mapping)
+	require.Equal(t, []string{"Comment"}, match.UnmatchedHeaders)
+	require.Equal(t, []string{"due_date"}, match.UnmatchedColumns)
+	require.Equal(t, 1.0, match.Suggestions["Invoice Number"][0].Score)
+	require.NotContains(t, match.Suggestions, "Comment")
+}
+
+func TestHeaderSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{a: "amount", b: "amount", want: 1},
+		{a: "amout", b: "amount", want: 1 - 1.0/6},
+		{a: "", b: "amount", want: 0},
+		{a: "abc", b: "xyz", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			require.InDelta(t, tt.want, HeaderSimilarity(tt.a, tt.b), 1e-9)
+		})
+	}
+}
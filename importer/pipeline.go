@@ -0,0 +1,277 @@
+// Package importer combines format detection, parsing,
+// header mapping, validation, and struct scanning
+// of imported CSV and Excel data behind one configurable Pipeline.
+//
+// XLSX support has to be registered with Pipeline.WithReader
+// because the exceltable package is a separate module:
+//
+//	pipeline := importer.NewPipeline[Invoice]().
+//		WithReader(importer.FormatXLSX, func(ctx context.Context, data []byte) (retable.View, error) {
+//			return exceltable.ReadFirstSheet(bytes.NewReader(data), false)
+//		})
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/domonda/go-retable"
+)
+
+// Pipeline imports data as slice of the struct type T.
+//
+// The steps of Pipeline.Run are:
+//  1. Detect the format of the data
+//  2. Read the data as View using the ReadFunc of the format
+//  3. Rename the source headers using the header mapping
+//  4. Check that all required columns are present
+//  5. Scan every row into a T and validate it
+//
+// Errors of single rows are collected as retable.RowErrors
+// in the Result instead of aborting the import.
+type Pipeline[T any] struct {
+	naming         *retable.StructFieldNaming
+	readers        map[Format]ReadFunc
+	headerMapping  map[string]string
+	requiredCols   []string
+	scanner        retable.Scanner
+	formatter      retable.Formatter
+	fieldValidator func(reflect.Value) error
+	rowValidator   func(T) error
+	maxRowErrors   int
+}
+
+// NewPipeline returns a Pipeline that reads CSV data
+// with auto detected format into structs of type T
+// using the struct field names as column titles.
+func NewPipeline[T any]() *Pipeline[T] {
+	return &Pipeline[T]{
+		naming:         nil, // OK to use nil *retable.StructFieldNaming
+		readers:        map[Format]ReadFunc{FormatCSV: ReadCSV(nil)},
+		headerMapping:  nil,
+		requiredCols:   nil,
+		scanner:        nil,
+		formatter:      nil,
+		fieldValidator: nil,
+		rowValidator:   nil,
+		maxRowErrors:   0,
+	}
+}
+
+func (p *Pipeline[T]) clone() *Pipeline[T] {
+	c := new(Pipeline[T])
+	*c = *p
+	return c
+}
+
+// Result of Pipeline.Run
+type Result[T any] struct {
+	// Format of the imported data
+	Format Format
+	// View of the imported data with mapped column titles
+	View retable.View
+	// Rows that were imported without errors
+	Rows []T
+	// RowIndices are the zero based data row indices
+	// of the View for every element of Rows
+	RowIndices []int
+	// Errors of rows that were not imported
+	// with a retable.CellError for every cell that could not be scanned
+	Errors *retable.RowErrors
+}
+
+// Run imports data by detecting its format
+// and calling RunView with the parsed View.
+func (p *Pipeline[T]) Run(ctx context.Context, data []byte) (*Result[T], error) {
+	format := DetectFormat(data)
+	if format == FormatUnknown {
+		return nil, errors.New("can't detect format of empty data")
+	}
+	read, ok := p.readers[format]
+	if !ok {
+		return nil, fmt.Errorf("no reader for format %s", format)
+	}
+	view, err := read(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("can't read %s data: %w", format, err)
+	}
+	result, err := p.RunView(ctx, view)
+	if result != nil {
+		result.Format = format
+	}
+	return result, err
+}
+
+// RunView imports the rows of an already parsed view.
+//
+// A non nil Result with the rows imported so far is returned
+// together with Result.Errors as error if the maximum number
+// of rows with errors configured with WithMaxRowErrors is exceeded,
+// see retable.RowErrors.LimitExceeded.
+func (p *Pipeline[T]) RunView(ctx context.Context, view retable.View) (*Result[T], error) {
+	rowType := reflect.TypeFor[T]()
+	if rowType.Kind() != reflect.Struct && (rowType.Kind() != reflect.Pointer || rowType.Elem().Kind() != reflect.Struct) {
+		return nil, fmt.Errorf("row type %s is not a struct or pointer to struct", rowType)
+	}
+
+	if len(p.headerMapping) > 0 {
+		columns := slices.Clone(view.Columns())
+		for i, column := range columns {
+			if mapped, ok := p.headerMapping[column]; ok {
+				columns[i] = mapped
+			}
+		}
+		view = retable.ViewWithColumns(view, columns)
+	}
+	columns := view.Columns()
+	for _, required := range p.requiredCols {
+		if !slices.Contains(columns, required) {
+			return nil, fmt.Errorf("required column %q not found", required)
+		}
+	}
+
+	mapping := retable.NewStructColumnMapping(rowType, p.naming)
+	fieldCols := make([]int, len(columns))
+	for col, column := range columns {
+		fieldCols[col] = mapping.ColumnIndex(column)
+	}
+
+	result := &Result[T]{View: view, Errors: retable.NewRowErrors(p.maxRowErrors)}
+	reflectView := retable.AsReflectCellView(view)
+	for row := 0; row < view.NumRows(); row++ {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		var rowVal T
+		rowStruct := reflect.ValueOf(&rowVal).Elem()
+		if rowType.Kind() == reflect.Pointer {
+			rowStruct.Set(reflect.New(rowType.Elem()))
+			rowStruct = rowStruct.Elem()
+		}
+		rowErr := p.scanRow(reflectView, row, rowStruct, mapping, fieldCols)
+		if rowErr == nil && p.rowValidator != nil {
+			rowErr = p.rowValidator(rowVal)
+		}
+		if rowErr != nil {
+			if !result.Errors.Add(row, rowErr) {
+				return result, result.Errors
+			}
+			continue
+		}
+		result.Rows = append(result.Rows, rowVal)
+		result.RowIndices = append(result.RowIndices, row)
+	}
+	return result, nil
+}
+
+func (p *Pipeline[T]) scanRow(view retable.ReflectCellView, row int, rowStruct reflect.Value, mapping *retable.StructColumnMapping, fieldCols []int) error {
+	for col := range fieldCols {
+		if fieldCols[col] < 0 {
+			continue
+		}
+		dst := mapping.FieldValue(rowStruct, fieldCols[col])
+		if !dst.IsValid() {
+			continue
+		}
+		src := view.ReflectCell(row, col)
+		if !src.IsValid() {
+			continue
+		}
+		err := retable.SmartAssign(dst, src, p.scanner, p.formatter)
+		if err == nil && p.fieldValidator != nil {
+			err = p.fieldValidator(dst)
+		}
+		if err != nil {
+			return retable.NewCellError(view, row, col, err)
+		}
+	}
+	return nil
+}
+
+// WithStructFieldNaming returns a new pipeline
+// that maps columns to struct fields using naming.
+func (p *Pipeline[T]) WithStructFieldNaming(naming *retable.StructFieldNaming) *Pipeline[T] {
+	mod := p.clone()
+	mod.naming = naming
+	return mod
+}
+
+// WithReader returns a new pipeline that reads data of format
+// with the passed ReadFunc.
+// If nil is passed as read, then the format is not supported anymore.
+func (p *Pipeline[T]) WithReader(format Format, read ReadFunc) *Pipeline[T] {
+	mod := p.clone()
+	mod.readers = maps.Clone(p.readers)
+	if read != nil {
+		mod.readers[format] = read
+	} else {
+		delete(mod.readers, format)
+	}
+	return mod
+}
+
+// WithHeaderMapping returns a new pipeline that renames
+// source headers that are keys of mapping to the mapped column titles
+// before they are matched with the struct fields.
+// Headers not in mapping are used as they are.
+func (p *Pipeline[T]) WithHeaderMapping(mapping map[string]string) *Pipeline[T] {
+	mod := p.clone()
+	mod.headerMapping = maps.Clone(mapping)
+	return mod
+}
+
+// WithRequiredColumns returns a new pipeline that fails
+// if any of the passed mapped column titles is missing.
+func (p *Pipeline[T]) WithRequiredColumns(columns ...string) *Pipeline[T] {
+	mod := p.clone()
+	mod.requiredCols = slices.Clone(columns)
+	return mod
+}
+
+// WithScanner returns a new pipeline that uses scanner
+// to convert cell strings to struct field types.
+func (p *Pipeline[T]) WithScanner(scanner retable.Scanner) *Pipeline[T] {
+	mod := p.clone()
+	mod.scanner = scanner
+	return mod
+}
+
+// WithFormatter returns a new pipeline that uses formatter
+// to convert non string cell values to strings
+// for struct fields that can only be assigned from strings.
+func (p *Pipeline[T]) WithFormatter(formatter retable.Formatter) *Pipeline[T] {
+	mod := p.clone()
+	mod.formatter = formatter
+	return mod
+}
+
+// WithFieldValidator returns a new pipeline that calls validate
+// with every assigned struct field value.
+// retable.CallValidateMethod can be passed to call Validate() methods.
+func (p *Pipeline[T]) WithFieldValidator(validate func(reflect.Value) error) *Pipeline[T] {
+	mod := p.clone()
+	mod.fieldValidator = validate
+	return mod
+}
+
+// WithRowValidator returns a new pipeline that calls validate
+// with every scanned row.
+func (p *Pipeline[T]) WithRowValidator(validate func(T) error) *Pipeline[T] {
+	mod := p.clone()
+	mod.rowValidator = validate
+	return mod
+}
+
+// WithMaxRowErrors returns a new pipeline that aborts
+// the import when more than maxErrors rows have errors,
+// see retable.RowErrors.Limit.
+// Values <= 0 don't limit the number of row errors.
+func (p *Pipeline[T]) WithMaxRowErrors(maxErrors int) *Pipeline[T] {
+	mod := p.clone()
+	mod.maxRowErrors = maxErrors
+	return mod
+}
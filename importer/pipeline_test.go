@@ -0,0 +1,54 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-retable"
+)
+
+type invoice struct {
+	Number string  `col:"number"`
+	Amount float64 `col:"amount"`
+}
+
+func TestPipeline_Run(t *testing.T) {
+	csv := []byte("Invoice No;Amount\nA-1;10.5\nA-2;not a number\nA-3;0\n")
+	errZero := errors.New("zero amount")
+	pipeline := NewPipeline[invoice]().
+		WithStructFieldNaming(&retable.StructFieldNaming{Tag: "col"}).
+		WithHeaderMapping(map[string]string{"Invoice No": "number", "Amount": "amount"}).
+		WithRequiredColumns("number").
+		WithRowValidator(func(inv invoice) error {
+			if inv.Amount == 0 {
+				return errZero
+			}
+			return nil
+		})
+
+	result, err := pipeline.Run(context.Background(), csv)
+	require.NoError(t, err)
+	require.Equal(t, FormatCSV, result.Format)
+	require.Equal(t, []invoice{{Number: "A-1", Amount: 10.5}}, result.Rows)
+	require.Equal(t, []int{0}, result.RowIndices)
+	require.Equal(t, []int{1, 2}, result.Errors.RowIndices())
+	var cellErr retable.CellError
+	require.ErrorAs(t, result.Errors.Row(1)[0], &cellErr)
+	require.Equal(t, "amount", cellErr.Column)
+	require.ErrorIs(t, result.Errors, errZero)
+
+	result, err = pipeline.WithMaxRowErrors(1).Run(context.Background(), csv)
+	var rowErrs *retable.RowErrors
+	require.ErrorAs(t, err, &rowErrs)
+	require.True(t, rowErrs.LimitExceeded())
+	require.Same(t, result.Errors, rowErrs)
+
+	_, err = pipeline.WithRequiredColumns("missing").Run(context.Background(), csv)
+	require.Error(t, err)
+
+	_, err = pipeline.Run(context.Background(), []byte("PK\x03\x04"))
+	require.Error(t, err, "XLSX reader not registered")
+}
@@ -0,0 +1,147 @@
+package retable
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the OpenTelemetry tracer
+// used for the spans created by writers.
+const TracerName = "github.com/domonda/go-retable"
+
+// MetricsRecorder records metrics of writers
+// so that large exports are observable in production.
+// Implementations have to be safe for concurrent use.
+type MetricsRecorder interface {
+	// RecordWrite is called after a view was written
+	// or writing failed with stats.Err.
+	RecordWrite(ctx context.Context, stats *WriteStats)
+
+	// RecordFormatterLatency is called with the time
+	// it took to format a cell of column.
+	RecordFormatterLatency(ctx context.Context, column string, latency time.Duration)
+}
+
+// WriteStats are the statistics of writing a view.
+type WriteStats struct {
+	// Format of the written output like "csv", "html", or "xlsx"
+	Format string
+	// Title of the written view
+	Title string
+	// Rows is the number of written data rows
+	Rows int
+	// Bytes is the number of bytes written to the destination
+	Bytes int64
+	// Duration of the write
+	Duration time.Duration
+	// Err is the error that stopped writing or nil
+	Err error
+}
+
+// RowsPerSecond returns the data rows written per second.
+func (s *WriteStats) RowsPerSecond() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+	return float64(s.Rows) / s.Duration.Seconds()
+}
+
+// InstrumentedWrite measures a single write of a view
+// for a MetricsRecorder and an OpenTelemetry span.
+// It is used by the implementations of writers.
+//
+// InstrumentedWrite implements io.Writer by counting
+// the bytes written to the wrapped destination.
+type InstrumentedWrite struct {
+	dest     io.Writer
+	recorder MetricsRecorder
+	span     trace.Span
+	start    time.Time
+	stats    WriteStats
+}
+
+// StartInstrumentedWrite starts an OpenTelemetry span
+// named "retable.Write" and the measurement of a write
+// of format to dest.
+// The recorder can be nil to only create the span.
+// End must be called with the result of the write.
+func StartInstrumentedWrite(ctx context.Context, recorder MetricsRecorder, format, title string, dest io.Writer) (context.Context, *InstrumentedWrite) {
+	ctx, span := otel.Tracer(TracerName).Start(ctx, "retable.Write",
+		trace.WithAttributes(
+			attribute.String("retable.format", format),
+			attribute.String("retable.title", title),
+		),
+	)
+	iw := &InstrumentedWrite{
+		dest:     dest,
+		recorder: recorder,
+		span:     span,
+		start:    time.Now(),
+		stats: WriteStats{
+			Format: format,
+			Title:  title,
+		},
+	}
+	return ctx, iw
+}
+
+// Write implements io.Writer by writing to the wrapped destination
+// and counting the written bytes.
+func (iw *InstrumentedWrite) Write(p []byte) (n int, err error) {
+	n, err = iw.dest.Write(p)
+	iw.stats.Bytes += int64(n)
+	return n, err
+}
+
+// Hooks returns WriteHooks that count the written rows
+// and record the formatter latency of cells
+// before calling the passed hooks that can be nil.
+func (iw *InstrumentedWrite) Hooks(hooks *WriteHooks) *WriteHooks {
+	instrumented := &WriteHooks{
+		OnRowEnd: func(ctx context.Context, view View, row int, err error) {
+			if err == nil {
+				iw.stats.Rows++
+			}
+			hooks.RowEnd(ctx, view, row, err)
+		},
+	}
+	if hooks != nil {
+		instrumented.OnRowStart = hooks.OnRowStart
+		instrumented.OnCellError = hooks.OnCellError
+	}
+	if iw.recorder != nil || (hooks != nil && hooks.OnCellFormatted != nil) {
+		instrumented.OnCellFormatted = func(ctx context.Context, view View, row, col int, latency time.Duration) {
+			if iw.recorder != nil {
+				iw.recorder.RecordFormatterLatency(ctx, view.Columns()[col], latency)
+			}
+			hooks.CellFormatted(ctx, view, row, col, latency)
+		}
+	}
+	return instrumented
+}
+
+// End records the WriteStats with the MetricsRecorder,
+// ends the span, and returns the passed err.
+func (iw *InstrumentedWrite) End(ctx context.Context, err error) error {
+	iw.stats.Duration = time.Since(iw.start)
+	iw.stats.Err = err
+	if iw.recorder != nil {
+		iw.recorder.RecordWrite(ctx, &iw.stats)
+	}
+	iw.span.SetAttributes(
+		attribute.Int("retable.rows", iw.stats.Rows),
+		attribute.Int64("retable.bytes", iw.stats.Bytes),
+	)
+	if err != nil {
+		iw.span.RecordError(err)
+		iw.span.SetStatus(codes.Error, err.Error())
+	}
+	iw.span.End()
+	return err
+}
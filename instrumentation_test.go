@@ -0,0 +1,72 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testMetricsRecorder struct {
+	mtx       sync.Mutex
+	writes    []WriteStats
+	latencies map[string]int
+}
+
+func (r *testMetricsRecorder) RecordWrite(ctx context.Context, stats *WriteStats) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.writes = append(r.writes, *stats)
+}
+
+func (r *testMetricsRecorder) RecordFormatterLatency(ctx context.Context, column string, latency time.Duration) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.latencies == nil {
+		r.latencies = make(map[string]int)
+	}
+	r.latencies[column]++
+}
+
+func TestInstrumentedWrite(t *testing.T) {
+	ctx := context.Background()
+	view := NewStringsView("Title", [][]string{{"A", "B"}, {"1", "2"}, {"3", "4"}})
+	recorder := new(testMetricsRecorder)
+	var dest strings.Builder
+	var hookedRows []int
+
+	ctx, iw := StartInstrumentedWrite(ctx, recorder, "test", view.Title(), &dest)
+	hooks := iw.Hooks(&WriteHooks{
+		OnRowEnd: func(ctx context.Context, view View, row int, err error) {
+			hookedRows = append(hookedRows, row)
+		},
+	})
+	for row := range view.NumRows() {
+		hooks.RowStart(ctx, view, row)
+		for col := range view.Columns() {
+			start := hooks.CellStart()
+			_, err := iw.Write([]byte(view.Cell(row, col).(string)))
+			require.NoError(t, err)
+			hooks.CellEnd(ctx, view, row, col, start)
+		}
+		hooks.RowEnd(ctx, view, row, nil)
+	}
+	writeErr := errors.New("test error")
+	err := iw.End(ctx, writeErr)
+	require.Equal(t, writeErr, err)
+
+	require.Equal(t, "1234", dest.String())
+	require.Equal(t, []int{0, 1}, hookedRows)
+	require.Len(t, recorder.writes, 1)
+	stats := recorder.writes[0]
+	require.Equal(t, "test", stats.Format)
+	require.Equal(t, "Title", stats.Title)
+	require.Equal(t, 2, stats.Rows)
+	require.Equal(t, int64(4), stats.Bytes)
+	require.Equal(t, writeErr, stats.Err)
+	require.Equal(t, map[string]int{"A": 2, "B": 2}, recorder.latencies)
+}
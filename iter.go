@@ -0,0 +1,55 @@
+package retable
+
+import "iter"
+
+// Rows returns an iterator over the rows of a View
+// yielding the row index and the cell values of the row.
+// A new slice is allocated for every row.
+func Rows(view View) iter.Seq2[int, []any] {
+	return func(yield func(int, []any) bool) {
+		numCols := len(view.Columns())
+		for row, numRows := 0, view.NumRows(); row < numRows; row++ {
+			values := make([]any, numCols)
+			for col := range values {
+				values[col] = view.Cell(row, col)
+			}
+			if !yield(row, values) {
+				return
+			}
+		}
+	}
+}
+
+// ColumnValues returns an iterator over the cell values
+// of the column with the index col for all rows of a View.
+func ColumnValues(view View, col int) iter.Seq[any] {
+	return func(yield func(any) bool) {
+		for row, numRows := 0, view.NumRows(); row < numRows; row++ {
+			if !yield(view.Cell(row, col)) {
+				return
+			}
+		}
+	}
+}
+
+// ViewCell is a cell value of a View at a row and column.
+type ViewCell struct {
+	Row   int
+	Col   int
+	Value any
+}
+
+// Cells returns an iterator over all cells of a View
+// row by row from left to right.
+func Cells(view View) iter.Seq[ViewCell] {
+	return func(yield func(ViewCell) bool) {
+		numCols := len(view.Columns())
+		for row, numRows := 0, view.NumRows(); row < numRows; row++ {
+			for col := 0; col < numCols; col++ {
+				if !yield(ViewCell{Row: row, Col: col, Value: view.Cell(row, col)}) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package retable
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterators(t *testing.T) {
+	view := &AnyValuesView{
+		Cols: []string{"A", "B"},
+		Rows: [][]any{{1, "one"}, {2, "two"}},
+	}
+
+	var rows [][]any
+	for row, values := range Rows(view) {
+		require.Equal(t, len(rows), row)
+		rows = append(rows, values)
+	}
+	require.Equal(t, view.Rows, rows)
+
+	require.Equal(t, []any{"one", "two"}, slices.Collect(ColumnValues(view, 1)))
+
+	var cells []ViewCell
+	for cell := range Cells(view) {
+		if cell.Row == 1 && cell.Col == 1 {
+			break
+		}
+		cells = append(cells, cell)
+	}
+	require.Equal(t, []ViewCell{{0, 0, 1}, {0, 1, "one"}, {1, 0, 2}}, cells)
+}
@@ -0,0 +1,413 @@
+// Package latextable writes views as booktabs style
+// LaTeX tabular environments for generated PDF reports.
+//
+// The generated LaTeX requires \usepackage{booktabs}.
+package latextable
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/domonda/go-retable"
+)
+
+// Writer writes views as LaTeX tabular environment
+// with \toprule, \midrule, and \bottomrule of booktabs:
+//
+//	\begin{tabular}{lr}
+//	\toprule
+//	Name & Age \\
+//	\midrule
+//	Erik & 42 \\
+//	\bottomrule
+//	\end{tabular}
+//
+// Formatted cell strings are escaped with Escape
+// unless the formatter returns them as raw LaTeX.
+type Writer[T any] struct {
+	viewer           retable.Viewer
+	columnFormatters map[int]retable.CellFormatter
+	typeFormatters   *retable.ReflectTypeCellFormatter
+	nullPolicy       retable.NullPolicy
+	columnNulls      retable.ColumnNullPolicies
+	errorPolicy      retable.ErrorPolicy
+	headerRow        bool
+	columnSpec       string
+	tableFloat       bool
+	recoverPanics    bool
+	pointerHandling  retable.PointerHandling
+	zeroAsNull       retable.ZeroAsNull
+	rawPolicy        retable.RawPolicy
+}
+
+func NewWriter[T any]() *Writer[T] {
+	return &Writer[T]{
+		columnFormatters: make(map[int]retable.CellFormatter),
+		typeFormatters:   nil, // OK to use nil retable.TypeFormatters
+		nullPolicy:       retable.RenderNullAs(""),
+		columnNulls:      retable.ColumnNullPolicies{},
+		errorPolicy:      retable.ErrorPolicy{},
+		headerRow:        true,
+		columnSpec:       "",
+		tableFloat:       false,
+		recoverPanics:    false,
+		pointerHandling:  retable.PointerKeep,
+		zeroAsNull:       retable.ZeroAsNull{},
+		rawPolicy:        retable.RawTrust,
+	}
+}
+
+func (w *Writer[T]) clone() *Writer[T] {
+	c := new(Writer[T])
+	*c = *w
+	return c
+}
+
+// Capabilities returns the capabilities of the LaTeX writer.
+func (w *Writer[T]) Capabilities() retable.WriterCapabilities {
+	return retable.WriterCapabilities{
+		Format: "latex",
+	}
+}
+
+// Write calls WriteView with the result of Viewer.NewView(table)
+// using the writer's viewer if not nil or else retable.DefaultViewer.
+func (w *Writer[T]) Write(ctx context.Context, dest io.Writer, table T) error {
+	viewer := w.viewer
+	if viewer == nil {
+		var err error
+		viewer, err = retable.SelectViewer(table)
+		if err != nil {
+			return err
+		}
+	}
+	return w.WriteWithViewer(ctx, dest, viewer, table)
+}
+
+// WriteWithViewer calls WriteView with the result of viewer.NewView(table).
+func (w *Writer[T]) WriteWithViewer(ctx context.Context, dest io.Writer, viewer retable.Viewer, table T) error {
+	view, err := viewer.NewView("", table)
+	if err != nil {
+		return err
+	}
+	return w.WriteView(ctx, dest, view)
+}
+
+// WriteView writes the view to dest as LaTeX tabular.
+//
+// If no column spec was set with WithColumnSpec,
+// then columns with numeric values are right aligned
+// and all other columns are left aligned.
+// If the writer was configured WithTableFloat,
+// then the tabular is wrapped in a table environment
+// with the view title as caption.
+func (w *Writer[T]) WriteView(ctx context.Context, dest io.Writer, view retable.View) error {
+	view = w.pointerHandling.View(view)
+	view = w.zeroAsNull.View(view)
+	columnSpec := w.columnSpec
+	if columnSpec == "" {
+		columnSpec = ColumnSpec(view)
+	}
+
+	var b bytes.Buffer
+	if w.tableFloat {
+		b.WriteString("\\begin{table}\n\\centering\n")
+	}
+	fmt.Fprintf(&b, "\\begin{tabular}{%s}\n\\toprule\n", columnSpec)
+	if w.headerRow {
+		for col, column := range view.Columns() {
+			if col > 0 {
+				b.WriteString(" & ")
+			}
+			b.WriteString(Escape(column))
+		}
+		b.WriteString(" \\\\\n\\midrule\n")
+	}
+	for row := 0; row < view.NumRows(); row++ {
+		for col := range view.Columns() {
+			if col > 0 {
+				b.WriteString(" & ")
+			}
+			str, err := w.cellString(ctx, view, row, col)
+			if err != nil {
+				return err
+			}
+			b.WriteString(str)
+		}
+		b.WriteString(" \\\\\n")
+	}
+	b.WriteString("\\bottomrule\n\\end{tabular}\n")
+	if w.tableFloat {
+		if title := view.Title(); title != "" {
+			fmt.Fprintf(&b, "\\caption{%s}\n", Escape(title))
+		}
+		b.WriteString("\\end{table}\n")
+	}
+	_, err := dest.Write(b.Bytes())
+	return err
+}
+
+func (w *Writer[T]) cellString(ctx context.Context, view retable.View, row, col int) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	if !retable.CellExists(view, row, col) {
+		return "", nil
+	}
+	str, isRaw, err := w.formatCell(ctx, view, row, col)
+	if err != nil {
+		str, isRaw, err = w.errorPolicy.FormatError(view, row, col, err)
+		if err != nil {
+			return "", err
+		}
+	}
+	if isRaw {
+		return str, nil
+	}
+	return Escape(str), nil
+}
+
+func (w *Writer[T]) formatCell(ctx context.Context, view retable.View, row, col int) (str string, isRaw bool, err error) {
+	colFormatter, ok := w.columnFormatters[col]
+	if !ok {
+		colFormatter = retable.ViewColumnFormatter(view, col)
+		ok = colFormatter != nil
+	}
+	colFormatter = w.rawPolicy.WrapFormatter(colFormatter)
+	if ok && w.recoverPanics {
+		colFormatter = retable.SafeCellFormatter(colFormatter)
+	}
+	if ok {
+		str, isRaw, err := colFormatter.FormatCell(ctx, view, row, col)
+		if err == nil {
+			return str, isRaw, nil
+		}
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return "", false, err
+		}
+		// Continue after errors.ErrUnsupported
+	}
+
+	typeFormatter := w.rawPolicy.WrapFormatter(w.typeFormatters)
+	if w.recoverPanics {
+		typeFormatter = retable.SafeCellFormatter(typeFormatter)
+	}
+	str, isRaw, err = typeFormatter.FormatCell(ctx, view, row, col)
+	if err == nil {
+		return str, isRaw, nil
+	}
+	if !errors.Is(err, errors.ErrUnsupported) {
+		return "", false, err
+	}
+	// Continue after errors.ErrUnsupported
+
+	// Use fallback methods for formatting
+	v := retable.AsReflectCellView(view).ReflectCell(row, col)
+	if retable.IsNullLike(v) {
+		return w.columnNulls.Policy(view, col, w.nullPolicy).FormatNull(view, row, col)
+	}
+	if cellErr := retable.CellErrorValue(view, row, col); cellErr != nil {
+		return w.errorPolicy.FormatError(view, row, col, cellErr)
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	return fmt.Sprint(v.Interface()), false, nil
+}
+
+var escaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+	"\r\n", " ",
+	"\n", " ",
+	"\r", " ",
+)
+
+// Escape returns str with the special characters of LaTeX escaped
+// and line breaks replaced with spaces.
+func Escape(str string) string {
+	return escaper.Replace(str)
+}
+
+// ColumnSpec returns a tabular column spec for view
+// with 'r' for columns whose first non null value
+// is a number and 'l' for all other columns.
+func ColumnSpec(view retable.View) string {
+	reflectView := retable.AsReflectCellView(view)
+	spec := make([]byte, len(view.Columns()))
+	for col := range spec {
+		spec[col] = 'l'
+		for row := 0; row < view.NumRows(); row++ {
+			v := reflectView.ReflectCell(row, col)
+			if retable.IsNullLike(v) {
+				continue
+			}
+			if v.Kind() == reflect.Pointer {
+				v = v.Elem()
+			}
+			switch v.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+				reflect.Float32, reflect.Float64:
+				spec[col] = 'r'
+			}
+			break
+		}
+	}
+	return string(spec)
+}
+
+func (w *Writer[T]) WithTableViewer(viewer retable.Viewer) *Writer[T] {
+	mod := w.clone()
+	mod.viewer = viewer
+	return mod
+}
+
+// WithColumnFormatter returns a new writer with the passed formatter
+// for the column at columnIndex.
+// If nil is passed as formatter, then a previous set formatter
+// for the column is removed.
+func (w *Writer[T]) WithColumnFormatter(columnIndex int, formatter retable.CellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.columnFormatters = make(map[int]retable.CellFormatter)
+	for key, val := range w.columnFormatters {
+		mod.columnFormatters[key] = val
+	}
+	if formatter == nil {
+		delete(mod.columnFormatters, columnIndex)
+	} else {
+		mod.columnFormatters[columnIndex] = formatter
+	}
+	return mod
+}
+
+// WithRecoverPanics returns a new writer that recovers
+// panics of column and type formatters and returns them
+// as errors with the coordinates of the formatted cell,
+// see retable.SafeCellFormatter.
+func (w *Writer[T]) WithRecoverPanics(recoverPanics bool) *Writer[T] {
+	mod := w.clone()
+	mod.recoverPanics = recoverPanics
+	return mod
+}
+
+// WithRawPolicy returns a new writer that handles
+// raw strings returned by column and type formatters
+// according to policy, see retable.RawPolicy.
+func (w *Writer[T]) WithRawPolicy(policy retable.RawPolicy) *Writer[T] {
+	mod := w.clone()
+	mod.rawPolicy = policy
+	return mod
+}
+
+// WithPointerHandling returns a new writer that formats
+// cells with pointer values according to handling,
+// see retable.PointerHandling.
+func (w *Writer[T]) WithPointerHandling(handling retable.PointerHandling) *Writer[T] {
+	mod := w.clone()
+	mod.pointerHandling = handling
+	return mod
+}
+
+// WithZeroAsNull returns a new writer that handles
+// the zero values of the cells selected by zeroAsNull
+// like null values using the null policy,
+// for example to render 0 amounts as empty cells.
+func (w *Writer[T]) WithZeroAsNull(zeroAsNull retable.ZeroAsNull) *Writer[T] {
+	mod := w.clone()
+	mod.zeroAsNull = zeroAsNull
+	return mod
+}
+
+func (w *Writer[T]) WithTypeFormatters(formatter *retable.ReflectTypeCellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.typeFormatters = formatter
+	return mod
+}
+
+func (w *Writer[T]) WithTypeFormatter(typ reflect.Type, fmt retable.CellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.typeFormatters = w.typeFormatters.WithTypeFormatter(typ, fmt)
+	return mod
+}
+
+func (w *Writer[T]) WithNullPolicy(policy retable.NullPolicy) *Writer[T] {
+	mod := w.clone()
+	mod.nullPolicy = policy
+	return mod
+}
+
+// WithColumnNilValue returns a new writer that renders
+// null-like values of the column with columnIndex
+// as the passed nilValue instead of using the null policy of the writer.
+func (w *Writer[T]) WithColumnNilValue(columnIndex int, nilValue string) *Writer[T] {
+	mod := w.clone()
+	mod.columnNulls = w.columnNulls.WithIndex(columnIndex, retable.RenderNullAs(nilValue))
+	return mod
+}
+
+// WithColumnTitleNilValue returns a new writer that renders
+// null-like values of the columns with the passed title
+// as nilValue instead of using the null policy of the writer.
+func (w *Writer[T]) WithColumnTitleNilValue(title string, nilValue string) *Writer[T] {
+	mod := w.clone()
+	mod.columnNulls = w.columnNulls.WithTitle(title, retable.RenderNullAs(nilValue))
+	return mod
+}
+
+// WithColumnNullPolicies returns a new writer that handles
+// null-like values of the columns selected by policies
+// with their own null policy.
+func (w *Writer[T]) WithColumnNullPolicies(policies retable.ColumnNullPolicies) *Writer[T] {
+	mod := w.clone()
+	mod.columnNulls = policies
+	return mod
+}
+
+// WithErrorPolicy returns a new writer that handles
+// cells with error values and cells whose formatting failed
+// with the passed policy.
+// The default policy aborts writing with a retable.CellError.
+func (w *Writer[T]) WithErrorPolicy(policy retable.ErrorPolicy) *Writer[T] {
+	mod := w.clone()
+	mod.errorPolicy = policy
+	return mod
+}
+
+// WithHeaderRow returns a new writer that writes
+// the column titles as header row, default is true.
+func (w *Writer[T]) WithHeaderRow(headerRow bool) *Writer[T] {
+	mod := w.clone()
+	mod.headerRow = headerRow
+	return mod
+}
+
+// WithColumnSpec returns a new writer using spec as
+// tabular column specification like "lrr" or "l|p{3cm}|r".
+// An empty spec derives the alignment from the view, see ColumnSpec.
+func (w *Writer[T]) WithColumnSpec(spec string) *Writer[T] {
+	mod := w.clone()
+	mod.columnSpec = spec
+	return mod
+}
+
+// WithTableFloat returns a new writer that wraps the tabular
+// in a table environment with the view title as caption.
+func (w *Writer[T]) WithTableFloat(tableFloat bool) *Writer[T] {
+	mod := w.clone()
+	mod.tableFloat = tableFloat
+	return mod
+}
@@ -0,0 +1,67 @@
+package latextable
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-retable"
+)
+
+func TestWriter_WriteView(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Tit:  "Costs & Fees",
+		Cols: []string{"Item_Name", "Cost"},
+		Rows: [][]any{{"50% off {sale}", 9.5}, {`C:\tmp #1`, nil}},
+	}
+	tests := []struct {
+		name     string
+		writer   *Writer[any]
+		wantDest string
+	}{
+		{
+			name:   "default",
+			writer: NewWriter[any](),
+			wantDest: `\begin{tabular}{lr}` + "\n" +
+				`\toprule` + "\n" +
+				`Item\_Name & Cost \\` + "\n" +
+				`\midrule` + "\n" +
+				`50\% off \{sale\} & 9.5 \\` + "\n" +
+				`C:\textbackslash{}tmp \#1 &  \\` + "\n" +
+				`\bottomrule` + "\n" +
+				`\end{tabular}` + "\n",
+		},
+		{
+			name: "table float without header",
+			writer: NewWriter[any]().
+				WithHeaderRow(false).
+				WithColumnSpec("p{3cm}c").
+				WithNullPolicy(retable.RenderNullAs("--")).
+				WithTableFloat(true),
+			wantDest: `\begin{table}` + "\n" +
+				`\centering` + "\n" +
+				`\begin{tabular}{p{3cm}c}` + "\n" +
+				`\toprule` + "\n" +
+				`50\% off \{sale\} & 9.5 \\` + "\n" +
+				`C:\textbackslash{}tmp \#1 & -- \\` + "\n" +
+				`\bottomrule` + "\n" +
+				`\end{tabular}` + "\n" +
+				`\caption{Costs \& Fees}` + "\n" +
+				`\end{table}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dest bytes.Buffer
+			err := tt.writer.WriteView(context.Background(), &dest, view)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantDest, dest.String())
+		})
+	}
+}
+
+func TestEscape(t *testing.T) {
+	require.Equal(t, `a\textasciitilde{}b\textasciicircum{}c\$ d`, Escape("a~b^c$\nd"))
+}
@@ -0,0 +1,50 @@
+package retable
+
+import (
+	"fmt"
+	"io"
+)
+
+// ErrMaxRowsExceeded is returned by writers configured
+// with a maximum number of rows when a view has more rows.
+type ErrMaxRowsExceeded struct {
+	Max     int
+	NumRows int
+}
+
+func (e ErrMaxRowsExceeded) Error() string {
+	return fmt.Sprintf("view has %d rows, exceeding the maximum of %d rows", e.NumRows, e.Max)
+}
+
+// ErrMaxOutputBytesExceeded is returned by writers configured
+// with a maximum output size when writing would exceed it.
+type ErrMaxOutputBytesExceeded struct {
+	Max int64
+}
+
+func (e ErrMaxOutputBytesExceeded) Error() string {
+	return fmt.Sprintf("output exceeds the maximum of %d bytes", e.Max)
+}
+
+// MaxBytesWriter writes to Dest until a write would exceed
+// Max bytes in total, then the write fails
+// with ErrMaxOutputBytesExceeded without writing anything.
+// Writers use it to implement output size limits
+// at row boundaries by writing every row with a single call.
+type MaxBytesWriter struct {
+	Dest io.Writer
+	// Max number of bytes to write, not limited if <= 0
+	Max int64
+	// Written is the number of bytes written to Dest
+	Written int64
+}
+
+// Write implements io.Writer
+func (w *MaxBytesWriter) Write(p []byte) (n int, err error) {
+	if w.Max > 0 && w.Written+int64(len(p)) > w.Max {
+		return 0, ErrMaxOutputBytesExceeded{Max: w.Max}
+	}
+	n, err = w.Dest.Write(p)
+	w.Written += int64(n)
+	return n, err
+}
@@ -0,0 +1,113 @@
+package retable
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var _ Viewer = new(MapRowsViewer)
+
+// MapRowsViewer implements Viewer for tables
+// represented by a slice or array of maps with string keys
+// like []map[string]any from decoded JSON objects.
+//
+// The columns are the union of the keys of all maps
+// in the order defined by ColumnOrder,
+// because maps have no stable iteration order.
+type MapRowsViewer struct {
+	// ColumnOrder of the map keys,
+	// if nil then the keys are sorted lexically.
+	ColumnOrder *ColumnOrder
+}
+
+// NewView returns a View for a table made up of
+// a slice or array of maps with string keys or a pointer to it.
+// NewView implements the Viewer interface for MapRowsViewer.
+func (v *MapRowsViewer) NewView(title string, table any) (View, error) {
+	if table == nil {
+		return nil, errors.New("table is untyped nil")
+	}
+	rows := derefTable(reflect.ValueOf(table))
+	if rows.Kind() != reflect.Slice && rows.Kind() != reflect.Array {
+		return nil, fmt.Errorf("table must be slice or array kind but is %T", table)
+	}
+	rowType := rows.Type().Elem()
+	if rowType.Kind() != reflect.Map || rowType.Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("row type must be a map with string keys but is %s", rowType)
+	}
+
+	var keys []string
+	seen := make(map[string]bool)
+	for i := range rows.Len() {
+		for iter := rows.Index(i).MapRange(); iter.Next(); {
+			key := iter.Key().String()
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	columns := v.ColumnOrder.Sort(keys)
+	mapKeys := make([]reflect.Value, len(columns))
+	for i, column := range columns {
+		mapKeys[i] = reflect.ValueOf(column).Convert(rowType.Key())
+	}
+	return &mapRowsView{title: title, columns: columns, keys: mapKeys, rows: rows}, nil
+}
+
+// WithColumnOrder returns a new MapRowsViewer with the passed ColumnOrder.
+func (v *MapRowsViewer) WithColumnOrder(order *ColumnOrder) *MapRowsViewer {
+	return &MapRowsViewer{ColumnOrder: order}
+}
+
+// isMapRowsTable returns if table is a slice or array
+// of maps with string keys or a pointer to it.
+func isMapRowsTable(table any) bool {
+	t := reflect.TypeOf(table)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || (t.Kind() != reflect.Slice && t.Kind() != reflect.Array) {
+		return false
+	}
+	return t.Elem().Kind() == reflect.Map && t.Elem().Key().Kind() == reflect.String
+}
+
+var _ SparseCellView = new(mapRowsView)
+
+type mapRowsView struct {
+	title   string
+	columns []string
+	keys    []reflect.Value
+	rows    reflect.Value // slice of maps
+}
+
+func (view *mapRowsView) Title() string     { return view.title }
+func (view *mapRowsView) Columns() []string { return view.columns }
+func (view *mapRowsView) NumRows() int      { return view.rows.Len() }
+
+// CellExists implements SparseCellView by returning
+// if the map of the row has the key of the column.
+func (view *mapRowsView) CellExists(row, col int) bool {
+	return view.ReflectCell(row, col).IsValid()
+}
+
+func (view *mapRowsView) Cell(row, col int) any {
+	v := view.ReflectCell(row, col)
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func (view *mapRowsView) ReflectCell(row, col int) reflect.Value {
+	if row < 0 || col < 0 || row >= view.rows.Len() || col >= len(view.columns) {
+		return reflect.Value{}
+	}
+	m := view.rows.Index(row)
+	if m.IsNil() {
+		return reflect.Value{}
+	}
+	return m.MapIndex(view.keys[col])
+}
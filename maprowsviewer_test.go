@@ -0,0 +1,109 @@
+package retable
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnOrder_Sort(t *testing.T) {
+	columns := []string{"b", "C", "a", "d"}
+	byLength := func(a, b string) int { return len(a) - len(b) }
+	tests := []struct {
+		name  string
+		order *ColumnOrder
+		want  []string
+	}{
+		{name: "nil", order: nil, want: []string{"C", "a", "b", "d"}},
+		{name: "listed first", order: NewColumnOrder("d", "x", "b"), want: []string{"d", "b", "C", "a"}},
+		{name: "only listed", order: &ColumnOrder{Columns: []string{"d", "b"}, OnlyListed: true}, want: []string{"d", "b"}},
+		{name: "compare", order: &ColumnOrder{Compare: func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) }}, want: []string{"a", "b", "C", "d"}},
+		{name: "stable compare", order: &ColumnOrder{Compare: byLength}, want: []string{"b", "C", "a", "d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.order.Sort(columns))
+			require.Equal(t, []string{"b", "C", "a", "d"}, columns, "not modified")
+		})
+	}
+}
+
+func TestColumnOrder_View(t *testing.T) {
+	view := NewStringsView("", [][]string{{"b", "a"}, {"1", "2"}})
+	ordered := NewColumnOrder().View(view)
+	require.Equal(t, []string{"a", "b"}, ordered.Columns())
+	require.Equal(t, "2", ordered.Cell(0, 0))
+}
+
+func TestMapRowsViewer(t *testing.T) {
+	type Key string
+	tests := []struct {
+		name    string
+		viewer  *MapRowsViewer
+		table   any
+		columns []string
+		rows    [][]any
+		wantErr bool
+	}{
+		{
+			name:    "sorted keys",
+			viewer:  &MapRowsViewer{},
+			table:   []map[string]any{{"b": 1, "a": "x"}, {"c": true}},
+			columns: []string{"a", "b", "c"},
+			rows:    [][]any{{"x", 1, nil}, {nil, nil, true}},
+		},
+		{
+			name:    "column order",
+			viewer:  new(MapRowsViewer).WithColumnOrder(NewColumnOrder("c")),
+			table:   &[]map[Key]int{{"b": 1, "a": 2}, nil, {"c": 3}},
+			columns: []string{"c", "a", "b"},
+			rows:    [][]any{{nil, 2, 1}, {nil, nil, nil}, {3, nil, nil}},
+		},
+		{
+			name:    "nil table",
+			viewer:  &MapRowsViewer{},
+			table:   []map[string]any(nil),
+			columns: nil,
+			rows:    nil,
+		},
+		{
+			name:    "int keys",
+			viewer:  &MapRowsViewer{},
+			table:   []map[int]any{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view, err := tt.viewer.NewView("Maps", tt.table)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "Maps", view.Title())
+			require.Equal(t, tt.columns, view.Columns())
+			var rows [][]any
+			for row := range view.NumRows() {
+				cells := make([]any, len(view.Columns()))
+				for col := range cells {
+					cells[col] = view.Cell(row, col)
+					require.Equal(t, cells[col] != nil, CellExists(view, row, col))
+				}
+				rows = append(rows, cells)
+			}
+			require.Equal(t, tt.rows, rows)
+		})
+	}
+
+	// Reproducible across runs despite random map iteration
+	table := []map[string]int{{"e": 1, "d": 2, "c": 3, "b": 4, "a": 5}}
+	viewer, err := SelectViewer(table)
+	require.NoError(t, err)
+	for range 10 {
+		view, err := viewer.NewView("", table)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b", "c", "d", "e"}, view.Columns())
+	}
+}
@@ -0,0 +1,202 @@
+package retable
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaskFunc masks personally identifiable information
+// in the string representation of a value.
+//
+// MaskFunc implements CellFormatter so that it can be
+// used as column formatter of writers,
+// MaskView applies mask functions to the columns of a View.
+type MaskFunc func(str string) string
+
+// FormatCell implements CellFormatter by masking the string representation
+// of the cell value. Null cells return errors.ErrUnsupported
+// so that they are handled by the NullPolicy of writers.
+func (mask MaskFunc) FormatCell(ctx context.Context, view View, row, col int) (str string, raw bool, err error) {
+	str, ok := cellString(AsReflectCellView(view).ReflectCell(row, col))
+	if !ok {
+		return "", false, fmt.Errorf("can't mask null value: %w", errors.ErrUnsupported)
+	}
+	return mask(str), false, nil
+}
+
+// cellString returns the string representation of a cell value
+// or false for null values.
+func cellString(v reflect.Value) (string, bool) {
+	if IsNullLike(v) {
+		return "", false
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.String {
+		return v.String(), true
+	}
+	return fmt.Sprint(v.Interface()), true
+}
+
+// MaskEmail masks the local part of an email address
+// except for the first character like "j***@example.com".
+// Strings without @ are masked completely.
+func MaskEmail(email string) string {
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return strings.Repeat("*", utf8.RuneCountInString(email))
+	}
+	local := []rune(email[:at])
+	if len(local) == 0 {
+		return email
+	}
+	return string(local[0]) + strings.Repeat("*", len(local)-1) + email[at:]
+}
+
+// MaskIBAN keeps the country code, the check digits,
+// and the last four characters of an IBAN and masks
+// the rest like "AT61************2345".
+// Spaces are removed.
+func MaskIBAN(iban string) string {
+	iban = strings.ReplaceAll(iban, " ", "")
+	if len(iban) <= 8 {
+		return strings.Repeat("*", len(iban))
+	}
+	return iban[:4] + strings.Repeat("*", len(iban)-8) + iban[len(iban)-4:]
+}
+
+// HashMask returns a MaskFunc that replaces a value
+// with the hex encoded SHA-256 hash of salt + value.
+// Equal values result in equal hashes so that masked
+// data can still be joined and grouped.
+// Use a secret salt to prevent dictionary attacks.
+func HashMask(salt string) MaskFunc {
+	return func(str string) string {
+		hash := sha256.Sum256([]byte(salt + str))
+		return hex.EncodeToString(hash[:])
+	}
+}
+
+// RedactMask returns a MaskFunc that replaces
+// every value with the passed placeholder.
+func RedactMask(placeholder string) MaskFunc {
+	return func(string) string { return placeholder }
+}
+
+// HashColumn returns a CellFormatter that replaces cell values
+// with their salted hash, see HashMask.
+func HashColumn(salt string) CellFormatter {
+	return HashMask(salt)
+}
+
+// RedactColumn returns a CellFormatter that replaces
+// non null cell values with the passed placeholder.
+func RedactColumn(placeholder string) CellFormatter {
+	return RedactMask(placeholder)
+}
+
+// DefaultRedactionPlaceholder is the placeholder
+// used by writers for redacted columns.
+const DefaultRedactionPlaceholder = "***"
+
+// RedactColumnsView returns a View that replaces the non null cells
+// of the columns with the passed titles with placeholder.
+// The column titles are kept.
+// If no columns are passed then source is returned unchanged.
+func RedactColumnsView(source View, placeholder string, columns ...string) View {
+	if len(columns) == 0 {
+		return source
+	}
+	masks := make(map[string]MaskFunc, len(columns))
+	for _, column := range columns {
+		masks[column] = RedactMask(placeholder)
+	}
+	return MaskView(source, masks)
+}
+
+// MaskView returns a View that applies the mask functions
+// to the string representation of the cells of the columns
+// with the titles used as map keys.
+// Masked cells are strings, null cells stay nil.
+// The row groups of a RowGroupsView source are kept.
+func MaskView(source View, masks map[string]MaskFunc) ReflectCellView {
+	columnMasks := make([]MaskFunc, len(source.Columns()))
+	for col, title := range source.Columns() {
+		columnMasks[col] = masks[title]
+	}
+	view := &maskView{source: AsReflectCellView(source), masks: columnMasks}
+	if groups, ok := source.(RowGroupsView); ok {
+		return &rowGroupsMaskView{maskView: view, groups: groups}
+	}
+	return view
+}
+
+var _ RowGroupsView = new(rowGroupsMaskView)
+
+type rowGroupsMaskView struct {
+	*maskView
+	groups RowGroupsView
+}
+
+func (view *rowGroupsMaskView) RowGroup(row int) (RowGroup, bool) {
+	return view.groups.RowGroup(row)
+}
+
+var _ SparseCellView = new(maskView)
+
+type maskView struct {
+	source ReflectCellView
+	masks  []MaskFunc
+}
+
+func (view *maskView) Title() string     { return view.source.Title() }
+func (view *maskView) Columns() []string { return view.source.Columns() }
+func (view *maskView) NumRows() int      { return view.source.NumRows() }
+
+// ColumnFormatter implements ColumnFormatterView
+// by returning the formatter of unmasked columns of the source.
+func (view *maskView) ColumnFormatter(col int) CellFormatter {
+	if col >= 0 && col < len(view.masks) && view.masks[col] != nil {
+		return nil
+	}
+	return ViewColumnFormatter(view.source, col)
+}
+
+func (view *maskView) ColumnDescription(col int) string {
+	return ViewColumnDescription(view.source, col)
+}
+
+func (view *maskView) ColumnHidden(col int) bool {
+	return ViewColumnHidden(view.source, col)
+}
+
+func (view *maskView) CellExists(row, col int) bool {
+	return CellExists(view.source, row, col)
+}
+
+func (view *maskView) Cell(row, col int) any {
+	v := view.ReflectCell(row, col)
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func (view *maskView) ReflectCell(row, col int) reflect.Value {
+	v := view.source.ReflectCell(row, col)
+	if col < 0 || col >= len(view.masks) || view.masks[col] == nil {
+		return v
+	}
+	str, ok := cellString(v)
+	if !ok {
+		return reflect.Value{}
+	}
+	return reflect.ValueOf(view.masks[col](str))
+}
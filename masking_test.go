@@ -0,0 +1,52 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskFuncs(t *testing.T) {
+	tests := []struct {
+		name string
+		mask MaskFunc
+		str  string
+		want string
+	}{
+		{name: "email", mask: MaskEmail, str: "john@example.com", want: "j***@example.com"},
+		{name: "email without @", mask: MaskEmail, str: "john", want: "****"},
+		{name: "IBAN", mask: MaskIBAN, str: "AT61 1904 3002 3457 3201", want: "AT61************3201"},
+		{name: "short IBAN", mask: MaskIBAN, str: "AT61", want: "****"},
+		{name: "redact", mask: RedactMask("[redacted]"), str: "secret", want: "[redacted]"},
+		{name: "hash", mask: HashMask("salt"), str: "x", want: HashMask("salt")("x")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.mask(tt.str))
+		})
+	}
+	require.NotEqual(t, HashMask("a")("x"), HashMask("b")("x"), "salt changes hash")
+}
+
+func TestMaskView(t *testing.T) {
+	source := &AnyValuesView{
+		Cols: []string{"Name", "Email", "IBAN"},
+		Rows: [][]any{
+			{"John", "john@example.com", "AT611904300234573201"},
+			{"Jane", nil, "AT611904300234579999"},
+		},
+	}
+	view := MaskView(source, map[string]MaskFunc{"Email": MaskEmail, "IBAN": MaskIBAN})
+	require.Equal(t, "John", view.Cell(0, 0))
+	require.Equal(t, "j***@example.com", view.Cell(0, 1))
+	require.Equal(t, "AT61************3201", view.Cell(0, 2))
+	require.Nil(t, view.Cell(1, 1))
+
+	str, _, err := RedactColumn("***").FormatCell(context.Background(), source, 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, "***", str)
+	_, _, err = RedactColumn("***").FormatCell(context.Background(), source, 1, 1)
+	require.True(t, errors.Is(err, errors.ErrUnsupported), "null cell unsupported")
+}
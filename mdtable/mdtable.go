@@ -0,0 +1,71 @@
+// Package mdtable writes views as GitHub flavored Markdown tables.
+package mdtable
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/domonda/go-retable"
+)
+
+var escaper = strings.NewReplacer(
+	"|", `\|`,
+	"\r\n", "<br>",
+	"\n", "<br>",
+)
+
+// Escape returns str escaped for a Markdown table cell
+// by escaping pipes and replacing line breaks with <br>.
+func Escape(str string) string {
+	return escaper.Replace(str)
+}
+
+// WriteView writes the view as GitHub flavored Markdown table
+// with the view columns as header row.
+func WriteView(ctx context.Context, dest io.Writer, view retable.View) error {
+	return WriteViewSplit(ctx, dest, view, 0)
+}
+
+// WriteViewSplit writes the view as multiple GitHub flavored
+// Markdown tables with at most maxRows rows each,
+// separated by an empty line.
+// Every table has the view columns as header row.
+// Values of maxRows <= 0 write a single table.
+func WriteViewSplit(ctx context.Context, dest io.Writer, view retable.View, maxRows int) error {
+	rows, err := retable.FormatViewAsStringsWithOptions(ctx, view, new(retable.FormatOptions).WithHeaderRow(true))
+	if err != nil {
+		return err
+	}
+	header, rows := rows[0], rows[1:]
+	if maxRows <= 0 {
+		maxRows = max(len(rows), 1)
+	}
+	w := bufio.NewWriter(dest)
+	for first := 0; first == 0 || first < len(rows); first += maxRows {
+		if first > 0 {
+			w.WriteString("\n")
+		}
+		writeRow(w, header)
+		w.WriteString("|")
+		for range header {
+			w.WriteString(" --- |")
+		}
+		w.WriteString("\n")
+		for _, row := range rows[first:min(first+maxRows, len(rows))] {
+			writeRow(w, row)
+		}
+	}
+	return w.Flush()
+}
+
+func writeRow(w *bufio.Writer, row []string) {
+	w.WriteString("|")
+	for _, cell := range row {
+		w.WriteString(" ")
+		w.WriteString(Escape(cell))
+		w.WriteString(" |")
+	}
+	w.WriteString("\n")
+}
@@ -0,0 +1,91 @@
+package mdtable
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-retable"
+)
+
+func TestWriteView(t *testing.T) {
+	type Invoice struct {
+		Number string  `col:"Invoice Number"`
+		Amount float64 `col:"Amount,format=money:2"`
+		Notes  string  `col:"-"`
+	}
+	tests := []struct {
+		name string
+		view retable.View
+		want string
+	}{
+		{
+			name: "escaped cells",
+			view: &retable.StringsView{
+				Cols: []string{"A", "B"},
+				Rows: [][]string{{"x|y", "line1\nline2"}},
+			},
+			want: "| A | B |\n| --- | --- |\n| x\\|y | line1<br>line2 |\n",
+		},
+		{
+			name: "struct field mappings",
+			view: retable.DefaultStructFieldNaming.FieldMappingsView(Invoice{}),
+			want: "" +
+				"| Field | Tag | Column | Type | Format | Ignored |\n" +
+				"| --- | --- | --- | --- | --- | --- |\n" +
+				"| Number | Invoice Number | Invoice Number | string |  | false |\n" +
+				"| Amount | Amount,format=money:2 | Amount | float64 | money:2 | false |\n" +
+				"| Notes | - |  | string |  | true |\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := WriteView(context.Background(), &buf, tt.view)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestWriteViewSplit(t *testing.T) {
+	view := &retable.StringsView{
+		Cols: []string{"A"},
+		Rows: [][]string{{"1"}, {"2"}, {"3"}},
+	}
+	tests := []struct {
+		name    string
+		view    retable.View
+		maxRows int
+		want    string
+	}{
+		{
+			name:    "split",
+			view:    view,
+			maxRows: 2,
+			want:    "| A |\n| --- |\n| 1 |\n| 2 |\n\n| A |\n| --- |\n| 3 |\n",
+		},
+		{
+			name:    "no split",
+			view:    view,
+			maxRows: 0,
+			want:    "| A |\n| --- |\n| 1 |\n| 2 |\n| 3 |\n",
+		},
+		{
+			name:    "no rows",
+			view:    &retable.StringsView{Cols: []string{"A"}},
+			maxRows: 2,
+			want:    "| A |\n| --- |\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := WriteViewSplit(context.Background(), &buf, tt.view, tt.maxRows)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, buf.String())
+		})
+	}
+}
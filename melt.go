@@ -0,0 +1,85 @@
+package retable
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Melt returns a View that converts the wide table of view
+// to the long format, also known as unpivot.
+//
+// Every row of view results in one row per column
+// that is not in idCols with the cells of the idCols,
+// the column title in the column varName,
+// and the cell of the column in the column valueName.
+// Empty varName and valueName default to "variable" and "value".
+//
+// An error is returned if an idCols column does not exist in view
+// or if varName or valueName are not unique.
+func Melt(view View, idCols []string, varName, valueName string) (ReflectCellView, error) {
+	if varName == "" {
+		varName = "variable"
+	}
+	if valueName == "" {
+		valueName = "value"
+	}
+	columns := view.Columns()
+	m := &meltView{
+		source:  AsReflectCellView(view),
+		columns: append(slices.Clone(idCols), varName, valueName),
+		idCols:  make([]int, len(idCols)),
+	}
+	for i, idCol := range idCols {
+		m.idCols[i] = slices.Index(columns, idCol)
+		if m.idCols[i] < 0 {
+			return nil, fmt.Errorf("id column %q not found", idCol)
+		}
+	}
+	for col := range columns {
+		if !slices.Contains(m.idCols, col) {
+			m.valueCols = append(m.valueCols, col)
+		}
+	}
+	if varName == valueName || slices.Contains(idCols, varName) || slices.Contains(idCols, valueName) {
+		return nil, fmt.Errorf("variable column %q and value column %q must be unique", varName, valueName)
+	}
+	return m, nil
+}
+
+type meltView struct {
+	source    ReflectCellView
+	columns   []string
+	idCols    []int
+	valueCols []int
+}
+
+func (m *meltView) Title() string     { return m.source.Title() }
+func (m *meltView) Columns() []string { return m.columns }
+
+func (m *meltView) NumRows() int {
+	return m.source.NumRows() * len(m.valueCols)
+}
+
+func (m *meltView) Cell(row, col int) any {
+	v := m.ReflectCell(row, col)
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func (m *meltView) ReflectCell(row, col int) reflect.Value {
+	if row < 0 || col < 0 || row >= m.NumRows() || col >= len(m.columns) {
+		return reflect.Value{}
+	}
+	sourceRow, valueCol := row/len(m.valueCols), m.valueCols[row%len(m.valueCols)]
+	switch {
+	case col < len(m.idCols):
+		return m.source.ReflectCell(sourceRow, m.idCols[col])
+	case col == len(m.idCols):
+		return reflect.ValueOf(m.source.Columns()[valueCol])
+	default:
+		return m.source.ReflectCell(sourceRow, valueCol)
+	}
+}
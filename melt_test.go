@@ -0,0 +1,38 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMelt(t *testing.T) {
+	wide := &AnyValuesView{
+		Tit:  "Sales",
+		Cols: []string{"Region", "Q1", "Q2"},
+		Rows: [][]any{{"North", 10, 20}, {"South", 30, nil}},
+	}
+
+	long, err := Melt(wide, []string{"Region"}, "Quarter", "")
+	require.NoError(t, err)
+	require.Equal(t, "Sales", long.Title())
+	require.Equal(t, []string{"Region", "Quarter", "value"}, long.Columns())
+	require.Equal(t, [][]any{
+		{"North", "Q1", 10},
+		{"North", "Q2", 20},
+		{"South", "Q1", 30},
+		{"South", "Q2", nil},
+	}, viewRows(long))
+	require.Nil(t, long.Cell(4, 0))
+
+	allIDs, err := Melt(wide, []string{"Region", "Q1", "Q2"}, "", "")
+	require.NoError(t, err)
+	require.Zero(t, allIDs.NumRows())
+
+	_, err = Melt(wide, []string{"Country"}, "", "")
+	require.Error(t, err)
+	_, err = Melt(wide, []string{"Region"}, "Region", "")
+	require.Error(t, err)
+	_, err = Melt(wide, nil, "x", "x")
+	require.Error(t, err)
+}
@@ -0,0 +1,118 @@
+package retable
+
+import "reflect"
+
+// MemStats is the result of EstimateMemory
+type MemStats struct {
+	// Rows is the number of rows of the view
+	Rows int
+	// Bytes is the estimated memory retained
+	// by the cell values of the view
+	Bytes int64
+	// SampledRows is the number of rows that were measured
+	// to estimate the memory
+	SampledRows int
+	// Exact is true if all rows were measured
+	Exact bool
+}
+
+// EstimateMemory estimates the memory retained by
+// the cell values of view by measuring up to sampleRows
+// evenly distributed rows and extrapolating to all rows,
+// for example to limit the number of concurrent exports.
+//
+// The size of a cell value is the size of its type
+// plus the memory referenced by strings, slices, maps,
+// pointers, and interfaces. Memory referenced multiple
+// times via the same pointer is only counted once per row.
+// Memory shared between rows and the overhead
+// of the view implementation itself are not included.
+func EstimateMemory(view View, sampleRows int) *MemStats {
+	numRows := view.NumRows()
+	sample := NewRowSampleView(view, sampleRows)
+	var bytes int64
+	for row := range sample.NumRows() {
+		visited := make(map[uintptr]bool)
+		for col := range sample.Columns() {
+			v := sample.ReflectCell(row, col)
+			if !v.IsValid() {
+				continue
+			}
+			bytes += int64(v.Type().Size()) + referencedMemory(v, visited)
+		}
+	}
+	stats := &MemStats{
+		Rows:        numRows,
+		Bytes:       bytes,
+		SampledRows: sample.NumRows(),
+		Exact:       sample.NumRows() == numRows,
+	}
+	if !stats.Exact && stats.SampledRows > 0 {
+		stats.Bytes = int64(float64(bytes) / float64(stats.SampledRows) * float64(numRows))
+	}
+	return stats
+}
+
+// referencedMemory returns the number of bytes referenced
+// by v not including the size of v itself.
+func referencedMemory(v reflect.Value, visited map[uintptr]bool) (size int64) {
+	switch v.Kind() {
+	case reflect.String:
+		return int64(v.Len())
+
+	case reflect.Pointer:
+		if v.IsNil() || visited[v.Pointer()] {
+			return 0
+		}
+		visited[v.Pointer()] = true
+		elem := v.Elem()
+		return int64(elem.Type().Size()) + referencedMemory(elem, visited)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		elem := v.Elem()
+		return int64(elem.Type().Size()) + referencedMemory(elem, visited)
+
+	case reflect.Slice:
+		if v.IsNil() || visited[v.Pointer()] {
+			return 0
+		}
+		visited[v.Pointer()] = true
+		size = int64(v.Cap()) * int64(v.Type().Elem().Size())
+		for i := range v.Len() {
+			size += referencedMemory(v.Index(i), visited)
+		}
+		return size
+
+	case reflect.Array:
+		for i := range v.Len() {
+			size += referencedMemory(v.Index(i), visited)
+		}
+		return size
+
+	case reflect.Struct:
+		if v.Type() == typeOfTime {
+			return 0 // Locations are shared
+		}
+		for i := range v.NumField() {
+			size += referencedMemory(v.Field(i), visited)
+		}
+		return size
+
+	case reflect.Map:
+		if v.IsNil() || visited[v.Pointer()] {
+			return 0
+		}
+		visited[v.Pointer()] = true
+		entrySize := int64(v.Type().Key().Size() + v.Type().Elem().Size())
+		size = int64(v.Len()) * entrySize
+		iter := v.MapRange()
+		for iter.Next() {
+			size += referencedMemory(iter.Key(), visited) + referencedMemory(iter.Value(), visited)
+		}
+		return size
+	}
+	return 0
+}
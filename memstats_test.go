@@ -0,0 +1,45 @@
+package retable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateMemory(t *testing.T) {
+	type Row struct {
+		Name  string
+		Tags  []string
+		Price *float64
+		Date  time.Time
+	}
+	price := 1.5
+	rows := make([]Row, 10)
+	for i := range rows {
+		rows[i] = Row{Name: "abcd", Tags: []string{"x", "yz"}, Price: &price, Date: time.Now()}
+	}
+	view, err := NoTagsStructRowsViewer().NewView("", rows)
+	require.NoError(t, err)
+
+	// Name: 16 + 4, Tags: 24 + 2*16 + 3, Price: 8 + 8, Date: 24
+	const rowBytes = 20 + 59 + 16 + 24
+
+	stats := EstimateMemory(view, 100)
+	require.Equal(t, &MemStats{Rows: 10, Bytes: 10 * rowBytes, SampledRows: 10, Exact: true}, stats)
+
+	stats = EstimateMemory(view, 5)
+	require.Equal(t, &MemStats{Rows: 10, Bytes: 10 * rowBytes, SampledRows: 5, Exact: false}, stats)
+
+	stats = EstimateMemory(NewStringsView("", nil, "A"), 100)
+	require.Equal(t, &MemStats{Rows: 0, Bytes: 0, SampledRows: 0, Exact: true}, stats)
+}
+
+func TestRowBufferPool(t *testing.T) {
+	buf := GetRowBuffer()
+	require.Equal(t, 0, buf.Len())
+	buf.WriteString("row")
+	PutRowBuffer(buf)
+	require.Equal(t, 0, GetRowBuffer().Len())
+	PutRowBuffer(nil)
+}
@@ -0,0 +1,59 @@
+package retable
+
+import "slices"
+
+// MergeViewsByColumnName returns a View that concatenates
+// the rows of views like an SQL UNION ALL,
+// aligning the columns by their titles instead
+// of their positions like ExtraRowView and ConcatView.
+//
+// The columns of the returned View are the distinct
+// columns of all views in the order of their first occurrence.
+// Cells of columns that are missing in a view are nil.
+// If a view has duplicate column titles,
+// then the first of them is used.
+// The title is taken from the first view.
+//
+// The number of rows of the views must not change
+// after the View was created.
+func MergeViewsByColumnName(views ...View) ReflectCellView {
+	var columns []string
+	for _, view := range views {
+		for _, column := range view.Columns() {
+			if !slices.Contains(columns, column) {
+				columns = append(columns, column)
+			}
+		}
+	}
+	aligned := make([]View, len(views))
+	for i, view := range views {
+		viewColumns := view.Columns()
+		mapping := make([]int, len(columns))
+		for col, column := range columns {
+			mapping[col] = slices.Index(viewColumns, column) // -1 results in nil cells
+		}
+		aligned[i] = &FilteredView{Source: view, ColumnMapping: mapping}
+	}
+	return ViewWithColumns(NewConcatView(aligned...), columns)
+}
+
+// UnionViewsByColumnName returns the rows of MergeViewsByColumnName
+// without duplicates like an SQL UNION.
+// Rows are compared by their hash using HashRow
+// and the first of equal rows is kept.
+func UnionViewsByColumnName(views ...View) ReflectCellView {
+	merged := MergeViewsByColumnName(views...)
+	var (
+		rows = make([]int, 0, merged.NumRows())
+		seen = make(map[string]struct{}, merged.NumRows())
+	)
+	for row := range merged.NumRows() {
+		hash := string(HashRow(merged, row))
+		if _, ok := seen[hash]; ok {
+			continue
+		}
+		seen[hash] = struct{}{}
+		rows = append(rows, row)
+	}
+	return NewRowIndicesView(merged, rows)
+}
@@ -0,0 +1,44 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeViewsByColumnName(t *testing.T) {
+	a := &AnyValuesView{Tit: "A", Cols: []string{"ID", "Name"}, Rows: [][]any{{1, "a"}, {2, "b"}}}
+	b := &AnyValuesView{Tit: "B", Cols: []string{"Name", "Email", "ID"}, Rows: [][]any{{"a", "a@example.com", 1}, {"a", nil, 1}}}
+
+	merged := MergeViewsByColumnName(a, b)
+	require.Equal(t, "A", merged.Title())
+	require.Equal(t, []string{"ID", "Name", "Email"}, merged.Columns())
+	require.Equal(t, 4, merged.NumRows())
+	require.Equal(t, [][]any{
+		{1, "a", nil},
+		{2, "b", nil},
+		{1, "a", "a@example.com"},
+		{1, "a", nil},
+	}, viewRows(merged))
+
+	union := UnionViewsByColumnName(a, b)
+	require.Equal(t, [][]any{
+		{1, "a", nil},
+		{2, "b", nil},
+		{1, "a", "a@example.com"},
+	}, viewRows(union))
+
+	require.Empty(t, MergeViewsByColumnName().Columns())
+	require.Zero(t, MergeViewsByColumnName().NumRows())
+}
+
+func viewRows(view View) [][]any {
+	rows := make([][]any, view.NumRows())
+	for row := range rows {
+		rows[row] = make([]any, len(view.Columns()))
+		for col := range rows[row] {
+			rows[row][col] = view.Cell(row, col)
+		}
+	}
+	return rows
+}
@@ -0,0 +1,86 @@
+package retable
+
+import (
+	"maps"
+	"reflect"
+)
+
+// MetadataView is a View carrying arbitrary key/value metadata
+// like sheet names or export timestamps that can be used by writers.
+type MetadataView interface {
+	ReflectCellView
+
+	// Metadata returns the key/value metadata of the View.
+	// The returned map must not be modified.
+	Metadata() map[string]any
+}
+
+// Metadata keys of string values used by the writers of this module.
+const (
+	// MetadataSheetName is used as sheet name
+	// by spreadsheet writers instead of the view title.
+	MetadataSheetName = "sheetName"
+
+	// MetadataCaption is used as table caption
+	// by HTML writers instead of the view title.
+	MetadataCaption = "caption"
+)
+
+// ViewWithMetadata returns a MetadataView that carries the passed metadata
+// and delegates all View methods to the source View.
+//
+// If the source is already a MetadataView, then the passed
+// metadata is merged into a copy of the source's metadata
+// with the passed metadata taking precedence.
+func ViewWithMetadata(source View, metadata map[string]any) MetadataView {
+	if m, ok := source.(MetadataView); ok {
+		merged := maps.Clone(m.Metadata())
+		if merged == nil {
+			merged = make(map[string]any, len(metadata))
+		}
+		maps.Copy(merged, metadata)
+		metadata = merged
+	}
+	return &metadataView{source: AsReflectCellView(source), metadata: metadata}
+}
+
+// ViewMetadata returns the metadata value for key
+// if the view implements MetadataView and has a value for the key.
+func ViewMetadata(view View, key string) (value any, ok bool) {
+	m, isMetadataView := view.(MetadataView)
+	if !isMetadataView {
+		return nil, false
+	}
+	value, ok = m.Metadata()[key]
+	return value, ok
+}
+
+// ViewMetadataString returns the metadata value for key
+// if the view implements MetadataView and has a non empty string for the key.
+func ViewMetadataString(view View, key string) (value string, ok bool) {
+	v, _ := ViewMetadata(view, key)
+	value, ok = v.(string)
+	return value, ok && value != ""
+}
+
+type metadataView struct {
+	source   ReflectCellView
+	metadata map[string]any
+}
+
+func (v *metadataView) Title() string            { return v.source.Title() }
+func (v *metadataView) Columns() []string        { return v.source.Columns() }
+func (v *metadataView) NumRows() int             { return v.source.NumRows() }
+func (v *metadataView) Metadata() map[string]any { return v.metadata }
+
+func (v *metadataView) CellExists(row, col int) bool {
+	return CellExists(v.source, row, col)
+}
+
+func (v *metadataView) Cell(row, col int) any {
+	return v.source.Cell(row, col)
+}
+
+func (v *metadataView) ReflectCell(row, col int) reflect.Value {
+	return v.source.ReflectCell(row, col)
+}
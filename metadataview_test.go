@@ -0,0 +1,37 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewWithMetadata(t *testing.T) {
+	source := &AnyValuesView{Tit: "Title", Cols: []string{"A"}, Rows: [][]any{{1}}}
+
+	view := ViewWithMetadata(source, map[string]any{"a": 1, MetadataSheetName: "Sheet"})
+	require.Equal(t, "Title", view.Title())
+	require.Equal(t, []string{"A"}, view.Columns())
+	require.Equal(t, 1, view.NumRows())
+	require.Equal(t, 1, view.Cell(0, 0))
+	require.Equal(t, 1, view.ReflectCell(0, 0).Interface())
+
+	value, ok := ViewMetadata(view, "a")
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+	_, ok = ViewMetadata(view, "missing")
+	require.False(t, ok)
+	_, ok = ViewMetadata(source, "a")
+	require.False(t, ok, "not a MetadataView")
+
+	name, ok := ViewMetadataString(view, MetadataSheetName)
+	require.True(t, ok)
+	require.Equal(t, "Sheet", name)
+	_, ok = ViewMetadataString(view, "a")
+	require.False(t, ok, "not a string")
+
+	// Merged into a copy with the passed metadata taking precedence
+	merged := ViewWithMetadata(view, map[string]any{"a": 2, "b": 3})
+	require.Equal(t, map[string]any{"a": 2, "b": 3, MetadataSheetName: "Sheet"}, merged.Metadata())
+	require.Equal(t, map[string]any{"a": 1, MetadataSheetName: "Sheet"}, view.Metadata())
+}
@@ -0,0 +1,136 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// AmountFormatter is implemented by money amount types
+// like money.Amount of github.com/domonda/go-types
+// that format themselves with separators and a precision.
+type AmountFormatter interface {
+	Format(thousandsSep, decimalSep rune, precision int) string
+}
+
+// StringScanner is implemented by types that parse
+// and optionally validate themselves from a string
+// like money.Amount, money.Currency, bank.IBAN, and bank.BIC
+// of github.com/domonda/go-types.
+//
+// SmartAssign uses StringScanner to assign strings
+// to destinations implementing it with validation.
+type StringScanner interface {
+	ScanString(source string, validate bool) error
+}
+
+var (
+	typeOfAmountFormatter = reflect.TypeFor[AmountFormatter]()
+	typeOfStringScanner   = reflect.TypeFor[StringScanner]()
+)
+
+var _ CellFormatter = MoneyCellFormatter{}
+
+// MoneyCellFormatter formats money amounts with
+// thousands and decimal separators and a fixed precision.
+//
+// Supported cell values are:
+//   - types implementing AmountFormatter like go-types money.Amount
+//   - float types
+//   - structs with a Currency string field and an Amount field
+//     of one of the above like go-types money.CurrencyAmount
+//     that are formatted with the currency code before the amount
+//     like "EUR 1,234.50"
+//
+// If both separators are zero and the context has a locale,
+// then the separators of the locale are used, see WithLocale.
+//
+// Null and other cells return errors.ErrUnsupported.
+type MoneyCellFormatter struct {
+	// ThousandsSep is the thousands separator,
+	// zero for no separator
+	ThousandsSep rune
+	// DecimalSep is the decimal separator,
+	// zero defaults to '.'
+	DecimalSep rune
+	// Precision is the number of decimal places
+	Precision int
+}
+
+func (f MoneyCellFormatter) FormatCell(ctx context.Context, view View, row, col int) (str string, raw bool, err error) {
+	v := AsReflectCellView(view).ReflectCell(row, col)
+	if IsNullLike(v) {
+		return "", false, errors.ErrUnsupported
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if f.ThousandsSep == 0 && f.DecimalSep == 0 {
+		if thousandsSep, decimalSep, ok := contextNumberSeparators(ctx); ok {
+			f.ThousandsSep, f.DecimalSep = thousandsSep, decimalSep
+		}
+	}
+	if v.Kind() == reflect.Struct {
+		currency := v.FieldByName("Currency")
+		amount := v.FieldByName("Amount")
+		if currency.Kind() != reflect.String || !amount.IsValid() {
+			return "", false, errors.ErrUnsupported
+		}
+		str, ok := f.formatAmount(amount)
+		if !ok {
+			return "", false, errors.ErrUnsupported
+		}
+		if currency.String() == "" {
+			return str, false, nil
+		}
+		return currency.String() + " " + str, false, nil
+	}
+	str, ok := f.formatAmount(v)
+	if !ok {
+		return "", false, errors.ErrUnsupported
+	}
+	return str, false, nil
+}
+
+func (f MoneyCellFormatter) formatAmount(v reflect.Value) (string, bool) {
+	decimalSep := f.DecimalSep
+	if decimalSep == 0 {
+		decimalSep = '.'
+	}
+	if v.CanInterface() {
+		if amount, ok := v.Interface().(AmountFormatter); ok {
+			return amount.Format(f.ThousandsSep, decimalSep, f.Precision), true
+		}
+	}
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		str := FloatCellFormatter{Precision: f.Precision}.Format(v.Float(), v.Type().Bits())
+		return formatNumberSeparators(str, f.ThousandsSep, decimalSep), true
+	}
+	return "", false
+}
+
+// formatNumberSeparators replaces the decimal point of the
+// formatted number str with decimalSep and groups
+// the integer digits by thousandsSep if not zero.
+func formatNumberSeparators(str string, thousandsSep, decimalSep rune) string {
+	sign, str := "", str
+	if strings.HasPrefix(str, "-") {
+		sign, str = "-", str[1:]
+	}
+	intPart, fracPart, hasFrac := strings.Cut(str, ".")
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, digit := range intPart {
+		if thousandsSep != 0 && i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteRune(thousandsSep)
+		}
+		b.WriteRune(digit)
+	}
+	if hasFrac {
+		b.WriteRune(decimalSep)
+		b.WriteString(fracPart)
+	}
+	return b.String()
+}
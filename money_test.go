@@ -0,0 +1,95 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testAmount mimics money.Amount of github.com/domonda/go-types
+type testAmount float64
+
+func (a testAmount) Format(thousandsSep, decimalSep rune, precision int) string {
+	str := FloatCellFormatter{Precision: precision}.Format(float64(a), 64)
+	return formatNumberSeparators(str, thousandsSep, decimalSep)
+}
+
+// testCurrencyAmount mimics money.CurrencyAmount of github.com/domonda/go-types
+type testCurrencyAmount struct {
+	Currency string
+	Amount   testAmount
+}
+
+// testIBAN mimics bank.IBAN of github.com/domonda/go-types
+type testIBAN string
+
+func (iban *testIBAN) ScanString(source string, validate bool) error {
+	normalized := strings.ToUpper(strings.ReplaceAll(source, " ", ""))
+	if validate && len(normalized) < 15 {
+		return fmt.Errorf("invalid IBAN %q", source)
+	}
+	*iban = testIBAN(normalized)
+	return nil
+}
+
+func TestMoneyCellFormatter(t *testing.T) {
+	tests := []struct {
+		name      string
+		formatter MoneyCellFormatter
+		value     any
+		want      string
+		wantErr   error
+	}{
+		{name: "amount", formatter: MoneyCellFormatter{Precision: 2}, value: testAmount(1234.5), want: "1234.50"},
+		{name: "amount separators", formatter: MoneyCellFormatter{ThousandsSep: '.', DecimalSep: ',', Precision: 2}, value: testAmount(-1234567.891), want: "-1.234.567,89"},
+		{name: "float", formatter: MoneyCellFormatter{ThousandsSep: ',', Precision: 2}, value: 1234.5, want: "1,234.50"},
+		{name: "currency amount", formatter: MoneyCellFormatter{ThousandsSep: ',', Precision: 2}, value: testCurrencyAmount{"EUR", 1234.5}, want: "EUR 1,234.50"},
+		{name: "currency amount pointer", formatter: MoneyCellFormatter{Precision: 0}, value: &testCurrencyAmount{"JPY", 1234}, want: "JPY 1234"},
+		{name: "no currency", formatter: MoneyCellFormatter{Precision: 1}, value: testCurrencyAmount{"", 1}, want: "1.0"},
+		{name: "int", formatter: MoneyCellFormatter{}, value: 1, wantErr: errors.ErrUnsupported},
+		{name: "other struct", formatter: MoneyCellFormatter{}, value: struct{ A int }{}, wantErr: errors.ErrUnsupported},
+		{name: "nil", formatter: MoneyCellFormatter{}, value: nil, wantErr: errors.ErrUnsupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := &AnyValuesView{Cols: []string{"A"}, Rows: [][]any{{tt.value}}}
+			str, _, err := tt.formatter.FormatCell(context.Background(), view, 0, 0)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, str)
+		})
+	}
+}
+
+func TestReflectTypeCellFormatter_WithMoneyFormatter(t *testing.T) {
+	formatter := NewReflectTypeCellFormatter().WithMoneyFormatter(MoneyCellFormatter{ThousandsSep: ',', Precision: 2})
+	view := &AnyValuesView{Cols: []string{"A"}, Rows: [][]any{{testAmount(1000)}, {1000.0}}}
+	str, _, err := formatter.FormatCell(context.Background(), view, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, "1,000.00", str)
+	_, _, err = formatter.FormatCell(context.Background(), view, 1, 0)
+	require.ErrorIs(t, err, errors.ErrUnsupported, "plain floats are not detected as money")
+}
+
+func TestSmartAssign_StringScanner(t *testing.T) {
+	var iban testIBAN
+	err := SmartAssign(reflect.ValueOf(&iban).Elem(), reflect.ValueOf("at61 1904 3002 3457 3201"), nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, testIBAN("AT611904300234573201"), iban)
+
+	err = SmartAssign(reflect.ValueOf(&iban).Elem(), reflect.ValueOf("invalid"), nil, nil)
+	require.Error(t, err)
+
+	var ibanPtr *testIBAN
+	err = SmartAssign(reflect.ValueOf(&ibanPtr).Elem(), reflect.ValueOf("AT61 1904 3002 3457 3201"), nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, testIBAN("AT611904300234573201"), *ibanPtr)
+}
@@ -0,0 +1,62 @@
+package retable
+
+import (
+	"reflect"
+	"slices"
+	"sync/atomic"
+)
+
+// NullChecker is a function that implements a custom
+// null convention for IsNullLike, like zero UUIDs
+// or sentinel dates.
+//
+// If the checker applies to the type of val,
+// it returns ok true and if val is null as isNull.
+// Otherwise it returns ok false to let IsNullLike
+// continue with the next checker and the built-in rules.
+type NullChecker func(val reflect.Value) (isNull, ok bool)
+
+var nullCheckers atomic.Pointer[[]NullChecker]
+
+// RegisterNullChecker registers a NullChecker
+// that is used by IsNullLike and so by all formatting,
+// scanning, and validation code that checks for null values.
+//
+// Registered checkers are called in the order of registration
+// for valid values that are not nil
+// before the built-in IsNull, IsZero, and driver.Valuer checks,
+// so they can also declare values as not null.
+//
+// RegisterNullChecker is safe for concurrent use,
+// but should be called once during program initialization.
+func RegisterNullChecker(checker NullChecker) {
+	if checker == nil {
+		panic("RegisterNullChecker: nil checker")
+	}
+	for {
+		old := nullCheckers.Load()
+		var checkers []NullChecker
+		if old != nil {
+			checkers = slices.Clone(*old)
+		}
+		checkers = append(checkers, checker)
+		if nullCheckers.CompareAndSwap(old, &checkers) {
+			return
+		}
+	}
+}
+
+// checkRegisteredNull returns the result of the first
+// registered NullChecker that applies to val.
+func checkRegisteredNull(val reflect.Value) (isNull, ok bool) {
+	checkers := nullCheckers.Load()
+	if checkers == nil {
+		return false, false
+	}
+	for _, checker := range *checkers {
+		if isNull, ok = checker(val); ok {
+			return isNull, true
+		}
+	}
+	return false, false
+}
@@ -0,0 +1,44 @@
+package retable
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testUUID [16]byte
+
+func TestRegisterNullChecker(t *testing.T) {
+	prev := nullCheckers.Load()
+	t.Cleanup(func() { nullCheckers.Store(prev) })
+
+	sentinelDate := time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+	require.False(t, IsNullLike(reflect.ValueOf(testUUID{})))
+	require.False(t, IsNullLike(reflect.ValueOf(sentinelDate)))
+
+	RegisterNullChecker(func(val reflect.Value) (isNull, ok bool) {
+		if uuid, ok := val.Interface().(testUUID); ok {
+			return uuid == testUUID{}, true
+		}
+		return false, false
+	})
+	RegisterNullChecker(func(val reflect.Value) (isNull, ok bool) {
+		if t, ok := val.Interface().(time.Time); ok {
+			// Zero times are not null, but the sentinel date
+			return t.Equal(sentinelDate), true
+		}
+		return false, false
+	})
+
+	require.True(t, IsNullLike(reflect.ValueOf(testUUID{})))
+	require.False(t, IsNullLike(reflect.ValueOf(testUUID{1})))
+	require.True(t, IsNullLike(reflect.ValueOf(sentinelDate)))
+	require.False(t, IsNullLike(reflect.ValueOf(time.Time{})), "checker overrides IsZero")
+	require.True(t, IsNullLike(reflect.ValueOf((*testUUID)(nil))), "nil is always null")
+	require.False(t, IsNullLike(reflect.ValueOf(0)), "built-in rules")
+	require.True(t, IsNullLike(reflect.ValueOf(struct{}{})), "built-in rules")
+
+	require.Panics(t, func() { RegisterNullChecker(nil) })
+}
@@ -0,0 +1,151 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+)
+
+// ErrNullValue is returned for null-like cell values
+// by a NullPolicy with the action NullError.
+var ErrNullValue = errors.New("null value")
+
+// NullAction defines what a writer does with null-like cell values.
+type NullAction int
+
+const (
+	// NullRender renders the NullPolicy.Value for null values.
+	NullRender NullAction = iota
+	// NullOmit omits null values in formats where
+	// values are named like JSON objects or XML elements.
+	// Table formats like CSV, HTML, or Excel don't omit the column
+	// because that would shift the following cells of the row,
+	// they write an empty cell like RenderNullAs("").
+	NullOmit
+	// NullError returns an error wrapping ErrNullValue
+	// for null values.
+	NullError
+)
+
+func (a NullAction) String() string {
+	switch a {
+	case NullRender:
+		return "NullRender"
+	case NullOmit:
+		return "NullOmit"
+	case NullError:
+		return "NullError"
+	}
+	return fmt.Sprintf("NullAction(%d)", int(a))
+}
+
+var _ CellFormatter = NullPolicy{}
+
+// NullPolicy defines how writers handle null-like
+// cell values as determined by IsNullLike.
+//
+// The zero value renders null values as empty strings.
+//
+// NullPolicy implements CellFormatter returning
+// errors.ErrUnsupported for non null values so it can
+// be used in front of other formatters.
+type NullPolicy struct {
+	// Action for null values
+	Action NullAction
+	// Value is rendered for null values if Action is NullRender
+	Value string
+	// Raw indicates that Value is in the raw format
+	// of the table format and must not be escaped.
+	Raw bool
+}
+
+// RenderNullAs returns a NullPolicy that renders
+// null values as the passed non-raw string.
+func RenderNullAs(value string) NullPolicy {
+	return NullPolicy{Action: NullRender, Value: value}
+}
+
+// RenderNullAsRaw returns a NullPolicy that renders
+// null values as the passed raw string.
+func RenderNullAsRaw(value string) NullPolicy {
+	return NullPolicy{Action: NullRender, Value: value, Raw: true}
+}
+
+// FormatCell implements CellFormatter.
+// It returns errors.ErrUnsupported if the cell value is not null-like.
+func (p NullPolicy) FormatCell(ctx context.Context, view View, row, col int) (str string, raw bool, err error) {
+	if !IsNullLike(AsReflectCellView(view).ReflectCell(row, col)) {
+		return "", false, errors.ErrUnsupported
+	}
+	return p.FormatNull(view, row, col)
+}
+
+// FormatNull returns the string for a null value at the
+// passed row and column of a View according to the policy.
+// It does not check if the cell value is actually null-like.
+func (p NullPolicy) FormatNull(view View, row, col int) (str string, raw bool, err error) {
+	switch p.Action {
+	case NullRender:
+		return p.Value, p.Raw, nil
+	case NullOmit:
+		return "", false, nil
+	case NullError:
+		var column string
+		if columns := view.Columns(); col >= 0 && col < len(columns) {
+			column = columns[col]
+		}
+		return "", false, fmt.Errorf("%w at row %d column %d %q", ErrNullValue, row, col, column)
+	}
+	return "", false, fmt.Errorf("invalid %s", p.Action)
+}
+
+// ColumnNullPolicies overrides the NullPolicy of a writer
+// for single columns selected by their index or title,
+// for example to render missing amounts as "0.00"
+// while missing names are rendered empty.
+// Column indices take precedence over titles.
+//
+// The zero value has no overrides.
+type ColumnNullPolicies struct {
+	Indices map[int]NullPolicy
+	Titles  map[string]NullPolicy
+}
+
+// Policy returns the NullPolicy for the column col of view
+// or defaultPolicy if there is no override for the column.
+func (c ColumnNullPolicies) Policy(view View, col int, defaultPolicy NullPolicy) NullPolicy {
+	if policy, ok := c.Indices[col]; ok {
+		return policy
+	}
+	if len(c.Titles) > 0 {
+		if columns := view.Columns(); col >= 0 && col < len(columns) {
+			if policy, ok := c.Titles[columns[col]]; ok {
+				return policy
+			}
+		}
+	}
+	return defaultPolicy
+}
+
+// WithIndex returns a copy of c with policy
+// for the column with the index col.
+func (c ColumnNullPolicies) WithIndex(col int, policy NullPolicy) ColumnNullPolicies {
+	c.Indices = maps.Clone(c.Indices)
+	if c.Indices == nil {
+		c.Indices = make(map[int]NullPolicy)
+	}
+	c.Indices[col] = policy
+	return c
+}
+
+// WithTitle returns a copy of c with policy
+// for the columns with the passed title.
+func (c ColumnNullPolicies) WithTitle(title string, policy NullPolicy) ColumnNullPolicies {
+	c.Titles = maps.Clone(c.Titles)
+	if c.Titles == nil {
+		c.Titles = make(map[string]NullPolicy)
+	}
+	c.Titles[title] = policy
+	return c
+}
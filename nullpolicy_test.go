@@ -0,0 +1,25 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnNullPolicies_Policy(t *testing.T) {
+	view := &StringsView{Cols: []string{"Name", "Amount", "Note"}}
+	defaultPolicy := RenderNullAs("")
+
+	var policies ColumnNullPolicies
+	require.Equal(t, defaultPolicy, policies.Policy(view, 1, defaultPolicy))
+
+	policies = policies.
+		WithTitle("Amount", RenderNullAs("0.00")).
+		WithTitle("Note", RenderNullAs("-"))
+	modified := policies.WithIndex(2, RenderNullAs("n/a"))
+	require.Equal(t, defaultPolicy, modified.Policy(view, 0, defaultPolicy))
+	require.Equal(t, RenderNullAs("0.00"), modified.Policy(view, 1, defaultPolicy))
+	require.Equal(t, RenderNullAs("n/a"), modified.Policy(view, 2, defaultPolicy), "index takes precedence")
+	require.Equal(t, RenderNullAs("-"), policies.Policy(view, 2, defaultPolicy), "copy not modified")
+	require.Equal(t, defaultPolicy, modified.Policy(view, 5, defaultPolicy))
+}
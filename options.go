@@ -2,6 +2,8 @@ package retable
 
 import "strings"
 
+// Option is a bit flag option for functions like FormatViewAsStrings.
+// See FormatOptions for a more complete set of options.
 type Option int
 
 const (
@@ -34,3 +36,92 @@ func HasOption(options []Option, option Option) bool {
 	}
 	return false
 }
+
+// FormatOptions configures how a View is formatted
+// as strings by FormatViewAsStringsWithOptions.
+//
+// The zero value formats all rows without a header row
+// using fmt.Sprint and empty strings for null-like values.
+type FormatOptions struct {
+	// HeaderRow adds the column titles as first row.
+	HeaderRow bool
+	// HeaderFormatter formats the cells of the header row
+	// which is a HeaderView created by NewHeaderViewFrom.
+	// If nil, then the Formatters are also used for the header row.
+	HeaderFormatter CellFormatter
+	// Formatters are tried in order until one returns
+	// no error or an error other than errors.ErrUnsupported.
+	// nil formatters are ignored.
+	Formatters []CellFormatter
+	// NilString is used for null-like values
+	// if no formatter supported the value.
+	// See IsNullLike.
+	NilString string
+	// MaxRows limits the number of formatted view rows
+	// not counting the header row if greater zero.
+	MaxRows int
+}
+
+// NewFormatOptions returns FormatOptions for the passed
+// formatter and bit flag options.
+func NewFormatOptions(formatter CellFormatter, options ...Option) *FormatOptions {
+	return &FormatOptions{
+		HeaderRow:  HasOption(options, OptionAddHeaderRow),
+		Formatters: []CellFormatter{formatter},
+	}
+}
+
+// WithHeaderRow returns a copy of the options with HeaderRow set.
+//
+// Valid to call with nil receiver.
+func (o *FormatOptions) WithHeaderRow(headerRow bool) *FormatOptions {
+	mod := o.cloneOrNew()
+	mod.HeaderRow = headerRow
+	return mod
+}
+
+// WithHeaderFormatter returns a copy of the options with HeaderFormatter set.
+//
+// Valid to call with nil receiver.
+func (o *FormatOptions) WithHeaderFormatter(formatter CellFormatter) *FormatOptions {
+	mod := o.cloneOrNew()
+	mod.HeaderFormatter = formatter
+	return mod
+}
+
+// WithFormatters returns a copy of the options
+// with the passed formatters appended to Formatters.
+//
+// Valid to call with nil receiver.
+func (o *FormatOptions) WithFormatters(formatters ...CellFormatter) *FormatOptions {
+	mod := o.cloneOrNew()
+	mod.Formatters = append(mod.Formatters, formatters...)
+	return mod
+}
+
+// WithNilString returns a copy of the options with NilString set.
+//
+// Valid to call with nil receiver.
+func (o *FormatOptions) WithNilString(nilString string) *FormatOptions {
+	mod := o.cloneOrNew()
+	mod.NilString = nilString
+	return mod
+}
+
+// WithMaxRows returns a copy of the options with MaxRows set.
+//
+// Valid to call with nil receiver.
+func (o *FormatOptions) WithMaxRows(maxRows int) *FormatOptions {
+	mod := o.cloneOrNew()
+	mod.MaxRows = maxRows
+	return mod
+}
+
+func (o *FormatOptions) cloneOrNew() *FormatOptions {
+	if o == nil {
+		return new(FormatOptions)
+	}
+	c := *o
+	c.Formatters = append([]CellFormatter(nil), o.Formatters...)
+	return &c
+}
@@ -2,6 +2,7 @@ package retable
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 	"time"
@@ -35,14 +36,38 @@ func NewStringParser() *StringParser {
 	return c
 }
 
+// ParseInt parses str as decimal integer
+// also accepting integral values in scientific notation
+// like "1E+5" as displayed by spreadsheet applications.
 func (p *StringParser) ParseInt(str string) (int64, error) {
-	return strconv.ParseInt(str, 10, 64)
+	i, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		if n, ok := parseScientificInt(str); ok && n.IsInt64() {
+			return n.Int64(), nil
+		}
+		return 0, err
+	}
+	return i, nil
 }
 
+// ParseUnt parses str as unsigned decimal integer
+// also accepting integral values in scientific notation
+// like "1E+5" as displayed by spreadsheet applications.
 func (p *StringParser) ParseUnt(str string) (uint64, error) {
-	return strconv.ParseUint(str, 10, 64)
+	u, err := strconv.ParseUint(str, 10, 64)
+	if err != nil {
+		if n, ok := parseScientificInt(str); ok && n.IsUint64() {
+			return n.Uint64(), nil
+		}
+		return 0, err
+	}
+	return u, nil
 }
 
+// ParseFloat parses str as float also accepting
+// scientific notation like "1.2e-3" or "1E+5"
+// and a single comma as decimal separator like "1,2E+05"
+// as displayed by spreadsheet applications in some locales.
 func (p *StringParser) ParseFloat(str string) (float64, error) {
 	f, err := strconv.ParseFloat(str, 64)
 	if err != nil {
@@ -132,3 +157,25 @@ const (
 	formatTimeString       = "2006-01-02 15:04:05.999999999 -0700 MST"
 	formatBrowserLocalTime = "2006-01-02T15:04"
 )
+
+// parseScientificInt returns the exact integer value
+// of str in scientific notation like "1.5E+3"
+// or false if str is not in scientific notation
+// or not an integral value.
+func parseScientificInt(str string) (*big.Int, bool) {
+	i := strings.IndexAny(str, "eE")
+	if i == -1 || !IsRawNumber(str) {
+		return nil, false
+	}
+	// Limit the exponent to the range of 64 bit integers
+	// to not compute huge powers of ten for exponents like "1E+999999999"
+	exp, err := strconv.Atoi(str[i+1:])
+	if err != nil || exp > 20 || exp < -len(str) {
+		return nil, false
+	}
+	r, ok := new(big.Rat).SetString(str)
+	if !ok || !r.IsInt() {
+		return nil, false
+	}
+	return r.Num(), true
+}
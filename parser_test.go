@@ -0,0 +1,75 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringParser_ParseFloat(t *testing.T) {
+	tests := []struct {
+		str     string
+		want    float64
+		wantErr bool
+	}{
+		{str: "1.5", want: 1.5},
+		{str: "1E+5", want: 1e5},
+		{str: "1.2e-3", want: 0.0012},
+		{str: "-1.23457E+11", want: -1.23457e11},
+		{str: "1,5", want: 1.5},
+		{str: "1,23E+05", want: 123000},
+		// Error cases
+		{str: "", wantErr: true},
+		{str: "1E", wantErr: true},
+		{str: "1,2,3E+05", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			got, err := NewStringParser().ParseFloat(tt.str)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStringParser_ParseInt(t *testing.T) {
+	tests := []struct {
+		str     string
+		want    int64
+		wantErr bool
+	}{
+		{str: "100000", want: 100000},
+		{str: "1E+5", want: 100000},
+		{str: "-1.5e3", want: -1500},
+		{str: "9.223372036854775807E+18", want: 9223372036854775807},
+		// Error cases
+		{str: "1.5", wantErr: true},
+		{str: "1.5E+0", wantErr: true},
+		{str: "1E+19", wantErr: true},
+		{str: "1E+1000000000", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			got, err := NewStringParser().ParseInt(tt.str)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStringParser_ParseUnt(t *testing.T) {
+	got, err := NewStringParser().ParseUnt("1.8446744073709551615E+19")
+	require.NoError(t, err)
+	require.Equal(t, uint64(18446744073709551615), got)
+
+	_, err = NewStringParser().ParseUnt("-1E+3")
+	require.Error(t, err)
+}
@@ -0,0 +1,59 @@
+// Package pdftable renders views as paginated PDF tables
+// with repeated header rows on every page.
+//
+// The PDF drawing is done by a pluggable Backend,
+// see the github.com/domonda/go-retable/pdftable/gofpdf
+// module for a backend using github.com/jung-kurt/gofpdf.
+package pdftable
+
+import "io"
+
+// Backend draws table cells on the pages of a PDF document.
+// All lengths are in the user units of the backend.
+type Backend interface {
+	// AddPage adds a new page to the document
+	// that becomes the page for DrawCell.
+	AddPage()
+	// PageSize returns the size of the pages.
+	PageSize() (width, height float64)
+	// TextWidth returns the width of text
+	// rendered with the font of style.
+	TextWidth(text string, style *CellStyle) float64
+	// DrawCell draws a cell with text and style
+	// at the position x, y of the current page.
+	DrawCell(x, y, width, height float64, text string, style *CellStyle)
+	// Output writes the PDF document to dest.
+	Output(dest io.Writer) error
+}
+
+// Align is the horizontal alignment of a cell text.
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignCenter
+	AlignRight
+)
+
+// Color is an RGB color.
+type Color struct {
+	R, G, B uint8
+}
+
+// CellStyle is the style of a table cell.
+type CellStyle struct {
+	// FontSize in points
+	FontSize float64
+	// Bold font
+	Bold bool
+	// Align of the text
+	Align Align
+	// TextColor of the text
+	TextColor Color
+	// FillColor of the cell background if Fill is true
+	FillColor Color
+	// Fill the cell background with FillColor
+	Fill bool
+	// Border around the cell
+	Border bool
+}
@@ -0,0 +1,87 @@
+// Package gofpdf implements a pdftable.Backend
+// using github.com/jung-kurt/gofpdf.
+package gofpdf
+
+import (
+	"io"
+
+	"github.com/jung-kurt/gofpdf"
+
+	"github.com/domonda/go-retable/pdftable"
+)
+
+var _ pdftable.Backend = new(Backend)
+
+// Backend implements pdftable.Backend with a gofpdf.Fpdf document
+// using the core Helvetica font with cp1252 encoding.
+type Backend struct {
+	pdf       *gofpdf.Fpdf
+	translate func(string) string
+}
+
+// New returns a Backend drawing to pdf.
+// Automatic page breaks of pdf are disabled
+// because pdftable.Writer adds pages itself.
+func New(pdf *gofpdf.Fpdf) *Backend {
+	pdf.SetAutoPageBreak(false, 0)
+	return &Backend{
+		pdf:       pdf,
+		translate: pdf.UnicodeTranslatorFromDescriptor(""),
+	}
+}
+
+// NewFunc returns a function creating Backends for new documents
+// with the orientation "P" or "L" and page size like "A4"
+// using millimeters as unit, to be passed to pdftable.NewWriter.
+func NewFunc(orientation, size string) func() pdftable.Backend {
+	return func() pdftable.Backend {
+		return New(gofpdf.New(orientation, "mm", size, ""))
+	}
+}
+
+// PDF returns the underlying gofpdf.Fpdf document.
+func (b *Backend) PDF() *gofpdf.Fpdf { return b.pdf }
+
+func (b *Backend) AddPage() {
+	b.pdf.AddPage()
+}
+
+func (b *Backend) PageSize() (width, height float64) {
+	return b.pdf.GetPageSize()
+}
+
+func (b *Backend) TextWidth(text string, style *pdftable.CellStyle) float64 {
+	b.setFont(style)
+	return b.pdf.GetStringWidth(b.translate(text))
+}
+
+func (b *Backend) DrawCell(x, y, width, height float64, text string, style *pdftable.CellStyle) {
+	b.setFont(style)
+	b.pdf.SetTextColor(int(style.TextColor.R), int(style.TextColor.G), int(style.TextColor.B))
+	b.pdf.SetFillColor(int(style.FillColor.R), int(style.FillColor.G), int(style.FillColor.B))
+	border := ""
+	if style.Border {
+		border = "1"
+	}
+	align := "L"
+	switch style.Align {
+	case pdftable.AlignCenter:
+		align = "C"
+	case pdftable.AlignRight:
+		align = "R"
+	}
+	b.pdf.SetXY(x, y)
+	b.pdf.CellFormat(width, height, b.translate(text), border, 0, align+"M", style.Fill, 0, "")
+}
+
+func (b *Backend) Output(dest io.Writer) error {
+	return b.pdf.Output(dest)
+}
+
+func (b *Backend) setFont(style *pdftable.CellStyle) {
+	fontStyle := ""
+	if style.Bold {
+		fontStyle = "B"
+	}
+	b.pdf.SetFont("Helvetica", fontStyle, style.FontSize)
+}
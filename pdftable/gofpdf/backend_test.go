@@ -0,0 +1,25 @@
+package gofpdf
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-retable"
+	"github.com/domonda/go-retable/pdftable"
+)
+
+func TestBackend(t *testing.T) {
+	rows := make([][]any, 100)
+	for i := range rows {
+		rows[i] = []any{i, "Grüße"}
+	}
+	view := &retable.AnyValuesView{Cols: []string{"Index", "Text"}, Rows: rows}
+
+	var dest bytes.Buffer
+	err := pdftable.NewWriter[any](NewFunc("P", "A4")).WriteView(context.Background(), &dest, view)
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(dest.Bytes(), []byte("%PDF-")))
+}
@@ -0,0 +1,414 @@
+package pdftable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/domonda/go-retable"
+)
+
+// CellStyler modifies the style of a cell before it is drawn.
+// The row is -1 for the header row.
+type CellStyler func(view retable.View, row, col int, style *CellStyle)
+
+// Writer renders views as PDF tables using a Backend.
+//
+// Rows that don't fit on the current page are drawn on a new page
+// that starts with the header row again.
+// Column widths are measured from the header and cell texts
+// and scaled to the available page width
+// if not set explicitly with WithColumnWidths.
+type Writer[T any] struct {
+	newBackend       func() Backend
+	viewer           retable.Viewer
+	columnFormatters map[int]retable.CellFormatter
+	typeFormatters   *retable.ReflectTypeCellFormatter
+	nullPolicy       retable.NullPolicy
+	columnNulls      retable.ColumnNullPolicies
+	errorPolicy      retable.ErrorPolicy
+	columnWidths     []float64
+	margin           float64
+	padding          float64
+	lineHeight       float64
+	headerStyle      CellStyle
+	cellStyle        CellStyle
+	styler           CellStyler
+	recoverPanics    bool
+	pointerHandling  retable.PointerHandling
+	zeroAsNull       retable.ZeroAsNull
+}
+
+// NewWriter returns a Writer that uses newBackend
+// to create a Backend for every written PDF document.
+func NewWriter[T any](newBackend func() Backend) *Writer[T] {
+	return &Writer[T]{
+		newBackend:       newBackend,
+		columnFormatters: make(map[int]retable.CellFormatter),
+		typeFormatters:   nil, // OK to use nil retable.TypeFormatters
+		nullPolicy:       retable.RenderNullAs(""),
+		columnNulls:      retable.ColumnNullPolicies{},
+		errorPolicy:      retable.ErrorPolicy{},
+		columnWidths:     nil,
+		margin:           10,
+		padding:          1,
+		lineHeight:       1.5,
+		headerStyle:      CellStyle{FontSize: 10, Bold: true, Fill: true, FillColor: Color{230, 230, 230}, Border: true},
+		cellStyle:        CellStyle{FontSize: 10, Border: true},
+		styler:           nil,
+		recoverPanics:    false,
+		pointerHandling:  retable.PointerKeep,
+		zeroAsNull:       retable.ZeroAsNull{},
+	}
+}
+
+func (w *Writer[T]) clone() *Writer[T] {
+	c := new(Writer[T])
+	*c = *w
+	return c
+}
+
+// Capabilities returns the capabilities of the PDF writer.
+func (w *Writer[T]) Capabilities() retable.WriterCapabilities {
+	return retable.WriterCapabilities{
+		Format: "pdf",
+		Styles: true,
+	}
+}
+
+// Write calls WriteView with the result of Viewer.NewView(table)
+// using the writer's viewer if not nil or else retable.DefaultViewer.
+func (w *Writer[T]) Write(ctx context.Context, dest io.Writer, table T) error {
+	viewer := w.viewer
+	if viewer == nil {
+		var err error
+		viewer, err = retable.SelectViewer(table)
+		if err != nil {
+			return err
+		}
+	}
+	return w.WriteWithViewer(ctx, dest, viewer, table)
+}
+
+// WriteWithViewer calls WriteView with the result of viewer.NewView(table).
+func (w *Writer[T]) WriteWithViewer(ctx context.Context, dest io.Writer, viewer retable.Viewer, table T) error {
+	view, err := viewer.NewView("", table)
+	if err != nil {
+		return err
+	}
+	return w.WriteView(ctx, dest, view)
+}
+
+// WriteView renders the view as PDF document to dest.
+func (w *Writer[T]) WriteView(ctx context.Context, dest io.Writer, view retable.View) error {
+	if w.newBackend == nil {
+		return errors.New("pdftable.Writer has no backend")
+	}
+	view = w.pointerHandling.View(view)
+	view = w.zeroAsNull.View(view)
+	backend := w.newBackend()
+	numCols := len(view.Columns())
+
+	// Format all cells first to measure the column widths
+	cells := make([][]string, view.NumRows())
+	for row := range cells {
+		cells[row] = make([]string, numCols)
+		for col := range cells[row] {
+			str, err := w.cellString(ctx, view, row, col)
+			if err != nil {
+				return err
+			}
+			cells[row][col] = str
+		}
+	}
+
+	pageWidth, pageHeight := backend.PageSize()
+	widths := w.measureColumnWidths(backend, view, cells, pageWidth-2*w.margin)
+
+	var (
+		y          float64
+		pageBottom = pageHeight - w.margin
+	)
+	drawRow := func(row int, texts []string, baseStyle *CellStyle) {
+		x := w.margin
+		height := w.rowHeight(baseStyle)
+		for col, text := range texts {
+			style := *baseStyle
+			if w.styler != nil {
+				w.styler(view, row, col, &style)
+			}
+			backend.DrawCell(x, y, widths[col], height, text, &style)
+			x += widths[col]
+		}
+		y += height
+	}
+	newPage := func() {
+		backend.AddPage()
+		y = w.margin
+		drawRow(-1, view.Columns(), &w.headerStyle)
+	}
+
+	newPage()
+	for row, texts := range cells {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if y+w.rowHeight(&w.cellStyle) > pageBottom {
+			newPage()
+		}
+		drawRow(row, texts, &w.cellStyle)
+	}
+	return backend.Output(dest)
+}
+
+// ptToMM converts font sizes in points to millimeters
+// which are the default user unit of PDF libraries.
+const ptToMM = 25.4 / 72
+
+// rowHeight returns the height of a row with the font size of style.
+func (w *Writer[T]) rowHeight(style *CellStyle) float64 {
+	return style.FontSize * ptToMM * w.lineHeight
+}
+
+// measureColumnWidths returns the configured column widths
+// or measures the widest text of every column
+// and scales the widths to fit into maxWidth.
+func (w *Writer[T]) measureColumnWidths(backend Backend, view retable.View, cells [][]string, maxWidth float64) []float64 {
+	numCols := len(view.Columns())
+	if len(w.columnWidths) == numCols {
+		return w.columnWidths
+	}
+	widths := make([]float64, numCols)
+	for col, title := range view.Columns() {
+		widths[col] = backend.TextWidth(title, &w.headerStyle)
+		for row := range cells {
+			widths[col] = max(widths[col], backend.TextWidth(cells[row][col], &w.cellStyle))
+		}
+	}
+	var total float64
+	for col := range widths {
+		widths[col] += 2 * w.padding
+		total += widths[col]
+	}
+	if total > maxWidth {
+		for col := range widths {
+			widths[col] *= maxWidth / total
+		}
+	}
+	return widths
+}
+
+func (w *Writer[T]) cellString(ctx context.Context, view retable.View, row, col int) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	if !retable.CellExists(view, row, col) {
+		return "", nil
+	}
+	str, err := w.formatCell(ctx, view, row, col)
+	if err != nil {
+		str, _, err = w.errorPolicy.FormatError(view, row, col, err)
+	}
+	return str, err
+}
+
+func (w *Writer[T]) formatCell(ctx context.Context, view retable.View, row, col int) (string, error) {
+	colFormatter, ok := w.columnFormatters[col]
+	if !ok {
+		colFormatter = retable.ViewColumnFormatter(view, col)
+		ok = colFormatter != nil
+	}
+	if ok && w.recoverPanics {
+		colFormatter = retable.SafeCellFormatter(colFormatter)
+	}
+	if ok {
+		str, _, err := colFormatter.FormatCell(ctx, view, row, col)
+		if err == nil {
+			return str, nil
+		}
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return "", err
+		}
+		// Continue after errors.ErrUnsupported
+	}
+
+	var typeFormatter retable.CellFormatter = w.typeFormatters
+	if w.recoverPanics {
+		typeFormatter = retable.SafeCellFormatter(typeFormatter)
+	}
+	str, _, err := typeFormatter.FormatCell(ctx, view, row, col)
+	if err == nil {
+		return str, nil
+	}
+	if !errors.Is(err, errors.ErrUnsupported) {
+		return "", err
+	}
+	// Continue after errors.ErrUnsupported
+
+	// Use fallback methods for formatting
+	v := retable.AsReflectCellView(view).ReflectCell(row, col)
+	if retable.IsNullLike(v) {
+		str, _, err := w.columnNulls.Policy(view, col, w.nullPolicy).FormatNull(view, row, col)
+		return str, err
+	}
+	if cellErr := retable.CellErrorValue(view, row, col); cellErr != nil {
+		str, _, err := w.errorPolicy.FormatError(view, row, col, cellErr)
+		return str, err
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	return fmt.Sprint(v.Interface()), nil
+}
+
+func (w *Writer[T]) WithTableViewer(viewer retable.Viewer) *Writer[T] {
+	mod := w.clone()
+	mod.viewer = viewer
+	return mod
+}
+
+// WithColumnFormatter returns a new writer with the passed formatter
+// for the column at columnIndex.
+// If nil is passed as formatter, then a previous set formatter
+// for the column is removed.
+func (w *Writer[T]) WithColumnFormatter(columnIndex int, formatter retable.CellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.columnFormatters = make(map[int]retable.CellFormatter)
+	for key, val := range w.columnFormatters {
+		mod.columnFormatters[key] = val
+	}
+	if formatter == nil {
+		delete(mod.columnFormatters, columnIndex)
+	} else {
+		mod.columnFormatters[columnIndex] = formatter
+	}
+	return mod
+}
+
+// WithRecoverPanics returns a new writer that recovers
+// panics of column and type formatters and returns them
+// as errors with the coordinates of the formatted cell,
+// see retable.SafeCellFormatter.
+func (w *Writer[T]) WithRecoverPanics(recoverPanics bool) *Writer[T] {
+	mod := w.clone()
+	mod.recoverPanics = recoverPanics
+	return mod
+}
+
+// WithPointerHandling returns a new writer that formats
+// cells with pointer values according to handling,
+// see retable.PointerHandling.
+func (w *Writer[T]) WithPointerHandling(handling retable.PointerHandling) *Writer[T] {
+	mod := w.clone()
+	mod.pointerHandling = handling
+	return mod
+}
+
+// WithZeroAsNull returns a new writer that handles
+// the zero values of the cells selected by zeroAsNull
+// like null values using the null policy,
+// for example to render 0 amounts as empty cells.
+func (w *Writer[T]) WithZeroAsNull(zeroAsNull retable.ZeroAsNull) *Writer[T] {
+	mod := w.clone()
+	mod.zeroAsNull = zeroAsNull
+	return mod
+}
+
+func (w *Writer[T]) WithTypeFormatters(formatter *retable.ReflectTypeCellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.typeFormatters = formatter
+	return mod
+}
+
+func (w *Writer[T]) WithTypeFormatter(typ reflect.Type, fmt retable.CellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.typeFormatters = w.typeFormatters.WithTypeFormatter(typ, fmt)
+	return mod
+}
+
+func (w *Writer[T]) WithNullPolicy(policy retable.NullPolicy) *Writer[T] {
+	mod := w.clone()
+	mod.nullPolicy = policy
+	return mod
+}
+
+// WithColumnNilValue returns a new writer that renders
+// null-like values of the column with columnIndex
+// as the passed nilValue instead of using the null policy of the writer.
+func (w *Writer[T]) WithColumnNilValue(columnIndex int, nilValue string) *Writer[T] {
+	mod := w.clone()
+	mod.columnNulls = w.columnNulls.WithIndex(columnIndex, retable.RenderNullAs(nilValue))
+	return mod
+}
+
+// WithColumnTitleNilValue returns a new writer that renders
+// null-like values of the columns with the passed title
+// as nilValue instead of using the null policy of the writer.
+func (w *Writer[T]) WithColumnTitleNilValue(title string, nilValue string) *Writer[T] {
+	mod := w.clone()
+	mod.columnNulls = w.columnNulls.WithTitle(title, retable.RenderNullAs(nilValue))
+	return mod
+}
+
+// WithColumnNullPolicies returns a new writer that handles
+// null-like values of the columns selected by policies
+// with their own null policy.
+func (w *Writer[T]) WithColumnNullPolicies(policies retable.ColumnNullPolicies) *Writer[T] {
+	mod := w.clone()
+	mod.columnNulls = policies
+	return mod
+}
+
+// WithErrorPolicy returns a new writer that handles
+// cells with error values and cells whose formatting failed
+// with the passed policy.
+// The default policy aborts writing with a retable.CellError.
+func (w *Writer[T]) WithErrorPolicy(policy retable.ErrorPolicy) *Writer[T] {
+	mod := w.clone()
+	mod.errorPolicy = policy
+	return mod
+}
+
+// WithColumnWidths returns a new writer using the passed
+// column widths instead of measuring them.
+// The widths are only used for views with
+// the same number of columns.
+func (w *Writer[T]) WithColumnWidths(widths ...float64) *Writer[T] {
+	mod := w.clone()
+	mod.columnWidths = widths
+	return mod
+}
+
+// WithMargin returns a new writer using the passed
+// page margin, default is 10.
+func (w *Writer[T]) WithMargin(margin float64) *Writer[T] {
+	mod := w.clone()
+	mod.margin = margin
+	return mod
+}
+
+// WithHeaderStyle returns a new writer using
+// style for the header row cells.
+func (w *Writer[T]) WithHeaderStyle(style CellStyle) *Writer[T] {
+	mod := w.clone()
+	mod.headerStyle = style
+	return mod
+}
+
+// WithCellStyle returns a new writer using
+// style for the data row cells.
+func (w *Writer[T]) WithCellStyle(style CellStyle) *Writer[T] {
+	mod := w.clone()
+	mod.cellStyle = style
+	return mod
+}
+
+// WithCellStyler returns a new writer that calls styler
+// to modify the style of every cell before it is drawn.
+func (w *Writer[T]) WithCellStyler(styler CellStyler) *Writer[T] {
+	mod := w.clone()
+	mod.styler = styler
+	return mod
+}
@@ -0,0 +1,77 @@
+package pdftable
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-retable"
+)
+
+// recordingBackend records the drawn cells per page
+// and measures text with a fixed width per character.
+type recordingBackend struct {
+	pages [][]string
+}
+
+func (b *recordingBackend) AddPage() { b.pages = append(b.pages, nil) }
+
+func (b *recordingBackend) PageSize() (width, height float64) { return 100, 50 }
+
+func (b *recordingBackend) TextWidth(text string, style *CellStyle) float64 {
+	return float64(len(text)) * 2
+}
+
+func (b *recordingBackend) DrawCell(x, y, width, height float64, text string, style *CellStyle) {
+	page := len(b.pages) - 1
+	b.pages[page] = append(b.pages[page], fmt.Sprintf("%s bold=%t", text, style.Bold))
+}
+
+func (b *recordingBackend) Output(dest io.Writer) error {
+	for i, page := range b.pages {
+		fmt.Fprintf(dest, "page %d: %s\n", i+1, strings.Join(page, ", "))
+	}
+	return nil
+}
+
+func TestWriter_WriteView(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Cols: []string{"Name", "Total"},
+		Rows: [][]any{{"A", 1}, {"B", 2}, {"C", nil}, {"D", 4}, {"E", -5}},
+	}
+	writer := NewWriter[any](func() Backend { return new(recordingBackend) }).
+		WithNullPolicy(retable.RenderNullAs("n/a")).
+		WithCellStyler(func(view retable.View, row, col int, style *CellStyle) {
+			if row >= 0 && col == 1 && strings.HasPrefix(fmt.Sprint(view.Cell(row, col)), "-") {
+				style.Bold = true
+			}
+		})
+
+	var dest strings.Builder
+	err := writer.WriteView(context.Background(), &dest, view)
+	require.NoError(t, err)
+	// 50 high pages with 10 margins fit a header and 4 rows of 10pt
+	want := "" +
+		"page 1: Name bold=true, Total bold=true, A bold=false, 1 bold=false, B bold=false, 2 bold=false, C bold=false, n/a bold=false, D bold=false, 4 bold=false\n" +
+		"page 2: Name bold=true, Total bold=true, E bold=false, -5 bold=true\n"
+	require.Equal(t, want, dest.String())
+}
+
+func TestWriter_measureColumnWidths(t *testing.T) {
+	view := &retable.AnyValuesView{Cols: []string{"A", "Long title"}}
+	writer := NewWriter[any](nil)
+	cells := [][]string{{"wide value", "x"}}
+
+	widths := writer.measureColumnWidths(new(recordingBackend), view, cells, 100)
+	require.Equal(t, []float64{22, 22}, widths)
+
+	widths = writer.measureColumnWidths(new(recordingBackend), view, cells, 22)
+	require.Equal(t, []float64{11, 11}, widths)
+
+	widths = writer.WithColumnWidths(30, 70).measureColumnWidths(new(recordingBackend), view, cells, 22)
+	require.Equal(t, []float64{30, 70}, widths)
+}
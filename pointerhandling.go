@@ -0,0 +1,130 @@
+package retable
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// PointerHandling defines how writers format
+// cells with pointer values.
+type PointerHandling int
+
+const (
+	// PointerKeep formats pointer cells with the formatters
+	// for the pointer types. The ReflectTypeCellFormatter
+	// falls back to the formatters of the pointed to types
+	// and nil pointers are handled by the NullPolicy
+	// if no formatter supports them.
+	// This is the default.
+	PointerKeep PointerHandling = iota
+	// PointerDeref dereferences all pointer cells
+	// so they are formatted exactly like values
+	// of the pointed to types by all formatters.
+	// Nil pointers become nil cells handled by the NullPolicy.
+	PointerDeref
+	// PointerNilAsNull keeps non nil pointer cells
+	// but nil pointers become nil cells handled by the NullPolicy
+	// instead of being passed as typed nil to the formatters.
+	PointerNilAsNull
+)
+
+func (h PointerHandling) String() string {
+	switch h {
+	case PointerKeep:
+		return "PointerKeep"
+	case PointerDeref:
+		return "PointerDeref"
+	case PointerNilAsNull:
+		return "PointerNilAsNull"
+	}
+	return fmt.Sprintf("PointerHandling(%d)", int(h))
+}
+
+// View returns a View wrapping source that implements
+// the pointer handling for its cells.
+// For PointerKeep source is returned unchanged.
+//
+// The returned View forwards the ColumnFormatterView,
+// ColumnDescriptionView, ColumnVisibilityView,
+// and SparseCellView interfaces of source.
+// The row groups of a RowGroupsView source are kept.
+func (h PointerHandling) View(source View) View {
+	if h == PointerKeep {
+		return source
+	}
+	view := &pointerHandlingView{source: AsReflectCellView(source), handling: h}
+	if groups, ok := source.(RowGroupsView); ok {
+		return &rowGroupsPointerHandlingView{pointerHandlingView: view, groups: groups}
+	}
+	return view
+}
+
+var _ RowGroupsView = new(rowGroupsPointerHandlingView)
+
+type rowGroupsPointerHandlingView struct {
+	*pointerHandlingView
+	groups RowGroupsView
+}
+
+func (v *rowGroupsPointerHandlingView) RowGroup(row int) (RowGroup, bool) {
+	return v.groups.RowGroup(row)
+}
+
+var (
+	_ ColumnFormatterView   = new(pointerHandlingView)
+	_ ColumnDescriptionView = new(pointerHandlingView)
+	_ ColumnVisibilityView  = new(pointerHandlingView)
+	_ SparseCellView        = new(pointerHandlingView)
+)
+
+type pointerHandlingView struct {
+	source   ReflectCellView
+	handling PointerHandling
+}
+
+func (v *pointerHandlingView) Title() string     { return v.source.Title() }
+func (v *pointerHandlingView) Columns() []string { return v.source.Columns() }
+func (v *pointerHandlingView) NumRows() int      { return v.source.NumRows() }
+
+func (v *pointerHandlingView) Cell(row, col int) any {
+	val := v.ReflectCell(row, col)
+	if !val.IsValid() || !val.CanInterface() {
+		return nil
+	}
+	return val.Interface()
+}
+
+func (v *pointerHandlingView) ReflectCell(row, col int) reflect.Value {
+	val := v.source.ReflectCell(row, col)
+	if val.Kind() != reflect.Pointer {
+		return val
+	}
+	if val.IsNil() {
+		return reflect.Value{}
+	}
+	if v.handling == PointerDeref {
+		for val.Kind() == reflect.Pointer && !val.IsNil() {
+			val = val.Elem()
+		}
+		if val.Kind() == reflect.Pointer {
+			return reflect.Value{}
+		}
+	}
+	return val
+}
+
+func (v *pointerHandlingView) ColumnFormatter(col int) CellFormatter {
+	return ViewColumnFormatter(v.source, col)
+}
+
+func (v *pointerHandlingView) ColumnDescription(col int) string {
+	return ViewColumnDescription(v.source, col)
+}
+
+func (v *pointerHandlingView) ColumnHidden(col int) bool {
+	return ViewColumnHidden(v.source, col)
+}
+
+func (v *pointerHandlingView) CellExists(row, col int) bool {
+	return CellExists(v.source, row, col)
+}
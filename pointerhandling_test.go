@@ -0,0 +1,54 @@
+package retable
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointerHandling_View(t *testing.T) {
+	var (
+		i    = 1
+		ptr  = &i
+		nilP *int
+	)
+	source := &AnyValuesView{
+		Cols: []string{"Value", "Pointer", "PointerPointer", "Nil"},
+		Rows: [][]any{{i, ptr, &ptr, nilP}},
+	}
+	require.Same(t, source, PointerKeep.View(source))
+
+	deref := PointerDeref.View(source)
+	require.Equal(t, 1, deref.Cell(0, 0))
+	require.Equal(t, 1, deref.Cell(0, 1))
+	require.Equal(t, 1, deref.Cell(0, 2))
+	require.Nil(t, deref.Cell(0, 3))
+	require.False(t, AsReflectCellView(deref).ReflectCell(0, 3).IsValid())
+
+	nilAsNull := PointerNilAsNull.View(source)
+	require.Equal(t, 1, nilAsNull.Cell(0, 0))
+	require.Equal(t, ptr, nilAsNull.Cell(0, 1))
+	require.Nil(t, nilAsNull.Cell(0, 3))
+
+	grouped := PointerDeref.View(NewGroupedRowsView(source, 0))
+	require.Implements(t, (*RowGroupsView)(nil), grouped)
+	group, isHeaderRow := grouped.(RowGroupsView).RowGroup(0)
+	require.True(t, isHeaderRow)
+	require.Equal(t, 1, group.Key)
+}
+
+func TestDerefView(t *testing.T) {
+	var (
+		i    = 1
+		nilP *int
+	)
+	view := DerefView(&AnyValuesView{
+		Cols: []string{"Value", "Pointer", "Nil"},
+		Rows: [][]any{{i, &i, nilP}},
+	})
+	require.Equal(t, 1, view.Cell(0, 0))
+	require.Equal(t, 1, view.Cell(0, 1))
+	require.Equal(t, reflect.Int, view.ReflectCell(0, 1).Kind())
+	require.Nil(t, view.Cell(0, 2))
+}
@@ -0,0 +1,37 @@
+package retable
+
+import (
+	"bytes"
+	"sync"
+)
+
+// MaxPooledRowBufferSize is the maximum capacity of buffers
+// returned to the pool by PutRowBuffer.
+// Larger buffers are left to the garbage collector
+// so that a few huge rows don't retain memory.
+const MaxPooledRowBufferSize = 64 * 1024
+
+var rowBufferPool = sync.Pool{
+	New: func() any { return bytes.NewBuffer(make([]byte, 0, 1024)) },
+}
+
+// GetRowBuffer returns an empty buffer from a pool
+// for writers that render rows into a buffer before
+// writing them to the destination.
+// This reduces GC pressure during concurrent exports.
+// Return the buffer with PutRowBuffer
+// when it is no longer used.
+func GetRowBuffer() *bytes.Buffer {
+	return rowBufferPool.Get().(*bytes.Buffer)
+}
+
+// PutRowBuffer resets buf and returns it to the pool
+// used by GetRowBuffer.
+// The buffer must not be used after calling PutRowBuffer.
+func PutRowBuffer(buf *bytes.Buffer) {
+	if buf == nil || buf.Cap() > MaxPooledRowBufferSize {
+		return
+	}
+	buf.Reset()
+	rowBufferPool.Put(buf)
+}
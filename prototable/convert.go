@@ -0,0 +1,128 @@
+// Package prototable converts between retable.View
+// and the protobuf Table message defined in table.proto
+// so that services can pass tables with typed cells
+// over gRPC boundaries.
+package prototable
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative table.proto
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/domonda/go-retable"
+)
+
+var typeOfTime = reflect.TypeFor[time.Time]()
+
+// TableFromView returns a Table message with the cells of view.
+//
+// Cell values are converted by their reflect.Kind:
+//
+//   - signed integers as int_value
+//   - unsigned integers as uint_value
+//   - floats as float_value
+//   - bools as bool_value
+//   - time.Time as time_value
+//   - []byte as bytes_value
+//   - strings and all other types formatted with fmt.Sprint as string_value
+//
+// Null cells have no value.
+func TableFromView(ctx context.Context, view retable.View) (*Table, error) {
+	table := &Table{
+		Title:   view.Title(),
+		Columns: view.Columns(),
+		Rows:    make([]*Row, view.NumRows()),
+	}
+	reflectView := retable.AsReflectCellView(view)
+	for row := range table.Rows {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		cells := make([]*Cell, len(table.Columns))
+		for col := range cells {
+			cells[col] = CellFromValue(reflectView.ReflectCell(row, col))
+		}
+		table.Rows[row] = &Row{Cells: cells}
+	}
+	return table, nil
+}
+
+// CellFromValue returns a Cell for v
+// as documented by TableFromView.
+func CellFromValue(v reflect.Value) *Cell {
+	if retable.IsNullLike(v) {
+		return &Cell{}
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Type() == typeOfTime {
+		return &Cell{Value: &Cell_TimeValue{TimeValue: timestamppb.New(v.Interface().(time.Time))}}
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return &Cell{Value: &Cell_StringValue{StringValue: v.String()}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Cell{Value: &Cell_IntValue{IntValue: v.Int()}}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Cell{Value: &Cell_UintValue{UintValue: v.Uint()}}
+	case reflect.Float32, reflect.Float64:
+		return &Cell{Value: &Cell_FloatValue{FloatValue: v.Float()}}
+	case reflect.Bool:
+		return &Cell{Value: &Cell_BoolValue{BoolValue: v.Bool()}}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return &Cell{Value: &Cell_BytesValue{BytesValue: v.Bytes()}}
+		}
+	}
+	return &Cell{Value: &Cell_StringValue{StringValue: fmt.Sprint(v.Interface())}}
+}
+
+// Interface returns the Go value of the cell
+// or nil if the cell is null.
+// Times are returned as time.Time in UTC.
+func (x *Cell) Interface() any {
+	switch value := x.GetValue().(type) {
+	case *Cell_StringValue:
+		return value.StringValue
+	case *Cell_IntValue:
+		return value.IntValue
+	case *Cell_UintValue:
+		return value.UintValue
+	case *Cell_FloatValue:
+		return value.FloatValue
+	case *Cell_BoolValue:
+		return value.BoolValue
+	case *Cell_BytesValue:
+		return value.BytesValue
+	case *Cell_TimeValue:
+		return value.TimeValue.AsTime()
+	}
+	return nil
+}
+
+// ViewFromTable returns a view with the cells of table.
+// An error is returned if a row has more cells than the table columns.
+func ViewFromTable(table *Table) (*retable.AnyValuesView, error) {
+	view := &retable.AnyValuesView{
+		Tit:  table.GetTitle(),
+		Cols: table.GetColumns(),
+		Rows: make([][]any, len(table.GetRows())),
+	}
+	for row, r := range table.GetRows() {
+		if len(r.GetCells()) > len(view.Cols) {
+			return nil, fmt.Errorf("row %d has %d cells but table has %d columns", row, len(r.GetCells()), len(view.Cols))
+		}
+		values := make([]any, len(view.Cols))
+		for col, cell := range r.GetCells() {
+			values[col] = cell.Interface()
+		}
+		view.Rows[row] = values
+	}
+	return view, nil
+}
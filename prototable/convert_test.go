@@ -0,0 +1,42 @@
+package prototable
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/domonda/go-retable"
+)
+
+func TestTableFromView(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Tit:  "Table",
+		Cols: []string{"String", "Int", "Uint", "Float", "Bool", "Bytes", "Time"},
+		Rows: [][]any{
+			{"a", int64(-1), uint64(1), 1.5, true, []byte("x"), time.Date(2024, 10, 16, 12, 0, 0, 0, time.UTC)},
+			{nil, nil, nil, nil, nil, nil, nil},
+		},
+	}
+
+	table, err := TableFromView(context.Background(), view)
+	require.NoError(t, err)
+
+	// Round trip through the wire format
+	data, err := proto.Marshal(table)
+	require.NoError(t, err)
+	var unmarshalled Table
+	err = proto.Unmarshal(data, &unmarshalled)
+	require.NoError(t, err)
+
+	result, err := ViewFromTable(&unmarshalled)
+	require.NoError(t, err)
+	require.Equal(t, view, result)
+
+	// Non proto types are formatted as strings
+	table, err = TableFromView(context.Background(), &retable.AnyValuesView{Cols: []string{"A"}, Rows: [][]any{{[]int{1, 2}}}})
+	require.NoError(t, err)
+	require.Equal(t, "[1 2]", table.Rows[0].Cells[0].GetStringValue())
+}
@@ -0,0 +1,24 @@
+module github.com/domonda/go-retable/prototable
+
+go 1.23
+
+replace github.com/domonda/go-retable => ..
+
+require github.com/domonda/go-retable v0.0.0-00010101000000-000000000000 // replaced
+
+require (
+	github.com/stretchr/testify v1.9.0
+	google.golang.org/protobuf v1.35.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/otel v1.31.0 // indirect
+	go.opentelemetry.io/otel/metric v1.31.0 // indirect
+	go.opentelemetry.io/otel/trace v1.31.0 // indirect
+	golang.org/x/text v0.19.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
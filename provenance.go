@@ -0,0 +1,60 @@
+package retable
+
+import (
+	"strconv"
+	"time"
+)
+
+// Provenance is audit trail metadata that table writers
+// emit along with the data, like CSV comment lines,
+// an HTML table footer note, or Excel document properties.
+//
+// The same *Provenance can be configured once
+// and passed to the writers of all formats.
+// A nil *Provenance is valid and results in no metadata.
+type Provenance struct {
+	// GeneratedAt is the time the data was generated.
+	// If zero, then the current time at writing is used.
+	GeneratedAt time.Time
+	// Source describes where the data comes from
+	// and is omitted if empty.
+	Source string
+	// Extra fields are emitted after the standard fields.
+	Extra []ProvenanceField
+}
+
+// ProvenanceField is a named value of Provenance metadata.
+type ProvenanceField struct {
+	Name  string
+	Value string
+}
+
+// String returns "Name: Value".
+func (f ProvenanceField) String() string {
+	return f.Name + ": " + f.Value
+}
+
+// Fields returns the metadata fields for writing numRows rows:
+// "Generated At" in UTC formatted as RFC 3339,
+// "Source" if not empty, "Rows", and the Extra fields.
+// A nil Provenance returns nil.
+func (p *Provenance) Fields(numRows int) []ProvenanceField {
+	if p == nil {
+		return nil
+	}
+	fields := []ProvenanceField{{Name: "Generated At", Value: p.GeneratedAtOrNow().Format(time.RFC3339)}}
+	if p.Source != "" {
+		fields = append(fields, ProvenanceField{Name: "Source", Value: p.Source})
+	}
+	fields = append(fields, ProvenanceField{Name: "Rows", Value: strconv.Itoa(numRows)})
+	return append(fields, p.Extra...)
+}
+
+// GeneratedAtOrNow returns GeneratedAt in UTC
+// or the current time if GeneratedAt is zero.
+func (p *Provenance) GeneratedAtOrNow() time.Time {
+	if p.GeneratedAt.IsZero() {
+		return time.Now().UTC()
+	}
+	return p.GeneratedAt.UTC()
+}
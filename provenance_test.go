@@ -0,0 +1,30 @@
+package retable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvenance_Fields(t *testing.T) {
+	var nilProvenance *Provenance
+	require.Nil(t, nilProvenance.Fields(1))
+
+	p := &Provenance{
+		GeneratedAt: time.Date(2024, 5, 6, 9, 8, 7, 0, time.FixedZone("CEST", 7200)),
+		Source:      "invoices.db",
+		Extra:       []ProvenanceField{{Name: "User", Value: "admin"}},
+	}
+	require.Equal(t,
+		[]ProvenanceField{
+			{Name: "Generated At", Value: "2024-05-06T07:08:07Z"},
+			{Name: "Source", Value: "invoices.db"},
+			{Name: "Rows", Value: "3"},
+			{Name: "User", Value: "admin"},
+		},
+		p.Fields(3),
+	)
+	require.Equal(t, "Rows: 0", (&Provenance{}).Fields(0)[1].String())
+	require.WithinDuration(t, time.Now(), (&Provenance{}).GeneratedAtOrNow(), time.Minute)
+}
@@ -0,0 +1,194 @@
+package retable
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+)
+
+// RawNumber is a number cell value stored as its string
+// representation like json.Number so that big integers
+// and exact decimals survive round trips
+// without the precision loss of float64.
+//
+// Writers write RawNumber values unchanged
+// and SmartAssign converts them exactly
+// to big.Int, big.Float, and big.Rat values.
+// An empty RawNumber is null.
+type RawNumber string
+
+var rawNumberRegexp = regexp.MustCompile(`^[+-]?(?:\d+(?:\.\d*)?|\.\d+)(?:[eE][+-]?\d+)?$`)
+
+// ParseRawNumber returns str as RawNumber
+// or an error if str is not a decimal number
+// with optional sign, fraction, and exponent.
+func ParseRawNumber(str string) (RawNumber, error) {
+	if !IsRawNumber(str) {
+		return "", fmt.Errorf("invalid number %q", str)
+	}
+	return RawNumber(str), nil
+}
+
+// IsRawNumber returns true if str is a decimal number
+// with optional sign, fraction, and exponent.
+func IsRawNumber(str string) bool {
+	return rawNumberRegexp.MatchString(str)
+}
+
+// String returns the number as string.
+func (n RawNumber) String() string {
+	return string(n)
+}
+
+// IsNull implements the nullable interface
+// returning true for an empty RawNumber.
+func (n RawNumber) IsNull() bool {
+	return n == ""
+}
+
+// Int64 returns the number as int64.
+func (n RawNumber) Int64() (int64, error) {
+	return strconv.ParseInt(string(n), 10, 64)
+}
+
+// Float64 returns the number as float64
+// which might lose precision.
+func (n RawNumber) Float64() (float64, error) {
+	return strconv.ParseFloat(string(n), 64)
+}
+
+// BigInt returns the number as big.Int
+// or false if it is not an integer.
+func (n RawNumber) BigInt() (*big.Int, bool) {
+	return new(big.Int).SetString(string(n), 10)
+}
+
+// Rat returns the number as exact big.Rat
+// or false if it is not a valid number.
+func (n RawNumber) Rat() (*big.Rat, bool) {
+	if !IsRawNumber(string(n)) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(string(n))
+}
+
+// MarshalJSON implements json.Marshaler
+// by returning the number unquoted
+// or null for an empty RawNumber.
+func (n RawNumber) MarshalJSON() ([]byte, error) {
+	if n == "" {
+		return []byte("null"), nil
+	}
+	if !IsRawNumber(string(n)) {
+		return nil, fmt.Errorf("invalid number %q", string(n))
+	}
+	return []byte(n), nil
+}
+
+var (
+	typeOfString    = reflect.TypeFor[string]()
+	typeOfRawNumber = reflect.TypeFor[RawNumber]()
+	typeOfBigInt    = reflect.TypeFor[big.Int]()
+	typeOfBigFloat  = reflect.TypeFor[big.Float]()
+	typeOfBigRat    = reflect.TypeFor[big.Rat]()
+)
+
+// assignRawNumber assigns n exactly to dst
+// if dst is a big.Int, big.Float, or big.Rat
+// or a pointer to one of them.
+func assignRawNumber(dst reflect.Value, n RawNumber) (assigned bool, err error) {
+	dstType := dst.Type()
+	if dstType.Kind() == reflect.Pointer {
+		switch dstType.Elem() {
+		case typeOfBigInt, typeOfBigFloat, typeOfBigRat:
+			newDst := reflect.New(dstType.Elem())
+			if _, err := assignRawNumber(newDst.Elem(), n); err != nil {
+				return true, err
+			}
+			dst.Set(newDst)
+			return true, nil
+		}
+		return false, nil
+	}
+	var ok bool
+	switch dstType {
+	case typeOfBigInt:
+		_, ok = dst.Addr().Interface().(*big.Int).SetString(string(n), 10)
+	case typeOfBigFloat:
+		_, ok = dst.Addr().Interface().(*big.Float).SetString(string(n))
+	case typeOfBigRat:
+		var r *big.Rat
+		r, ok = n.Rat()
+		if ok {
+			dst.Set(reflect.ValueOf(r).Elem())
+		}
+	default:
+		return false, nil
+	}
+	if !ok {
+		return true, fmt.Errorf("can't assign number %q to %s", string(n), dstType)
+	}
+	return true, nil
+}
+
+// RawNumbersView returns a View that returns the string cells
+// of source that are numbers according to IsRawNumber as RawNumber,
+// for example for views of parsed CSV files
+// or Excel sheets read with raw cell strings.
+// All other cells are returned unchanged.
+//
+// The returned View forwards the ColumnFormatterView,
+// ColumnDescriptionView, ColumnVisibilityView,
+// and SparseCellView interfaces of source.
+func RawNumbersView(source View) ReflectCellView {
+	return &rawNumbersView{source: AsReflectCellView(source)}
+}
+
+var (
+	_ ColumnFormatterView   = new(rawNumbersView)
+	_ ColumnDescriptionView = new(rawNumbersView)
+	_ ColumnVisibilityView  = new(rawNumbersView)
+	_ SparseCellView        = new(rawNumbersView)
+)
+
+type rawNumbersView struct {
+	source ReflectCellView
+}
+
+func (v *rawNumbersView) Title() string     { return v.source.Title() }
+func (v *rawNumbersView) Columns() []string { return v.source.Columns() }
+func (v *rawNumbersView) NumRows() int      { return v.source.NumRows() }
+
+func (v *rawNumbersView) Cell(row, col int) any {
+	cell := v.source.Cell(row, col)
+	if str, ok := cell.(string); ok && IsRawNumber(str) {
+		return RawNumber(str)
+	}
+	return cell
+}
+
+func (v *rawNumbersView) ReflectCell(row, col int) reflect.Value {
+	val := v.source.ReflectCell(row, col)
+	if val.IsValid() && val.Type() == typeOfString && IsRawNumber(val.String()) {
+		return reflect.ValueOf(RawNumber(val.String()))
+	}
+	return val
+}
+
+func (v *rawNumbersView) ColumnFormatter(col int) CellFormatter {
+	return ViewColumnFormatter(v.source, col)
+}
+
+func (v *rawNumbersView) ColumnDescription(col int) string {
+	return ViewColumnDescription(v.source, col)
+}
+
+func (v *rawNumbersView) ColumnHidden(col int) bool {
+	return ViewColumnHidden(v.source, col)
+}
+
+func (v *rawNumbersView) CellExists(row, col int) bool {
+	return CellExists(v.source, row, col)
+}
@@ -0,0 +1,66 @@
+package retable
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRawNumber(t *testing.T) {
+	for _, str := range []string{"0", "-1", "+1.5", "123456789012345678901234567890", "0.1", ".5", "5.", "1e10", "1.5E-3"} {
+		require.True(t, IsRawNumber(str), str)
+	}
+	for _, str := range []string{"", "-", ".", "1,5", "1e", "0x1F", "NaN", "Inf", " 1", "1/2"} {
+		require.False(t, IsRawNumber(str), str)
+	}
+	_, err := ParseRawNumber("abc")
+	require.Error(t, err)
+}
+
+func TestRawNumber(t *testing.T) {
+	n := RawNumber("123456789012345678901234567890")
+	i, ok := n.BigInt()
+	require.True(t, ok)
+	require.Equal(t, "123456789012345678901234567890", i.String())
+	_, err := n.Int64()
+	require.Error(t, err)
+
+	r, ok := RawNumber("0.1").Rat()
+	require.True(t, ok)
+	require.Equal(t, "1/10", r.String())
+
+	data, err := json.Marshal(struct{ A, B RawNumber }{A: "0.10", B: ""})
+	require.NoError(t, err)
+	require.Equal(t, `{"A":0.10,"B":null}`, string(data))
+	require.True(t, IsNullLike(reflect.ValueOf(RawNumber(""))))
+}
+
+func TestSmartAssign_RawNumber(t *testing.T) {
+	src := reflect.ValueOf(RawNumber("123456789012345678901234567890.25"))
+
+	var rat big.Rat
+	require.NoError(t, SmartAssign(reflect.ValueOf(&rat).Elem(), src, nil, nil))
+	require.Equal(t, "123456789012345678901234567890.25", rat.FloatString(2))
+
+	var ratPtr *big.Rat
+	require.NoError(t, SmartAssign(reflect.ValueOf(&ratPtr).Elem(), src, nil, nil))
+	require.Equal(t, 0, ratPtr.Cmp(&rat))
+
+	var bigInt big.Int
+	require.Error(t, SmartAssign(reflect.ValueOf(&bigInt).Elem(), src, nil, nil), "not an integer")
+
+	var f float64
+	require.NoError(t, SmartAssign(reflect.ValueOf(&f).Elem(), reflect.ValueOf(RawNumber("1.5")), nil, nil))
+	require.Equal(t, 1.5, f)
+}
+
+func TestRawNumbersView(t *testing.T) {
+	view := RawNumbersView(NewStringsView("", [][]string{{"ID", "Amount", "Name"}, {"007", "0.10", "x1"}}))
+	require.Equal(t, RawNumber("007"), view.Cell(0, 0))
+	require.Equal(t, RawNumber("0.10"), view.Cell(0, 1))
+	require.Equal(t, "x1", view.Cell(0, 2))
+	require.Equal(t, typeOfRawNumber, view.ReflectCell(0, 1).Type())
+}
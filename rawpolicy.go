@@ -0,0 +1,78 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrRawRejected is returned for raw strings
+// of cell formatters by writers with the RawPolicy RawReject.
+var ErrRawRejected = errors.New("raw string rejected")
+
+// RawPolicy defines how writers handle strings that cell formatters
+// return as raw, meaning already in the target format
+// and not to be escaped.
+//
+// The meaning of raw depends on the format:
+//   - CSV: written as is without quoting, so delimiters,
+//     quotes, or newlines in the string corrupt the row structure
+//   - HTML: written as HTML without escaping
+//   - XML: written as XML without escaping
+//   - LaTeX: written as LaTeX without escaping
+//   - Excel: strings starting with "=" are written as formulas
+//
+// Formats without escaping like YAML or PDF ignore raw strings.
+type RawPolicy int
+
+const (
+	// RawTrust writes raw strings as they are.
+	// This is the default.
+	RawTrust RawPolicy = iota
+	// RawEscape escapes raw strings like all other strings.
+	RawEscape
+	// RawReject returns a CellError wrapping ErrRawRejected
+	// for raw strings.
+	RawReject
+)
+
+func (p RawPolicy) String() string {
+	switch p {
+	case RawTrust:
+		return "RawTrust"
+	case RawEscape:
+		return "RawEscape"
+	case RawReject:
+		return "RawReject"
+	}
+	return fmt.Sprintf("RawPolicy(%d)", int(p))
+}
+
+// WrapFormatter returns a CellFormatter that applies
+// the policy to the raw strings returned by f.
+// For RawTrust or a nil f, f is returned unchanged.
+func (p RawPolicy) WrapFormatter(f CellFormatter) CellFormatter {
+	if p == RawTrust || f == nil {
+		return f
+	}
+	return rawPolicyFormatter{formatter: f, policy: p}
+}
+
+type rawPolicyFormatter struct {
+	formatter CellFormatter
+	policy    RawPolicy
+}
+
+func (f rawPolicyFormatter) FormatCell(ctx context.Context, view View, row, col int) (str string, raw bool, err error) {
+	str, raw, err = f.formatter.FormatCell(ctx, view, row, col)
+	if err != nil || !raw {
+		return str, raw, err
+	}
+	switch f.policy {
+	case RawEscape:
+		return str, false, nil
+	case RawReject:
+		return "", false, NewCellError(view, row, col, ErrRawRejected)
+	}
+	return "", false, fmt.Errorf("invalid %s", f.policy)
+}
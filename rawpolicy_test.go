@@ -0,0 +1,32 @@
+package retable
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawPolicy_WrapFormatter(t *testing.T) {
+	view := &StringsView{Cols: []string{"A"}, Rows: [][]string{{"a,b"}}}
+	rawFormatter := SprintCellFormatter(true)
+	_, wrapped := RawTrust.WrapFormatter(rawFormatter).(rawPolicyFormatter)
+	require.False(t, wrapped, "RawTrust does not wrap")
+	require.Nil(t, RawReject.WrapFormatter(nil))
+
+	str, raw, err := RawEscape.WrapFormatter(rawFormatter).FormatCell(context.Background(), view, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, "a,b", str)
+	require.False(t, raw)
+
+	_, _, err = RawReject.WrapFormatter(rawFormatter).FormatCell(context.Background(), view, 0, 0)
+	require.ErrorIs(t, err, ErrRawRejected)
+	var cellErr CellError
+	require.ErrorAs(t, err, &cellErr)
+	require.Equal(t, "A", cellErr.Column)
+
+	str, raw, err = RawReject.WrapFormatter(SprintCellFormatter(false)).FormatCell(context.Background(), view, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, "a,b", str)
+	require.False(t, raw)
+}
@@ -3,6 +3,7 @@ package retable
 import (
 	"context"
 	"errors"
+	"maps"
 	"reflect"
 )
 
@@ -29,6 +30,10 @@ func (f *ReflectTypeCellFormatter) FormatCell(ctx context.Context, view View, ro
 		return "", false, err
 	}
 	cellVal := AsReflectCellView(view).ReflectCell(row, col)
+	if !cellVal.IsValid() {
+		// No type for nil cells
+		return "", false, errors.ErrUnsupported
+	}
 	cellType := cellVal.Type()
 	if typeFmt, ok := f.Types[cellType]; ok {
 		str, raw, err := typeFmt.FormatCell(ctx, view, row, col)
@@ -130,6 +135,53 @@ func (f *ReflectTypeCellFormatter) WithKindFormatter(kind reflect.Kind, fmt Cell
 	return mod
 }
 
+// WithFloatFormatter returns a copy of the formatter
+// that uses fmt for all FloatKinds.
+func (f *ReflectTypeCellFormatter) WithFloatFormatter(fmt CellFormatter) *ReflectTypeCellFormatter {
+	mod := f.cloneOrNew()
+	if mod.Kinds == nil {
+		mod.Kinds = make(map[reflect.Kind]CellFormatter)
+	}
+	for _, kind := range FloatKinds {
+		mod.Kinds[kind] = fmt
+	}
+	return mod
+}
+
+// WithMoneyFormatter returns a copy of the formatter
+// that uses fmt for all types implementing AmountFormatter
+// like go-types money.Amount.
+func (f *ReflectTypeCellFormatter) WithMoneyFormatter(fmt MoneyCellFormatter) *ReflectTypeCellFormatter {
+	return f.WithInterfaceTypeFormatter(typeOfAmountFormatter, fmt)
+}
+
+// WithFormatters returns a copy of the formatter
+// with all formatters of other added,
+// overriding existing formatters for the same types and kinds.
+// The Default formatter is only overridden if not nil in other.
+func (f *ReflectTypeCellFormatter) WithFormatters(other *ReflectTypeCellFormatter) *ReflectTypeCellFormatter {
+	mod := f.cloneOrNew()
+	if other == nil {
+		return mod
+	}
+	if len(other.Types) > 0 && mod.Types == nil {
+		mod.Types = make(map[reflect.Type]CellFormatter, len(other.Types))
+	}
+	maps.Copy(mod.Types, other.Types)
+	if len(other.InterfaceTypes) > 0 && mod.InterfaceTypes == nil {
+		mod.InterfaceTypes = make(map[reflect.Type]CellFormatter, len(other.InterfaceTypes))
+	}
+	maps.Copy(mod.InterfaceTypes, other.InterfaceTypes)
+	if len(other.Kinds) > 0 && mod.Kinds == nil {
+		mod.Kinds = make(map[reflect.Kind]CellFormatter, len(other.Kinds))
+	}
+	maps.Copy(mod.Kinds, other.Kinds)
+	if other.Default != nil {
+		mod.Default = other.Default
+	}
+	return mod
+}
+
 func (f *ReflectTypeCellFormatter) WithDefaultFormatter(fmt CellFormatter) *ReflectTypeCellFormatter {
 	mod := f.cloneOrNew()
 	mod.Default = fmt
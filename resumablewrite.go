@@ -0,0 +1,78 @@
+package retable
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// ErrPartialWrite is returned by ResumableWrite.Write
+// when writing failed after NextRow rows of the view
+// were completely written.
+type ErrPartialWrite struct {
+	// NextRow is the index of the first row
+	// of the view that was not completely written.
+	NextRow int
+	Err     error
+}
+
+func (e ErrPartialWrite) Error() string {
+	return fmt.Sprintf("write failed at row %d: %s", e.NextRow, e.Err)
+}
+
+func (e ErrPartialWrite) Unwrap() error {
+	return e.Err
+}
+
+// ResumableWrite writes a View in a way that can be
+// continued after an error, for example when streaming
+// exports to flaky destinations.
+//
+// Completely written rows are tracked with the OnRowEnd
+// callback of WriteHooks, so writers that call the hooks
+// after writing every row to the destination can be resumed.
+type ResumableWrite struct {
+	// View to write
+	View View
+	// NextRow is the index of the next row of View to write.
+	// Write continues with this row and updates it.
+	NextRow int
+}
+
+// NewResumableWrite returns a ResumableWrite for view
+// starting with the first row.
+func NewResumableWrite(view View) *ResumableWrite {
+	return &ResumableWrite{View: view}
+}
+
+// Write calls write with the rows of View starting at NextRow
+// as FilteredView and hooks that have to be passed to the writer,
+// for example:
+//
+//	err := resumable.Write(ctx, dest, func(ctx context.Context, dest io.Writer, view retable.View, hooks *retable.WriteHooks) error {
+//		return writer.WithHeaderRow(resumable.NextRow == 0).WithWriteHooks(hooks).WriteView(ctx, dest, view)
+//	})
+//
+// If write returns an error, then ErrPartialWrite is returned
+// and Write can be called again with a new destination
+// to continue with the first not completely written row.
+func (r *ResumableWrite) Write(ctx context.Context, dest io.Writer, write func(ctx context.Context, dest io.Writer, view View, hooks *WriteHooks) error) error {
+	offset := r.NextRow
+	hooks := &WriteHooks{
+		OnRowEnd: func(ctx context.Context, view View, row int, err error) {
+			if err == nil && offset+row == r.NextRow {
+				r.NextRow++
+			}
+		},
+	}
+	err := write(ctx, dest, &FilteredView{Source: r.View, RowOffset: offset}, hooks)
+	if err != nil {
+		return ErrPartialWrite{NextRow: r.NextRow, Err: err}
+	}
+	return nil
+}
+
+// Done returns if all rows of View have been written.
+func (r *ResumableWrite) Done() bool {
+	return r.NextRow >= r.View.NumRows()
+}
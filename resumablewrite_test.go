@@ -0,0 +1,62 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResumableWrite(t *testing.T) {
+	view := NewStringsView("", [][]string{{"A"}, {"1"}, {"2"}, {"3"}})
+	errFlaky := errors.New("flaky")
+	// write fails before writing the row failAt of the view
+	write := func(failAt int) func(ctx context.Context, dest io.Writer, view View, hooks *WriteHooks) error {
+		return func(ctx context.Context, dest io.Writer, view View, hooks *WriteHooks) error {
+			for row := range view.NumRows() {
+				hooks.RowStart(ctx, view, row)
+				if row == failAt {
+					hooks.RowEnd(ctx, view, row, errFlaky)
+					return errFlaky
+				}
+				_, err := fmt.Fprintln(dest, view.Cell(row, 0))
+				hooks.RowEnd(ctx, view, row, err)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	resumable := NewResumableWrite(view)
+	var dest strings.Builder
+	err := resumable.Write(context.Background(), &dest, write(2))
+	require.ErrorIs(t, err, errFlaky)
+	require.Equal(t, ErrPartialWrite{NextRow: 2, Err: errFlaky}, err)
+	require.Equal(t, 2, resumable.NextRow)
+	require.False(t, resumable.Done())
+
+	err = resumable.Write(context.Background(), &dest, write(-1))
+	require.NoError(t, err)
+	require.True(t, resumable.Done())
+	require.Equal(t, "1\n2\n3\n", dest.String())
+}
+
+func TestFilteredView_ColumnFormatter(t *testing.T) {
+	type Row struct {
+		Name   string
+		Margin float64 `col:"Margin,format=percent:1"`
+	}
+	source, err := DefaultStructRowsViewer().NewView("", []Row{{}})
+	require.NoError(t, err)
+	view, err := NewFilteredView(source, 0, 0, 1, 0)
+	require.NoError(t, err)
+	require.NotNil(t, ViewColumnFormatter(view, 0))
+	require.Nil(t, ViewColumnFormatter(view, 1))
+	require.Nil(t, ViewColumnFormatter(view, 2))
+}
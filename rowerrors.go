@@ -0,0 +1,144 @@
+package retable
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// RowErrors collects the errors of multiple rows
+// of operations like reading a view into structs,
+// validating rows, or writing views, so that callers
+// can show exactly which rows failed
+// instead of aborting on the first error.
+//
+// The zero value is ready to use.
+type RowErrors struct {
+	// Limit is the maximum number of rows with errors.
+	// Errors of further rows are not collected,
+	// see Add and LimitExceeded.
+	// Values <= 0 don't limit the number of rows.
+	Limit int
+
+	// Rows maps the zero based row index
+	// to the errors of the row.
+	Rows map[int][]error
+
+	limitExceeded bool
+}
+
+// NewRowErrors returns RowErrors with the passed Limit.
+func NewRowErrors(limit int) *RowErrors {
+	return &RowErrors{Limit: limit}
+}
+
+// Add adds err for row if err is not nil.
+// It returns false if the error was not added
+// because the Limit of rows with errors is exceeded,
+// so callers should abort the operation.
+func (e *RowErrors) Add(row int, err error) bool {
+	if err == nil {
+		return !e.limitExceeded
+	}
+	if _, ok := e.Rows[row]; !ok && e.Limit > 0 && len(e.Rows) >= e.Limit {
+		e.limitExceeded = true
+		return false
+	}
+	if e.Rows == nil {
+		e.Rows = make(map[int][]error)
+	}
+	e.Rows[row] = append(e.Rows[row], err)
+	return true
+}
+
+// Len returns the number of rows with errors.
+func (e *RowErrors) Len() int {
+	if e == nil {
+		return 0
+	}
+	return len(e.Rows)
+}
+
+// RowIndices returns the sorted indices of the rows with errors.
+func (e *RowErrors) RowIndices() []int {
+	if e == nil {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(e.Rows))
+}
+
+// Row returns the errors of row or nil.
+func (e *RowErrors) Row(row int) []error {
+	if e == nil {
+		return nil
+	}
+	return e.Rows[row]
+}
+
+// LimitExceeded returns if errors were not added
+// because of the Limit of rows with errors.
+func (e *RowErrors) LimitExceeded() bool {
+	return e != nil && e.limitExceeded
+}
+
+// Err returns e as error if it has errors or else nil.
+func (e *RowErrors) Err() error {
+	if e.Len() == 0 {
+		return nil
+	}
+	return e
+}
+
+// Error returns a summary with the errors of the first rows.
+func (e *RowErrors) Error() string {
+	const maxSummaryRows = 3
+
+	rows := e.RowIndices()
+	var b strings.Builder
+	fmt.Fprintf(&b, "errors in %d rows", len(rows))
+	if e.LimitExceeded() {
+		b.WriteString(" (limit exceeded)")
+	}
+	for i, row := range rows {
+		if i == maxSummaryRows {
+			fmt.Fprintf(&b, "; and %d more rows", len(rows)-maxSummaryRows)
+			break
+		}
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString("; ")
+		}
+		fmt.Fprintf(&b, "row %d: ", row)
+		for j, err := range e.Rows[row] {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(err.Error())
+		}
+	}
+	return b.String()
+}
+
+// Unwrap returns the errors of all rows
+// in row order for errors.Is and errors.As.
+func (e *RowErrors) Unwrap() []error {
+	var errs []error
+	for _, row := range e.RowIndices() {
+		errs = append(errs, e.Rows[row]...)
+	}
+	return errs
+}
+
+// OnCellError can be used as WriteHooks.OnCellError
+// to collect the cell errors of writers by row
+// and continue writing with empty cells
+// until the Limit of rows with errors is exceeded.
+func (e *RowErrors) OnCellError(ctx context.Context, view View, row, col int, err error) error {
+	if !e.Add(row, NewCellError(view, row, col, err)) {
+		return e
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowErrors(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+
+	var nilErrs *RowErrors
+	require.Equal(t, 0, nilErrs.Len())
+	require.NoError(t, nilErrs.Err())
+
+	errs := NewRowErrors(2)
+	require.NoError(t, errs.Err())
+	require.True(t, errs.Add(5, nil))
+	require.True(t, errs.Add(5, errA))
+	require.True(t, errs.Add(1, errB))
+	require.True(t, errs.Add(5, errB), "row already has errors")
+	require.False(t, errs.Add(7, errA), "limit exceeded")
+	require.False(t, errs.Add(8, nil), "limit exceeded without error")
+	require.True(t, errs.LimitExceeded())
+
+	require.Equal(t, 2, errs.Len())
+	require.Equal(t, []int{1, 5}, errs.RowIndices())
+	require.Equal(t, []error{errA, errB}, errs.Row(5))
+	require.Nil(t, errs.Row(7))
+	require.Equal(t, []error{errB, errA, errB}, errs.Unwrap())
+	require.ErrorIs(t, errs.Err(), errA)
+	require.Equal(t, "errors in 2 rows (limit exceeded): row 1: b; row 5: a, b", errs.Error())
+
+	many := new(RowErrors)
+	for row := range 5 {
+		many.Add(row, errA)
+	}
+	require.Equal(t, "errors in 5 rows: row 0: a; row 1: a; row 2: a; and 2 more rows", many.Error())
+}
+
+func TestRowErrors_OnCellError(t *testing.T) {
+	errs := NewRowErrors(1)
+	view := &StringsView{Cols: []string{"A"}, Rows: [][]string{{"1"}, {"2"}}}
+	require.NoError(t, errs.OnCellError(context.Background(), view, 0, 0, errors.New("invalid")))
+	require.Equal(t, []error{CellError{Row: 0, Col: 0, Column: "A", Err: errors.New("invalid")}}, errs.Row(0))
+	require.ErrorIs(t, errs.OnCellError(context.Background(), view, 1, 0, errors.New("invalid")), errs)
+}
+
+func TestViewToStructSliceCollectErrors(t *testing.T) {
+	type Row struct {
+		Name  string
+		Count int
+	}
+	view := NewStringsView("", [][]string{
+		{"Name", "Count"},
+		{"a", "1"},
+		{"b", "x"},
+		{"c", "3"},
+		{"d", "y"},
+	})
+
+	rows, err := ViewToStructSliceCollectErrors[Row](view, nil, nil, nil, nil, 0)
+	var rowErrs *RowErrors
+	require.ErrorAs(t, err, &rowErrs)
+	require.Equal(t, []int{1, 3}, rowErrs.RowIndices())
+	require.Len(t, rows, 4)
+	require.Equal(t, Row{Name: "c", Count: 3}, rows[2])
+
+	rows, err = ViewToStructSliceCollectErrors[Row](view, nil, nil, nil, nil, 1)
+	require.ErrorAs(t, err, &rowErrs)
+	require.True(t, rowErrs.LimitExceeded())
+	require.Len(t, rows, 3)
+
+	ptrRows, err := ViewToStructSliceCollectErrors[*Row](NewStringsView("", [][]string{{"Count"}, {"1"}}), nil, nil, nil, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, []*Row{{Count: 1}}, ptrRows)
+}
@@ -0,0 +1,189 @@
+package retable
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// RowHasher computes stable hashes of view rows
+// for deduplication, change detection,
+// and idempotent import keys.
+//
+// Cells are hashed by their canonical string
+// so that equal data read from different sources
+// like a CSV file and a slice of structs
+// results in equal hashes, see CanonicalCellString.
+// Null cells hash different from empty strings.
+type RowHasher struct {
+	// NewHash returns the hash function to use.
+	// If nil, then SHA-256 is used.
+	NewHash func() hash.Hash
+	// Formatter formats cells instead of the canonical formatting.
+	// If it returns errors.ErrUnsupported for a cell,
+	// then the canonical formatting is used.
+	Formatter CellFormatter
+}
+
+// DefaultRowHasher is the RowHasher used by HashRow and HashView
+var DefaultRowHasher RowHasher
+
+// HashRow returns the hash of the cells of row
+// in the columns with the passed indices
+// or of all columns if no columns are passed
+// using DefaultRowHasher.
+// It panics if row or a column is out of range.
+func HashRow(view View, row int, cols ...int) []byte {
+	sum, err := DefaultRowHasher.HashRow(context.Background(), view, row, cols...)
+	if err != nil {
+		panic(err)
+	}
+	return sum
+}
+
+// HashView returns the hash of the columns
+// and all rows of view using DefaultRowHasher.
+func HashView(view View) []byte {
+	sum, err := DefaultRowHasher.HashView(context.Background(), view)
+	if err != nil {
+		panic(err) // Can't happen without Formatter
+	}
+	return sum
+}
+
+// HashRow returns the hash of the cells of row
+// in the columns with the passed indices
+// or of all columns if no columns are passed.
+func (h RowHasher) HashRow(ctx context.Context, view View, row int, cols ...int) ([]byte, error) {
+	if row < 0 || row >= view.NumRows() {
+		return nil, fmt.Errorf("row %d out of range of %d rows", row, view.NumRows())
+	}
+	if len(cols) == 0 {
+		cols = make([]int, len(view.Columns()))
+		for col := range cols {
+			cols[col] = col
+		}
+	}
+	for _, col := range cols {
+		if col < 0 || col >= len(view.Columns()) {
+			return nil, fmt.Errorf("column %d out of range of %d columns", col, len(view.Columns()))
+		}
+	}
+	hash := h.newHash()
+	err := h.writeRow(ctx, hash, view, row, cols)
+	if err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
+
+// HashView returns the hash of the columns and all rows of view.
+// The title of the view is not hashed.
+func (h RowHasher) HashView(ctx context.Context, view View) ([]byte, error) {
+	hash := h.newHash()
+	columns := view.Columns()
+	hash.Write(binary.AppendUvarint(nil, uint64(len(columns))))
+	for _, column := range columns {
+		writeHashString(hash, 1, column)
+	}
+	cols := make([]int, len(columns))
+	for col := range cols {
+		cols[col] = col
+	}
+	for row := range view.NumRows() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		err := h.writeRow(ctx, hash, view, row, cols)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return hash.Sum(nil), nil
+}
+
+func (h RowHasher) newHash() hash.Hash {
+	if h.NewHash == nil {
+		return sha256.New()
+	}
+	return h.NewHash()
+}
+
+func (h RowHasher) writeRow(ctx context.Context, hash hash.Hash, view View, row int, cols []int) error {
+	reflectView := AsReflectCellView(view)
+	for _, col := range cols {
+		if h.Formatter != nil {
+			str, _, err := h.Formatter.FormatCell(ctx, view, row, col)
+			if err == nil {
+				writeHashString(hash, 1, str)
+				continue
+			}
+			if !errors.Is(err, errors.ErrUnsupported) {
+				return NewCellError(view, row, col, err)
+			}
+		}
+		v := reflectView.ReflectCell(row, col)
+		if IsNullLike(v) {
+			writeHashString(hash, 0, "")
+			continue
+		}
+		writeHashString(hash, 1, CanonicalCellString(v))
+	}
+	return nil
+}
+
+// writeHashString writes a marker followed by the
+// length prefixed str so that the concatenation
+// of cells is unambiguous.
+func writeHashString(hash hash.Hash, marker uint64, str string) {
+	hash.Write(binary.AppendUvarint(nil, marker))
+	hash.Write(binary.AppendUvarint(nil, uint64(len(str))))
+	hash.Write([]byte(str))
+}
+
+// CanonicalCellString returns a string representation
+// of a non null cell value that does not depend on
+// the Go type or time zone of the value:
+//
+//   - pointers are dereferenced
+//   - time.Time is formatted in UTC with time.RFC3339Nano
+//   - floats are formatted with the least number of digits
+//     that represent the value exactly
+//   - []byte is used as string
+//   - other values are formatted with fmt.Sprint
+func CanonicalCellString(v reflect.Value) string {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return ""
+	}
+	if v.Type() == typeOfTime {
+		return v.Interface().(time.Time).UTC().Format(time.RFC3339Nano)
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes())
+		}
+	}
+	if !v.CanInterface() {
+		return fmt.Sprint(v)
+	}
+	return fmt.Sprint(v.Interface())
+}
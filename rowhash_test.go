@@ -0,0 +1,128 @@
+package retable
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashRow(t *testing.T) {
+	type Row struct {
+		Name   string
+		Amount float64
+		Date   *time.Time
+	}
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	structs, err := NoTagsStructRowsViewer().NewView("", []Row{
+		{Name: "A", Amount: 1.5, Date: &date},
+		{Name: "B", Amount: 2},
+	})
+	require.NoError(t, err)
+	strs := &StringsView{
+		Cols: []string{"Name", "Amount", "Date"},
+		Rows: [][]string{
+			{"A", "1.5", "2024-01-02T00:00:00Z"},
+			{"B", "2", ""},
+		},
+	}
+
+	// Canonical formatting makes hashes independent of the source types
+	require.Equal(t, HashRow(structs, 0), HashRow(strs, 0))
+	require.Equal(t, HashRow(structs, 0, 0, 1), HashRow(strs, 0, 0, 1))
+	require.NotEqual(t, HashRow(structs, 0), HashRow(structs, 1))
+	require.Equal(t, HashRow(structs, 0, 0), HashRow(structs, 0, 0))
+	// Null differs from empty string
+	require.NotEqual(t, HashRow(structs, 1), HashRow(strs, 1))
+	require.Equal(t, HashRow(structs, 1, 0, 1), HashRow(strs, 1, 0, 1))
+	// Cell boundaries are part of the hash
+	require.NotEqual(t,
+		HashRow(&StringsView{Cols: []string{"A", "B"}, Rows: [][]string{{"ab", "c"}}}, 0),
+		HashRow(&StringsView{Cols: []string{"A", "B"}, Rows: [][]string{{"a", "bc"}}}, 0),
+	)
+	require.Len(t, HashRow(structs, 0), 32)
+
+	require.Panics(t, func() { HashRow(structs, 2) })
+	require.Panics(t, func() { HashRow(structs, 0, 3) })
+}
+
+func TestHashView(t *testing.T) {
+	view := &StringsView{
+		Cols: []string{"A", "B"},
+		Rows: [][]string{{"1", "2"}, {"3", "4"}},
+	}
+	changed := &StringsView{
+		Cols: []string{"A", "B"},
+		Rows: [][]string{{"1", "2"}, {"3", "5"}},
+	}
+	renamed := &StringsView{
+		Cols: []string{"A", "C"},
+		Rows: [][]string{{"1", "2"}, {"3", "4"}},
+	}
+	require.Equal(t, HashView(view), HashView(&StringsView{Tit: "Other", Cols: view.Cols, Rows: view.Rows}))
+	require.NotEqual(t, HashView(view), HashView(changed))
+	require.NotEqual(t, HashView(view), HashView(renamed))
+
+	md5Hasher := RowHasher{NewHash: md5.New}
+	sum, err := md5Hasher.HashView(context.Background(), view)
+	require.NoError(t, err)
+	require.Len(t, sum, md5.Size)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = md5Hasher.HashView(ctx, view)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRowHasher_Formatter(t *testing.T) {
+	view := &StringsView{
+		Cols: []string{"Name"},
+		Rows: [][]string{{"Alice"}, {"ALICE"}, {"fail"}},
+	}
+	hasher := RowHasher{
+		Formatter: CellFormatterFunc(func(ctx context.Context, view View, row, col int) (string, bool, error) {
+			switch str := view.Cell(row, col).(string); str {
+			case "fail":
+				return "", false, errors.New("failed")
+			case "ALICE":
+				return "Alice", false, nil
+			}
+			return "", false, errors.ErrUnsupported
+		}),
+	}
+	ctx := context.Background()
+	a, err := hasher.HashRow(ctx, view, 0)
+	require.NoError(t, err)
+	b, err := hasher.HashRow(ctx, view, 1)
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(a), hex.EncodeToString(b))
+	_, err = hasher.HashRow(ctx, view, 2)
+	require.ErrorAs(t, err, new(CellError))
+}
+
+func TestCanonicalCellString(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{name: "string", value: "x", want: "x"},
+		{name: "int", value: 42, want: "42"},
+		{name: "float64", value: 0.1, want: "0.1"},
+		{name: "float32", value: float32(0.1), want: "0.1"},
+		{name: "bool", value: true, want: "true"},
+		{name: "bytes", value: []byte("abc"), want: "abc"},
+		{name: "time", value: time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600)), want: "2024-01-02T02:04:05Z"},
+		{name: "pointer", value: new(int), want: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, CanonicalCellString(reflect.ValueOf(tt.value)))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package retable
+
+import "reflect"
+
+// NewRowIndicesView returns a view with the rows
+// of source at the passed row indices in the passed order.
+// Rows can be selected multiple times.
+// Indices out of range of source result in empty rows.
+func NewRowIndicesView(source View, rows []int) ReflectCellView {
+	return &rowIndicesView{source: AsReflectCellView(source), rows: rows}
+}
+
+var _ SparseCellView = new(rowIndicesView)
+
+type rowIndicesView struct {
+	source ReflectCellView
+	rows   []int
+}
+
+func (view *rowIndicesView) Title() string     { return view.source.Title() }
+func (view *rowIndicesView) Columns() []string { return view.source.Columns() }
+func (view *rowIndicesView) NumRows() int      { return len(view.rows) }
+
+// sourceRow returns the row index of the source for row or -1
+func (view *rowIndicesView) sourceRow(row int) int {
+	if row < 0 || row >= len(view.rows) {
+		return -1
+	}
+	return view.rows[row]
+}
+
+func (view *rowIndicesView) ColumnFormatter(col int) CellFormatter {
+	return ViewColumnFormatter(view.source, col)
+}
+
+func (view *rowIndicesView) ColumnDescription(col int) string {
+	return ViewColumnDescription(view.source, col)
+}
+
+func (view *rowIndicesView) ColumnHidden(col int) bool {
+	return ViewColumnHidden(view.source, col)
+}
+
+func (view *rowIndicesView) CellExists(row, col int) bool {
+	sourceRow := view.sourceRow(row)
+	if sourceRow < 0 || sourceRow >= view.source.NumRows() {
+		return false
+	}
+	return CellExists(view.source, sourceRow, col)
+}
+
+func (view *rowIndicesView) Cell(row, col int) any {
+	sourceRow := view.sourceRow(row)
+	if sourceRow < 0 || sourceRow >= view.source.NumRows() {
+		return nil
+	}
+	return view.source.Cell(sourceRow, col)
+}
+
+func (view *rowIndicesView) ReflectCell(row, col int) reflect.Value {
+	sourceRow := view.sourceRow(row)
+	if sourceRow < 0 || sourceRow >= view.source.NumRows() {
+		return reflect.Value{}
+	}
+	return view.source.ReflectCell(sourceRow, col)
+}
@@ -0,0 +1,56 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TimeoutError is returned by writers when formatting
+// a row took longer than the configured per-row timeout.
+// errors.Is(err, context.DeadlineExceeded) is true for TimeoutError.
+type TimeoutError struct {
+	Row     int
+	Timeout time.Duration
+}
+
+func (e TimeoutError) Error() string {
+	return fmt.Sprintf("formatting row %d exceeded timeout of %s", e.Row, e.Timeout)
+}
+
+func (e TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// CallWithRowTimeout calls format for row with a context
+// derived from ctx that has a deadline after timeout.
+//
+// format is called in the goroutine of the caller,
+// so no formatter, hook, or view is used concurrently
+// or after CallWithRowTimeout returned.
+// This requires format to check the context between cells
+// and formatters to respect its deadline,
+// a formatter that ignores the deadline can't be interrupted
+// and the timeout is only reported after it returned.
+//
+// A TimeoutError is returned if format returns after the deadline
+// or with an error because of the deadline.
+// If ctx is done before the timeout, then its error is returned.
+// A timeout <= 0 calls format directly with ctx.
+func CallWithRowTimeout(ctx context.Context, row int, timeout time.Duration, format func(ctx context.Context) error) error {
+	if timeout <= 0 {
+		return format(ctx)
+	}
+	rowCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := format(rowCtx)
+	if rowCtx.Err() == nil && (err == nil || !errors.Is(err, context.DeadlineExceeded)) {
+		return err
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return TimeoutError{Row: row, Timeout: timeout}
+}
@@ -0,0 +1,69 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallWithRowTimeout(t *testing.T) {
+	errFormat := errors.New("format")
+
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		format  func(ctx context.Context) error
+		wantErr error
+	}{
+		{name: "no timeout", timeout: 0, format: func(ctx context.Context) error { return nil }},
+		{name: "in time", timeout: time.Second, format: func(ctx context.Context) error { return nil }},
+		{name: "error", timeout: time.Second, format: func(ctx context.Context) error { return errFormat }, wantErr: errFormat},
+		{
+			name:    "respects deadline",
+			timeout: time.Millisecond,
+			format: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			wantErr: TimeoutError{Row: 3, Timeout: time.Millisecond},
+		},
+		{
+			name:    "ignores deadline",
+			timeout: time.Millisecond,
+			format: func(ctx context.Context) error {
+				time.Sleep(10 * time.Millisecond)
+				return nil
+			},
+			wantErr: TimeoutError{Row: 3, Timeout: time.Millisecond},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CallWithRowTimeout(context.Background(), 3, tt.timeout, tt.format)
+			require.Equal(t, tt.wantErr, err)
+		})
+	}
+
+	err := CallWithRowTimeout(context.Background(), 1, time.Millisecond, func(ctx context.Context) error { <-ctx.Done(); return nil })
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.EqualError(t, err, "formatting row 1 exceeded timeout of 1ms")
+
+	// format must have returned when CallWithRowTimeout returns
+	returned := false
+	err = CallWithRowTimeout(context.Background(), 1, time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(time.Millisecond)
+		returned = true
+		return ctx.Err()
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.True(t, returned, "format returned")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = CallWithRowTimeout(ctx, 1, time.Second, func(ctx context.Context) error { return ctx.Err() })
+	require.ErrorIs(t, err, context.Canceled)
+}
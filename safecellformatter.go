@@ -0,0 +1,51 @@
+package retable
+
+import (
+	"context"
+	"fmt"
+)
+
+// FormatterPanicError is the error of a recovered panic
+// of a CellFormatter, see SafeCellFormatter.
+type FormatterPanicError struct {
+	// Value passed to panic
+	Value any
+}
+
+func (e FormatterPanicError) Error() string {
+	return fmt.Sprintf("cell formatter panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e FormatterPanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// SafeCellFormatter returns a CellFormatter that recovers
+// panics of f, like failed type assertions of user provided
+// formatters, and returns them as CellError with the
+// coordinates of the cell wrapping a FormatterPanicError.
+// A nil f is returned as nil.
+func SafeCellFormatter(f CellFormatter) CellFormatter {
+	if f == nil {
+		return nil
+	}
+	if _, isSafe := f.(safeCellFormatter); isSafe {
+		return f
+	}
+	return safeCellFormatter{f}
+}
+
+type safeCellFormatter struct {
+	formatter CellFormatter
+}
+
+func (f safeCellFormatter) FormatCell(ctx context.Context, view View, row, col int) (str string, raw bool, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			str, raw, err = "", false, NewCellError(view, row, col, FormatterPanicError{Value: p})
+		}
+	}()
+	return f.formatter.FormatCell(ctx, view, row, col)
+}
@@ -0,0 +1,38 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeCellFormatter(t *testing.T) {
+	view := &AnyValuesView{Cols: []string{"A", "B"}, Rows: [][]any{{true, "not a bool"}}}
+	formatter := SafeCellFormatter(StringIfTrue("yes"))
+	require.Equal(t, formatter, SafeCellFormatter(formatter), "not wrapped twice")
+	require.Nil(t, SafeCellFormatter(nil))
+
+	str, _, err := formatter.FormatCell(context.Background(), view, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, "yes", str)
+
+	_, _, err = formatter.FormatCell(context.Background(), view, 0, 1)
+	var cellErr CellError
+	require.ErrorAs(t, err, &cellErr)
+	require.Equal(t, 0, cellErr.Row)
+	require.Equal(t, 1, cellErr.Col)
+	require.Equal(t, "B", cellErr.Column)
+	var panicErr FormatterPanicError
+	require.ErrorAs(t, err, &panicErr)
+	var typeErr interface{ RuntimeError() }
+	require.True(t, errors.As(err, &typeErr), "runtime error unwrapped")
+
+	errPanic := errors.New("panic")
+	panicking := SafeCellFormatter(CellFormatterFunc(func(ctx context.Context, view View, row, col int) (string, bool, error) {
+		panic(errPanic)
+	}))
+	_, _, err = panicking.FormatCell(context.Background(), view, 0, 0)
+	require.ErrorIs(t, err, errPanic)
+}
@@ -0,0 +1,102 @@
+package retable
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ColumnSchema describes a column of a view.
+type ColumnSchema struct {
+	// Name is the column title
+	Name string
+	// Type of the non null values of the column,
+	// nil matches any type
+	Type reflect.Type
+	// Description documents the column,
+	// it is not compared by EnsureSchema
+	Description string
+}
+
+// Schema describes the columns of a view.
+type Schema []ColumnSchema
+
+// Descriptions returns the descriptions of the columns
+// that can be passed to ViewWithColumnDescriptions.
+func (s Schema) Descriptions() []string {
+	descriptions := make([]string, len(s))
+	for col, column := range s {
+		descriptions[col] = column.Description
+	}
+	return descriptions
+}
+
+// ViewSchema returns the Schema of view
+// with the type of the first non null value of every column
+// and the column descriptions, see ViewColumnDescription.
+// Columns with only null values have a nil type.
+func ViewSchema(view View) Schema {
+	reflectView := AsReflectCellView(view)
+	columns := view.Columns()
+	schema := make(Schema, len(columns))
+	for col, name := range columns {
+		schema[col].Name = name
+		schema[col].Description = ViewColumnDescription(view, col)
+		for row := 0; row < view.NumRows(); row++ {
+			v := reflectView.ReflectCell(row, col)
+			if !IsNullLike(v) {
+				schema[col].Type = v.Type()
+				break
+			}
+		}
+	}
+	return schema
+}
+
+// SchemaMismatchError is returned by EnsureCompatible and EnsureSchema
+// with a description of every mismatch.
+type SchemaMismatchError struct {
+	Mismatches []string
+}
+
+func (e *SchemaMismatchError) Error() string {
+	return "schema mismatch: " + strings.Join(e.Mismatches, "; ")
+}
+
+// EnsureCompatible returns a *SchemaMismatchError if the views
+// a and b don't have the same number of columns with equal names
+// and types of their values as returned by ViewSchema.
+//
+// Use it before positional operations like concatenating views
+// with ExtraRowView to prevent silent misalignment of columns.
+func EnsureCompatible(a, b View) error {
+	return EnsureSchema(b, ViewSchema(a))
+}
+
+// EnsureSchema returns a *SchemaMismatchError if view
+// does not have the columns of schema in the same order
+// with values of the types of the schema as returned by ViewSchema.
+// Nil types in the schema and columns with only null values
+// match any type.
+func EnsureSchema(view View, schema Schema) error {
+	var (
+		mismatches []string
+		viewSchema = ViewSchema(view)
+	)
+	if len(viewSchema) != len(schema) {
+		mismatches = append(mismatches, fmt.Sprintf("expected %d columns but got %d", len(schema), len(viewSchema)))
+	}
+	for col := range min(len(viewSchema), len(schema)) {
+		expected, actual := schema[col], viewSchema[col]
+		if actual.Name != expected.Name {
+			mismatches = append(mismatches, fmt.Sprintf("column %d: expected name %q but got %q", col, expected.Name, actual.Name))
+		}
+		if expected.Type != nil && actual.Type != nil && actual.Type != expected.Type {
+			mismatches = append(mismatches, fmt.Sprintf("column %d %q: expected type %s but got %s", col, expected.Name, expected.Type, actual.Type))
+		}
+	}
+	if len(mismatches) > 0 {
+		return &SchemaMismatchError{Mismatches: mismatches}
+	}
+	return nil
+}
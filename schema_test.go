@@ -0,0 +1,67 @@
+package retable
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewSchema(t *testing.T) {
+	view := &AnyValuesView{
+		Cols: []string{"ID", "Name", "Note"},
+		Rows: [][]any{{1, nil, nil}, {2, "b", nil}},
+	}
+	require.Equal(t, Schema{
+		{Name: "ID", Type: reflect.TypeFor[int]()},
+		{Name: "Name", Type: reflect.TypeFor[string]()},
+		{Name: "Note"},
+	}, ViewSchema(view))
+}
+
+func TestEnsureCompatible(t *testing.T) {
+	a := &AnyValuesView{Cols: []string{"ID", "Name"}, Rows: [][]any{{1, "a"}}}
+	tests := []struct {
+		name           string
+		b              View
+		wantMismatches []string
+	}{
+		{
+			name: "compatible",
+			b:    &AnyValuesView{Cols: []string{"ID", "Name"}, Rows: [][]any{{2, nil}}},
+		},
+		{
+			name:           "column count",
+			b:              &AnyValuesView{Cols: []string{"ID"}, Rows: [][]any{{2}}},
+			wantMismatches: []string{"expected 2 columns but got 1"},
+		},
+		{
+			name: "names and types",
+			b:    &AnyValuesView{Cols: []string{"Name", "ID"}, Rows: [][]any{{"b", 2}}},
+			wantMismatches: []string{
+				`column 0: expected name "ID" but got "Name"`,
+				`column 0 "ID": expected type int but got string`,
+				`column 1: expected name "Name" but got "ID"`,
+				`column 1 "Name": expected type string but got int`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := EnsureCompatible(a, tt.b)
+			if tt.wantMismatches == nil {
+				require.NoError(t, err)
+				return
+			}
+			var mismatchErr *SchemaMismatchError
+			require.ErrorAs(t, err, &mismatchErr)
+			require.Equal(t, tt.wantMismatches, mismatchErr.Mismatches)
+		})
+	}
+}
+
+func TestEnsureSchema(t *testing.T) {
+	view := &AnyValuesView{Cols: []string{"ID", "Name"}, Rows: [][]any{{1, "a"}}}
+	require.NoError(t, EnsureSchema(view, Schema{{Name: "ID"}, {Name: "Name", Type: reflect.TypeFor[string]()}}))
+	require.Error(t, EnsureSchema(view, Schema{{Name: "ID", Type: reflect.TypeFor[int64]()}, {Name: "Name"}}))
+}
@@ -0,0 +1,142 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Search finds the rows of a View with cells
+// matching a query string or regular expression,
+// for example to implement quick-filter boxes
+// over in-memory tables.
+//
+// Cells are matched by their CanonicalCellString
+// or the result of Formatter, null cells never match.
+type Search struct {
+	// Query is searched as substring of the cells.
+	// An empty Query matches all rows.
+	Query string
+	// Regexp is matched against the cells instead of Query if not nil.
+	Regexp *regexp.Regexp
+	// IgnoreCase makes the Query matching case-insensitive.
+	// Use the (?i) flag for a case-insensitive Regexp.
+	IgnoreCase bool
+	// Columns are the titles of the columns to search in.
+	// If empty, then all columns are searched.
+	Columns []string
+	// Formatter formats cells instead of CanonicalCellString.
+	// If it returns errors.ErrUnsupported for a cell,
+	// then CanonicalCellString is used.
+	Formatter CellFormatter
+}
+
+// SearchRows returns the indices of the rows of view
+// with a cell containing query ignoring case.
+func SearchRows(view View, query string) []int {
+	rows, err := (&Search{Query: query, IgnoreCase: true}).Rows(context.Background(), view)
+	if err != nil {
+		panic(err) // Can't happen without Formatter and Columns
+	}
+	return rows
+}
+
+// SearchView returns a view with the rows of view
+// with a cell containing query ignoring case.
+func SearchView(view View, query string) ReflectCellView {
+	return NewRowIndicesView(view, SearchRows(view, query))
+}
+
+// Rows returns the indices of the matching rows of view.
+func (s *Search) Rows(ctx context.Context, view View) ([]int, error) {
+	cols, err := s.columnIndices(view)
+	if err != nil {
+		return nil, err
+	}
+	rows := []int{}
+	if s.Regexp == nil && s.Query == "" {
+		for row := range view.NumRows() {
+			rows = append(rows, row)
+		}
+		return rows, nil
+	}
+	match := s.matcher()
+	reflectView := AsReflectCellView(view)
+	for row := range view.NumRows() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for _, col := range cols {
+			str, ok, err := s.cellString(ctx, reflectView, row, col)
+			if err != nil {
+				return nil, err
+			}
+			if ok && match(str) {
+				rows = append(rows, row)
+				break
+			}
+		}
+	}
+	return rows, nil
+}
+
+// View returns a view with the matching rows of view.
+func (s *Search) View(ctx context.Context, view View) (ReflectCellView, error) {
+	rows, err := s.Rows(ctx, view)
+	if err != nil {
+		return nil, err
+	}
+	return NewRowIndicesView(view, rows), nil
+}
+
+func (s *Search) columnIndices(view View) ([]int, error) {
+	columns := view.Columns()
+	if len(s.Columns) == 0 {
+		cols := make([]int, len(columns))
+		for i := range cols {
+			cols[i] = i
+		}
+		return cols, nil
+	}
+	cols := make([]int, len(s.Columns))
+	for i, column := range s.Columns {
+		cols[i] = slices.Index(columns, column)
+		if cols[i] == -1 {
+			return nil, fmt.Errorf("search column %q not found in view columns", column)
+		}
+	}
+	return cols, nil
+}
+
+func (s *Search) matcher() func(string) bool {
+	switch {
+	case s.Regexp != nil:
+		return s.Regexp.MatchString
+	case s.IgnoreCase:
+		query := strings.ToLower(s.Query)
+		return func(str string) bool { return strings.Contains(strings.ToLower(str), query) }
+	}
+	return func(str string) bool { return strings.Contains(str, s.Query) }
+}
+
+// cellString returns the string to match for a cell
+// or false if the cell is null.
+func (s *Search) cellString(ctx context.Context, view ReflectCellView, row, col int) (string, bool, error) {
+	if s.Formatter != nil {
+		str, _, err := s.Formatter.FormatCell(ctx, view, row, col)
+		if err == nil {
+			return str, true, nil
+		}
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return "", false, NewCellError(view, row, col, err)
+		}
+	}
+	v := view.ReflectCell(row, col)
+	if IsNullLike(v) {
+		return "", false, nil
+	}
+	return CanonicalCellString(v), true, nil
+}
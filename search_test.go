@@ -0,0 +1,61 @@
+package retable
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch_Rows(t *testing.T) {
+	type Row struct {
+		Name   string
+		City   *string
+		Amount float64
+	}
+	vienna := "Vienna"
+	view, err := DefaultStructRowsViewer().NewView("", []Row{
+		{Name: "Alice", City: &vienna, Amount: 1.5},
+		{Name: "Bob", Amount: 20},
+		{Name: "Carol", City: new(string), Amount: 300},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		search  Search
+		want    []int
+		wantErr bool
+	}{
+		{name: "empty query", search: Search{}, want: []int{0, 1, 2}},
+		{name: "substring", search: Search{Query: "o"}, want: []int{1, 2}},
+		{name: "case sensitive", search: Search{Query: "bob"}, want: []int{}},
+		{name: "ignore case", search: Search{Query: "bob", IgnoreCase: true}, want: []int{1}},
+		{name: "pointer cell", search: Search{Query: "enn"}, want: []int{0}},
+		{name: "number cell", search: Search{Query: "1.5"}, want: []int{0}},
+		{name: "columns", search: Search{Query: "o", Columns: []string{"City"}}, want: []int{}},
+		{name: "regexp", search: Search{Regexp: regexp.MustCompile(`^\d{2,}$`)}, want: []int{1, 2}},
+		{name: "regexp ignores query", search: Search{Query: "x", Regexp: regexp.MustCompile(`(?i)^a`)}, want: []int{0}},
+		{name: "unknown column", search: Search{Query: "x", Columns: []string{"Unknown"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.search.Rows(context.Background(), view)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSearchView(t *testing.T) {
+	view := NewStringsView("Cities", [][]string{{"City"}, {"Vienna"}, {"Graz"}, {"VILLACH"}})
+	result := SearchView(view, "vi")
+	require.Equal(t, "Cities", result.Title())
+	require.Equal(t, 2, result.NumRows())
+	require.Equal(t, "VILLACH", result.Cell(1, 0))
+}
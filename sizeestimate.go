@@ -0,0 +1,114 @@
+package retable
+
+import (
+	"context"
+	"io"
+	"reflect"
+)
+
+// DefaultEstimateSampleRows is the number of rows
+// sampled by the EstimateSize methods of writers.
+const DefaultEstimateSampleRows = 100
+
+// SizeEstimate is the result of EstimateSize
+type SizeEstimate struct {
+	// Rows is the number of data rows of the view
+	Rows int
+	// Bytes is the estimated output size in bytes
+	Bytes int64
+	// SampledRows is the number of rows that were written
+	// to estimate the size
+	SampledRows int
+	// Exact is true if all rows were sampled
+	// so that Bytes is the exact output size
+	Exact bool
+}
+
+// EstimateSize estimates the output size of writing view
+// with the passed write function by writing only sampleRows
+// evenly distributed rows of the view to io.Discard.
+//
+// The size of the output without rows like headers and footers
+// is measured by writing the view without rows and
+// the bytes per row are extrapolated from the sample rows.
+// This enables pre-flight checks before large exports.
+func EstimateSize(ctx context.Context, view View, sampleRows int, write func(context.Context, io.Writer, View) error) (*SizeEstimate, error) {
+	numRows := view.NumRows()
+
+	empty := &MaxBytesWriter{Dest: io.Discard}
+	err := write(ctx, empty, NewRowSampleView(view, 0))
+	if err != nil {
+		return nil, err
+	}
+	sampleView := NewRowSampleView(view, sampleRows)
+	sample := &MaxBytesWriter{Dest: io.Discard}
+	err = write(ctx, sample, sampleView)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &SizeEstimate{
+		Rows:        numRows,
+		Bytes:       sample.Written,
+		SampledRows: sampleView.NumRows(),
+		Exact:       sampleView.NumRows() == numRows,
+	}
+	if !estimate.Exact && estimate.SampledRows > 0 {
+		bytesPerRow := float64(sample.Written-empty.Written) / float64(estimate.SampledRows)
+		estimate.Bytes = empty.Written + int64(bytesPerRow*float64(numRows))
+	}
+	return estimate, nil
+}
+
+// NewRowSampleView returns a view with up to numRows rows
+// evenly distributed over the rows of source.
+// If source has not more than numRows rows,
+// then all rows of source are used.
+func NewRowSampleView(source View, numRows int) ReflectCellView {
+	numRows = max(min(numRows, source.NumRows()), 0)
+	return &rowSampleView{
+		source:  AsReflectCellView(source),
+		numRows: numRows,
+	}
+}
+
+var _ SparseCellView = new(rowSampleView)
+
+type rowSampleView struct {
+	source  ReflectCellView
+	numRows int
+}
+
+func (view *rowSampleView) Title() string     { return view.source.Title() }
+func (view *rowSampleView) Columns() []string { return view.source.Columns() }
+func (view *rowSampleView) NumRows() int      { return view.numRows }
+
+// sourceRow returns the row index of the source for row
+func (view *rowSampleView) sourceRow(row int) int {
+	return row * view.source.NumRows() / view.numRows
+}
+
+func (view *rowSampleView) ColumnFormatter(col int) CellFormatter {
+	return ViewColumnFormatter(view.source, col)
+}
+
+func (view *rowSampleView) CellExists(row, col int) bool {
+	if row < 0 || row >= view.numRows {
+		return false
+	}
+	return CellExists(view.source, view.sourceRow(row), col)
+}
+
+func (view *rowSampleView) Cell(row, col int) any {
+	if row < 0 || row >= view.numRows {
+		return nil
+	}
+	return view.source.Cell(view.sourceRow(row), col)
+}
+
+func (view *rowSampleView) ReflectCell(row, col int) reflect.Value {
+	if row < 0 || row >= view.numRows {
+		return reflect.Value{}
+	}
+	return view.source.ReflectCell(view.sourceRow(row), col)
+}
@@ -0,0 +1,46 @@
+package retable
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateSize(t *testing.T) {
+	// Writes a header line and a line with 10 bytes per row
+	write := func(ctx context.Context, dest io.Writer, view View) error {
+		_, err := fmt.Fprintln(dest, "header")
+		for row := range view.NumRows() {
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(dest, "row %05d\n", view.Cell(row, 0))
+		}
+		return err
+	}
+	rows := make([][]any, 1000)
+	for i := range rows {
+		rows[i] = []any{i}
+	}
+	view := &AnyValuesView{Cols: []string{"A"}, Rows: rows}
+
+	estimate, err := EstimateSize(context.Background(), view, 10, write)
+	require.NoError(t, err)
+	require.Equal(t, &SizeEstimate{Rows: 1000, Bytes: 7 + 1000*10, SampledRows: 10, Exact: false}, estimate)
+
+	estimate, err = EstimateSize(context.Background(), view, 2000, write)
+	require.NoError(t, err)
+	require.Equal(t, &SizeEstimate{Rows: 1000, Bytes: 7 + 1000*10, SampledRows: 1000, Exact: true}, estimate)
+}
+
+func TestNewRowSampleView(t *testing.T) {
+	view := &AnyValuesView{Cols: []string{"A"}, Rows: [][]any{{0}, {1}, {2}, {3}, {4}, {5}}}
+	sample := NewRowSampleView(view, 3)
+	require.Equal(t, 3, sample.NumRows())
+	require.Equal(t, []any{0, 2, 4}, []any{sample.Cell(0, 0), sample.Cell(1, 0), sample.Cell(2, 0)})
+	require.Equal(t, 6, NewRowSampleView(view, 10).NumRows())
+	require.Equal(t, 0, NewRowSampleView(view, -1).NumRows())
+}
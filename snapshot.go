@@ -0,0 +1,175 @@
+package retable
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Snapshot holds the fingerprints of the rows of a view
+// identified by the values of key columns
+// to find the rows that changed since the snapshot
+// for incremental exports, see RowHasher.Delta.
+//
+// Snapshots are persisted as JSON with Write and ReadSnapshot.
+type Snapshot struct {
+	// KeyColumns are the titles of the columns
+	// that identify a row
+	KeyColumns []string `json:"keyColumns"`
+	// Rows are the fingerprints of the rows
+	Rows []SnapshotRow `json:"rows"`
+}
+
+// SnapshotRow is the fingerprint of a row of a Snapshot.
+type SnapshotRow struct {
+	// Key are the canonical strings of the key column cells
+	Key []string `json:"key"`
+	// Hash is the hex encoded hash of the row
+	Hash string `json:"hash"`
+}
+
+// ReadSnapshot reads a Snapshot written by Snapshot.Write.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	var snapshot Snapshot
+	err := json.NewDecoder(r).Decode(&snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("can't read snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// Write writes the snapshot as JSON to w.
+func (s *Snapshot) Write(w io.Writer) error {
+	return json.NewEncoder(w).Encode(s)
+}
+
+// Delta is the result of RowHasher.Delta.
+type Delta struct {
+	// Changed has the new and changed rows of the current view
+	Changed View
+	// Deleted has the key column values of the rows
+	// of the previous snapshot that are not in the current view
+	// as tombstones for downstream systems
+	Deleted View
+	// Snapshot of the current view to be passed
+	// as previous snapshot for the next delta
+	Snapshot *Snapshot
+}
+
+// NewSnapshot returns a Snapshot of the rows of view
+// identified by the passed key columns
+// using DefaultRowHasher.
+func NewSnapshot(ctx context.Context, view View, keyColumns ...string) (*Snapshot, error) {
+	return DefaultRowHasher.Snapshot(ctx, view, keyColumns...)
+}
+
+// NewDelta returns the rows of current that changed
+// since the previous snapshot using DefaultRowHasher,
+// see RowHasher.Delta.
+func NewDelta(ctx context.Context, previous *Snapshot, current View, keyColumns ...string) (*Delta, error) {
+	return DefaultRowHasher.Delta(ctx, previous, current, keyColumns...)
+}
+
+// Snapshot returns a Snapshot of the rows of view
+// identified by the passed key columns.
+// An error is returned if a key column does not exist
+// or if multiple rows have the same key.
+func (h RowHasher) Snapshot(ctx context.Context, view View, keyColumns ...string) (*Snapshot, error) {
+	if len(keyColumns) == 0 {
+		return nil, fmt.Errorf("no key columns for snapshot of view %q", view.Title())
+	}
+	keyCols := make([]int, len(keyColumns))
+	for i, column := range keyColumns {
+		keyCols[i] = slices.Index(view.Columns(), column)
+		if keyCols[i] == -1 {
+			return nil, fmt.Errorf("key column %q not found in view %q", column, view.Title())
+		}
+	}
+	reflectView := AsReflectCellView(view)
+	snapshot := &Snapshot{
+		KeyColumns: slices.Clone(keyColumns),
+		Rows:       make([]SnapshotRow, view.NumRows()),
+	}
+	keys := make(map[string]int, view.NumRows())
+	for row := range view.NumRows() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		key := make([]string, len(keyCols))
+		for i, col := range keyCols {
+			if v := reflectView.ReflectCell(row, col); !IsNullLike(v) {
+				key[i] = CanonicalCellString(v)
+			}
+		}
+		keyStr := snapshotKeyString(key)
+		if other, exists := keys[keyStr]; exists {
+			return nil, fmt.Errorf("rows %d and %d have the same key %q in view %q", other, row, key, view.Title())
+		}
+		keys[keyStr] = row
+		hash, err := h.HashRow(ctx, view, row)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Rows[row] = SnapshotRow{Key: key, Hash: hex.EncodeToString(hash)}
+	}
+	return snapshot, nil
+}
+
+// Delta returns the new and changed rows of current
+// since the previous snapshot and the keys of the deleted rows
+// together with the snapshot of current for the next delta.
+//
+// If previous is nil, then all rows of current are returned as changed.
+// An error is returned if previous was created for other key columns.
+func (h RowHasher) Delta(ctx context.Context, previous *Snapshot, current View, keyColumns ...string) (*Delta, error) {
+	if previous != nil && !slices.Equal(previous.KeyColumns, keyColumns) {
+		return nil, fmt.Errorf("previous snapshot key columns %q differ from %q", previous.KeyColumns, keyColumns)
+	}
+	snapshot, err := h.Snapshot(ctx, current, keyColumns...)
+	if err != nil {
+		return nil, err
+	}
+	previousHashes := make(map[string]string)
+	if previous != nil {
+		for _, row := range previous.Rows {
+			previousHashes[snapshotKeyString(row.Key)] = row.Hash
+		}
+	}
+	var changed []int
+	for row, snapshotRow := range snapshot.Rows {
+		keyStr := snapshotKeyString(snapshotRow.Key)
+		if hash, ok := previousHashes[keyStr]; !ok || hash != snapshotRow.Hash {
+			changed = append(changed, row)
+		}
+		delete(previousHashes, keyStr)
+	}
+	deleted := &StringsView{Tit: current.Title(), Cols: slices.Clone(keyColumns)}
+	if previous != nil {
+		for _, row := range previous.Rows {
+			if _, ok := previousHashes[snapshotKeyString(row.Key)]; ok {
+				deleted.Rows = append(deleted.Rows, row.Key)
+			}
+		}
+	}
+	return &Delta{
+		Changed:  NewRowIndicesView(current, changed),
+		Deleted:  deleted,
+		Snapshot: snapshot,
+	}, nil
+}
+
+// snapshotKeyString returns an unambiguous
+// string of the length prefixed key values
+// to be used as map key.
+func snapshotKeyString(key []string) string {
+	var b []byte
+	for _, k := range key {
+		b = binary.AppendUvarint(b, uint64(len(k)))
+		b = append(b, k...)
+	}
+	return string(b)
+}
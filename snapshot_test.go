@@ -0,0 +1,82 @@
+package retable
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowHasher_Delta(t *testing.T) {
+	ctx := context.Background()
+	first := NewStringsView("Customers", [][]string{
+		{"ID", "Name", "City"},
+		{"1", "Alice", "Vienna"},
+		{"2", "Bob", "Graz"},
+		{"3", "Carol", "Linz"},
+	})
+	second := NewStringsView("Customers", [][]string{
+		{"ID", "Name", "City"},
+		{"1", "Alice", "Vienna"},
+		{"3", "Carol", "Salzburg"},
+		{"4", "Dave", "Graz"},
+	})
+
+	delta, err := NewDelta(ctx, nil, first, "ID")
+	require.NoError(t, err)
+	require.Equal(t, 3, delta.Changed.NumRows())
+	require.Equal(t, 0, delta.Deleted.NumRows())
+
+	// Persist and read back the snapshot like a file
+	var buf bytes.Buffer
+	err = delta.Snapshot.Write(&buf)
+	require.NoError(t, err)
+	previous, err := ReadSnapshot(&buf)
+	require.NoError(t, err)
+	require.Equal(t, delta.Snapshot, previous)
+
+	delta, err = NewDelta(ctx, previous, second, "ID")
+	require.NoError(t, err)
+	require.Equal(t, "Customers", delta.Changed.Title())
+	require.Equal(t, first.Columns(), delta.Changed.Columns())
+	require.Equal(t, 2, delta.Changed.NumRows())
+	require.Equal(t, "3", delta.Changed.Cell(0, 0))
+	require.Equal(t, "Salzburg", delta.Changed.Cell(0, 2))
+	require.Equal(t, "4", delta.Changed.Cell(1, 0))
+	require.Equal(t, []string{"ID"}, delta.Deleted.Columns())
+	require.Equal(t, 1, delta.Deleted.NumRows())
+	require.Equal(t, "2", delta.Deleted.Cell(0, 0))
+
+	// No changes since the last snapshot
+	delta, err = NewDelta(ctx, delta.Snapshot, second, "ID")
+	require.NoError(t, err)
+	require.Equal(t, 0, delta.Changed.NumRows())
+	require.Equal(t, 0, delta.Deleted.NumRows())
+
+	_, err = NewDelta(ctx, previous, second, "Name")
+	require.Error(t, err, "different key columns")
+	_, err = NewDelta(ctx, nil, second, "Missing")
+	require.Error(t, err, "missing key column")
+	_, err = NewDelta(ctx, nil, second)
+	require.Error(t, err, "no key columns")
+}
+
+func TestRowHasher_Snapshot(t *testing.T) {
+	ctx := context.Background()
+	view := NewStringsView("", [][]string{
+		{"Year", "Month", "Total"},
+		{"2024", "1", "10"},
+		{"2024", "2", "20"},
+		{"2024", "1", "30"},
+	})
+	_, err := NewSnapshot(ctx, view, "Year", "Month")
+	require.Error(t, err, "duplicate key")
+
+	snapshot, err := NewSnapshot(ctx, NewRowIndicesView(view, []int{0, 1}), "Year", "Month")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Year", "Month"}, snapshot.KeyColumns)
+	require.Len(t, snapshot.Rows, 2)
+	require.Equal(t, []string{"2024", "2"}, snapshot.Rows[1].Key)
+	require.Len(t, snapshot.Rows[1].Hash, 64)
+}
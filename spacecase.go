@@ -0,0 +1,152 @@
+package retable
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultAcronyms are common acronyms in field names
+// of business data for SpaceCaseOptions.Acronyms.
+var DefaultAcronyms = []string{"ID", "URL", "API", "IBAN", "VAT"}
+
+// SpaceCaseOptions extends SpaceGoCase and SpacePascalCase
+// with a dictionary of acronyms and title casing
+// to improve the column titles of untagged struct fields:
+//
+//	naming := retable.StructFieldNaming{
+//		Tag:      "col",
+//		Ignore:   "-",
+//		Untagged: retable.SpaceCaseOptions{Acronyms: retable.DefaultAcronyms}.SpaceGoCase,
+//	}
+type SpaceCaseOptions struct {
+	// Acronyms are written in the passed spelling
+	// if a word matches them case insensitively
+	// so that "UserId" becomes "User ID".
+	// Words starting with acronyms are split
+	// so that "IBANCode" becomes "IBAN Code" also with
+	// SpacePascalCase and "VATID" becomes "VAT ID".
+	Acronyms []string
+	// TitleCase converts the first character
+	// of every word to upper case.
+	TitleCase bool
+}
+
+// SpaceGoCase returns SpaceGoCase(name)
+// with the acronyms and title case applied.
+func (o SpaceCaseOptions) SpaceGoCase(name string) string {
+	return o.apply(SpaceGoCase(name))
+}
+
+// SpacePascalCase returns SpacePascalCase(name)
+// with the acronyms and title case applied.
+func (o SpaceCaseOptions) SpacePascalCase(name string) string {
+	return o.apply(SpacePascalCase(name))
+}
+
+func (o SpaceCaseOptions) apply(spaced string) string {
+	if len(o.Acronyms) == 0 && !o.TitleCase {
+		return spaced
+	}
+	// Try longer acronyms first so that
+	// the longest acronym prefix is split off
+	acronyms := slices.SortedStableFunc(slices.Values(o.Acronyms), func(a, b string) int {
+		return cmp.Compare(len(b), len(a))
+	})
+	var words []string
+	for _, word := range strings.Split(spaced, " ") {
+		if parts := splitAcronyms(word, acronyms); parts != nil {
+			words = append(words, parts...)
+			continue
+		}
+		if o.TitleCase {
+			word = upperFirst(word)
+		}
+		words = append(words, word)
+	}
+	return strings.Join(words, " ")
+}
+
+// splitAcronyms returns word split into acronyms
+// optionally followed by a capitalized word
+// or nil if word does not start with an acronym.
+func splitAcronyms(word string, acronyms []string) []string {
+	for _, acronym := range acronyms {
+		if strings.EqualFold(word, acronym) {
+			return []string{acronym}
+		}
+	}
+	for _, acronym := range acronyms {
+		rest, ok := strings.CutPrefix(word, acronym)
+		if !ok || rest == "" {
+			continue
+		}
+		first, size := utf8.DecodeRuneInString(rest)
+		second, _ := utf8.DecodeRuneInString(rest[size:])
+		if unicode.IsUpper(first) && second != utf8.RuneError && !unicode.IsUpper(second) {
+			// Capitalized word after the acronym
+			return []string{acronym, rest}
+		}
+		if parts := splitAcronyms(rest, acronyms); parts != nil {
+			return append([]string{acronym}, parts...)
+		}
+	}
+	return nil
+}
+
+func upperFirst(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	if r == utf8.RuneError || unicode.IsUpper(r) {
+		return word
+	}
+	return string(unicode.ToUpper(r)) + word[size:]
+}
+
+// nameWords splits name into words at the positions
+// where SpaceGoCase inserts spaces and at
+// whitespace, underscores, hyphens and dots.
+func nameWords(name string) []string {
+	return strings.FieldsFunc(SpaceGoCase(name), func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-' || r == '.'
+	})
+}
+
+// ToSnakeCase converts a Go case name or column title
+// to lower case words separated by underscores
+// like "InvoiceNumber" or "Invoice Number" to "invoice_number".
+// Usable for StructFieldNaming.Untagged
+// and to normalize column titles.
+func ToSnakeCase(name string) string {
+	return strings.ToLower(strings.Join(nameWords(name), "_"))
+}
+
+// ToKebabCase converts a Go case name or column title
+// to lower case words separated by hyphens
+// like "InvoiceNumber" or "Invoice Number" to "invoice-number".
+// Usable for StructFieldNaming.Untagged
+// and to normalize column titles.
+func ToKebabCase(name string) string {
+	return strings.ToLower(strings.Join(nameWords(name), "-"))
+}
+
+// ToLowerCamel converts a Go case name or column title
+// to lower camel case like "InvoiceNumber" or
+// "invoice_number" to "invoiceNumber".
+// Acronyms are treated as words so that
+// "HTTPServerID" becomes "httpServerId".
+// Usable for StructFieldNaming.Untagged
+// and to normalize column titles.
+func ToLowerCamel(name string) string {
+	var b strings.Builder
+	b.Grow(len(name))
+	for i, word := range nameWords(name) {
+		word = strings.ToLower(word)
+		if i > 0 {
+			word = upperFirst(word)
+		}
+		b.WriteString(word)
+	}
+	return b.String()
+}
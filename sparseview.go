@@ -0,0 +1,98 @@
+package retable
+
+import "reflect"
+
+var (
+	_ SparseCellView = new(SparseView)
+	_ SparseCellView = new(StringsView)
+	_ SparseCellView = new(FilteredView)
+)
+
+// SparseCellView is implemented by views that can have
+// missing cells, distinguishing a missing cell from a cell
+// that exists but has a nil value.
+//
+// Writers render missing cells as empty cells
+// instead of using their NullPolicy.
+type SparseCellView interface {
+	View
+
+	// CellExists returns if the cell at the given row and column exists.
+	// If row and col are out of bounds then false is returned.
+	CellExists(row, col int) bool
+}
+
+// CellExists returns if a cell exists at the passed row and column.
+// If the view implements SparseCellView then its CellExists method is used,
+// else all cells within the bounds of the view exist.
+func CellExists(view View, row, col int) bool {
+	if sparse, ok := view.(SparseCellView); ok {
+		return sparse.CellExists(row, col)
+	}
+	return row >= 0 && col >= 0 && row < view.NumRows() && col < len(view.Columns())
+}
+
+// SparseView is a View implementation that holds
+// its rows as maps from column index to value,
+// where a missing map key is a missing cell.
+type SparseView struct {
+	Tit  string
+	Cols []string
+	Rows []map[int]any
+}
+
+// NewSparseView returns an empty SparseView.
+func NewSparseView(title string, cols ...string) *SparseView {
+	return &SparseView{Tit: title, Cols: cols}
+}
+
+// Set sets the value of the cell at row and col
+// adding empty rows as needed.
+// It panics if row or col are negative
+// or if col is not smaller than the number of columns.
+func (view *SparseView) Set(row, col int, value any) {
+	if row < 0 || col < 0 || col >= len(view.Cols) {
+		panic("row or column index out of range")
+	}
+	for len(view.Rows) <= row {
+		view.Rows = append(view.Rows, nil)
+	}
+	if view.Rows[row] == nil {
+		view.Rows[row] = make(map[int]any)
+	}
+	view.Rows[row][col] = value
+}
+
+// Delete makes the cell at row and col a missing cell.
+func (view *SparseView) Delete(row, col int) {
+	if row < 0 || row >= len(view.Rows) {
+		return
+	}
+	delete(view.Rows[row], col)
+}
+
+func (view *SparseView) Title() string     { return view.Tit }
+func (view *SparseView) Columns() []string { return view.Cols }
+func (view *SparseView) NumRows() int      { return len(view.Rows) }
+
+func (view *SparseView) CellExists(row, col int) bool {
+	if row < 0 || col < 0 || row >= len(view.Rows) || col >= len(view.Cols) {
+		return false
+	}
+	_, exists := view.Rows[row][col]
+	return exists
+}
+
+func (view *SparseView) Cell(row, col int) any {
+	if row < 0 || col < 0 || row >= len(view.Rows) || col >= len(view.Cols) {
+		return nil
+	}
+	return view.Rows[row][col]
+}
+
+func (view *SparseView) ReflectCell(row, col int) reflect.Value {
+	if row < 0 || col < 0 || row >= len(view.Rows) || col >= len(view.Cols) {
+		return reflect.Value{}
+	}
+	return reflect.ValueOf(view.Rows[row][col])
+}
@@ -0,0 +1,73 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringsView_CellExists(t *testing.T) {
+	view := &StringsView{
+		Cols: []string{"A", "B", "C"},
+		Rows: [][]string{
+			{"a", "b", "c"},
+			{"a"},
+			{"a", ""},
+		},
+	}
+	tests := []struct {
+		row, col   int
+		wantExists bool
+		wantCell   any
+	}{
+		{row: 0, col: 2, wantExists: true, wantCell: "c"},
+		{row: 1, col: 0, wantExists: true, wantCell: "a"},
+		// Cells beyond the length of a row are missing
+		// but read as empty strings
+		{row: 1, col: 1, wantExists: false, wantCell: ""},
+		{row: 1, col: 2, wantExists: false, wantCell: ""},
+		{row: 2, col: 1, wantExists: true, wantCell: ""},
+		{row: 2, col: 2, wantExists: false, wantCell: ""},
+		// Out of bounds
+		{row: 3, col: 0, wantExists: false, wantCell: nil},
+		{row: 0, col: 3, wantExists: false, wantCell: nil},
+		{row: -1, col: 0, wantExists: false, wantCell: nil},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.wantExists, view.CellExists(tt.row, tt.col), "CellExists(%d, %d)", tt.row, tt.col)
+		require.Equal(t, tt.wantCell, view.Cell(tt.row, tt.col), "Cell(%d, %d)", tt.row, tt.col)
+	}
+}
+
+func TestCellExists_decorators(t *testing.T) {
+	source := &StringsView{
+		Cols: []string{"A", "B"},
+		Rows: [][]string{{"a", "b"}, {"a"}},
+	}
+	tests := []struct {
+		name string
+		view View
+		// wantExists[row][col]
+		wantExists [][]bool
+	}{
+		{name: "source", view: source, wantExists: [][]bool{{true, true}, {true, false}}},
+		{name: "FilteredView", view: &FilteredView{Source: source, RowOffset: 1}, wantExists: [][]bool{{true, false}}},
+		{name: "FilteredView mapping", view: &FilteredView{Source: source, ColumnMapping: []int{1, 0}}, wantExists: [][]bool{{true, true}, {false, true}}},
+		{name: "ViewWithTitle", view: ViewWithTitle(source, "Title"), wantExists: [][]bool{{true, true}, {true, false}}},
+		{name: "ViewWithColumns", view: ViewWithColumns(source, []string{"X", "Y", "Z"}), wantExists: [][]bool{{true, true, true}, {true, false, true}}},
+		{name: "ViewWithMetadata", view: ViewWithMetadata(source, map[string]any{"a": 1}), wantExists: [][]bool{{true, true}, {true, false}}},
+		{name: "ExtraRowView", view: ExtraRowView{source, source}, wantExists: [][]bool{{true, true}, {true, false}, {true, true}, {true, false}}},
+		{name: "ConcatView", view: NewConcatView(source, source), wantExists: [][]bool{{true, true}, {true, false}, {true, true}, {true, false}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, len(tt.wantExists), tt.view.NumRows())
+			for row, wantRow := range tt.wantExists {
+				for col, want := range wantRow {
+					require.Equal(t, want, CellExists(tt.view, row, col), "CellExists(%d, %d)", row, col)
+				}
+			}
+			require.False(t, CellExists(tt.view, len(tt.wantExists), 0), "row out of bounds")
+		})
+	}
+}
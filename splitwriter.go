@@ -0,0 +1,182 @@
+package retable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// SplitPart is the metadata of a part written by SplitWriter.
+type SplitPart struct {
+	// Index of the part starting at zero
+	Index int
+	// FirstRow is the index of the first row
+	// of the view written to the part
+	FirstRow int
+	// NumRows is the number of rows written to the part
+	NumRows int
+	// Bytes is the size of the part including the header
+	Bytes int64
+}
+
+// SplitWriter splits writing a view into multiple parts
+// of at most a maximum number of rows or bytes,
+// for example for upload size limits of partner systems.
+//
+// Every part is written as separate view with the
+// write function like the WriteView method of a writer
+// so that every part repeats the header row.
+//
+// To find the number of rows of a part that fit
+// into the maximum number of bytes,
+// parts are written to io.Discard with an increasing
+// number of rows before writing the part.
+type SplitWriter struct {
+	write      func(context.Context, io.Writer, View) error
+	createPart func(index int) (io.WriteCloser, error)
+	maxRows    int
+	maxBytes   int64
+}
+
+// NewSplitWriter returns a SplitWriter that writes
+// the parts with write to the io.WriteCloser
+// returned by createPart for every part index.
+// Without WithMaxRows or WithMaxBytes all rows
+// are written to a single part.
+func NewSplitWriter(write func(context.Context, io.Writer, View) error, createPart func(index int) (io.WriteCloser, error)) *SplitWriter {
+	return &SplitWriter{
+		write:      write,
+		createPart: createPart,
+		maxRows:    0,
+		maxBytes:   0,
+	}
+}
+
+// SplitFiles returns a createPart function for NewSplitWriter
+// that creates files named by formatting pattern
+// with the part number starting at 1 like "export-%03d.csv".
+func SplitFiles(pattern string) func(index int) (io.WriteCloser, error) {
+	return func(index int) (io.WriteCloser, error) {
+		return os.Create(fmt.Sprintf(pattern, index+1))
+	}
+}
+
+func (w *SplitWriter) clone() *SplitWriter {
+	c := *w
+	return &c
+}
+
+// WithMaxRows returns a new writer with the maximum number
+// of rows per part, not limited if <= 0.
+func (w *SplitWriter) WithMaxRows(maxRows int) *SplitWriter {
+	mod := w.clone()
+	mod.maxRows = maxRows
+	return mod
+}
+
+// WithMaxBytes returns a new writer with the maximum number
+// of bytes per part including the header, not limited if <= 0.
+func (w *SplitWriter) WithMaxBytes(maxBytes int64) *SplitWriter {
+	mod := w.clone()
+	mod.maxBytes = maxBytes
+	return mod
+}
+
+// WriteView writes the view split into parts
+// and returns the metadata of the written parts.
+// A view without rows is written as a single part.
+//
+// An error wrapping ErrMaxOutputBytesExceeded is returned
+// if a single row does not fit into the maximum number of bytes.
+func (w *SplitWriter) WriteView(ctx context.Context, view View) ([]SplitPart, error) {
+	numRows := view.NumRows()
+	var parts []SplitPart
+	for firstRow := 0; firstRow < numRows || len(parts) == 0; {
+		partRows, err := w.partRows(ctx, view, firstRow)
+		if err != nil {
+			return parts, err
+		}
+		part := SplitPart{Index: len(parts), FirstRow: firstRow, NumRows: partRows}
+		part.Bytes, err = w.writePart(ctx, part.Index, splitPartView(view, firstRow, partRows))
+		if err != nil {
+			return parts, err
+		}
+		parts = append(parts, part)
+		firstRow += partRows
+	}
+	return parts, nil
+}
+
+// partRows returns the number of rows starting at firstRow
+// that can be written to a part.
+func (w *SplitWriter) partRows(ctx context.Context, view View, firstRow int) (int, error) {
+	limit := view.NumRows() - firstRow
+	if w.maxRows > 0 {
+		limit = min(limit, w.maxRows)
+	}
+	if w.maxBytes <= 0 || limit == 0 {
+		return limit, nil
+	}
+	fits := func(numRows int) (bool, error) {
+		counter := &MaxBytesWriter{Dest: io.Discard}
+		err := w.write(ctx, counter, splitPartView(view, firstRow, numRows))
+		return counter.Written <= w.maxBytes, err
+	}
+	// Double the number of rows until the part
+	// does not fit anymore, then bisect
+	lo, hi := 0, limit+1
+	for numRows := 1; ; numRows *= 2 {
+		numRows = min(numRows, limit)
+		ok, err := fits(numRows)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			hi = numRows
+			break
+		}
+		lo = numRows
+		if numRows == limit {
+			break
+		}
+	}
+	for hi-lo > 1 {
+		mid := (lo + hi) / 2
+		ok, err := fits(mid)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	if lo == 0 {
+		return 0, fmt.Errorf("row %d does not fit into a part: %w", firstRow, ErrMaxOutputBytesExceeded{Max: w.maxBytes})
+	}
+	return lo, nil
+}
+
+func (w *SplitWriter) writePart(ctx context.Context, index int, view View) (written int64, err error) {
+	dest, err := w.createPart(index)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err = errors.Join(err, dest.Close())
+	}()
+	counter := &MaxBytesWriter{Dest: dest}
+	err = w.write(ctx, counter, view)
+	return counter.Written, err
+}
+
+func splitPartView(view View, firstRow, numRows int) View {
+	rows := make([]int, numRows)
+	for i := range rows {
+		rows[i] = firstRow + i
+	}
+	return NewRowIndicesView(view, rows)
+}
@@ -0,0 +1,146 @@
+package retable
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeLines writes the header and rows of view
+// as comma separated lines for testing.
+func writeLines(ctx context.Context, dest io.Writer, view View) error {
+	rows, err := FormatViewAsStrings(ctx, view, nil, OptionAddHeaderRow)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		_, err = fmt.Fprintln(dest, strings.Join(row, ","))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type bufferCloser struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *bufferCloser) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestSplitWriter_WriteView(t *testing.T) {
+	view := NewStringsView("", [][]string{
+		{"ID", "Name"},
+		{"1", "a"},
+		{"2", "bb"},
+		{"3", "ccc"},
+		{"4", "dddd"},
+		{"5", "eeeee"},
+	})
+	tests := []struct {
+		name      string
+		view      View
+		maxRows   int
+		maxBytes  int64
+		wantParts []string
+		wantMeta  []SplitPart
+	}{
+		{
+			name:      "no limits",
+			view:      view,
+			wantParts: []string{"ID,Name\n1,a\n2,bb\n3,ccc\n4,dddd\n5,eeeee\n"},
+			wantMeta:  []SplitPart{{Index: 0, FirstRow: 0, NumRows: 5, Bytes: 38}},
+		},
+		{
+			name:      "max rows",
+			view:      view,
+			maxRows:   2,
+			wantParts: []string{"ID,Name\n1,a\n2,bb\n", "ID,Name\n3,ccc\n4,dddd\n", "ID,Name\n5,eeeee\n"},
+			wantMeta: []SplitPart{
+				{Index: 0, FirstRow: 0, NumRows: 2, Bytes: 17},
+				{Index: 1, FirstRow: 2, NumRows: 2, Bytes: 21},
+				{Index: 2, FirstRow: 4, NumRows: 1, Bytes: 16},
+			},
+		},
+		{
+			name:      "max bytes",
+			view:      view,
+			maxBytes:  23,
+			wantParts: []string{"ID,Name\n1,a\n2,bb\n3,ccc\n", "ID,Name\n4,dddd\n5,eeeee\n"},
+			wantMeta: []SplitPart{
+				{Index: 0, FirstRow: 0, NumRows: 3, Bytes: 23},
+				{Index: 1, FirstRow: 3, NumRows: 2, Bytes: 23},
+			},
+		},
+		{
+			name:      "max rows and bytes",
+			view:      view,
+			maxRows:   2,
+			maxBytes:  22,
+			wantParts: []string{"ID,Name\n1,a\n2,bb\n", "ID,Name\n3,ccc\n4,dddd\n", "ID,Name\n5,eeeee\n"},
+			wantMeta: []SplitPart{
+				{Index: 0, FirstRow: 0, NumRows: 2, Bytes: 17},
+				{Index: 1, FirstRow: 2, NumRows: 2, Bytes: 21},
+				{Index: 2, FirstRow: 4, NumRows: 1, Bytes: 16},
+			},
+		},
+		{
+			name:      "empty view",
+			view:      &StringsView{Cols: []string{"ID", "Name"}},
+			maxRows:   2,
+			wantParts: []string{"ID,Name\n"},
+			wantMeta:  []SplitPart{{Index: 0, FirstRow: 0, NumRows: 0, Bytes: 8}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var parts []*bufferCloser
+			w := NewSplitWriter(writeLines, func(index int) (io.WriteCloser, error) {
+				require.Equal(t, len(parts), index)
+				parts = append(parts, new(bufferCloser))
+				return parts[index], nil
+			}).WithMaxRows(tt.maxRows).WithMaxBytes(tt.maxBytes)
+			meta, err := w.WriteView(context.Background(), tt.view)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantMeta, meta)
+			require.Len(t, parts, len(tt.wantParts))
+			for i, part := range parts {
+				require.True(t, part.closed)
+				require.Equal(t, tt.wantParts[i], part.String())
+			}
+		})
+	}
+}
+
+func TestSplitWriter_RowTooLarge(t *testing.T) {
+	view := NewStringsView("", [][]string{{"ID", "Name"}, {"1", "a"}, {"2", "a very long name"}})
+	w := NewSplitWriter(writeLines, func(int) (io.WriteCloser, error) {
+		return new(bufferCloser), nil
+	}).WithMaxBytes(16)
+	parts, err := w.WriteView(context.Background(), view)
+	require.ErrorAs(t, err, new(ErrMaxOutputBytesExceeded))
+	require.Len(t, parts, 1)
+}
+
+func TestSplitFiles(t *testing.T) {
+	dir := t.TempDir()
+	view := NewStringsView("", [][]string{{"ID"}, {"1"}, {"2"}, {"3"}})
+	w := NewSplitWriter(writeLines, SplitFiles(filepath.Join(dir, "part-%d.csv"))).WithMaxRows(2)
+	parts, err := w.WriteView(context.Background(), view)
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+	data, err := os.ReadFile(filepath.Join(dir, "part-2.csv"))
+	require.NoError(t, err)
+	require.Equal(t, "ID\n3\n", string(data))
+}
@@ -4,12 +4,21 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/domonda/go-retable"
 )
 
+// InformationSchemaTables is the name of the pseudo table
+// listing the registered views with the columns
+// table_schema, table_name, and table_type.
+const InformationSchemaTables = "information_schema.tables"
+
 func NewViewsDB(views map[string]retable.View) *sql.DB {
-	return sql.OpenDB(database{views: views})
+	return sql.OpenDB(NewConnector(views))
 }
 
 func NewViewDB(viewName string, view retable.View) *sql.DB {
@@ -18,16 +27,112 @@ func NewViewDB(viewName string, view retable.View) *sql.DB {
 	})
 }
 
-type database struct {
-	views map[string]retable.View
+var _ driver.Connector = new(Connector)
+
+// Connector is a driver.Connector for views as tables
+// that can be modified at runtime.
+// Use sql.OpenDB(connector) to get a *sql.DB.
+//
+// The set of tables can be queried with:
+//
+//	SELECT table_name FROM information_schema.tables
+type Connector struct {
+	mtx        sync.RWMutex
+	views      map[string]retable.View
+	normalizer *retable.HeaderNormalizer
 }
 
-func (c database) Connect(context.Context) (driver.Conn, error) {
-	return c, nil
+// NewConnector returns a Connector with a copy of the passed views map.
+func NewConnector(views map[string]retable.View) *Connector {
+	return &Connector{views: maps.Clone(views)}
+}
+
+func (c *Connector) Connect(context.Context) (driver.Conn, error) {
+	return database{connector: c}, nil
+}
+
+func (c *Connector) Driver() driver.Driver {
+	return database{connector: c}
+}
+
+// RegisterView registers view as table with name.
+// An already registered view with the same name is replaced.
+func (c *Connector) RegisterView(name string, view retable.View) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.views == nil {
+		c.views = make(map[string]retable.View)
+	}
+	c.views[name] = view
+}
+
+// DropView removes the view registered as table with name
+// and returns false if there was no such view.
+func (c *Connector) DropView(name string) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	_, ok := c.views[name]
+	delete(c.views, name)
+	return ok
 }
 
-func (c database) Driver() driver.Driver {
-	return c
+// SetHeaderNormalizer sets the HeaderNormalizer used to match
+// the column names of queries with the column titles of the views
+// for lenient matching. Pass nil for exact matching.
+func (c *Connector) SetHeaderNormalizer(normalizer *retable.HeaderNormalizer) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.normalizer = normalizer
+}
+
+// headerNormalizer returns the HeaderNormalizer or nil
+func (c *Connector) headerNormalizer() *retable.HeaderNormalizer {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	return c.normalizer
+}
+
+// TableNames returns the sorted names of the registered views.
+func (c *Connector) TableNames() []string {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	return slices.Sorted(maps.Keys(c.views))
+}
+
+// tables returns a snapshot of the registered views
+// including the InformationSchemaTables pseudo table
+func (c *Connector) tables() map[string]retable.View {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	tables := maps.Clone(c.views)
+	if tables == nil {
+		tables = make(map[string]retable.View)
+	}
+	if _, ok := tables[InformationSchemaTables]; !ok {
+		schemaTables := &retable.AnyValuesView{
+			Tit:  InformationSchemaTables,
+			Cols: []string{"table_schema", "table_name", "table_type"},
+		}
+		for _, name := range slices.Sorted(maps.Keys(c.views)) {
+			schema, table, found := strings.Cut(name, ".")
+			if !found {
+				schema, table = "public", name
+			}
+			schemaTables.Rows = append(schemaTables.Rows, []any{schema, table, "VIEW"})
+		}
+		tables[InformationSchemaTables] = schemaTables
+	}
+	return tables
+}
+
+type database struct {
+	connector *Connector
 }
 
 func (c database) Open(string) (driver.Conn, error) {
@@ -35,11 +140,11 @@ func (c database) Open(string) (driver.Conn, error) {
 }
 
 func (c database) OpenConnector(string) (driver.Connector, error) {
-	return c, nil
+	return c.connector, nil
 }
 
 func (c database) Prepare(query string) (driver.Stmt, error) {
-	return newStmt(c.views, query)
+	return newStmt(c.connector.tables(), c.connector.headerNormalizer(), query)
 }
 
 func (database) Close() error {
@@ -0,0 +1,81 @@
+package sqltable
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-retable"
+)
+
+func TestConnector(t *testing.T) {
+	ctx := context.Background()
+	connector := NewConnector(map[string]retable.View{
+		"a": &retable.AnyValuesView{Cols: []string{"x"}, Rows: [][]any{{int64(1)}}},
+	})
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	tableNames := func() []any {
+		rows, err := db.QueryContext(ctx, `SELECT table_name FROM information_schema.tables`)
+		require.NoError(t, err)
+		view, err := ScanRowsAsView(ctx, rows)
+		require.NoError(t, err)
+		var names []any
+		for _, row := range view.Rows {
+			names = append(names, row[0])
+		}
+		return names
+	}
+	require.Equal(t, []any{"a"}, tableNames())
+
+	connector.RegisterView("my.b", &retable.AnyValuesView{Cols: []string{"y"}, Rows: [][]any{{"z"}}})
+	require.Equal(t, []string{"a", "my.b"}, connector.TableNames())
+	require.Equal(t, []any{"a", "b"}, tableNames())
+
+	rows, err := db.QueryContext(ctx, `SELECT * FROM my.b`)
+	require.NoError(t, err)
+	view, err := ScanRowsAsView(ctx, rows)
+	require.NoError(t, err)
+	require.Equal(t, []string{"y"}, view.Cols)
+	require.Equal(t, [][]any{{"z"}}, view.Rows)
+
+	require.True(t, connector.DropView("a"))
+	require.False(t, connector.DropView("a"))
+	require.Equal(t, []any{"b"}, tableNames())
+	_, err = db.QueryContext(ctx, `SELECT x FROM a`)
+	require.Error(t, err)
+}
+
+func TestConnector_SetHeaderNormalizer(t *testing.T) {
+	ctx := context.Background()
+	connector := NewConnector(map[string]retable.View{
+		"addresses": &retable.AnyValuesView{Cols: []string{"Straße ", "PLZ"}, Rows: [][]any{{"Hauptstraße 1", "1010"}}},
+	})
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	_, err := db.QueryContext(ctx, `SELECT strasse FROM addresses`)
+	require.Error(t, err, "exact column matching")
+
+	connector.SetHeaderNormalizer(retable.LenientHeaderNormalizer())
+	rows, err := db.QueryContext(ctx, `SELECT strasse, plz FROM addresses`)
+	require.NoError(t, err)
+	view, err := ScanRowsAsView(ctx, rows)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Straße ", "PLZ"}, view.Cols)
+	require.Equal(t, [][]any{{"Hauptstraße 1", "1010"}}, view.Rows)
+}
+
+func TestAmbiguousColumn(t *testing.T) {
+	ctx := context.Background()
+	db := NewViewDB("t", &retable.AnyValuesView{Cols: []string{"a", "b", "a"}, Rows: [][]any{{1, 2, 3}}})
+	defer db.Close()
+
+	_, err := db.QueryContext(ctx, `SELECT a FROM t`)
+	require.ErrorContains(t, err, "ambiguous")
+	_, err = db.QueryContext(ctx, `SELECT b FROM t`)
+	require.NoError(t, err)
+}
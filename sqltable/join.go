@@ -0,0 +1,494 @@
+package sqltable
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/domonda/go-retable"
+)
+
+// joinQuery is a parsed SELECT query with JOIN clauses like:
+//
+//	SELECT o.id, c.name FROM orders o LEFT JOIN customers AS c ON o.customer_id = c.id
+type joinQuery struct {
+	columns []selectColumn
+	tables  []tableRef
+	joins   []joinClause
+
+	// normalizer matches column references with column titles
+	normalizer *retable.HeaderNormalizer
+}
+
+type selectColumn struct {
+	ref   []string // Column reference parts, last element is "*" for all columns
+	alias string
+}
+
+type tableRef struct {
+	name  string
+	alias string
+}
+
+type joinClause struct {
+	left       bool // LEFT JOIN instead of INNER JOIN
+	conditions [][2][]string
+}
+
+// isJoinQuery returns true if the query contains a JOIN keyword.
+func isJoinQuery(query string) bool {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return false
+	}
+	for _, t := range tokens {
+		if t.isKeyword("JOIN") {
+			return true
+		}
+	}
+	return false
+}
+
+type token struct {
+	text   string
+	quoted bool
+}
+
+func (t token) isKeyword(keyword string) bool {
+	return !t.quoted && strings.EqualFold(t.text, keyword)
+}
+
+func (t token) isSymbol(symbol string) bool {
+	return !t.quoted && t.text == symbol
+}
+
+func (t token) isIdent() bool {
+	if t.quoted {
+		return true
+	}
+	c := t.text[0]
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func tokenize(query string) (tokens []token, err error) {
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"':
+			end := strings.IndexByte(query[i+1:], '"')
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated quoted identifier in query %q", query)
+			}
+			tokens = append(tokens, token{text: query[i+1 : i+1+end], quoted: true})
+			i += end + 2
+		case strings.IndexByte(",.=*;", c) != -1:
+			tokens = append(tokens, token{text: query[i : i+1]})
+			i++
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			start := i
+			for i < len(query) && (query[i] == '_' || query[i] >= 'a' && query[i] <= 'z' || query[i] >= 'A' && query[i] <= 'Z' || query[i] >= '0' && query[i] <= '9') {
+				i++
+			}
+			tokens = append(tokens, token{text: query[start:i]})
+		default:
+			return nil, fmt.Errorf("unexpected character %q in query %q", c, query)
+		}
+	}
+	return tokens, nil
+}
+
+type joinParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *joinParser) peek() token {
+	if p.pos >= len(p.tokens) {
+		return token{text: ";"} // Treat the end like a statement terminator
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *joinParser) next() token {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *joinParser) expectKeyword(keyword string) error {
+	if t := p.next(); !t.isKeyword(keyword) {
+		return fmt.Errorf("expected %s but got %q", keyword, t.text)
+	}
+	return nil
+}
+
+func (p *joinParser) isReserved(t token) bool {
+	for _, keyword := range []string{"SELECT", "FROM", "AS", "INNER", "LEFT", "OUTER", "JOIN", "ON", "AND"} {
+		if t.isKeyword(keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseName parses a dotted name like schema.table or alias.column.
+// If allowStar is true then the last part may be "*".
+func (p *joinParser) parseName(allowStar bool) ([]string, error) {
+	var parts []string
+	for {
+		t := p.next()
+		switch {
+		case allowStar && t.isSymbol("*"):
+			return append(parts, "*"), nil
+		case t.isIdent() && !p.isReserved(t):
+			parts = append(parts, t.text)
+		default:
+			return nil, fmt.Errorf("expected name but got %q", t.text)
+		}
+		if !p.peek().isSymbol(".") {
+			return parts, nil
+		}
+		p.next()
+	}
+}
+
+// parseAlias parses an optional [AS] alias
+func (p *joinParser) parseAlias() (string, error) {
+	if p.peek().isKeyword("AS") {
+		p.next()
+		t := p.next()
+		if !t.isIdent() || p.isReserved(t) {
+			return "", fmt.Errorf("expected alias after AS but got %q", t.text)
+		}
+		return t.text, nil
+	}
+	if t := p.peek(); t.isIdent() && !p.isReserved(t) {
+		p.next()
+		return t.text, nil
+	}
+	return "", nil
+}
+
+func (p *joinParser) parseTableRef() (ref tableRef, err error) {
+	name, err := p.parseName(false)
+	if err != nil {
+		return ref, err
+	}
+	ref.name = strings.Join(name, ".")
+	ref.alias, err = p.parseAlias()
+	return ref, err
+}
+
+func parseJoinQuery(query string) (*joinQuery, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &joinParser{tokens: tokens}
+	q := new(joinQuery)
+
+	if err := p.expectKeyword("SELECT"); err != nil {
+		return nil, err
+	}
+	for {
+		ref, err := p.parseName(true)
+		if err != nil {
+			return nil, err
+		}
+		col := selectColumn{ref: ref}
+		if ref[len(ref)-1] != "*" {
+			col.alias, err = p.parseAlias()
+			if err != nil {
+				return nil, err
+			}
+		}
+		q.columns = append(q.columns, col)
+		if !p.peek().isSymbol(",") {
+			break
+		}
+		p.next()
+	}
+
+	if err := p.expectKeyword("FROM"); err != nil {
+		return nil, err
+	}
+	table, err := p.parseTableRef()
+	if err != nil {
+		return nil, err
+	}
+	q.tables = append(q.tables, table)
+
+	for !p.peek().isSymbol(";") {
+		var join joinClause
+		switch t := p.next(); {
+		case t.isKeyword("INNER"):
+			err = p.expectKeyword("JOIN")
+		case t.isKeyword("LEFT"):
+			join.left = true
+			if p.peek().isKeyword("OUTER") {
+				p.next()
+			}
+			err = p.expectKeyword("JOIN")
+		case t.isKeyword("JOIN"):
+			// INNER JOIN
+		default:
+			err = fmt.Errorf("expected JOIN but got %q", t.text)
+		}
+		if err != nil {
+			return nil, err
+		}
+		table, err := p.parseTableRef()
+		if err != nil {
+			return nil, err
+		}
+		q.tables = append(q.tables, table)
+		if err := p.expectKeyword("ON"); err != nil {
+			return nil, err
+		}
+		for {
+			left, err := p.parseName(false)
+			if err != nil {
+				return nil, err
+			}
+			if t := p.next(); !t.isSymbol("=") {
+				return nil, fmt.Errorf("only equality join conditions are supported, got %q", t.text)
+			}
+			right, err := p.parseName(false)
+			if err != nil {
+				return nil, err
+			}
+			join.conditions = append(join.conditions, [2][]string{left, right})
+			if !p.peek().isKeyword("AND") {
+				break
+			}
+			p.next()
+		}
+		q.joins = append(q.joins, join)
+	}
+	for p.pos < len(p.tokens) {
+		if t := p.next(); !t.isSymbol(";") {
+			return nil, fmt.Errorf("unexpected %q after query", t.text)
+		}
+	}
+	return q, nil
+}
+
+// joinColumn is a column of the joined tables
+type joinColumn struct {
+	table int // Index into joinQuery.tables
+	title string
+}
+
+// execute joins the referenced views and returns the result as view.
+func (q *joinQuery) execute(views map[string]retable.View) (retable.View, error) {
+	var (
+		tableViews []retable.View
+		columns    []joinColumn
+	)
+	for i, table := range q.tables {
+		view := views[table.name]
+		if view == nil {
+			return nil, fmt.Errorf("view %q not found", table.name)
+		}
+		tableViews = append(tableViews, view)
+		for _, title := range view.Columns() {
+			columns = append(columns, joinColumn{table: i, title: title})
+		}
+	}
+
+	// Start with the rows of the first table
+	numTableCols := func(table int) int { return len(tableViews[table].Columns()) }
+	var rows [][]any
+	for row := 0; row < tableViews[0].NumRows(); row++ {
+		rows = append(rows, viewRow(tableViews[0], row))
+	}
+	numJoinedCols := numTableCols(0)
+
+	for i, join := range q.joins {
+		table := i + 1
+		right := tableViews[table]
+		var leftCols, rightCols []int
+		for _, cond := range join.conditions {
+			a, err := q.resolveColumn(columns, cond[0], table)
+			if err != nil {
+				return nil, err
+			}
+			b, err := q.resolveColumn(columns, cond[1], table)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case columns[a].table == table && columns[b].table < table:
+				a, b = b, a
+			case columns[b].table == table && columns[a].table < table:
+			default:
+				return nil, fmt.Errorf("join condition %s = %s must compare a column of %s with a column of a previous table", strings.Join(cond[0], "."), strings.Join(cond[1], "."), q.tables[table].name)
+			}
+			leftCols = append(leftCols, a)
+			rightCols = append(rightCols, b-numJoinedCols)
+		}
+
+		rightRowsByKey := make(map[string][]int)
+		for row := 0; row < right.NumRows(); row++ {
+			if key, ok := joinKey(right, row, rightCols); ok {
+				rightRowsByKey[key] = append(rightRowsByKey[key], row)
+			}
+		}
+		leftView := &retable.AnyValuesView{Rows: rows}
+		var joined [][]any
+		for leftRow, values := range rows {
+			var matches []int
+			if key, ok := joinKey(leftView, leftRow, leftCols); ok {
+				matches = rightRowsByKey[key]
+			}
+			for _, rightRow := range matches {
+				joined = append(joined, append(values[:numJoinedCols:numJoinedCols], viewRow(right, rightRow)...))
+			}
+			if len(matches) == 0 && join.left {
+				joined = append(joined, append(values[:numJoinedCols:numJoinedCols], make([]any, numTableCols(table))...))
+			}
+		}
+		rows = joined
+		numJoinedCols += numTableCols(table)
+	}
+
+	// Project the selected columns
+	var (
+		resultCols    []string
+		resultIndices []int
+	)
+	for _, selected := range q.columns {
+		if last := len(selected.ref) - 1; selected.ref[last] == "*" {
+			table := -1
+			if last > 0 {
+				var err error
+				table, err = q.resolveTable(selected.ref[:last])
+				if err != nil {
+					return nil, err
+				}
+			}
+			for i, col := range columns {
+				if table == -1 || col.table == table {
+					resultCols = append(resultCols, col.title)
+					resultIndices = append(resultIndices, i)
+				}
+			}
+			continue
+		}
+		i, err := q.resolveColumn(columns, selected.ref, len(q.tables)-1)
+		if err != nil {
+			return nil, err
+		}
+		title := selected.alias
+		if title == "" {
+			title = columns[i].title
+		}
+		resultCols = append(resultCols, title)
+		resultIndices = append(resultIndices, i)
+	}
+	result := &retable.AnyValuesView{
+		Tit:  q.tables[0].name,
+		Cols: resultCols,
+		Rows: make([][]any, len(rows)),
+	}
+	for row, values := range rows {
+		result.Rows[row] = make([]any, len(resultIndices))
+		for col, i := range resultIndices {
+			result.Rows[row][col] = values[i]
+		}
+	}
+	return result, nil
+}
+
+// resolveTable returns the index of the table with the qualifier as alias or name.
+func (q *joinQuery) resolveTable(qualifier []string) (int, error) {
+	name := strings.Join(qualifier, ".")
+	for i, table := range q.tables {
+		if table.alias == name || table.alias == "" && table.name == name {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("table %q not found in query", name)
+}
+
+// resolveColumn returns the index into columns for the passed
+// column reference searching the tables up to maxTable.
+func (q *joinQuery) resolveColumn(columns []joinColumn, ref []string, maxTable int) (int, error) {
+	table := -1
+	if len(ref) > 1 {
+		var err error
+		table, err = q.resolveTable(ref[:len(ref)-1])
+		if err != nil {
+			return -1, err
+		}
+	}
+	title := ref[len(ref)-1]
+	found := -1
+	for i, col := range columns {
+		if !q.normalizer.Equal(col.title, title) || col.table > maxTable || table != -1 && col.table != table {
+			continue
+		}
+		if found != -1 {
+			return -1, fmt.Errorf("column reference %q is ambiguous", title)
+		}
+		found = i
+	}
+	if found == -1 {
+		return -1, fmt.Errorf("column %q not found", strings.Join(ref, "."))
+	}
+	return found, nil
+}
+
+func viewRow(view retable.View, row int) []any {
+	values := make([]any, len(view.Columns()))
+	for col := range values {
+		values[col] = view.Cell(row, col)
+	}
+	return values
+}
+
+// joinKey returns a string key for the values of the passed columns
+// or false if any of the values is NULL because NULL never equals anything.
+// Numbers are normalized so that different integer types compare as equal.
+func joinKey(view retable.View, row int, cols []int) (string, bool) {
+	var b strings.Builder
+	for _, col := range cols {
+		val := view.Cell(row, col)
+		if retable.IsNullLike(reflect.ValueOf(val)) {
+			return "", false
+		}
+		if b.Len() > 0 {
+			b.WriteByte(0)
+		}
+		fmt.Fprintf(&b, "%#v", normalizeJoinValue(val))
+	}
+	return b.String(), true
+}
+
+func normalizeJoinValue(val any) any {
+	v := reflect.Indirect(reflect.ValueOf(val))
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := v.Uint(); u <= 1<<63-1 {
+			return int64(u)
+		}
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f == float64(int64(f)) {
+			return int64(f)
+		}
+		return f
+	case reflect.String:
+		return v.String()
+	case reflect.Slice:
+		if b, ok := v.Interface().([]byte); ok {
+			return string(b)
+		}
+	}
+	return v.Interface()
+}
@@ -0,0 +1,64 @@
+package sqltable
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-retable"
+)
+
+func TestJoinQuery(t *testing.T) {
+	views := map[string]retable.View{
+		"orders": &retable.AnyValuesView{
+			Cols: []string{"id", "customer_id", "amount"},
+			Rows: [][]any{{int64(1), int64(10), 5.0}, {int64(2), int64(20), 7.5}, {int64(3), 99, 1.0}, {int64(4), nil, 2.0}},
+		},
+		"customers": &retable.AnyValuesView{
+			Cols: []string{"id", "name"},
+			Rows: [][]any{{int64(10), "Alice"}, {int64(20), "Bob"}},
+		},
+	}
+	tests := []struct {
+		query    string
+		wantCols []string
+		wantRows [][]any
+		wantErr  bool
+	}{
+		{
+			query:    `SELECT o.id, c.name FROM orders o INNER JOIN customers AS c ON o.customer_id = c.id`,
+			wantCols: []string{"id", "name"},
+			wantRows: [][]any{{int64(1), "Alice"}, {int64(2), "Bob"}},
+		},
+		{
+			query:    `select o.id AS order_id, name from orders o left outer join customers c on c.id = o.customer_id;`,
+			wantCols: []string{"order_id", "name"},
+			wantRows: [][]any{{int64(1), "Alice"}, {int64(2), "Bob"}, {int64(3), nil}, {int64(4), nil}},
+		},
+		{
+			query:    `SELECT c.*, amount FROM orders JOIN customers c ON customer_id = c.id`,
+			wantCols: []string{"id", "name", "amount"},
+			wantRows: [][]any{{int64(10), "Alice", 5.0}, {int64(20), "Bob", 7.5}},
+		},
+		{query: `SELECT id FROM orders o JOIN customers c ON o.customer_id = c.id`, wantErr: true},       // ambiguous
+		{query: `SELECT o.id FROM orders o JOIN missing m ON o.customer_id = m.id`, wantErr: true},       // unknown table
+		{query: `SELECT o.id FROM orders o JOIN customers c ON o.customer_id < c.id`, wantErr: true},     // not equality
+		{query: `SELECT o.id FROM orders o JOIN customers c ON o.amount = o.customer_id`, wantErr: true}, // same table
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			db := NewViewsDB(views)
+			rows, err := db.QueryContext(context.Background(), tt.query)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			view, err := ScanRowsAsView(context.Background(), rows)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCols, view.Cols)
+			require.Equal(t, tt.wantRows, view.Rows)
+		})
+	}
+}
@@ -18,7 +18,19 @@ type stmt struct {
 	view retable.View
 }
 
-func newStmt(views map[string]retable.View, query string) (*stmt, error) {
+func newStmt(views map[string]retable.View, normalizer *retable.HeaderNormalizer, query string) (*stmt, error) {
+	if isJoinQuery(query) {
+		q, err := parseJoinQuery(query)
+		if err != nil {
+			return nil, err
+		}
+		q.normalizer = normalizer
+		view, err := q.execute(views)
+		if err != nil {
+			return nil, err
+		}
+		return &stmt{view: view}, nil
+	}
 	queryColumns, table, offset, limit, err := parseQuery(query)
 	if err != nil {
 		return nil, err
@@ -28,6 +40,9 @@ func newStmt(views map[string]retable.View, query string) (*stmt, error) {
 		return nil, fmt.Errorf("view %q not found", table)
 	}
 	sourceColumns := view.Columns()
+	if len(queryColumns) == 1 && queryColumns[0] == "*" {
+		queryColumns = sourceColumns
+	}
 	columnsIdentical := slices.Equal(queryColumns, sourceColumns)
 	if columnsIdentical && offset == 0 && limit == 0 {
 		return &stmt{view: view}, nil
@@ -40,10 +55,13 @@ func newStmt(views map[string]retable.View, query string) (*stmt, error) {
 	if !columnsIdentical {
 		filtered.ColumnMapping = make([]int, len(queryColumns))
 		for i, queryColumn := range queryColumns {
-			filtered.ColumnMapping[i] = slices.Index(sourceColumns, queryColumn)
+			filtered.ColumnMapping[i] = normalizer.Index(sourceColumns, queryColumn)
 			if filtered.ColumnMapping[i] == -1 {
 				return nil, fmt.Errorf("column %q not found", queryColumn)
 			}
+			if slices.IndexFunc(sourceColumns[filtered.ColumnMapping[i]+1:], func(c string) bool { return normalizer.Equal(c, queryColumn) }) != -1 {
+				return nil, fmt.Errorf("column reference %q is ambiguous", queryColumn)
+			}
 		}
 	}
 	return &stmt{view: filtered}, nil
@@ -0,0 +1,106 @@
+package sqltable
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+
+	"github.com/domonda/go-retable"
+)
+
+// ValuesRows is a minimal interface for iterating result rows
+// of database drivers that don't implement database/sql
+// and return the values of a row as slice, like pgx.Rows.
+type ValuesRows interface {
+	// Next prepares the next row for reading with Values.
+	// It returns false if there is no next row or an error happened.
+	Next() bool
+
+	// Values returns the values of the current row.
+	Values() ([]any, error)
+
+	// Err returns the error, if any, that was encountered during iteration.
+	Err() error
+}
+
+// ValuesRowsAsView reads all rows into an AnyValuesView.
+//
+// If columns is nil then the column names are read from rows
+// if it implements one of the methods
+//
+//	Columns() ([]string, error)
+//	Columns() []string
+//	FieldDescriptions() []T // where T is a struct with a Name string field like in pgx
+//
+// otherwise the column names will be empty strings.
+//
+// If rows implements Close() or Close() error then it is closed
+// after reading.
+func ValuesRowsAsView(ctx context.Context, columns []string, rows ValuesRows) (view *retable.AnyValuesView, err error) {
+	defer func() {
+		switch r := rows.(type) {
+		case interface{ Close() error }:
+			err = errors.Join(err, r.Close())
+		case interface{ Close() }:
+			r.Close()
+		}
+	}()
+
+	if columns == nil {
+		columns, err = rowsColumns(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+	view = &retable.AnyValuesView{Cols: columns}
+	for rows.Next() {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		values, err := rows.Values()
+		if err != nil {
+			return view, err
+		}
+		// Copy values because drivers may reuse the slice and byte buffers
+		row := make([]any, max(len(columns), len(values)))
+		for i, value := range values {
+			if b, ok := value.([]byte); ok {
+				value = slices.Clone(b)
+			}
+			row[i] = value
+		}
+		if len(row) > len(view.Cols) {
+			view.Cols = append(view.Cols, make([]string, len(row)-len(view.Cols))...)
+		}
+		view.Rows = append(view.Rows, row)
+	}
+	return view, rows.Err()
+}
+
+func rowsColumns(rows any) ([]string, error) {
+	switch r := rows.(type) {
+	case interface{ Columns() ([]string, error) }:
+		return r.Columns()
+	case interface{ Columns() []string }:
+		return r.Columns(), nil
+	}
+	// Use reflection to support pgx.Rows.FieldDescriptions
+	// without importing pgx
+	method := reflect.ValueOf(rows).MethodByName("FieldDescriptions")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 || method.Type().Out(0).Kind() != reflect.Slice {
+		return nil, nil
+	}
+	descriptions := method.Call(nil)[0]
+	columns := make([]string, descriptions.Len())
+	for i := range columns {
+		desc := reflect.Indirect(descriptions.Index(i))
+		if desc.Kind() != reflect.Struct {
+			return nil, nil
+		}
+		if name := desc.FieldByName("Name"); name.Kind() == reflect.String {
+			columns[i] = name.String()
+		}
+	}
+	return columns, nil
+}
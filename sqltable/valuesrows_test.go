@@ -0,0 +1,50 @@
+package sqltable
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fieldDescription struct {
+	Name string
+	OID  uint32
+}
+
+// pgxLikeRows mimics the methods of pgx.Rows
+type pgxLikeRows struct {
+	rows   [][]any
+	row    int
+	closed bool
+}
+
+func (r *pgxLikeRows) FieldDescriptions() []fieldDescription {
+	return []fieldDescription{{Name: "id"}, {Name: "data"}}
+}
+
+func (r *pgxLikeRows) Next() bool {
+	if r.row >= len(r.rows) {
+		return false
+	}
+	r.row++
+	return true
+}
+
+func (r *pgxLikeRows) Values() ([]any, error) { return r.rows[r.row-1], nil }
+func (r *pgxLikeRows) Err() error             { return nil }
+func (r *pgxLikeRows) Close()                 { r.closed = true }
+
+func TestValuesRowsAsView(t *testing.T) {
+	rows := &pgxLikeRows{rows: [][]any{{1, []byte("a")}, {2, nil}}}
+	view, err := ValuesRowsAsView(context.Background(), nil, rows)
+	require.NoError(t, err)
+	require.True(t, rows.closed, "rows closed")
+	require.Equal(t, []string{"id", "data"}, view.Columns())
+	require.Equal(t, [][]any{{1, []byte("a")}, {2, nil}}, view.Rows)
+
+	rows = &pgxLikeRows{rows: [][]any{{1, "x", true}}}
+	view, err = ValuesRowsAsView(context.Background(), []string{"a"}, rows)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "", ""}, view.Columns())
+}
@@ -0,0 +1,80 @@
+package sqlwrite
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/domonda/go-retable"
+)
+
+// CopyFromSource implements the pgx.CopyFromSource interface
+// for the rows of a View without importing pgx:
+//
+//	src := sqlwrite.NewCopyFromSource(view)
+//	n, err := conn.CopyFrom(ctx, pgx.Identifier{"items"}, src.Columns(), src)
+//
+// The values slice returned by Values is reused for every row
+// to minimize allocations, which is safe because pgx encodes
+// the values of a row before calling Next again.
+type CopyFromSource struct {
+	view     retable.View
+	viewCols []int
+	columns  []string
+	row      int
+	values   []any
+	err      error
+}
+
+// NewCopyFromSource returns a CopyFromSource for view.
+// Optional columns select the view columns by title
+// in the order in which they are passed,
+// else all view columns are used.
+// An unknown column name is returned as error from Err.
+func NewCopyFromSource(view retable.View, columns ...string) *CopyFromSource {
+	src := &CopyFromSource{view: view, row: -1}
+	if len(columns) == 0 {
+		columns = view.Columns()
+	}
+	src.columns = columns
+	src.viewCols = make([]int, len(columns))
+	for i, column := range columns {
+		src.viewCols[i] = slices.Index(view.Columns(), column)
+		if src.viewCols[i] == -1 && src.err == nil {
+			src.err = fmt.Errorf("column %q not found in view %q", column, view.Title())
+		}
+	}
+	src.values = make([]any, len(columns))
+	return src
+}
+
+// Columns returns the column names
+// to pass to pgx.Conn.CopyFrom.
+func (src *CopyFromSource) Columns() []string {
+	return src.columns
+}
+
+// Next returns true if there is another row
+// and makes the next row the current row.
+func (src *CopyFromSource) Next() bool {
+	if src.err != nil || src.row+1 >= src.view.NumRows() {
+		return false
+	}
+	src.row++
+	return true
+}
+
+// Values returns the values of the current row.
+func (src *CopyFromSource) Values() ([]any, error) {
+	if src.err != nil {
+		return nil, src.err
+	}
+	for i, col := range src.viewCols {
+		src.values[i] = src.view.Cell(src.row, col)
+	}
+	return src.values, nil
+}
+
+// Err returns any error that has been encountered.
+func (src *CopyFromSource) Err() error {
+	return src.err
+}
@@ -0,0 +1,31 @@
+package sqlwrite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-retable"
+)
+
+func TestCopyFromSource(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Cols: []string{"id", "name"},
+		Rows: [][]any{{1, "A"}, {2, nil}},
+	}
+
+	src := NewCopyFromSource(view, "name", "id")
+	require.Equal(t, []string{"name", "id"}, src.Columns())
+	var rows [][]any
+	for src.Next() {
+		values, err := src.Values()
+		require.NoError(t, err)
+		rows = append(rows, append([]any(nil), values...))
+	}
+	require.NoError(t, src.Err())
+	require.Equal(t, [][]any{{"A", 1}, {nil, 2}}, rows)
+
+	src = NewCopyFromSource(view, "missing")
+	require.False(t, src.Next())
+	require.Error(t, src.Err())
+}
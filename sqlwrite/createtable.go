@@ -0,0 +1,217 @@
+package sqlwrite
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/domonda/go-retable"
+)
+
+// ColumnDef defines a table column for GenerateCreateTable.
+type ColumnDef struct {
+	// Name of the column
+	Name string
+	// Type is the Go type of the column values
+	// that is mapped to an SQL type by Dialect.ColumnType
+	Type reflect.Type
+	// NotNull adds a NOT NULL constraint
+	NotNull bool
+	// PrimaryKey makes the column part of the primary key
+	PrimaryKey bool
+}
+
+// StructColumnDefs returns the column definitions
+// for the exported fields of a struct or struct pointer
+// with the column names of naming.
+//
+// Fields with pointer, interface, map, or slice types
+// and sql.Null types are nullable, all other fields are NOT NULL.
+// Fields with a "pk" option in the struct field tag
+// named naming.Tag are part of the primary key:
+//
+//	ID int64 `db:"id,pk"`
+func StructColumnDefs(strct any, naming *retable.StructFieldNaming) []ColumnDef {
+	mapping := retable.NewStructColumnMapping(reflect.TypeOf(strct), naming)
+	columns := make([]ColumnDef, 0, len(mapping.Columns()))
+	for col, name := range mapping.Columns() {
+		field := mapping.Field(col)
+		pk := hasTagOption(field, naming, "pk")
+		columns = append(columns, ColumnDef{
+			Name:       name,
+			Type:       field.Type,
+			NotNull:    pk || !isNullableType(field.Type),
+			PrimaryKey: pk,
+		})
+	}
+	return columns
+}
+
+// ViewColumnDefs returns nullable column definitions
+// for the columns of view with the type of the
+// first non null value of every column.
+// Columns with only null values have the type string.
+func ViewColumnDefs(view retable.View) []ColumnDef {
+	reflectView := retable.AsReflectCellView(view)
+	columns := make([]ColumnDef, len(view.Columns()))
+	for col, name := range view.Columns() {
+		columns[col] = ColumnDef{Name: name, Type: reflect.TypeFor[string]()}
+		for row := 0; row < view.NumRows(); row++ {
+			v := reflectView.ReflectCell(row, col)
+			if !retable.IsNullLike(v) {
+				columns[col].Type = v.Type()
+				break
+			}
+		}
+	}
+	return columns
+}
+
+// GenerateCreateTable returns a CREATE TABLE statement
+// for the table tableName with the passed columns.
+func GenerateCreateTable(tableName string, columns []ColumnDef, dialect Dialect) (string, error) {
+	if len(columns) == 0 {
+		return "", errors.New("no columns for table")
+	}
+	var (
+		b          strings.Builder
+		primaryKey []string
+	)
+	fmt.Fprintf(&b, "CREATE TABLE %s (\n", dialect.QuoteIdentifier(tableName))
+	for i, column := range columns {
+		if column.Name == "" {
+			return "", fmt.Errorf("column %d has no name", i)
+		}
+		if i > 0 {
+			b.WriteString(",\n")
+		}
+		sqlType := dialect.ColumnType(column.Type)
+		if column.PrimaryKey && dialect == MySQL && sqlType == "TEXT" {
+			// MySQL can't index TEXT columns without a prefix length
+			sqlType = "VARCHAR(255)"
+		}
+		fmt.Fprintf(&b, "\t%s %s", dialect.QuoteIdentifier(column.Name), sqlType)
+		if column.NotNull {
+			b.WriteString(" NOT NULL")
+		}
+		if column.PrimaryKey {
+			primaryKey = append(primaryKey, dialect.QuoteIdentifier(column.Name))
+		}
+	}
+	if len(primaryKey) > 0 {
+		fmt.Fprintf(&b, ",\n\tPRIMARY KEY (%s)", strings.Join(primaryKey, ", "))
+	}
+	b.WriteString("\n)")
+	return b.String(), nil
+}
+
+var (
+	typeOfTime          = reflect.TypeFor[time.Time]()
+	typeOfBytes         = reflect.TypeFor[[]byte]()
+	typeOfValuer        = reflect.TypeFor[driver.Valuer]()
+	typeOfTextMarshaler = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// ColumnType returns the SQL column type of the dialect
+// for values of the Go type t.
+// Structs, maps, slices other than []byte, and arrays
+// are mapped to JSON types unless they implement
+// driver.Valuer or encoding.TextMarshaler like UUIDs or decimals,
+// which are mapped to text because they are not written as JSON.
+// sql.Null types like sql.NullString are mapped
+// to the type of their value.
+func (d Dialect) ColumnType(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if valueType, ok := nullTypeValueType(t); ok {
+		t = valueType
+	}
+	types := func(postgres, mysql, sqlite string) string {
+		switch d {
+		case MySQL:
+			return mysql
+		case SQLite:
+			return sqlite
+		}
+		return postgres
+	}
+	switch {
+	case t == typeOfTime:
+		return types("timestamptz", "DATETIME", "DATETIME")
+	case t == typeOfBytes:
+		return types("bytea", "BLOB", "BLOB")
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return types("boolean", "BOOLEAN", "INTEGER")
+	case reflect.Int8, reflect.Int16, reflect.Uint8:
+		return types("smallint", "SMALLINT", "INTEGER")
+	case reflect.Int32, reflect.Uint16:
+		return types("integer", "INT", "INTEGER")
+	case reflect.Int, reflect.Int64, reflect.Uint32:
+		return types("bigint", "BIGINT", "INTEGER")
+	case reflect.Uint, reflect.Uint64:
+		return types("numeric(20)", "BIGINT UNSIGNED", "INTEGER")
+	case reflect.Float32:
+		return types("real", "FLOAT", "REAL")
+	case reflect.Float64:
+		return types("double precision", "DOUBLE", "REAL")
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		if implementsValuerOrTextMarshaler(t) {
+			return types("text", "TEXT", "TEXT")
+		}
+		return types("jsonb", "JSON", "TEXT")
+	}
+	return types("text", "TEXT", "TEXT")
+}
+
+// nullTypeValueType returns the type of the value field
+// of sql.Null types that are structs with
+// a value field followed by a Valid bool field.
+func nullTypeValueType(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() != reflect.Struct || t.NumField() != 2 {
+		return nil, false
+	}
+	if valid := t.Field(1); valid.Name != "Valid" || valid.Type.Kind() != reflect.Bool {
+		return nil, false
+	}
+	return t.Field(0).Type, true
+}
+
+// implementsValuerOrTextMarshaler returns if t or a pointer to t
+// implements driver.Valuer or encoding.TextMarshaler.
+func implementsValuerOrTextMarshaler(t reflect.Type) bool {
+	for _, iface := range []reflect.Type{typeOfValuer, typeOfTextMarshaler} {
+		if t.Implements(iface) || reflect.PointerTo(t).Implements(iface) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNullableType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		return true
+	}
+	_, isNullType := nullTypeValueType(t)
+	return isNullType
+}
+
+func hasTagOption(field reflect.StructField, naming *retable.StructFieldNaming, option string) bool {
+	if naming == nil || naming.Tag == "" {
+		return false
+	}
+	tag, ok := field.Tag.Lookup(naming.Tag)
+	if !ok {
+		return false
+	}
+	_, options, _ := strings.Cut(tag, ",")
+	return slices.Contains(strings.Split(options, ","), option)
+}
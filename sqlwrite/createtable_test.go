@@ -0,0 +1,104 @@
+package sqlwrite
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-retable"
+)
+
+type createTableRow struct {
+	ID      string         `db:"id,pk"`
+	Amount  float64        `db:"amount"`
+	Count   *int32         `db:"count"`
+	Note    sql.NullString `db:"note"`
+	Created time.Time      `db:"created"`
+	Tags    []string       `db:"tags"`
+	Skip    bool           `db:"-"`
+	Meta    rawJSON        `db:"meta"`
+	UUID    valuerUUID     `db:"uuid"`
+	Amount2 textAmount     `db:"amount2"`
+}
+
+type rawJSON []byte
+
+// valuerUUID is stored as [16]byte but written as string
+type valuerUUID [16]byte
+
+func (u valuerUUID) Value() (driver.Value, error) {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:]), nil
+}
+
+// textAmount is a struct that is written as text
+type textAmount struct {
+	Cents int64
+}
+
+func (a *textAmount) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "%d.%02d", a.Cents/100, a.Cents%100), nil
+}
+
+func TestGenerateCreateTable(t *testing.T) {
+	naming := &retable.StructFieldNaming{Tag: "db", Ignore: "-"}
+	columns := StructColumnDefs(createTableRow{}, naming)
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{
+			dialect: PostgreSQL,
+			want: `CREATE TABLE "staging"."rows" (` + "\n" +
+				`	"id" text NOT NULL,` + "\n" +
+				`	"amount" double precision NOT NULL,` + "\n" +
+				`	"count" integer,` + "\n" +
+				`	"note" text,` + "\n" +
+				`	"created" timestamptz NOT NULL,` + "\n" +
+				`	"tags" jsonb,` + "\n" +
+				`	"meta" jsonb,` + "\n" +
+				`	"uuid" text NOT NULL,` + "\n" +
+				`	"amount2" text NOT NULL,` + "\n" +
+				`	PRIMARY KEY ("id")` + "\n" +
+				`)`,
+		},
+		{
+			dialect: MySQL,
+			want: "CREATE TABLE `staging`.`rows` (\n" +
+				"\t`id` VARCHAR(255) NOT NULL,\n" +
+				"\t`amount` DOUBLE NOT NULL,\n" +
+				"\t`count` INT,\n" +
+				"\t`note` TEXT,\n" +
+				"\t`created` DATETIME NOT NULL,\n" +
+				"\t`tags` JSON,\n" +
+				"\t`meta` JSON,\n" +
+				"\t`uuid` TEXT NOT NULL,\n" +
+				"\t`amount2` TEXT NOT NULL,\n" +
+				"\tPRIMARY KEY (`id`)\n" +
+				")",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dialect.String(), func(t *testing.T) {
+			got, err := GenerateCreateTable("staging.rows", columns, tt.dialect)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := GenerateCreateTable("empty", nil, PostgreSQL)
+	require.Error(t, err)
+}
+
+func TestViewColumnDefs(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Cols: []string{"a", "b", "c"},
+		Rows: [][]any{{nil, int64(1), nil}, {true, int64(2), nil}},
+	}
+	got, err := GenerateCreateTable("t", ViewColumnDefs(view), SQLite)
+	require.NoError(t, err)
+	require.Equal(t, "CREATE TABLE \"t\" (\n\t\"a\" INTEGER,\n\t\"b\" INTEGER,\n\t\"c\" TEXT\n)", got)
+}
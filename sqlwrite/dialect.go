@@ -0,0 +1,66 @@
+package sqlwrite
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dialect of the SQL database used for placeholders,
+// identifier quoting, and upsert syntax.
+type Dialect int
+
+const (
+	// PostgreSQL uses $1, $2, ... placeholders
+	// and ON CONFLICT for upserts.
+	PostgreSQL Dialect = iota
+	// MySQL uses ? placeholders, backtick quoted identifiers,
+	// and ON DUPLICATE KEY UPDATE for upserts.
+	MySQL
+	// SQLite uses ? placeholders and ON CONFLICT for upserts.
+	SQLite
+)
+
+func (d Dialect) String() string {
+	switch d {
+	case PostgreSQL:
+		return "PostgreSQL"
+	case MySQL:
+		return "MySQL"
+	case SQLite:
+		return "SQLite"
+	}
+	return fmt.Sprintf("Dialect(%d)", int(d))
+}
+
+// Placeholder returns the placeholder for the
+// query argument with the 1 based index n.
+func (d Dialect) Placeholder(n int) string {
+	if d == PostgreSQL {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// QuoteIdentifier quotes a table or column name.
+// Dots separate schema and table name which are quoted individually.
+func (d Dialect) QuoteIdentifier(name string) string {
+	quote := `"`
+	if d == MySQL {
+		quote = "`"
+	}
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = quote + strings.ReplaceAll(part, quote, quote+quote) + quote
+	}
+	return strings.Join(parts, ".")
+}
+
+// MaxArgs returns the maximum number of
+// query arguments supported by the dialect.
+func (d Dialect) MaxArgs() int {
+	if d == SQLite {
+		return 32766
+	}
+	return 65535
+}
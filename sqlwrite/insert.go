@@ -0,0 +1,264 @@
+// Package sqlwrite loads views into SQL database tables
+// using batched INSERT statements.
+package sqlwrite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/domonda/go-retable"
+)
+
+// DefaultBatchSize is the number of rows
+// inserted per statement if InsertOptions.BatchSize is not set.
+const DefaultBatchSize = 1000
+
+// Execer is implemented by *sql.DB, *sql.Tx, and *sql.Conn.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// InsertOptions configure InsertView.
+// The zero value inserts all view columns
+// into a PostgreSQL table in batches of DefaultBatchSize rows.
+type InsertOptions struct {
+	// Dialect of the database
+	Dialect Dialect
+
+	// BatchSize is the maximum number of rows per INSERT statement.
+	// Values <= 0 use DefaultBatchSize.
+	// The batch size is reduced if the number of query arguments
+	// would exceed Dialect.MaxArgs.
+	BatchSize int
+
+	// Columns are the table column names for the view columns.
+	// If nil, the view column titles are used.
+	// Empty strings skip the view column at the same index.
+	Columns []string
+
+	// ConflictColumns turns the inserts into upserts
+	// that update existing rows with the same values in those columns.
+	// MySQL ignores ConflictColumns and uses the
+	// primary key and unique indexes of the table instead,
+	// but at least one column must be set to enable upserts.
+	ConflictColumns []string
+
+	// UpdateColumns are the columns updated by an upsert.
+	// If nil, all inserted columns except the ConflictColumns are updated.
+	UpdateColumns []string
+
+	// DoNothingOnConflict skips rows that conflict with existing rows
+	// instead of updating them.
+	// For PostgreSQL and SQLite without ConflictColumns,
+	// conflicts with any unique constraint are ignored.
+	DoNothingOnConflict bool
+
+	// CollectRowErrors continues the insert after a failed batch
+	// by retrying the rows of the batch one by one
+	// and collecting the errors of the failed rows
+	// as retable.RowErrors that are returned as error.
+	//
+	// Don't use it with a PostgreSQL transaction
+	// because a failed statement aborts the transaction.
+	CollectRowErrors bool
+
+	// MaxRowErrors is the maximum number of failed rows
+	// collected with CollectRowErrors before the insert is aborted.
+	// Values <= 0 don't limit the number of failed rows.
+	MaxRowErrors int
+}
+
+// InsertView inserts all rows of view into the table tableName
+// using batched INSERT statements executed with db.
+// The view cells are passed as query arguments,
+// so they must be types supported by the database driver
+// or implement driver.Valuer.
+//
+// Use a *sql.Tx as db to insert all rows in one transaction.
+//
+// With InsertOptions.CollectRowErrors the errors of single rows
+// are returned as *retable.RowErrors after all other rows are inserted.
+func InsertView(ctx context.Context, db Execer, tableName string, view retable.View, opts *InsertOptions) error {
+	if opts == nil {
+		opts = new(InsertOptions)
+	}
+	columns := opts.Columns
+	if columns == nil {
+		columns = view.Columns()
+	}
+	var (
+		viewCols  []int
+		insertCol []string
+	)
+	for col, name := range columns {
+		if name != "" && col < len(view.Columns()) {
+			viewCols = append(viewCols, col)
+			insertCol = append(insertCol, name)
+		}
+	}
+	if len(insertCol) == 0 {
+		return errors.New("no columns to insert")
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	batchSize = max(min(batchSize, opts.Dialect.MaxArgs()/len(insertCol)), 1)
+
+	rowErrs := retable.NewRowErrors(opts.MaxRowErrors)
+	numRows := view.NumRows()
+	for first := 0; first < numRows; first += batchSize {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		last := min(first+batchSize, numRows)
+		args := make([]any, 0, (last-first)*len(insertCol))
+		for row := first; row < last; row++ {
+			for _, col := range viewCols {
+				args = append(args, view.Cell(row, col))
+			}
+		}
+		query, err := InsertQuery(tableName, insertCol, last-first, opts)
+		if err != nil {
+			return err
+		}
+		_, err = db.ExecContext(ctx, query, args...)
+		if err != nil && opts.CollectRowErrors {
+			err = insertRowsOneByOne(ctx, db, tableName, insertCol, args, first, opts, rowErrs)
+		}
+		if err != nil {
+			return fmt.Errorf("can't insert rows %d to %d into %s: %w", first, last-1, tableName, err)
+		}
+	}
+	return rowErrs.Err()
+}
+
+// insertRowsOneByOne inserts the rows of a failed batch
+// starting at the view row first one by one
+// and adds the errors of the failed rows to rowErrs.
+// rowErrs is returned as error if its limit is exceeded.
+func insertRowsOneByOne(ctx context.Context, db Execer, tableName string, columns []string, args []any, first int, opts *InsertOptions, rowErrs *retable.RowErrors) error {
+	query, err := InsertQuery(tableName, columns, 1, opts)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(args); i += len(columns) {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		_, err = db.ExecContext(ctx, query, args[i:i+len(columns)]...)
+		if !rowErrs.Add(first+i/len(columns), err) {
+			return rowErrs
+		}
+	}
+	return nil
+}
+
+// InsertQuery returns an INSERT statement for numRows rows
+// with placeholders for the values of the passed columns.
+// Only the Dialect and the upsert options of opts are used.
+func InsertQuery(tableName string, columns []string, numRows int, opts *InsertOptions) (string, error) {
+	if opts == nil {
+		opts = new(InsertOptions)
+	}
+	if len(columns) == 0 {
+		return "", errors.New("no columns to insert")
+	}
+	if numRows <= 0 {
+		return "", fmt.Errorf("invalid number of rows %d", numRows)
+	}
+	d := opts.Dialect
+	upsert := len(opts.ConflictColumns) > 0
+	update := updateColumns(columns, opts)
+
+	var b strings.Builder
+	if d == MySQL && (opts.DoNothingOnConflict || upsert && len(update) == 0) {
+		b.WriteString("INSERT IGNORE INTO ")
+	} else {
+		b.WriteString("INSERT INTO ")
+	}
+	b.WriteString(d.QuoteIdentifier(tableName))
+	b.WriteString(" (")
+	for i, column := range columns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(d.QuoteIdentifier(column))
+	}
+	b.WriteString(") VALUES ")
+	arg := 1
+	for row := 0; row < numRows; row++ {
+		if row > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('(')
+		for i := range columns {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(d.Placeholder(arg))
+			arg++
+		}
+		b.WriteByte(')')
+	}
+
+	if d == MySQL {
+		if upsert && !opts.DoNothingOnConflict && len(update) > 0 {
+			b.WriteString(" ON DUPLICATE KEY UPDATE ")
+			for i, column := range update {
+				if i > 0 {
+					b.WriteString(", ")
+				}
+				quoted := d.QuoteIdentifier(column)
+				fmt.Fprintf(&b, "%s = VALUES(%s)", quoted, quoted)
+			}
+		}
+		return b.String(), nil
+	}
+
+	if !upsert {
+		if opts.DoNothingOnConflict {
+			b.WriteString(" ON CONFLICT DO NOTHING")
+		}
+		return b.String(), nil
+	}
+	b.WriteString(" ON CONFLICT (")
+	for i, column := range opts.ConflictColumns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(d.QuoteIdentifier(column))
+	}
+	b.WriteString(")")
+	if opts.DoNothingOnConflict || len(update) == 0 {
+		b.WriteString(" DO NOTHING")
+		return b.String(), nil
+	}
+	b.WriteString(" DO UPDATE SET ")
+	for i, column := range update {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		quoted := d.QuoteIdentifier(column)
+		fmt.Fprintf(&b, "%s = EXCLUDED.%s", quoted, quoted)
+	}
+	return b.String(), nil
+}
+
+func updateColumns(columns []string, opts *InsertOptions) []string {
+	if opts.UpdateColumns != nil {
+		return opts.UpdateColumns
+	}
+	var update []string
+	for _, column := range columns {
+		if !slices.Contains(opts.ConflictColumns, column) {
+			update = append(update, column)
+		}
+	}
+	return update
+}
@@ -0,0 +1,118 @@
+package sqlwrite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-retable"
+)
+
+type execCall struct {
+	query string
+	args  []any
+}
+
+type recordingExecer struct {
+	calls []execCall
+}
+
+func (r *recordingExecer) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	r.calls = append(r.calls, execCall{query, args})
+	return nil, nil
+}
+
+func TestInsertView(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Cols: []string{"id", "name", "note"},
+		Rows: [][]any{{1, "A", "x"}, {2, "B", nil}, {3, "C", "z"}},
+	}
+	db := new(recordingExecer)
+	err := InsertView(context.Background(), db, "public.items", view, &InsertOptions{
+		BatchSize:       2,
+		Columns:         []string{"id", "name", ""},
+		ConflictColumns: []string{"id"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []execCall{
+		{
+			query: `INSERT INTO "public"."items" ("id", "name") VALUES ($1, $2), ($3, $4) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"`,
+			args:  []any{1, "A", 2, "B"},
+		},
+		{
+			query: `INSERT INTO "public"."items" ("id", "name") VALUES ($1, $2) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"`,
+			args:  []any{3, "C"},
+		},
+	}, db.calls)
+}
+
+// failingExecer fails all statements with an argument equal to fail
+type failingExecer struct {
+	recordingExecer
+	fail any
+}
+
+func (f *failingExecer) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if slices.Contains(args, f.fail) {
+		return nil, errors.New("constraint violation")
+	}
+	return f.recordingExecer.ExecContext(ctx, query, args...)
+}
+
+func TestInsertView_CollectRowErrors(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Cols: []string{"id", "name"},
+		Rows: [][]any{{1, "A"}, {2, "invalid"}, {3, "C"}, {4, "invalid"}},
+	}
+
+	db := &failingExecer{fail: "invalid"}
+	err := InsertView(context.Background(), db, "items", view, &InsertOptions{BatchSize: 3})
+	require.Error(t, err, "abort on first failed batch")
+	require.Empty(t, db.calls)
+
+	db = &failingExecer{fail: "invalid"}
+	err = InsertView(context.Background(), db, "items", view, &InsertOptions{BatchSize: 3, CollectRowErrors: true})
+	var rowErrs *retable.RowErrors
+	require.ErrorAs(t, err, &rowErrs)
+	require.Equal(t, []int{1, 3}, rowErrs.RowIndices())
+	require.Equal(t, []execCall{
+		{query: `INSERT INTO "items" ("id", "name") VALUES ($1, $2)`, args: []any{1, "A"}},
+		{query: `INSERT INTO "items" ("id", "name") VALUES ($1, $2)`, args: []any{3, "C"}},
+	}, db.calls)
+
+	db = &failingExecer{fail: "invalid"}
+	err = InsertView(context.Background(), db, "items", view, &InsertOptions{BatchSize: 3, CollectRowErrors: true, MaxRowErrors: 1})
+	require.ErrorAs(t, err, &rowErrs)
+	require.True(t, rowErrs.LimitExceeded())
+	require.Equal(t, []int{1}, rowErrs.RowIndices())
+}
+
+func TestInsertQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *InsertOptions
+		want    string
+		wantErr bool
+	}{
+		{name: "nil options", opts: nil, want: `INSERT INTO "t" ("a", "b") VALUES ($1, $2), ($3, $4)`},
+		{name: "SQLite do nothing", opts: &InsertOptions{Dialect: SQLite, DoNothingOnConflict: true}, want: `INSERT INTO "t" ("a", "b") VALUES (?, ?), (?, ?) ON CONFLICT DO NOTHING`},
+		{name: "MySQL upsert", opts: &InsertOptions{Dialect: MySQL, ConflictColumns: []string{"a"}}, want: "INSERT INTO `t` (`a`, `b`) VALUES (?, ?), (?, ?) ON DUPLICATE KEY UPDATE `b` = VALUES(`b`)"},
+		{name: "MySQL ignore", opts: &InsertOptions{Dialect: MySQL, DoNothingOnConflict: true}, want: "INSERT IGNORE INTO `t` (`a`, `b`) VALUES (?, ?), (?, ?)"},
+		{name: "no update columns", opts: &InsertOptions{ConflictColumns: []string{"a", "b"}}, want: `INSERT INTO "t" ("a", "b") VALUES ($1, $2), ($3, $4) ON CONFLICT ("a", "b") DO NOTHING`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InsertQuery("t", []string{"a", "b"}, 2, tt.opts)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
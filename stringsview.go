@@ -7,9 +7,12 @@ import (
 // StringsView is a View that uses strings as values.
 // Cols defines the column names and number of columns.
 //
-// StringsView is a sparse table int the sense that
+// StringsView is a sparse table in the sense that
 // a row within Rows can have fewer slice elements
 // than Cols in which case empty strings are used as value.
+// The cells beyond the length of a row are missing cells
+// of a SparseCellView, so writers render them as empty cells
+// without calling column formatters or applying a NullPolicy.
 type StringsView struct {
 	Tit  string
 	Cols []string
@@ -37,6 +40,12 @@ func (view *StringsView) Title() string     { return view.Tit }
 func (view *StringsView) Columns() []string { return view.Cols }
 func (view *StringsView) NumRows() int      { return len(view.Rows) }
 
+// CellExists implements SparseCellView by returning false
+// for cells beyond the length of a row.
+func (view *StringsView) CellExists(row, col int) bool {
+	return row >= 0 && col >= 0 && row < len(view.Rows) && col < len(view.Cols) && col < len(view.Rows[row])
+}
+
 func (view *StringsView) Cell(row, col int) any {
 	if row < 0 || col < 0 || row >= len(view.Rows) || col >= len(view.Cols) {
 		return nil
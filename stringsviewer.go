@@ -8,14 +8,56 @@ var _ Viewer = new(StringsViewer)
 // using Cols as optional column names.
 type StringsViewer struct {
 	Cols []string
+	// DuplicateColumns is the policy for duplicate column names
+	DuplicateColumns DuplicateColumnPolicy
 }
 
 // NewView creates a View with the passed title
-// for the passed table which must be of type [][]string.
+// for the passed table which must be of type [][]string
+// or *[][]string where a nil pointer is handled like no rows.
 func (v StringsViewer) NewView(title string, table any) (View, error) {
-	rows, ok := table.([][]string)
-	if !ok {
+	var rows [][]string
+	switch t := table.(type) {
+	case [][]string:
+		rows = t
+	case *[][]string:
+		if t != nil {
+			rows = *t
+		}
+	default:
 		return nil, fmt.Errorf("expected table of type [][]string, but got %T", table)
 	}
-	return NewStringsView(title, rows, v.Cols...), nil
+	view := NewStringsView(title, rows, v.Cols...)
+	cols, keep, err := v.DuplicateColumns.Apply(view.Cols)
+	if err != nil {
+		return nil, err
+	}
+	view.Cols = cols
+	for _, k := range keep {
+		if !k {
+			dropColumns(view, keep)
+			break
+		}
+	}
+	return view, nil
+}
+
+// dropColumns removes the columns and row cells
+// of view where keep is false.
+func dropColumns(view *StringsView, keep []bool) {
+	filter := func(row []string) []string {
+		filtered := make([]string, 0, len(row))
+		for col, cell := range row {
+			if col >= len(keep) || keep[col] {
+				filtered = append(filtered, cell)
+			}
+		}
+		return filtered
+	}
+	view.Cols = filter(view.Cols)
+	rows := make([][]string, len(view.Rows))
+	for i, row := range view.Rows {
+		rows[i] = filter(row)
+	}
+	view.Rows = rows
 }
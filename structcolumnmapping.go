@@ -0,0 +1,161 @@
+package retable
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// StructColumnMapping is the mapping of the columns
+// of a struct type to its fields built once
+// from the struct type and a StructFieldNaming
+// to avoid repeated reflection when reading
+// or writing many rows.
+//
+// Ignored fields are not part of the mapping.
+// A StructColumnMapping is immutable and safe for concurrent use.
+type StructColumnMapping struct {
+	structType  reflect.Type
+	naming      *StructFieldNaming
+	columns     []string
+	fields      []reflect.StructField
+	indexPaths  [][]int
+	columnIndex map[string]int
+}
+
+// NewStructColumnMapping returns the StructColumnMapping
+// for a struct or struct pointer type using naming
+// which may be nil.
+//
+// It panics for non struct or struct pointer types.
+func NewStructColumnMapping(structType reflect.Type, naming *StructFieldNaming) *StructColumnMapping {
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		panic("expected struct or pointer to struct instead of " + structType.String())
+	}
+	m := &StructColumnMapping{
+		structType:  structType,
+		naming:      naming,
+		columns:     make([]string, 0, structType.NumField()),
+		columnIndex: make(map[string]int),
+	}
+	m.addFields(structType, nil, "")
+	return m
+}
+
+// StructColumnMappingFor returns the StructColumnMapping
+// for the struct or struct pointer type T using naming.
+func StructColumnMappingFor[T any](naming *StructFieldNaming) *StructColumnMapping {
+	return NewStructColumnMapping(reflect.TypeFor[T](), naming)
+}
+
+func (m *StructColumnMapping) addFields(t reflect.Type, indexPath []int, prefix string) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	for i := range t.NumField() {
+		field := t.Field(i)
+		fieldPath := append(indexPath[:len(indexPath):len(indexPath)], i)
+		if field.Anonymous {
+			// Recurse into anonymous embedded structs
+			m.addFields(field.Type, fieldPath, prefix+m.naming.embeddedPrefix(field))
+			continue
+		}
+		column := m.naming.StructFieldColumn(field)
+		if m.naming.IsIgnored(column) {
+			continue
+		}
+		column = prefix + column
+		if _, exists := m.columnIndex[column]; !exists {
+			m.columnIndex[column] = len(m.columns)
+		}
+		m.columns = append(m.columns, column)
+		m.fields = append(m.fields, field)
+		m.indexPaths = append(m.indexPaths, fieldPath)
+	}
+}
+
+// StructType returns the mapped struct type.
+func (m *StructColumnMapping) StructType() reflect.Type {
+	return m.structType
+}
+
+// Columns returns the column titles of the mapped fields.
+// The returned slice must not be modified.
+func (m *StructColumnMapping) Columns() []string {
+	return m.columns
+}
+
+// ColumnIndex returns the index of the first column
+// matching name using the HeaderNormalizer of the naming
+// or -1 if no field is mapped to the column.
+func (m *StructColumnMapping) ColumnIndex(name string) int {
+	if col, ok := m.columnIndex[name]; ok {
+		return col
+	}
+	return m.naming.headerNormalizer().Index(m.columns, name)
+}
+
+// Field returns the struct field of the column with index col.
+func (m *StructColumnMapping) Field(col int) reflect.StructField {
+	return m.fields[col]
+}
+
+// FieldIndexPath returns the index path of the struct field
+// of the column with index col for reflect.Value.FieldByIndex.
+// The returned slice must not be modified.
+func (m *StructColumnMapping) FieldIndexPath(col int) []int {
+	return m.indexPaths[col]
+}
+
+// FieldValue returns the field of structVal
+// for the column with index col.
+// structVal must be a struct or pointer to a struct
+// of the mapped type.
+// Nil pointers to embedded structs are allocated
+// if they are settable, else an invalid reflect.Value
+// is returned for their fields.
+func (m *StructColumnMapping) FieldValue(structVal reflect.Value, col int) reflect.Value {
+	if structVal.Kind() == reflect.Pointer {
+		structVal = structVal.Elem()
+	}
+	if structVal.Type() != m.structType {
+		panic(fmt.Sprintf("expected struct type %s instead of %s", m.structType, structVal.Type()))
+	}
+	v := structVal
+	for i, index := range m.indexPaths[col] {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(index)
+	}
+	return v
+}
+
+// ColumnFieldValue returns the field of structVal
+// for the column matching name, see ColumnIndex and FieldValue,
+// or an invalid reflect.Value if no field is mapped to the column.
+func (m *StructColumnMapping) ColumnFieldValue(structVal reflect.Value, name string) reflect.Value {
+	col := m.ColumnIndex(name)
+	if col < 0 {
+		return reflect.Value{}
+	}
+	return m.FieldValue(structVal, col)
+}
+
+// columnIndices returns the index of the mapped column
+// for every column of viewCols or -1 for unmapped columns.
+func (m *StructColumnMapping) columnIndices(viewCols []string) []int {
+	indices := make([]int, len(viewCols))
+	for i, column := range viewCols {
+		indices[i] = m.ColumnIndex(column)
+	}
+	return indices
+}
@@ -0,0 +1,49 @@
+package retable
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructColumnMapping(t *testing.T) {
+	type Address struct {
+		Street string `col:"Street"`
+		City   string `col:"City"`
+	}
+	type Customer struct {
+		*Address
+		Name     string `col:"Name"`
+		Internal string `col:"-"`
+		Email    string `col:"E-Mail"`
+		hidden   int
+	}
+	mapping := StructColumnMappingFor[*Customer](&DefaultStructFieldNaming)
+	require.Equal(t, reflect.TypeFor[Customer](), mapping.StructType())
+	require.Equal(t, []string{"Street", "City", "Name", "E-Mail"}, mapping.Columns())
+	require.Equal(t, 1, mapping.ColumnIndex("City"))
+	require.Equal(t, 3, mapping.ColumnIndex("E-Mail"))
+	require.Equal(t, -1, mapping.ColumnIndex("Internal"))
+	require.Equal(t, -1, mapping.ColumnIndex("-"))
+	require.Equal(t, -1, mapping.ColumnIndex("e-mail"))
+	require.Equal(t, []int{0, 1}, mapping.FieldIndexPath(1))
+	require.Equal(t, []int{3}, mapping.FieldIndexPath(3))
+	require.Equal(t, "Email", mapping.Field(3).Name)
+
+	var customer Customer
+	mapping.ColumnFieldValue(reflect.ValueOf(&customer), "E-Mail").SetString("a@example.com")
+	mapping.ColumnFieldValue(reflect.ValueOf(&customer), "City").SetString("Vienna")
+	require.Equal(t, Customer{Address: &Address{City: "Vienna"}, Email: "a@example.com"}, customer)
+	require.False(t, mapping.ColumnFieldValue(reflect.ValueOf(&customer), "Unknown").IsValid())
+
+	// Nil embedded pointers of not settable values can't be allocated
+	require.False(t, mapping.FieldValue(reflect.ValueOf(Customer{}), 0).IsValid())
+	require.Panics(t, func() { mapping.FieldValue(reflect.ValueOf(Address{}), 0) })
+
+	lenient := StructColumnMappingFor[Customer](DefaultStructFieldNaming.WithHeaderNormalizer(LenientHeaderNormalizer()))
+	require.Equal(t, 3, lenient.ColumnIndex(" e-mail"))
+
+	prefixed := StructColumnMappingFor[Customer](DefaultStructFieldNaming.WithPrefixEmbedded(true))
+	require.Equal(t, []string{"Address.Street", "Address.City", "Name", "E-Mail"}, prefixed.Columns())
+}
@@ -0,0 +1,90 @@
+package retable
+
+import (
+	"reflect"
+)
+
+// StructFieldMapping describes how a struct field
+// is mapped to a column by a StructFieldNaming.
+type StructFieldMapping struct {
+	// Field is the name of the struct field,
+	// prefixed with the names of embedded structs
+	Field string `col:"Field"`
+	// Tag is the value of the struct field tag
+	// named StructFieldNaming.Tag
+	Tag string `col:"Tag"`
+	// Column is the column title of the field
+	Column string `col:"Column"`
+	// Type is the Go type of the field
+	Type string `col:"Type"`
+	// Format is the format option of the struct field tag
+	Format string `col:"Format"`
+	// Ignored is true if the field is not mapped to a column
+	Ignored bool `col:"Ignored"`
+}
+
+// FieldMappings returns how the exported fields
+// of a struct or pointer to a struct are mapped to columns
+// including the ignored fields,
+// for example to document export formats.
+//
+// It panics for non struct or struct pointer types.
+//
+// Valid to call with nil receiver.
+func (n *StructFieldNaming) FieldMappings(strct any) []StructFieldMapping {
+	return n.fieldMappings(reflect.TypeOf(strct), "", "")
+}
+
+func (n *StructFieldNaming) fieldMappings(t reflect.Type, fieldPrefix, columnPrefix string) []StructFieldMapping {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		panic("expected struct or pointer to struct instead of " + t.String())
+	}
+	var mappings []StructFieldMapping
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if field.Anonymous {
+			// Recurse into anonymous embedded structs
+			mappings = append(mappings, n.fieldMappings(field.Type, fieldPrefix+field.Name+".", columnPrefix+n.embeddedPrefix(field))...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		mapping := StructFieldMapping{
+			Field:  fieldPrefix + field.Name,
+			Column: n.prefixColumn(columnPrefix, n.StructFieldColumn(field)),
+			Type:   field.Type.String(),
+			Format: n.StructFieldFormat(field),
+		}
+		if n != nil && n.Tag != "" {
+			mapping.Tag = field.Tag.Get(n.Tag)
+		}
+		mapping.Ignored = n.IsIgnored(mapping.Column)
+		if mapping.Ignored {
+			mapping.Column = ""
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings
+}
+
+// FieldMappingsView returns a View of the FieldMappings
+// of a struct or pointer to a struct titled with the struct type name
+// that can be written as documentation table
+// with the HTML or Markdown writers.
+//
+// Valid to call with nil receiver.
+func (n *StructFieldNaming) FieldMappingsView(strct any) View {
+	t := reflect.TypeOf(strct)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	view, err := DefaultStructRowsViewer().NewView(t.Name(), n.FieldMappings(strct))
+	if err != nil {
+		panic(err) // Can't happen for []StructFieldMapping
+	}
+	return view
+}
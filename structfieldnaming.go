@@ -2,7 +2,9 @@ package retable
 
 import (
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"strings"
 )
 
@@ -28,6 +30,22 @@ type StructFieldNaming struct {
 	// return a title in case the struct field has no tag named Tag.
 	// If Untagged is nil, then the struct field name will be used.
 	Untagged func(fieldName string) (column string)
+	// Overrides maps struct field names to column titles
+	// taking precedence over Tag and Untagged
+	// for struct types whose tags can't be modified,
+	// like types from other modules.
+	// Map a field name to Ignore to ignore the field.
+	Overrides map[string]string
+	// PrefixEmbedded prefixes the column titles of the fields
+	// of anonymously embedded structs with the tagged name
+	// or the field name of the embedded struct and a dot
+	// like "Invoice.Total" to disambiguate embedded structs
+	// with the same field names.
+	PrefixEmbedded bool
+	// HeaderNormalizer is used to match the columns of read views
+	// with the column titles of struct fields for lenient matching.
+	// If nil, then columns have to match exactly.
+	HeaderNormalizer *HeaderNormalizer
 }
 
 // String implements the fmt.Stringer interface for StructFieldNaming.
@@ -50,6 +68,9 @@ func (n *StructFieldNaming) StructFieldColumn(field reflect.StructField) string
 	if n == nil {
 		return field.Name
 	}
+	if column, ok := n.Overrides[field.Name]; ok {
+		return column
+	}
 	if n.Tag != "" {
 		if tag, ok := field.Tag.Lookup(n.Tag); ok {
 			if i := strings.IndexByte(tag, ','); i != -1 {
@@ -66,18 +87,68 @@ func (n *StructFieldNaming) StructFieldColumn(field reflect.StructField) string
 	return n.Untagged(field.Name)
 }
 
+// StructFieldFormat returns the value of the format option
+// of the struct field tag named Tag or an empty string.
+// The format option follows the column title
+// separated by a comma, for example:
+//
+//	Margin float64 `col:"Margin,format=percent:2"`
+//
+// See ParseCellFormat for the supported formats.
+//
+// Valid to call with nil receiver.
+func (n *StructFieldNaming) StructFieldFormat(field reflect.StructField) string {
+	if n == nil || n.Tag == "" {
+		return ""
+	}
+	tag, ok := field.Tag.Lookup(n.Tag)
+	if !ok {
+		return ""
+	}
+	_, options, _ := strings.Cut(tag, ",")
+	for _, option := range strings.Split(options, ",") {
+		if format, ok := strings.CutPrefix(option, "format="); ok {
+			return format
+		}
+	}
+	return ""
+}
+
+// StructFieldHidden returns true if the struct field tag
+// named Tag has the HiddenTagOption, for example:
+//
+//	ID int64 `col:"ID,hidden"`
+//
+// Valid to call with nil receiver.
+func (n *StructFieldNaming) StructFieldHidden(field reflect.StructField) bool {
+	if n == nil || n.Tag == "" {
+		return false
+	}
+	tag, ok := field.Tag.Lookup(n.Tag)
+	if !ok {
+		return false
+	}
+	_, options, _ := strings.Cut(tag, ",")
+	return slices.Contains(strings.Split(options, ","), HiddenTagOption)
+}
+
 func (n *StructFieldNaming) IsIgnored(column string) bool {
 	return column == "" || (n != nil && column == n.Ignore)
 }
 
 // ColumnStructFieldValue returns the reflect.Value of the struct field
-// that is mapped to the column title.
+// that is mapped to the column title
+// matched using the HeaderNormalizer.
 //
 // Valid to call with nil receiver.
 func (n *StructFieldNaming) ColumnStructFieldValue(structVal reflect.Value, column string) reflect.Value {
 	if n.IsIgnored(column) {
 		return reflect.Value{}
 	}
+	return n.columnStructFieldValue(structVal, column, "")
+}
+
+func (n *StructFieldNaming) columnStructFieldValue(structVal reflect.Value, column, prefix string) reflect.Value {
 	if structVal.Kind() == reflect.Pointer {
 		structVal = structVal.Elem()
 	}
@@ -89,12 +160,12 @@ func (n *StructFieldNaming) ColumnStructFieldValue(structVal reflect.Value, colu
 		field := structType.Field(i)
 		if field.Anonymous {
 			// Recurse into anonymous embedded structs
-			if v := n.ColumnStructFieldValue(structVal.Field(i), column); v.IsValid() {
+			if v := n.columnStructFieldValue(structVal.Field(i), column, prefix+n.embeddedPrefix(field)); v.IsValid() {
 				return v
 			}
 			continue
 		}
-		if n.StructFieldColumn(field) == column {
+		if n.headerNormalizer().Equal(prefix+n.StructFieldColumn(field), column) {
 			return structVal.Field(i)
 		}
 	}
@@ -112,6 +183,13 @@ func (n *StructFieldNaming) Columns(strct any) []string {
 }
 
 func (n *StructFieldNaming) columns(t reflect.Type) []string {
+	return NewStructColumnMapping(t, n).Columns()
+}
+
+// structFieldColumns returns the column titles for the
+// fields returned by StructFieldTypes(t) including
+// the ignored columns and the prefixes of embedded structs.
+func (n *StructFieldNaming) structFieldColumns(t reflect.Type, prefix string) []string {
 	if t.Kind() == reflect.Pointer {
 		t = t.Elem()
 	}
@@ -121,19 +199,45 @@ func (n *StructFieldNaming) columns(t reflect.Type) []string {
 	columns := make([]string, 0, t.NumField())
 	for i := range t.NumField() {
 		field := t.Field(i)
-		if field.Anonymous {
+		switch {
+		case field.Anonymous:
 			// Recurse into anonymous embedded structs
-			columns = append(columns, n.columns(field.Type)...)
-			continue
-		}
-		column := n.StructFieldColumn(field)
-		if !n.IsIgnored(column) {
-			columns = append(columns, column)
+			columns = append(columns, n.structFieldColumns(field.Type, prefix+n.embeddedPrefix(field))...)
+		case field.IsExported():
+			columns = append(columns, n.prefixColumn(prefix, n.StructFieldColumn(field)))
 		}
 	}
 	return columns
 }
 
+// embeddedPrefix returns the column title prefix
+// for the fields of the anonymously embedded struct field
+// or an empty string if PrefixEmbedded is false.
+func (n *StructFieldNaming) embeddedPrefix(field reflect.StructField) string {
+	if n == nil || !n.PrefixEmbedded {
+		return ""
+	}
+	name := field.Name
+	if n.Tag != "" {
+		if tag, ok := field.Tag.Lookup(n.Tag); ok {
+			tag, _, _ = strings.Cut(tag, ",")
+			if tag != "" && tag != n.Ignore {
+				name = tag
+			}
+		}
+	}
+	return name + "."
+}
+
+// prefixColumn returns column with prefix
+// unless the column is ignored.
+func (n *StructFieldNaming) prefixColumn(prefix, column string) string {
+	if prefix == "" || n.IsIgnored(column) {
+		return column
+	}
+	return prefix + column
+}
+
 // NewView returns a View for a table made up of
 // a slice or array of structs.
 // NewView implements the Viewer interface for StructFieldNaming.
@@ -153,3 +257,41 @@ func (n *StructFieldNaming) WithIgnore(ignore string) *StructFieldNaming {
 	mod.Ignore = ignore
 	return &mod
 }
+
+// WithPrefixEmbedded returns a copy of the naming
+// with StructFieldNaming.PrefixEmbedded set.
+func (n *StructFieldNaming) WithPrefixEmbedded(prefixEmbedded bool) *StructFieldNaming {
+	mod := *n
+	mod.PrefixEmbedded = prefixEmbedded
+	return &mod
+}
+
+// WithOverride returns a copy of the naming
+// that maps the struct field fieldName to column,
+// see StructFieldNaming.Overrides.
+func (n *StructFieldNaming) WithOverride(fieldName, column string) *StructFieldNaming {
+	mod := *n
+	mod.Overrides = maps.Clone(n.Overrides)
+	if mod.Overrides == nil {
+		mod.Overrides = make(map[string]string)
+	}
+	mod.Overrides[fieldName] = column
+	return &mod
+}
+
+// WithHeaderNormalizer returns a copy of the naming
+// using normalizer to match columns with struct fields.
+func (n *StructFieldNaming) WithHeaderNormalizer(normalizer *HeaderNormalizer) *StructFieldNaming {
+	mod := *n
+	mod.HeaderNormalizer = normalizer
+	return &mod
+}
+
+// headerNormalizer returns the HeaderNormalizer
+// or nil if n is nil.
+func (n *StructFieldNaming) headerNormalizer() *HeaderNormalizer {
+	if n == nil {
+		return nil
+	}
+	return n.HeaderNormalizer
+}
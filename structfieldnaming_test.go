@@ -94,6 +94,20 @@ func TestStructFieldNaming_Columns(t *testing.T) {
 			}{},
 			want: []string{"Int", "float", "Struct"},
 		},
+		{
+			name: "overrides, DefaultStructFieldNaming",
+			naming: DefaultStructFieldNaming.
+				WithOverride("Int", "Integer").
+				WithOverride("Bool", "-").
+				WithOverride("Float", "Number"),
+			strct: struct {
+				Int        int  `col:"int"`
+				Bool       bool `col:"boolean"`
+				HelloWorld string
+				StructWithFloat
+			}{},
+			want: []string{"Integer", "Hello World", "Number"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -102,3 +116,137 @@ func TestStructFieldNaming_Columns(t *testing.T) {
 		})
 	}
 }
+
+func TestStructFieldNaming_FieldMappings(t *testing.T) {
+	type Embedded struct {
+		ID string `col:"ID"`
+	}
+	type Invoice struct {
+		Embedded
+		Number   string  `col:"Invoice Number"`
+		Amount   float64 `col:"Amount,format=money:2"`
+		Internal string  `col:"-"`
+		Untagged bool
+		private  int
+	}
+	tests := []struct {
+		name   string
+		naming *StructFieldNaming
+		strct  any
+		want   []StructFieldMapping
+	}{
+		{
+			name:   "empty struct",
+			naming: &DefaultStructFieldNaming,
+			strct:  struct{}{},
+			want:   nil,
+		},
+		{
+			name:   "default naming",
+			naming: &DefaultStructFieldNaming,
+			strct:  new(Invoice),
+			want: []StructFieldMapping{
+				{Field: "Embedded.ID", Tag: "ID", Column: "ID", Type: "string"},
+				{Field: "Number", Tag: "Invoice Number", Column: "Invoice Number", Type: "string"},
+				{Field: "Amount", Tag: "Amount,format=money:2", Column: "Amount", Type: "float64", Format: "money:2"},
+				{Field: "Internal", Tag: "-", Type: "string", Ignored: true},
+				{Field: "Untagged", Column: "Untagged", Type: "bool"},
+			},
+		},
+		{
+			name:   "nil naming",
+			naming: nil,
+			strct:  Invoice{},
+			want: []StructFieldMapping{
+				{Field: "Embedded.ID", Column: "ID", Type: "string"},
+				{Field: "Number", Column: "Number", Type: "string"},
+				{Field: "Amount", Column: "Amount", Type: "float64"},
+				{Field: "Internal", Column: "Internal", Type: "string"},
+				{Field: "Untagged", Column: "Untagged", Type: "bool"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.naming.FieldMappings(tt.strct)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStructFieldNaming_FieldMappingsView(t *testing.T) {
+	type Row struct {
+		Name   string `col:"Name"`
+		Secret string `col:"-"`
+	}
+	view := DefaultStructFieldNaming.FieldMappingsView(Row{})
+	require.Equal(t, "Row", view.Title())
+	require.Equal(t, []string{"Field", "Tag", "Column", "Type", "Format", "Ignored"}, view.Columns())
+	require.Equal(t, 2, view.NumRows())
+	require.Equal(t, "Secret", view.Cell(1, 0))
+	require.Equal(t, true, view.Cell(1, 5))
+}
+
+func TestStructFieldNaming_WithOverride(t *testing.T) {
+	type Row struct {
+		Name string `col:"name"`
+	}
+	base := DefaultStructFieldNaming.WithOverride("Name", "Full Name")
+	mod := base.WithOverride("Name", "Last Name")
+	require.Equal(t, []string{"Full Name"}, base.Columns(Row{}), "original not modified")
+	require.Equal(t, []string{"Last Name"}, mod.Columns(Row{}))
+	require.Nil(t, DefaultStructFieldNaming.Overrides)
+
+	var rows []Row
+	err := RowsToStructs(NewStringsView("", [][]string{{"Last Name"}, {"Doe"}}), &rows, mod)
+	require.NoError(t, err)
+	require.Equal(t, []Row{{Name: "Doe"}}, rows)
+}
+
+func TestStructFieldNaming_PrefixEmbedded(t *testing.T) {
+	type Amounts struct {
+		Total float64
+		Tax   float64 `col:"tax"`
+	}
+	type Invoice struct {
+		Amounts
+		Number string
+	}
+	type Row struct {
+		Invoice
+		Amounts `col:"Credit"`
+		Note    string `col:"-"`
+	}
+	naming := DefaultStructFieldNaming.WithPrefixEmbedded(true)
+
+	require.Equal(t,
+		[]string{"Total", "tax", "Number", "Total", "tax"},
+		DefaultStructFieldNaming.Columns(Row{}),
+		"duplicate columns without prefix",
+	)
+	require.Equal(t,
+		[]string{"Invoice.Amounts.Total", "Invoice.Amounts.tax", "Invoice.Number", "Credit.Total", "Credit.tax"},
+		naming.Columns(Row{}),
+	)
+
+	rows := []Row{{
+		Invoice: Invoice{Amounts: Amounts{Total: 100, Tax: 20}, Number: "1"},
+		Amounts: Amounts{Total: -10, Tax: -2},
+	}}
+	view, err := naming.NewView("", rows)
+	require.NoError(t, err)
+	require.Equal(t, naming.Columns(Row{}), view.Columns())
+	require.Equal(t, -10.0, view.Cell(0, 3))
+
+	var read []Row
+	err = RowsToStructs(view, &read, naming)
+	require.NoError(t, err)
+	require.Equal(t, rows, read)
+
+	mappings := naming.FieldMappings(Row{})
+	require.Equal(t, "Invoice.Amounts.Total", mappings[0].Field)
+	require.Equal(t, "Invoice.Amounts.Total", mappings[0].Column)
+	require.Equal(t, "Amounts.Tax", mappings[4].Field)
+	require.Equal(t, "Credit.tax", mappings[4].Column)
+	require.Equal(t, "", mappings[5].Column, "ignored")
+}
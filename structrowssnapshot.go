@@ -0,0 +1,54 @@
+package retable
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// StructRowsSnapshot defines if a StructRowsViewer copies
+// the struct rows when creating a View so that later
+// modifications of the table don't affect the View.
+type StructRowsSnapshot int
+
+const (
+	// SnapshotNone shares the rows with the table.
+	// Modifications of the struct rows are visible in the View,
+	// but cached values of the last read row may be stale
+	// until StructRowsView.InvalidateCache is called.
+	// If the table was passed as pointer to a slice,
+	// then the View follows the slice pointed to,
+	// so appended or removed rows are visible.
+	SnapshotNone StructRowsSnapshot = iota
+	// SnapshotRows copies the rows into a new slice
+	// at View creation. Data referenced by the rows
+	// like pointers, slices, and maps is still shared.
+	SnapshotRows
+	// SnapshotDeep deep copies the rows at View creation,
+	// see CloneView for the aliasing guarantees.
+	SnapshotDeep
+)
+
+func (s StructRowsSnapshot) String() string {
+	switch s {
+	case SnapshotNone:
+		return "SnapshotNone"
+	case SnapshotRows:
+		return "SnapshotRows"
+	case SnapshotDeep:
+		return "SnapshotDeep"
+	}
+	return fmt.Sprintf("StructRowsSnapshot(%d)", int(s))
+}
+
+// Rows returns rows or a copy of it depending on s.
+func (s StructRowsSnapshot) Rows(rows reflect.Value) reflect.Value {
+	switch s {
+	case SnapshotRows:
+		c := reflect.MakeSlice(reflect.SliceOf(rows.Type().Elem()), rows.Len(), rows.Len())
+		reflect.Copy(c, rows)
+		return c
+	case SnapshotDeep:
+		return deepCopyValue(rows, make(map[seenPointer]reflect.Value))
+	}
+	return rows
+}
@@ -10,6 +10,15 @@ type StructRowsView struct {
 	columns []string
 	indices []int         // nil for 1:1 mapping of columns to struct fields
 	rows    reflect.Value // slice of structs
+	// tablePtr is the pointer to the slice of rows
+	// if the view follows the slice pointed to, else invalid
+	tablePtr reflect.Value
+	// formatters of the columns from struct field tags, nil if none
+	formatters []CellFormatter
+	// descriptions of the columns from struct field tags, nil if none
+	descriptions []string
+	// hidden columns from struct field tags, nil if none
+	hidden []bool
 
 	cachedRow           int
 	cachedValues        []any
@@ -68,16 +77,68 @@ func is1on1Mapping(columns []string, indices []int) bool {
 
 func (view *StructRowsView) Title() string     { return view.title }
 func (view *StructRowsView) Columns() []string { return view.columns }
-func (view *StructRowsView) NumRows() int      { return view.rows.Len() }
+func (view *StructRowsView) NumRows() int      { return view.currentRows().Len() }
+
+// InvalidateCache discards the cached values of the last read row
+// so that modifications of the struct rows become visible.
+func (view *StructRowsView) InvalidateCache() {
+	view.cachedRow = -1
+	view.cachedValues = nil
+	view.cachedReflectValues = nil
+}
+
+// currentRows returns the rows of the view
+// after updating them from tablePtr
+// if the slice pointed to has changed.
+func (view *StructRowsView) currentRows() reflect.Value {
+	if !view.tablePtr.IsValid() {
+		return view.rows
+	}
+	rows := derefTable(view.tablePtr)
+	if rows.Len() != view.rows.Len() || rows.Pointer() != view.rows.Pointer() {
+		view.rows = rows
+		view.InvalidateCache()
+	}
+	return view.rows
+}
+
+// ColumnFormatter implements ColumnFormatterView
+// by returning the formatter for the format option
+// of the struct field tag of the column or nil.
+func (view *StructRowsView) ColumnFormatter(col int) CellFormatter {
+	if col < 0 || col >= len(view.formatters) {
+		return nil
+	}
+	return view.formatters[col]
+}
+
+// ColumnDescription implements ColumnDescriptionView
+// by returning the DescriptionTag of the struct field
+// of the column or an empty string.
+func (view *StructRowsView) ColumnDescription(col int) string {
+	if col < 0 || col >= len(view.descriptions) {
+		return ""
+	}
+	return view.descriptions[col]
+}
+
+// ColumnHidden implements ColumnVisibilityView
+// by returning if the struct field tag of the column
+// has the HiddenTagOption.
+func (view *StructRowsView) ColumnHidden(col int) bool {
+	if col < 0 || col >= len(view.hidden) {
+		return false
+	}
+	return view.hidden[col]
+}
 
 func (view *StructRowsView) Cell(row, col int) any {
-	if row < 0 || col < 0 || row >= view.rows.Len() || col >= len(view.columns) {
+	if row < 0 || col < 0 || row >= view.currentRows().Len() || col >= len(view.columns) {
 		return nil
 	}
 	if row != view.cachedRow {
+		view.InvalidateCache()
 		view.cachedRow = row
-		view.cachedValues = nil
-		view.cachedReflectValues = nil
 	}
 	if view.cachedValues == nil {
 		if view.indices != nil {
@@ -90,13 +151,12 @@ func (view *StructRowsView) Cell(row, col int) any {
 }
 
 func (view *StructRowsView) ReflectCell(row, col int) reflect.Value {
-	if row < 0 || col < 0 || row >= view.rows.Len() || col >= len(view.columns) {
+	if row < 0 || col < 0 || row >= view.currentRows().Len() || col >= len(view.columns) {
 		return reflect.Value{}
 	}
 	if row != view.cachedRow {
+		view.InvalidateCache()
 		view.cachedRow = row
-		view.cachedValues = nil
-		view.cachedReflectValues = nil
 	}
 	if view.cachedReflectValues == nil {
 		if view.indices != nil {
@@ -1,6 +1,7 @@
 package retable
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 )
@@ -19,6 +20,14 @@ type StructRowsViewer struct {
 	// Mapping a struct field index to -1 will ignore this field
 	// and not create a column for it..
 	MapIndices map[int]int
+
+	// DuplicateColumns is the policy for struct fields
+	// with the same column title
+	DuplicateColumns DuplicateColumnPolicy
+
+	// Snapshot defines if the rows of the table
+	// are copied at View creation
+	Snapshot StructRowsSnapshot
 }
 
 func (v *StructRowsViewer) clone() *StructRowsViewer {
@@ -37,14 +46,18 @@ func (v *StructRowsViewer) String() string {
 }
 
 // NewView returns a View for a table made up of
-// a slice or array of structs.
+// a slice or array of structs or a pointer to it.
+// Nil slices and nil pointers to slices result in a View
+// without rows but with the columns of the struct type.
+// See StructRowsSnapshot for how later modifications
+// of the table affect the View.
 // NewView implements the Viewer interface for StructRowsViewer.
 func (v *StructRowsViewer) NewView(title string, table any) (View, error) {
-	rows := reflect.ValueOf(table)
-	for rows.Kind() == reflect.Pointer && !rows.IsNil() {
-		rows = rows.Elem()
+	if table == nil {
+		return nil, errors.New("table is untyped nil")
 	}
-	if rows.Kind() != reflect.Slice || rows.Kind() == reflect.Array {
+	rows := derefTable(reflect.ValueOf(table))
+	if rows.Kind() != reflect.Slice && rows.Kind() != reflect.Array {
 		return nil, fmt.Errorf("table must be slice or array kind but is %T", table)
 	}
 	rowType := rows.Type().Elem()
@@ -56,8 +69,18 @@ func (v *StructRowsViewer) NewView(title string, table any) (View, error) {
 	}
 
 	structFields := StructFieldTypes(rowType)
+	structColumns, err := v.structColumns(rowType)
+	if err != nil {
+		return nil, err
+	}
 	indices := make([]int, len(structFields))
 	columns := make([]string, 0, len(structFields))
+	formatters := make([]CellFormatter, len(structFields))
+	hasFormatters := false
+	descriptions := make([]string, len(structFields))
+	hasDescriptions := false
+	hidden := make([]bool, len(structFields))
+	hasHidden := false
 
 	columnIndexUsed := make(map[int]bool)
 	getNextFreeColumnIndex := func() int {
@@ -70,7 +93,7 @@ func (v *StructRowsViewer) NewView(title string, table any) (View, error) {
 	}
 
 	for i, structField := range structFields {
-		column := v.StructFieldColumn(structField)
+		column := structColumns[i]
 		if column == v.Ignore {
 			indices[i] = -1
 			continue
@@ -92,9 +115,81 @@ func (v *StructRowsViewer) NewView(title string, table any) (View, error) {
 		columnIndexUsed[index] = true
 
 		columns = append(columns, column)
+
+		if format := v.StructFieldFormat(structField); format != "" {
+			formatter, err := ParseCellFormat(format)
+			if err != nil {
+				return nil, fmt.Errorf("struct field %s: %w", structField.Name, err)
+			}
+			formatters[index] = formatter
+			hasFormatters = true
+		}
+		if description := StructFieldDescription(structField); description != "" {
+			descriptions[index] = description
+			hasDescriptions = true
+		}
+		if v.StructFieldHidden(structField) {
+			hidden[index] = true
+			hasHidden = true
+		}
+	}
+
+	view := NewStructRowsView(title, columns, indices, v.Snapshot.Rows(rows)).(*StructRowsView)
+	if hasFormatters {
+		view.formatters = formatters[:len(columns)]
+	}
+	if hasDescriptions {
+		view.descriptions = descriptions[:len(columns)]
+	}
+	if hasHidden {
+		view.hidden = hidden[:len(columns)]
 	}
+	if table := reflect.ValueOf(table); v.Snapshot == SnapshotNone && table.Kind() == reflect.Pointer && rows.Kind() == reflect.Slice && !table.IsNil() {
+		view.tablePtr = table
+	}
+	return view, nil
+}
 
-	return NewStructRowsView(title, columns, indices, rows), nil
+// derefTable dereferences pointers to tables.
+// A nil pointer results in the zero value
+// of the pointed to type, like a nil slice.
+func derefTable(table reflect.Value) reflect.Value {
+	for table.Kind() == reflect.Pointer {
+		if table.IsNil() {
+			return reflect.Zero(table.Type().Elem())
+		}
+		table = table.Elem()
+	}
+	return table
+}
+
+// structColumns returns the column titles for the fields
+// returned by StructFieldTypes(rowType) with the DuplicateColumns
+// policy applied to the not ignored columns.
+// Columns dropped by the policy are returned as ignored.
+func (v *StructRowsViewer) structColumns(rowType reflect.Type) ([]string, error) {
+	columns := v.structFieldColumns(rowType, "")
+	var (
+		titles       []string
+		titleColumns []int
+	)
+	for i, column := range columns {
+		if column != v.Ignore {
+			titles = append(titles, column)
+			titleColumns = append(titleColumns, i)
+		}
+	}
+	titles, keep, err := v.DuplicateColumns.Apply(titles)
+	if err != nil {
+		return nil, fmt.Errorf("struct %s: %w", rowType, err)
+	}
+	for i, col := range titleColumns {
+		columns[col] = titles[i]
+		if !keep[i] {
+			columns[col] = v.Ignore
+		}
+	}
+	return columns, nil
 }
 
 func (v *StructRowsViewer) WithTag(tag string) *StructRowsViewer {
@@ -109,6 +204,24 @@ func (v *StructRowsViewer) WithIgnore(ignore string) *StructRowsViewer {
 	return mod
 }
 
+func (v *StructRowsViewer) WithPrefixEmbedded(prefixEmbedded bool) *StructRowsViewer {
+	mod := v.clone()
+	mod.PrefixEmbedded = prefixEmbedded
+	return mod
+}
+
+func (v *StructRowsViewer) WithDuplicateColumns(policy DuplicateColumnPolicy) *StructRowsViewer {
+	mod := v.clone()
+	mod.DuplicateColumns = policy
+	return mod
+}
+
+func (v *StructRowsViewer) WithSnapshot(snapshot StructRowsSnapshot) *StructRowsViewer {
+	mod := v.clone()
+	mod.Snapshot = snapshot
+	return mod
+}
+
 func (v *StructRowsViewer) WithMapIndex(fieldIndex, columnIndex int) *StructRowsViewer {
 	mod := v.clone()
 	mod.MapIndices[fieldIndex] = columnIndex
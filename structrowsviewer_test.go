@@ -1,5 +1,78 @@
 package retable
 
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructRowsViewer_NewView_EmptyTables(t *testing.T) {
+	type Row struct {
+		Name  string
+		Count int `col:"Number"`
+	}
+	var (
+		nilSlice   []Row
+		nilPtrRows []*Row
+		nilPointer *[]Row
+	)
+	tests := []struct {
+		name  string
+		table any
+	}{
+		{name: "nil slice", table: nilSlice},
+		{name: "nil slice of pointers", table: nilPtrRows},
+		{name: "pointer to nil slice", table: &nilSlice},
+		{name: "nil pointer to slice", table: nilPointer},
+		{name: "empty array", table: [0]Row{}},
+		{name: "pointer to empty array", table: new([0]Row)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viewer, err := SelectViewer(tt.table)
+			require.NoError(t, err)
+			view, err := viewer.NewView("Empty", tt.table)
+			require.NoError(t, err)
+			require.Equal(t, "Empty", view.Title())
+			require.Equal(t, []string{"Name", "Number"}, view.Columns())
+			require.Equal(t, 0, view.NumRows())
+		})
+	}
+
+	t.Run("pointer to slice", func(t *testing.T) {
+		rows := []Row{{Name: "a", Count: 1}}
+		view, err := DefaultStructRowsViewer().NewView("", &rows)
+		require.NoError(t, err)
+		require.Equal(t, 1, view.NumRows())
+		require.Equal(t, 1, view.Cell(0, 1))
+	})
+
+	t.Run("array", func(t *testing.T) {
+		view, err := DefaultStructRowsViewer().NewView("", [2]Row{{Name: "a"}, {Name: "b"}})
+		require.NoError(t, err)
+		require.Equal(t, 2, view.NumRows())
+		require.Equal(t, "b", view.Cell(1, 0))
+	})
+
+	t.Run("strings", func(t *testing.T) {
+		var nilStrings *[][]string
+		viewer, err := SelectViewer(nilStrings)
+		require.NoError(t, err)
+		view, err := StringsViewer{Cols: []string{"A"}}.NewView("", nilStrings)
+		require.NoError(t, err)
+		require.Equal(t, []string{"A"}, view.Columns())
+		require.Equal(t, 0, view.NumRows())
+		view, err = viewer.NewView("", &[][]string{{"A"}, {"1"}})
+		require.NoError(t, err)
+		require.Equal(t, 1, view.NumRows())
+	})
+
+	t.Run("untyped nil", func(t *testing.T) {
+		_, err := DefaultStructRowsViewer().NewView("", nil)
+		require.Error(t, err)
+	})
+}
+
 // func TestReflectColumnTitles_ColumnTitlesAndRowReflector(t *testing.T) {
 // 	tests := []struct {
 // 		name        string
@@ -34,3 +107,64 @@ package retable
 // 		})
 // 	}
 // }
+
+func TestStructRowsViewer_Snapshot(t *testing.T) {
+	type Row struct {
+		Name string
+		Tags []string
+	}
+	newRows := func() []Row {
+		return []Row{{Name: "a", Tags: []string{"x"}}, {Name: "b"}}
+	}
+
+	t.Run("SnapshotNone slice", func(t *testing.T) {
+		rows := newRows()
+		view, err := DefaultStructRowsViewer().NewView("", rows)
+		require.NoError(t, err)
+		require.Equal(t, "a", view.Cell(0, 0))
+		rows[0].Name = "changed"
+		require.Equal(t, "a", view.Cell(0, 0), "cached row")
+		view.(*StructRowsView).InvalidateCache()
+		require.Equal(t, "changed", view.Cell(0, 0))
+		rows = append(rows, Row{Name: "c"})
+		require.Equal(t, 2, view.NumRows(), "slice header copied")
+	})
+
+	t.Run("SnapshotNone pointer to slice", func(t *testing.T) {
+		rows := newRows()
+		view, err := DefaultStructRowsViewer().NewView("", &rows)
+		require.NoError(t, err)
+		require.Equal(t, "a", view.Cell(0, 0))
+		rows = append(rows, Row{Name: "c"})
+		require.Equal(t, 3, view.NumRows())
+		require.Equal(t, "c", view.Cell(2, 0))
+		rows[2].Name = "changed"
+		rows = rows[:0]
+		require.Equal(t, 0, view.NumRows())
+		require.Nil(t, view.Cell(0, 0))
+		rows = append(rows, Row{Name: "new"})
+		require.Equal(t, "new", view.Cell(0, 0), "cache invalidated by length change")
+	})
+
+	t.Run("SnapshotRows", func(t *testing.T) {
+		rows := newRows()
+		view, err := DefaultStructRowsViewer().WithSnapshot(SnapshotRows).NewView("", &rows)
+		require.NoError(t, err)
+		rows[0].Name = "changed"
+		rows[0].Tags[0] = "shared"
+		rows = append(rows, Row{Name: "c"})
+		require.Equal(t, 2, view.NumRows())
+		require.Equal(t, "a", view.Cell(0, 0))
+		require.Equal(t, []string{"shared"}, view.Cell(0, 1))
+	})
+
+	t.Run("SnapshotDeep", func(t *testing.T) {
+		rows := newRows()
+		view, err := DefaultStructRowsViewer().WithSnapshot(SnapshotDeep).NewView("", rows)
+		require.NoError(t, err)
+		rows[0].Name = "changed"
+		rows[0].Tags[0] = "changed"
+		require.Equal(t, "a", view.Cell(0, 0))
+		require.Equal(t, []string{"x"}, view.Cell(0, 1))
+	})
+}
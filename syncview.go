@@ -0,0 +1,79 @@
+package retable
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+)
+
+var _ ReflectCellView = new(SyncView)
+
+// SyncView wraps a source View and guards all access
+// to it with a sync.RWMutex so that it can be shared
+// between goroutines, for example by multiple HTTP handlers.
+//
+// Title, Columns, and NumRows are called under a read lock.
+// Cell and ReflectCell are called under an exclusive lock
+// because View implementations like StructRowsView
+// cache row values when a cell is read.
+//
+// Use Update to modify or replace the wrapped source View.
+type SyncView struct {
+	mtx    sync.RWMutex
+	source View
+}
+
+// NewSyncView returns a SyncView wrapping the passed source View.
+func NewSyncView(source View) *SyncView {
+	return &SyncView{source: source}
+}
+
+func (view *SyncView) Title() string {
+	view.mtx.RLock()
+	defer view.mtx.RUnlock()
+
+	return view.source.Title()
+}
+
+// Columns returns a copy of the source View's columns
+// so that the result can be used without holding the lock.
+func (view *SyncView) Columns() []string {
+	view.mtx.RLock()
+	defer view.mtx.RUnlock()
+
+	return slices.Clone(view.source.Columns())
+}
+
+func (view *SyncView) NumRows() int {
+	view.mtx.RLock()
+	defer view.mtx.RUnlock()
+
+	return view.source.NumRows()
+}
+
+func (view *SyncView) Cell(row, col int) any {
+	view.mtx.Lock()
+	defer view.mtx.Unlock()
+
+	return view.source.Cell(row, col)
+}
+
+func (view *SyncView) ReflectCell(row, col int) reflect.Value {
+	view.mtx.Lock()
+	defer view.mtx.Unlock()
+
+	return AsReflectCellView(view.source).ReflectCell(row, col)
+}
+
+// Update calls modify with the wrapped source View
+// while holding an exclusive lock.
+// The View returned by modify replaces the source View
+// or the source is kept if modify returns nil.
+func (view *SyncView) Update(modify func(source View) View) {
+	view.mtx.Lock()
+	defer view.mtx.Unlock()
+
+	if updated := modify(view.source); updated != nil {
+		view.source = updated
+	}
+}
@@ -0,0 +1,43 @@
+package retable
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncView(t *testing.T) {
+	type row struct {
+		A int
+		B string
+	}
+	rows := make([]row, 100)
+	for i := range rows {
+		rows[i] = row{A: i, B: "x"}
+	}
+	source, err := DefaultStructRowsViewer().NewView("Rows", rows)
+	require.NoError(t, err)
+	view := NewSyncView(source)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < view.NumRows(); i++ {
+				assert.Equal(t, i, view.Cell(i, 0))
+				assert.Equal(t, "x", view.ReflectCell(i, 1).Interface())
+			}
+		}()
+	}
+	wg.Wait()
+
+	view.Update(func(View) View {
+		return &AnyValuesView{Tit: "Updated", Cols: []string{"C"}, Rows: [][]any{{1}}}
+	})
+	require.Equal(t, "Updated", view.Title())
+	require.Equal(t, []string{"C"}, view.Columns())
+	require.Equal(t, 1, view.Cell(0, 0))
+}
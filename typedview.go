@@ -0,0 +1,78 @@
+package retable
+
+import "reflect"
+
+var (
+	_ ColumnFormatterView   = new(TypedView[struct{}])
+	_ ColumnDescriptionView = new(TypedView[struct{}])
+	_ ColumnVisibilityView  = new(TypedView[struct{}])
+)
+
+// TypedView is a View of a slice of structs
+// or struct pointers that also gives typed access
+// to the rows without reflection
+// for application code that knows the row type.
+type TypedView[T any] struct {
+	view ReflectCellView
+	rows []T
+}
+
+// NewTypedView returns a TypedView for rows
+// with the columns defined by naming which may be nil.
+// The rows slice is not copied and must not
+// be modified while the view is used.
+// An error is returned if T is not a struct
+// or pointer to struct type.
+func NewTypedView[T any](title string, rows []T, naming *StructFieldNaming) (*TypedView[T], error) {
+	viewer := StructRowsViewer{}
+	if naming != nil {
+		viewer.StructFieldNaming = *naming
+	}
+	view, err := viewer.NewView(title, rows)
+	if err != nil {
+		return nil, err
+	}
+	return &TypedView[T]{view: AsReflectCellView(view), rows: rows}, nil
+}
+
+// ViewToTypedView reads the rows of view into a TypedView
+// using ViewToStructSlice with naming.
+func ViewToTypedView[T any](view View, naming *StructFieldNaming) (*TypedView[T], error) {
+	rows, err := ViewToStructSlice[T](view, naming, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return NewTypedView(view.Title(), rows, naming)
+}
+
+// Row returns the row with index i.
+// It panics if i is out of range.
+func (v *TypedView[T]) Row(i int) T { return v.rows[i] }
+
+// Rows returns the rows of the view.
+// The returned slice must not be modified.
+func (v *TypedView[T]) Rows() []T { return v.rows }
+
+func (v *TypedView[T]) Title() string     { return v.view.Title() }
+func (v *TypedView[T]) Columns() []string { return v.view.Columns() }
+func (v *TypedView[T]) NumRows() int      { return len(v.rows) }
+
+func (v *TypedView[T]) Cell(row, col int) any {
+	return v.view.Cell(row, col)
+}
+
+func (v *TypedView[T]) ReflectCell(row, col int) reflect.Value {
+	return v.view.ReflectCell(row, col)
+}
+
+func (v *TypedView[T]) ColumnFormatter(col int) CellFormatter {
+	return ViewColumnFormatter(v.view, col)
+}
+
+func (v *TypedView[T]) ColumnDescription(col int) string {
+	return ViewColumnDescription(v.view, col)
+}
+
+func (v *TypedView[T]) ColumnHidden(col int) bool {
+	return ViewColumnHidden(v.view, col)
+}
@@ -0,0 +1,47 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypedView(t *testing.T) {
+	type Invoice struct {
+		Number string  `col:"Number"`
+		Amount float64 `col:"Amount,format=percent:2"`
+		Note   string  `col:"-"`
+	}
+	invoices := []Invoice{
+		{Number: "1", Amount: 10, Note: "first"},
+		{Number: "2", Amount: 20.5},
+	}
+	view, err := NewTypedView("Invoices", invoices, &DefaultStructFieldNaming)
+	require.NoError(t, err)
+
+	// Typed access
+	require.Equal(t, invoices[1], view.Row(1))
+	require.Equal(t, invoices, view.Rows())
+
+	// Dynamic View access
+	require.Equal(t, "Invoices", view.Title())
+	require.Equal(t, []string{"Number", "Amount"}, view.Columns())
+	require.Equal(t, 2, view.NumRows())
+	require.Equal(t, 20.5, view.Cell(1, 1))
+	require.Equal(t, "2", view.ReflectCell(1, 0).Interface())
+	require.NotNil(t, view.ColumnFormatter(1))
+	require.Nil(t, view.ColumnFormatter(0))
+
+	ptrView, err := NewTypedView("", []*Invoice{&invoices[0]}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Number", "Amount", "Note"}, ptrView.Columns())
+	require.Same(t, &invoices[0], ptrView.Row(0))
+
+	_, err = NewTypedView("", []int{1}, nil)
+	require.Error(t, err)
+
+	read, err := ViewToTypedView[Invoice](NewStringsView("Read", [][]string{{"Number", "Amount"}, {"3", "1.5"}}), &DefaultStructFieldNaming)
+	require.NoError(t, err)
+	require.Equal(t, "Read", read.Title())
+	require.Equal(t, []Invoice{{Number: "3", Amount: 1.5}}, read.Rows())
+}
@@ -239,23 +239,11 @@ func SpaceGoCase(name string) string {
 // table as count of UTF-8 runes.
 // maxCols limits the number of columns to consider,
 // if maxCols is -1, then all columns are considered.
+//
+// Use MeasureColumnWidths to account for East Asian wide runes
+// and ANSI escape sequences.
 func StringColumnWidths(rows [][]string, maxCols int) []int {
-	if maxCols < 0 {
-		for _, r := range rows {
-			maxCols = max(maxCols, len(r))
-		}
-	}
-	if maxCols == 0 {
-		return nil
-	}
-	colWidths := make([]int, maxCols)
-	for row := range rows {
-		for col := 0; col < maxCols && col < len(rows[row]); col++ {
-			numRunes := utf8.RuneCountInString(rows[row][col])
-			colWidths[col] = max(colWidths[col], numRunes)
-		}
-	}
-	return colWidths
+	return MeasureColumnWidths(rows, maxCols, nil)
 }
 
 // UseTitle returns a function that
@@ -272,6 +260,10 @@ func UseTitle(columnTitle string) func(fieldName string) (columnTitle string) {
 //   - implements the IsNull() bool method which returns true,
 //   - implements the IsZero() bool method which returns true,
 //   - implements the driver.Valuer interface which returns nil, nil.
+//
+// Custom null conventions can be added with RegisterNullChecker.
+// They take precedence over all conditions except
+// for invalid and nil values.
 func IsNullLike(val reflect.Value) bool {
 	// Treat zero value of reflect.Value as nil
 	if !val.IsValid() {
@@ -282,6 +274,9 @@ func IsNullLike(val reflect.Value) bool {
 		reflect.Chan, reflect.Func, reflect.UnsafePointer:
 		return val.IsNil()
 	}
+	if isNull, ok := checkRegisteredNull(val); ok {
+		return isNull
+	}
 	// Treat struct{}{} as nil
 	if val.Type() == typeOfEmptyStruct {
 		return true
@@ -357,7 +352,7 @@ func FprintlnView(w io.Writer, view View) error {
 			return err
 		}
 	}
-	colWidths := StringColumnWidths(rows, -1)
+	colWidths := MeasureColumnWidths(rows, -1, DisplayWidthOptions)
 	for _, rowStrs := range rows {
 		for col, colWidth := range colWidths {
 			switch {
@@ -377,7 +372,7 @@ func FprintlnView(w io.Writer, view View) error {
 			if err != nil {
 				return err
 			}
-			strLen := utf8.RuneCountInString(str)
+			strLen := StringWidth(str, DisplayWidthOptions)
 			for i := strLen; i < colWidth; i++ {
 				_, err = w.Write([]byte{' '})
 				if err != nil {
@@ -2,8 +2,11 @@ package retable
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/require"
 )
@@ -300,3 +303,109 @@ func ExamplePrintlnTable() {
 	// | Last row | 0          |                               |
 
 }
+
+func FuzzSpacePascalCase(f *testing.F) {
+	for _, name := range []string{"", "HelloWorld", "_Hello_World", "helloWorld", "HTTPServer", "A_B", "Ünïcödé", "\xff"} {
+		f.Add(name)
+	}
+	f.Fuzz(func(t *testing.T, name string) {
+		fuzzCheckSpacedName(t, name, SpacePascalCase(name))
+	})
+}
+
+func FuzzSpaceGoCase(f *testing.F) {
+	for _, name := range []string{"", "HelloWorld", "_Hello_World", "helloWorld", "HTTPServer", "ServeHTTP", "A_B", "ÄÖÜx", "\xff"} {
+		f.Add(name)
+	}
+	f.Fuzz(func(t *testing.T, name string) {
+		fuzzCheckSpacedName(t, name, SpaceGoCase(name))
+	})
+}
+
+func fuzzCheckSpacedName(t *testing.T, name, spaced string) {
+	t.Helper()
+	if strings.TrimSpace(spaced) != spaced {
+		t.Fatalf("%q: result %q has leading or trailing space", name, spaced)
+	}
+	if strings.ContainsRune(spaced, '_') {
+		t.Fatalf("%q: result %q contains underscore", name, spaced)
+	}
+	if utf8.ValidString(name) && !utf8.ValidString(spaced) {
+		t.Fatalf("%q: result %q is not valid UTF-8", name, spaced)
+	}
+	// Only spaces are inserted and underscores removed
+	stripped := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if r == '_' || unicode.IsSpace(r) {
+				return -1
+			}
+			return r
+		}, s)
+	}
+	if stripped(spaced) != stripped(name) {
+		t.Fatalf("%q: result %q changed non space characters", name, spaced)
+	}
+}
+
+func TestSpaceCaseOptions(t *testing.T) {
+	defaultAcronyms := SpaceCaseOptions{Acronyms: DefaultAcronyms}
+	titleCase := SpaceCaseOptions{Acronyms: DefaultAcronyms, TitleCase: true}
+	tests := []struct {
+		name       string
+		options    SpaceCaseOptions
+		pascalCase bool
+		input      string
+		want       string
+	}{
+		{name: "no options", options: SpaceCaseOptions{}, input: "VATNumber", want: "VAT Number"},
+		{name: "empty", options: defaultAcronyms, input: "", want: ""},
+		{name: "acronym prefix", options: defaultAcronyms, input: "VATNumber", want: "VAT Number"},
+		{name: "acronym word", options: defaultAcronyms, input: "Id", want: "ID"},
+		{name: "acronym suffix", options: defaultAcronyms, input: "UserId", want: "User ID"},
+		{name: "lower case acronym", options: defaultAcronyms, input: "user_url", want: "user URL"},
+		{name: "concatenated acronyms", options: defaultAcronyms, input: "VATID", want: "VAT ID"},
+		{name: "concatenated acronyms and word", options: defaultAcronyms, input: "APIURLPath", want: "API URL Path"},
+		{name: "no acronym split", options: defaultAcronyms, input: "IDENTITY", want: "IDENTITY"},
+		{name: "unknown acronym", options: defaultAcronyms, input: "HTTPServer", want: "HTTP Server"},
+		{name: "pascal case acronym prefix", options: defaultAcronyms, pascalCase: true, input: "IBANCode", want: "IBAN Code"},
+		{name: "pascal case unknown acronym", options: defaultAcronyms, pascalCase: true, input: "HTTPServer", want: "HTTPServer"},
+		{name: "title case", options: titleCase, input: "user_name_id", want: "User Name ID"},
+		{name: "title case only", options: SpaceCaseOptions{TitleCase: true}, input: "helloWorld", want: "Hello World"},
+		{name: "custom acronyms", options: SpaceCaseOptions{Acronyms: []string{"SKU", "EAN"}}, input: "SkuEan", want: "SKU EAN"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spaceCase := tt.options.SpaceGoCase
+			if tt.pascalCase {
+				spaceCase = tt.options.SpacePascalCase
+			}
+			require.Equal(t, tt.want, spaceCase(tt.input))
+		})
+	}
+}
+
+func TestCaseConversions(t *testing.T) {
+	tests := []struct {
+		name      string
+		wantSnake string
+		wantKebab string
+		wantCamel string
+	}{
+		{name: "", wantSnake: "", wantKebab: "", wantCamel: ""},
+		{name: "InvoiceNumber", wantSnake: "invoice_number", wantKebab: "invoice-number", wantCamel: "invoiceNumber"},
+		{name: "Invoice Number", wantSnake: "invoice_number", wantKebab: "invoice-number", wantCamel: "invoiceNumber"},
+		{name: "invoice_number", wantSnake: "invoice_number", wantKebab: "invoice-number", wantCamel: "invoiceNumber"},
+		{name: "invoice-number", wantSnake: "invoice_number", wantKebab: "invoice-number", wantCamel: "invoiceNumber"},
+		{name: "  Invoice   Number ", wantSnake: "invoice_number", wantKebab: "invoice-number", wantCamel: "invoiceNumber"},
+		{name: "HTTPServerID", wantSnake: "http_server_id", wantKebab: "http-server-id", wantCamel: "httpServerId"},
+		{name: "user.name", wantSnake: "user_name", wantKebab: "user-name", wantCamel: "userName"},
+		{name: "ÄrgerÜber", wantSnake: "ärger_über", wantKebab: "ärger-über", wantCamel: "ärgerÜber"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantSnake, ToSnakeCase(tt.name), "ToSnakeCase")
+			require.Equal(t, tt.wantKebab, ToKebabCase(tt.name), "ToKebabCase")
+			require.Equal(t, tt.wantCamel, ToLowerCamel(tt.name), "ToLowerCamel")
+		})
+	}
+}
@@ -14,6 +14,11 @@ import (
 // into memory and then wrapped as View,
 // so the View methods don't need a
 // context parameter and error result.
+//
+// View implementations are not required to be safe
+// for concurrent use. Some, like StructRowsView,
+// even modify internal caches when cells are read.
+// Use NewSyncView to share a View between goroutines.
 type View interface {
 	// Title of the View
 	Title() string
@@ -56,3 +61,7 @@ type wrapAsReflectCellView struct {
 func (w wrapAsReflectCellView) ReflectCell(row, col int) reflect.Value {
 	return reflect.ValueOf(w.View.Cell(row, col))
 }
+
+func (w wrapAsReflectCellView) CellExists(row, col int) bool {
+	return CellExists(w.View, row, col)
+}
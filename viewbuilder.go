@@ -0,0 +1,136 @@
+package retable
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ViewBuilder assembles a View row by row.
+//
+// The type of every column is tracked from the first
+// non nil value added to it and values of other types
+// added later to the same column result in an error
+// returned by Build.
+//
+// The methods return the builder to allow chaining,
+// the first error that happened is returned by Build.
+type ViewBuilder struct {
+	title    string
+	columns  []string
+	colTypes []reflect.Type
+	rows     [][]any
+	naming   *StructFieldNaming
+	err      error
+}
+
+// NewViewBuilder returns a ViewBuilder for a View with the passed title
+// that uses DefaultStructFieldNaming for AddStructRow.
+func NewViewBuilder(title string) *ViewBuilder {
+	return &ViewBuilder{title: title, naming: &DefaultStructFieldNaming}
+}
+
+// SetColumns sets the column titles of the View.
+// Columns can only be set before any rows were added.
+func (b *ViewBuilder) SetColumns(columns ...string) *ViewBuilder {
+	if b.err != nil {
+		return b
+	}
+	if len(b.rows) > 0 {
+		b.err = errors.New("can't set columns after rows were added")
+		return b
+	}
+	b.columns = columns
+	b.colTypes = make([]reflect.Type, len(columns))
+	return b
+}
+
+// SetStructFieldNaming sets the StructFieldNaming used by AddStructRow
+// to get the column titles of struct fields.
+// A nil naming uses the struct field names as column titles.
+func (b *ViewBuilder) SetStructFieldNaming(naming *StructFieldNaming) *ViewBuilder {
+	b.naming = naming
+	return b
+}
+
+// AddRow adds a row with the passed values.
+// Missing values at the end of the row are nil.
+func (b *ViewBuilder) AddRow(values ...any) *ViewBuilder {
+	if b.err != nil {
+		return b
+	}
+	if len(values) > len(b.columns) {
+		b.err = fmt.Errorf("row %d has %d values but there are only %d columns", len(b.rows), len(values), len(b.columns))
+		return b
+	}
+	for col, value := range values {
+		if value == nil {
+			continue
+		}
+		valueType := reflect.TypeOf(value)
+		switch colType := b.colTypes[col]; {
+		case colType == nil:
+			b.colTypes[col] = valueType
+		case colType != valueType:
+			b.err = fmt.Errorf("row %d value of type %s does not match type %s of column %q", len(b.rows), valueType, colType, b.columns[col])
+			return b
+		}
+	}
+	row := make([]any, len(b.columns))
+	copy(row, values)
+	b.rows = append(b.rows, row)
+	return b
+}
+
+// AddStructRow adds the exported fields of the passed
+// struct or struct pointer as row by matching the
+// column titles from the builder's StructFieldNaming
+// with the columns of the builder.
+//
+// If no columns have been set before, then the columns
+// of the struct will be used as columns of the View.
+// Columns without a matching struct field will have nil values.
+func (b *ViewBuilder) AddStructRow(s any) *ViewBuilder {
+	if b.err != nil {
+		return b
+	}
+	structVal := reflect.ValueOf(s)
+	for structVal.Kind() == reflect.Pointer && !structVal.IsNil() {
+		structVal = structVal.Elem()
+	}
+	if structVal.Kind() != reflect.Struct {
+		b.err = fmt.Errorf("expected struct or struct pointer but got %T", s)
+		return b
+	}
+	if b.columns == nil {
+		b.SetColumns(b.naming.columns(structVal.Type())...)
+	}
+	values := make([]any, len(b.columns))
+	for col, column := range b.columns {
+		if v := b.naming.ColumnStructFieldValue(structVal, column); v.IsValid() {
+			values[col] = v.Interface()
+		}
+	}
+	return b.AddRow(values...)
+}
+
+// ColumnTypes returns the types of the columns tracked
+// from the added values. The type of a column
+// without any non nil values is nil.
+func (b *ViewBuilder) ColumnTypes() []reflect.Type {
+	return b.colTypes
+}
+
+// NumRows returns the number of rows added so far.
+func (b *ViewBuilder) NumRows() int {
+	return len(b.rows)
+}
+
+// Build returns the assembled View or the first error
+// that happened while adding rows.
+func (b *ViewBuilder) Build() (View, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return &AnyValuesView{Tit: b.title, Cols: b.columns, Rows: b.rows}, nil
+}
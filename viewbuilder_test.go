@@ -0,0 +1,40 @@
+package retable
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewBuilder(t *testing.T) {
+	type Row struct {
+		Name  string `col:"Name"`
+		Count int    `col:"Count"`
+		Skip  bool   `col:"-"`
+	}
+
+	view, err := NewViewBuilder("Report").
+		AddStructRow(Row{Name: "A", Count: 1}).
+		AddStructRow(&Row{Name: "B", Count: 2}).
+		AddRow("C").
+		Build()
+	require.NoError(t, err)
+	require.Equal(t, "Report", view.Title())
+	require.Equal(t, []string{"Name", "Count"}, view.Columns())
+	require.Equal(t, 3, view.NumRows())
+	require.Equal(t, 2, view.Cell(1, 1))
+	require.Nil(t, view.Cell(2, 1))
+
+	b := NewViewBuilder("").SetColumns("X", "Y").AddRow(1, "a").AddRow(nil, "b")
+	require.Equal(t, []reflect.Type{reflect.TypeFor[int](), reflect.TypeFor[string]()}, b.ColumnTypes())
+
+	_, err = b.AddRow("wrong type").Build()
+	require.Error(t, err, "type mismatch")
+
+	_, err = NewViewBuilder("").SetColumns("X").AddRow(1, 2).Build()
+	require.Error(t, err, "too many values")
+
+	_, err = NewViewBuilder("").AddRow().SetColumns("X").Build()
+	require.Error(t, err, "columns after rows")
+}
@@ -0,0 +1,123 @@
+package retable
+
+import "sync"
+
+// ViewCache memoizes expensive derived views
+// like sorted, grouped, or profiled views
+// keyed by a caller provided fingerprint of the source data,
+// for example for dashboard endpoints that are
+// requested repeatedly with the same data.
+//
+// Cached views are shared between callers and must not
+// be modified, wrap them with NewSyncView if their cells
+// are read concurrently by multiple goroutines.
+//
+// Entries are only removed by Invalidate, Clear,
+// or by evicting the least recently used entry
+// when the maximum number of entries is exceeded.
+// A ViewCache is safe for concurrent use.
+type ViewCache struct {
+	mtx        sync.Mutex
+	maxEntries int
+	entries    map[string]*viewCacheEntry
+	useCounter uint64
+}
+
+type viewCacheEntry struct {
+	ready    chan struct{} // closed when view and err are set
+	view     View
+	err      error
+	lastUsed uint64
+}
+
+// NewViewCache returns a ViewCache that holds
+// at most maxEntries views, or an unlimited
+// number of views if maxEntries is not greater than zero.
+func NewViewCache(maxEntries int) *ViewCache {
+	return &ViewCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*viewCacheEntry),
+	}
+}
+
+// Get returns the cached view for fingerprint
+// or calls create and caches the returned view.
+//
+// Concurrent calls for the same fingerprint
+// wait for the view of the first call
+// instead of calling create again.
+// Errors returned by create are not cached.
+func (c *ViewCache) Get(fingerprint string, create func() (View, error)) (View, error) {
+	c.mtx.Lock()
+	c.useCounter++
+	if entry, ok := c.entries[fingerprint]; ok {
+		entry.lastUsed = c.useCounter
+		c.mtx.Unlock()
+
+		<-entry.ready
+		return entry.view, entry.err
+	}
+	entry := &viewCacheEntry{
+		ready:    make(chan struct{}),
+		lastUsed: c.useCounter,
+	}
+	c.entries[fingerprint] = entry
+	c.evictLeastRecentlyUsed()
+	c.mtx.Unlock()
+
+	defer close(entry.ready)
+	entry.view, entry.err = create()
+	if entry.err != nil {
+		c.mtx.Lock()
+		if c.entries[fingerprint] == entry {
+			delete(c.entries, fingerprint)
+		}
+		c.mtx.Unlock()
+	}
+	return entry.view, entry.err
+}
+
+// evictLeastRecentlyUsed removes the least recently used entries
+// until the maximum number of entries is not exceeded.
+// c.mtx must be locked.
+func (c *ViewCache) evictLeastRecentlyUsed() {
+	for c.maxEntries > 0 && len(c.entries) > c.maxEntries {
+		var (
+			oldestKey  string
+			oldestUsed uint64
+		)
+		for key, entry := range c.entries {
+			if oldestUsed == 0 || entry.lastUsed < oldestUsed {
+				oldestKey, oldestUsed = key, entry.lastUsed
+			}
+		}
+		delete(c.entries, oldestKey)
+	}
+}
+
+// Invalidate removes the view cached for fingerprint.
+// A view that is currently created for fingerprint
+// is still returned to its callers but not cached.
+func (c *ViewCache) Invalidate(fingerprint string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	delete(c.entries, fingerprint)
+}
+
+// Clear removes all cached views.
+func (c *ViewCache) Clear() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	clear(c.entries)
+}
+
+// Len returns the number of cached views
+// including views that are currently created.
+func (c *ViewCache) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return len(c.entries)
+}
@@ -0,0 +1,63 @@
+package retable
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewCache(t *testing.T) {
+	var numCreated atomic.Int32
+	create := func(title string) func() (View, error) {
+		return func() (View, error) {
+			numCreated.Add(1)
+			return &AnyValuesView{Tit: title}, nil
+		}
+	}
+	cache := NewViewCache(2)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			view, err := cache.Get("a", create("A"))
+			require.NoError(t, err)
+			require.Equal(t, "A", view.Title())
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), numCreated.Load(), "created once for concurrent calls")
+
+	_, err := cache.Get("b", create("B"))
+	require.NoError(t, err)
+	_, err = cache.Get("a", create("A"))
+	require.NoError(t, err)
+	_, err = cache.Get("c", create("C"))
+	require.NoError(t, err)
+	require.Equal(t, 2, cache.Len())
+	require.Equal(t, int32(3), numCreated.Load())
+
+	// b was least recently used and evicted
+	_, err = cache.Get("b", create("B"))
+	require.NoError(t, err)
+	require.Equal(t, int32(4), numCreated.Load())
+
+	cache.Invalidate("b")
+	_, err = cache.Get("b", create("B"))
+	require.NoError(t, err)
+	require.Equal(t, int32(5), numCreated.Load())
+
+	// Errors are not cached
+	errFailed := errors.New("failed")
+	_, err = cache.Get("d", func() (View, error) { return nil, errFailed })
+	require.ErrorIs(t, err, errFailed)
+	_, err = cache.Get("d", create("D"))
+	require.NoError(t, err)
+
+	cache.Clear()
+	require.Equal(t, 0, cache.Len())
+}
@@ -3,7 +3,6 @@ package retable
 import (
 	"fmt"
 	"reflect"
-	"slices"
 )
 
 // ViewToStructSlice converts a View to a slice of structs
@@ -34,23 +33,17 @@ func ViewToStructSlice[T any](view View, naming *StructFieldNaming, dstScanner S
 
 	viewCols := view.Columns()
 	reflectView := AsReflectCellView(view)
+	mapping := NewStructColumnMapping(rowType, naming)
 
-	if len(requiredCols) > 0 {
-		var v reflect.Value
-		if rowType.Kind() == reflect.Pointer {
-			v = reflect.New(rowType.Elem()).Elem()
-		} else {
-			v = reflect.New(rowType).Elem()
+	for _, requiredCol := range requiredCols {
+		if naming.headerNormalizer().Index(viewCols, requiredCol) == -1 {
+			return nil, fmt.Errorf("required column %q not found in View columns", requiredCol)
 		}
-		for _, requiredCol := range requiredCols {
-			if !slices.Contains(viewCols, requiredCol) {
-				return nil, fmt.Errorf("required column %q not found in View columns", requiredCol)
-			}
-			if !naming.ColumnStructFieldValue(v, requiredCol).IsValid() {
-				return nil, fmt.Errorf("required column %q not found as struct field", requiredCol)
-			}
+		if mapping.ColumnIndex(requiredCol) == -1 {
+			return nil, fmt.Errorf("required column %q not found as struct field", requiredCol)
 		}
 	}
+	fieldCols := mapping.columnIndices(viewCols)
 
 	rows := make([]T, view.NumRows())
 	for rowIndex := range rows {
@@ -59,27 +52,132 @@ func ViewToStructSlice[T any](view View, naming *StructFieldNaming, dstScanner S
 			rowStruct.Set(reflect.New(rowType.Elem())) // Set allocated struct pointer for row
 			rowStruct = rowStruct.Elem()               // Continue with struct value instead of pointer
 		}
-		for colIndex, colName := range viewCols {
-			dst := naming.ColumnStructFieldValue(rowStruct, colName)
-			if !dst.IsValid() {
-				continue
-			}
-			src := reflectView.ReflectCell(rowIndex, colIndex)
-			if !src.IsValid() {
-				continue
-			}
-			err := SmartAssign(dst, src, dstScanner, srcFormatter)
-			if err == nil && validate != nil {
-				err = validate(dst)
-			}
-			if err != nil {
-				return nil, err
-			}
+		err := assignRowToStruct(reflectView, rowIndex, rowStruct, mapping, fieldCols, dstScanner, srcFormatter, validate)
+		if err != nil {
+			return nil, err
 		}
 	}
 	return rows, nil
 }
 
+// ViewToStructSliceCollectErrors converts a View to a slice of structs
+// like ViewToStructSlice but does not abort on the first row error.
+// The errors of the rows are collected as RowErrors with the passed limit
+// of rows with errors and returned as error if not empty.
+//
+// The returned slice has a struct for every row of the view,
+// structs of rows with errors are only partially assigned.
+// If the limit of rows with errors is exceeded,
+// then the slice is returned with the rows converted so far.
+func ViewToStructSliceCollectErrors[T any](view View, naming *StructFieldNaming, dstScanner Scanner, srcFormatter Formatter, validate func(reflect.Value) error, limit int) ([]T, error) {
+	rowType := reflect.TypeFor[T]()
+	if rowType.Kind() != reflect.Struct && (rowType.Kind() != reflect.Pointer || rowType.Elem().Kind() != reflect.Struct) {
+		return nil, fmt.Errorf("slice element type %s is not a struct or pointer to struct", rowType)
+	}
+
+	reflectView := AsReflectCellView(view)
+	mapping := NewStructColumnMapping(rowType, naming)
+	fieldCols := mapping.columnIndices(view.Columns())
+	rowErrs := NewRowErrors(limit)
+
+	rows := make([]T, view.NumRows())
+	for rowIndex := range rows {
+		rowStruct := reflect.ValueOf(&rows[rowIndex]).Elem()
+		if rowType.Kind() == reflect.Pointer {
+			rowStruct.Set(reflect.New(rowType.Elem())) // Set allocated struct pointer for row
+			rowStruct = rowStruct.Elem()               // Continue with struct value instead of pointer
+		}
+		err := assignRowToStruct(reflectView, rowIndex, rowStruct, mapping, fieldCols, dstScanner, srcFormatter, validate)
+		if !rowErrs.Add(rowIndex, err) {
+			return rows[:rowIndex], rowErrs
+		}
+	}
+	return rows, rowErrs.Err()
+}
+
+// RowToStruct assigns the cells of a View row to the fields
+// of the struct pointed to by dstStruct, matching the View's columns
+// with the struct fields using the passed StructFieldNaming.
+// Cells of columns without matching struct field are ignored.
+//
+// SmartAssign is used to assign the cell values to the struct fields.
+func RowToStruct(view View, row int, dstStruct any, naming *StructFieldNaming) error {
+	if row < 0 || row >= view.NumRows() {
+		return fmt.Errorf("row %d out of range for %d rows", row, view.NumRows())
+	}
+	structVal := reflect.ValueOf(dstStruct)
+	if structVal.Kind() != reflect.Pointer || structVal.IsNil() || structVal.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected non nil struct pointer but got %T", dstStruct)
+	}
+	mapping := NewStructColumnMapping(structVal.Type(), naming)
+	return assignRowToStruct(AsReflectCellView(view), row, structVal.Elem(), mapping, mapping.columnIndices(view.Columns()), nil, nil, nil)
+}
+
+// RowsToStructs assigns all rows of a View to the slice pointed to by dstSlicePtr
+// which must have a struct or struct pointer element type.
+// The columns of the View are matched with the struct fields
+// using the passed StructFieldNaming.
+// Cells of columns without matching struct field are ignored.
+//
+// SmartAssign is used to assign the cell values to the struct fields.
+//
+// See ViewToStructSlice for a generic function with more options.
+func RowsToStructs(view View, dstSlicePtr any, naming *StructFieldNaming) error {
+	sliceVal := reflect.ValueOf(dstSlicePtr)
+	if sliceVal.Kind() != reflect.Pointer || sliceVal.IsNil() || sliceVal.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("expected non nil slice pointer but got %T", dstSlicePtr)
+	}
+	sliceVal = sliceVal.Elem()
+	rowType := sliceVal.Type().Elem()
+	if rowType.Kind() != reflect.Struct && (rowType.Kind() != reflect.Pointer || rowType.Elem().Kind() != reflect.Struct) {
+		return fmt.Errorf("slice element type %s is not a struct or pointer to struct", rowType)
+	}
+
+	reflectView := AsReflectCellView(view)
+	mapping := NewStructColumnMapping(rowType, naming)
+	fieldCols := mapping.columnIndices(view.Columns())
+	rows := reflect.MakeSlice(sliceVal.Type(), view.NumRows(), view.NumRows())
+	for rowIndex := range rows.Len() {
+		rowStruct := rows.Index(rowIndex)
+		if rowType.Kind() == reflect.Pointer {
+			rowStruct.Set(reflect.New(rowType.Elem())) // Set allocated struct pointer for row
+			rowStruct = rowStruct.Elem()               // Continue with struct value instead of pointer
+		}
+		err := assignRowToStruct(reflectView, rowIndex, rowStruct, mapping, fieldCols, nil, nil, nil)
+		if err != nil {
+			return err
+		}
+	}
+	sliceVal.Set(rows)
+	return nil
+}
+
+// assignRowToStruct assigns the cells of row to the fields of rowStruct
+// where fieldCols has the mapping column index for every view column.
+func assignRowToStruct(view ReflectCellView, row int, rowStruct reflect.Value, mapping *StructColumnMapping, fieldCols []int, dstScanner Scanner, srcFormatter Formatter, validate func(reflect.Value) error) error {
+	for col, fieldCol := range fieldCols {
+		if fieldCol < 0 {
+			continue
+		}
+		dst := mapping.FieldValue(rowStruct, fieldCol)
+		if !dst.IsValid() {
+			continue
+		}
+		src := view.ReflectCell(row, col)
+		if !src.IsValid() {
+			continue
+		}
+		err := SmartAssign(dst, src, dstScanner, srcFormatter)
+		if err == nil && validate != nil {
+			err = validate(dst)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // CallValidateMethod calls the `Validate() error` or `Valid() bool`
 // method on v.Interface() if available and v is not nil.
 func CallValidateMethod(v reflect.Value) error {
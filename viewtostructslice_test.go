@@ -0,0 +1,40 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowsToStructs(t *testing.T) {
+	type Row struct {
+		Name  string `col:"Name"`
+		Count int    `col:"Count"`
+	}
+	view := NewStringsView("", [][]string{
+		{"Count", "Ignored", "Name"},
+		{"1", "x", "A"},
+		{"2", "y", "B"},
+	})
+
+	var row Row
+	err := RowToStruct(view, 1, &row, &DefaultStructFieldNaming)
+	require.NoError(t, err)
+	require.Equal(t, Row{Name: "B", Count: 2}, row)
+
+	err = RowToStruct(view, 2, &row, &DefaultStructFieldNaming)
+	require.Error(t, err, "row out of range")
+
+	var rows []Row
+	err = RowsToStructs(view, &rows, &DefaultStructFieldNaming)
+	require.NoError(t, err)
+	require.Equal(t, []Row{{Name: "A", Count: 1}, {Name: "B", Count: 2}}, rows)
+
+	var rowPtrs []*Row
+	err = RowsToStructs(view, &rowPtrs, &DefaultStructFieldNaming)
+	require.NoError(t, err)
+	require.Equal(t, []*Row{{Name: "A", Count: 1}, {Name: "B", Count: 2}}, rowPtrs)
+
+	err = RowsToStructs(view, rows, &DefaultStructFieldNaming)
+	require.Error(t, err, "not a slice pointer")
+}
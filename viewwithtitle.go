@@ -2,7 +2,15 @@ package retable
 
 import "reflect"
 
-func ViewWithTitle(source View, title string) View {
+// ViewWithTitle returns a View that uses the passed title
+// and delegates all other methods to the source View.
+//
+// The result is returned as ReflectCellView because it
+// implements ReflectCell by delegating to the source View.
+// Callers that assigned the result to a View are not affected,
+// but function values with the former signature
+// func(View, string) View have to be wrapped.
+func ViewWithTitle(source View, title string) ReflectCellView {
 	return viewWithTitle{source: AsReflectCellView(source), title: title}
 }
 
@@ -15,10 +23,60 @@ func (v viewWithTitle) Title() string     { return v.title }
 func (v viewWithTitle) Columns() []string { return v.source.Columns() }
 func (v viewWithTitle) NumRows() int      { return v.source.NumRows() }
 
+func (v viewWithTitle) CellExists(row, col int) bool {
+	return CellExists(v.source, row, col)
+}
+
 func (v viewWithTitle) Cell(row, col int) any {
 	return v.source.Cell(row, col)
 }
 
 func (v viewWithTitle) ReflectCell(row, col int) reflect.Value {
-	return v.source.ReflectCell(row, col).Elem()
+	return v.source.ReflectCell(row, col)
+}
+
+// ViewWithColumns returns a View that uses the passed columns
+// and delegates all other methods to the source View.
+//
+// The columns replace the column titles of the source View
+// by position, so len(columns) determines the number of columns.
+// Cells of columns beyond the source View's columns are nil.
+func ViewWithColumns(source View, columns []string) ReflectCellView {
+	return viewWithColumns{source: AsReflectCellView(source), columns: columns}
+}
+
+type viewWithColumns struct {
+	source  ReflectCellView
+	columns []string
+}
+
+func (v viewWithColumns) Title() string     { return v.source.Title() }
+func (v viewWithColumns) Columns() []string { return v.columns }
+func (v viewWithColumns) NumRows() int      { return v.source.NumRows() }
+
+// CellExists returns if the source cell exists
+// or true for the nil cells of columns beyond the source columns.
+func (v viewWithColumns) CellExists(row, col int) bool {
+	if v.hasSourceCol(col) {
+		return CellExists(v.source, row, col)
+	}
+	return row >= 0 && col >= 0 && row < v.NumRows() && col < len(v.columns)
+}
+
+func (v viewWithColumns) Cell(row, col int) any {
+	if !v.hasSourceCol(col) {
+		return nil
+	}
+	return v.source.Cell(row, col)
+}
+
+func (v viewWithColumns) ReflectCell(row, col int) reflect.Value {
+	if !v.hasSourceCol(col) {
+		return reflect.Value{}
+	}
+	return v.source.ReflectCell(row, col)
+}
+
+func (v viewWithColumns) hasSourceCol(col int) bool {
+	return col >= 0 && col < len(v.columns) && col < len(v.source.Columns())
 }
@@ -0,0 +1,53 @@
+package retable
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewWithTitle(t *testing.T) {
+	source := &AnyValuesView{Tit: "Source", Cols: []string{"A", "B"}, Rows: [][]any{{1, "x"}}}
+
+	view := ViewWithTitle(source, "Title")
+	require.Equal(t, "Title", view.Title())
+	require.Equal(t, []string{"A", "B"}, view.Columns())
+	require.Equal(t, 1, view.NumRows())
+	require.Equal(t, "x", view.Cell(0, 1))
+	// ReflectCell returns the cell value, not its element
+	require.Equal(t, 1, view.ReflectCell(0, 0).Interface())
+	require.Equal(t, "x", view.ReflectCell(0, 1).Interface())
+}
+
+func TestViewWithColumns(t *testing.T) {
+	source := &AnyValuesView{Tit: "Source", Cols: []string{"A", "B"}, Rows: [][]any{{1, "x"}}}
+
+	tests := []struct {
+		name    string
+		columns []string
+		wantRow []any
+	}{
+		{name: "renamed", columns: []string{"X", "Y"}, wantRow: []any{1, "x"}},
+		{name: "fewer", columns: []string{"X"}, wantRow: []any{1}},
+		{name: "more", columns: []string{"X", "Y", "Z"}, wantRow: []any{1, "x", nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := ViewWithColumns(source, tt.columns)
+			require.Equal(t, "Source", view.Title())
+			require.Equal(t, tt.columns, view.Columns())
+			require.Equal(t, 1, view.NumRows())
+			for col, want := range tt.wantRow {
+				require.Equal(t, want, view.Cell(0, col), "column %d", col)
+				if want == nil {
+					require.Equal(t, reflect.Value{}, view.ReflectCell(0, col), "column %d", col)
+				} else {
+					require.Equal(t, want, view.ReflectCell(0, col).Interface(), "column %d", col)
+				}
+			}
+			require.Nil(t, view.Cell(0, -1))
+			require.Nil(t, view.Cell(0, len(tt.columns)))
+		})
+	}
+}
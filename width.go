@@ -0,0 +1,124 @@
+package retable
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+// WidthOptions configure how StringWidth and MeasureColumnWidths
+// measure the display width of strings.
+//
+// A nil *WidthOptions is valid and counts every UTF-8 rune
+// as one column like StringColumnWidths.
+type WidthOptions struct {
+	// EastAsianWidth counts East Asian wide and fullwidth runes
+	// like CJK characters as two columns and combining marks,
+	// zero width, and control characters as zero columns.
+	EastAsianWidth bool
+
+	// IgnoreANSI ignores ANSI escape sequences
+	// like terminal colors.
+	IgnoreANSI bool
+}
+
+// DisplayWidthOptions measure strings as displayed
+// by terminals and fixed width fonts.
+var DisplayWidthOptions = &WidthOptions{
+	EastAsianWidth: true,
+	IgnoreANSI:     true,
+}
+
+// StringWidth returns the width of str
+// measured as configured by options.
+func StringWidth(str string, options *WidthOptions) int {
+	if options == nil || (!options.EastAsianWidth && !options.IgnoreANSI) {
+		return utf8.RuneCountInString(str)
+	}
+	w := 0
+	for i := 0; i < len(str); {
+		if options.IgnoreANSI && str[i] == '\x1b' {
+			i += ansiEscapeLen(str[i:])
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(str[i:])
+		i += size
+		if options.EastAsianWidth {
+			w += runeWidth(r)
+		} else {
+			w++
+		}
+	}
+	return w
+}
+
+// MeasureColumnWidths returns the column widths of the passed
+// table measured by StringWidth with options.
+// maxCols limits the number of columns to consider,
+// if maxCols is -1, then all columns are considered.
+func MeasureColumnWidths(rows [][]string, maxCols int, options *WidthOptions) []int {
+	if maxCols < 0 {
+		maxCols = 0
+		for _, r := range rows {
+			maxCols = max(maxCols, len(r))
+		}
+	}
+	if maxCols == 0 {
+		return nil
+	}
+	colWidths := make([]int, maxCols)
+	for row := range rows {
+		for col := 0; col < maxCols && col < len(rows[row]); col++ {
+			colWidths[col] = max(colWidths[col], StringWidth(rows[row][col], options))
+		}
+	}
+	return colWidths
+}
+
+// runeWidth returns the number of terminal columns of r.
+func runeWidth(r rune) int {
+	switch {
+	case r == '\u200b' || r == '\u200c' || r == '\u200d' || r == '\ufeff':
+		// Zero width space, non-joiner, joiner, and BOM
+		return 0
+	case unicode.IsControl(r) || unicode.In(r, unicode.Mn, unicode.Me):
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// ansiEscapeLen returns the byte length of the ANSI escape
+// sequence at the start of str that starts with ESC.
+// CSI sequences like "\x1b[31m" end with a byte in the range @ to ~,
+// OSC sequences like hyperlinks end with BEL or ESC \.
+func ansiEscapeLen(str string) int {
+	if len(str) < 2 {
+		return len(str)
+	}
+	switch str[1] {
+	case '[':
+		for i := 2; i < len(str); i++ {
+			if str[i] >= '@' && str[i] <= '~' {
+				return i + 1
+			}
+		}
+	case ']':
+		for i := 2; i < len(str); i++ {
+			if str[i] == '\a' {
+				return i + 1
+			}
+			if str[i] == '\x1b' && i+1 < len(str) && str[i+1] == '\\' {
+				return i + 2
+			}
+		}
+	default:
+		// Two byte escape sequence
+		return 2
+	}
+	return len(str)
+}
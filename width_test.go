@@ -0,0 +1,45 @@
+package retable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringWidth(t *testing.T) {
+	tests := []struct {
+		name    string
+		str     string
+		options *WidthOptions
+		want    int
+	}{
+		{name: "empty", str: "", options: DisplayWidthOptions, want: 0},
+		{name: "ASCII", str: "Hello", options: DisplayWidthOptions, want: 5},
+		{name: "runes", str: "Grüße", options: nil, want: 5},
+		{name: "CJK runes", str: "日本語", options: nil, want: 3},
+		{name: "CJK display", str: "日本語", options: DisplayWidthOptions, want: 6},
+		{name: "fullwidth", str: "ＡＢ", options: DisplayWidthOptions, want: 4},
+		{name: "combining mark", str: "é", options: DisplayWidthOptions, want: 1},
+		{name: "ANSI color", str: "\x1b[31mred\x1b[0m", options: DisplayWidthOptions, want: 3},
+		{name: "ANSI not ignored", str: "\x1b[31mred\x1b[0m", options: &WidthOptions{EastAsianWidth: true}, want: 10},
+		{name: "ANSI hyperlink", str: "\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", options: DisplayWidthOptions, want: 4},
+		{name: "ANSI and CJK", str: "\x1b[1m表\x1b[0m", options: DisplayWidthOptions, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, StringWidth(tt.str, tt.options))
+		})
+	}
+}
+
+func TestMeasureColumnWidths(t *testing.T) {
+	rows := [][]string{
+		{"Name", "City"},
+		{"山田", "東京都"},
+		{"\x1b[32mJohn\x1b[0m", "Vienna"},
+	}
+	require.Equal(t, []int{4, 6}, MeasureColumnWidths(rows, -1, DisplayWidthOptions))
+	require.Equal(t, []int{13, 6}, MeasureColumnWidths(rows, -1, nil))
+	require.Equal(t, []int{4}, MeasureColumnWidths(rows, 1, DisplayWidthOptions))
+	require.Nil(t, MeasureColumnWidths(nil, -1, DisplayWidthOptions))
+}
@@ -0,0 +1,85 @@
+package retable
+
+import (
+	"context"
+	"time"
+)
+
+// WriteHooks are optional callbacks that table writers
+// call while writing the data rows of a view
+// to log, meter, or collect partial failures
+// without wrapping every formatter.
+//
+// Any of the callbacks can be nil
+// and a nil *WriteHooks is valid and does nothing.
+// Header rows are not reported.
+type WriteHooks struct {
+	// OnRowStart is called before the row of the view is written.
+	OnRowStart func(ctx context.Context, view View, row int)
+
+	// OnRowEnd is called after the row of the view was written
+	// or writing it failed with a non nil err.
+	OnRowEnd func(ctx context.Context, view View, row int, err error)
+
+	// OnCellError is called when a cell of the view could not be
+	// formatted or written.
+	// If OnCellError returns nil, then the cell is left empty
+	// and writing continues, else the returned error
+	// aborts writing.
+	// If OnCellError is nil, then err aborts writing.
+	OnCellError func(ctx context.Context, view View, row, col int, err error) error
+
+	// OnCellFormatted is called with the latency
+	// of formatting a cell of the view.
+	OnCellFormatted func(ctx context.Context, view View, row, col int, latency time.Duration)
+}
+
+// RowStart calls OnRowStart if h and OnRowStart are not nil.
+func (h *WriteHooks) RowStart(ctx context.Context, view View, row int) {
+	if h != nil && h.OnRowStart != nil {
+		h.OnRowStart(ctx, view, row)
+	}
+}
+
+// RowEnd calls OnRowEnd if h and OnRowEnd are not nil.
+func (h *WriteHooks) RowEnd(ctx context.Context, view View, row int, err error) {
+	if h != nil && h.OnRowEnd != nil {
+		h.OnRowEnd(ctx, view, row, err)
+	}
+}
+
+// CellError returns the result of OnCellError
+// if h and OnCellError are not nil, else err is returned.
+// Errors of a canceled ctx are always returned
+// without calling OnCellError.
+func (h *WriteHooks) CellError(ctx context.Context, view View, row, col int, err error) error {
+	if h == nil || h.OnCellError == nil || ctx.Err() != nil {
+		return err
+	}
+	return h.OnCellError(ctx, view, row, col, err)
+}
+
+// CellStart returns the current time if OnCellFormatted is set
+// to measure the latency of formatting a cell,
+// else the zero time is returned to avoid the overhead.
+func (h *WriteHooks) CellStart() time.Time {
+	if h == nil || h.OnCellFormatted == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// CellEnd calls OnCellFormatted with the time since start
+// if start is not zero, see CellStart.
+func (h *WriteHooks) CellEnd(ctx context.Context, view View, row, col int, start time.Time) {
+	if !start.IsZero() {
+		h.CellFormatted(ctx, view, row, col, time.Since(start))
+	}
+}
+
+// CellFormatted calls OnCellFormatted if h and OnCellFormatted are not nil.
+func (h *WriteHooks) CellFormatted(ctx context.Context, view View, row, col int, latency time.Duration) {
+	if h != nil && h.OnCellFormatted != nil {
+		h.OnCellFormatted(ctx, view, row, col, latency)
+	}
+}
@@ -0,0 +1,91 @@
+package xmltable
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/domonda/go-retable"
+)
+
+// Read parses row oriented XML like it is written by Writer
+// and returns the cells as strings.
+//
+// Every element with the local name rowElement
+// is a row and its attributes and the text content
+// of its direct child elements are the cells of the row.
+// The columns are the attribute and child element names
+// in the order of their first occurrence.
+// A title attribute of the root element is used as view title.
+func Read(reader io.Reader, rowElement string) (*retable.StringsView, error) {
+	var (
+		view     = new(retable.StringsView)
+		colIndex = make(map[string]int)
+		decoder  = xml.NewDecoder(reader)
+		depth    int
+		rowDepth int // zero if not within a row element
+		row      []string
+		cellCol  = -1
+		cellText strings.Builder
+	)
+	setCell := func(name, value string) {
+		col, ok := colIndex[name]
+		if !ok {
+			col = len(view.Cols)
+			colIndex[name] = col
+			view.Cols = append(view.Cols, name)
+		}
+		for len(row) <= col {
+			row = append(row, "")
+		}
+		row[col] = value
+	}
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return view, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+			switch {
+			case depth == 1 && t.Name.Local != rowElement:
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "title" {
+						view.Tit = attr.Value
+					}
+				}
+			case rowDepth == 0 && t.Name.Local == rowElement:
+				rowDepth = depth
+				row = nil
+				for _, attr := range t.Attr {
+					setCell(attr.Name.Local, attr.Value)
+				}
+			case rowDepth > 0 && depth == rowDepth+1:
+				setCell(t.Name.Local, "")
+				cellCol = colIndex[t.Name.Local]
+				cellText.Reset()
+			}
+
+		case xml.CharData:
+			if cellCol >= 0 {
+				cellText.Write(t)
+			}
+
+		case xml.EndElement:
+			switch {
+			case rowDepth > 0 && depth == rowDepth+1:
+				row[cellCol] = cellText.String()
+				cellCol = -1
+			case rowDepth > 0 && depth == rowDepth:
+				view.Rows = append(view.Rows, row)
+				rowDepth = 0
+			}
+			depth--
+		}
+	}
+}
@@ -0,0 +1,452 @@
+// Package xmltable writes views as row oriented XML
+// and reads simple row oriented XML back into views
+// for integrations that exchange tables as XML.
+package xmltable
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/domonda/go-retable"
+)
+
+// Writer writes views as XML with a root element
+// containing one element per row.
+//
+// The cells of a row are written as child elements
+// named after the columns, or as attributes of the
+// row element if cell attributes are enabled:
+//
+//	<table title="Title">
+//	  <row><Name>Erik</Name><Age>42</Age></row>
+//	</table>
+type Writer[T any] struct {
+	viewer           retable.Viewer
+	columnFormatters map[int]retable.CellFormatter
+	typeFormatters   *retable.ReflectTypeCellFormatter
+	nullPolicy       retable.NullPolicy
+	columnNulls      retable.ColumnNullPolicies
+	errorPolicy      retable.ErrorPolicy
+	rootElement      string
+	rowElement       string
+	cellAttributes   bool
+	indent           string
+	hooks            *retable.WriteHooks
+	metrics          retable.MetricsRecorder
+	recoverPanics    bool
+	pointerHandling  retable.PointerHandling
+	zeroAsNull       retable.ZeroAsNull
+	rawPolicy        retable.RawPolicy
+}
+
+func NewWriter[T any]() *Writer[T] {
+	return &Writer[T]{
+		columnFormatters: make(map[int]retable.CellFormatter),
+		typeFormatters:   nil, // OK to use nil retable.TypeFormatters
+		nullPolicy:       retable.RenderNullAs(""),
+		columnNulls:      retable.ColumnNullPolicies{},
+		errorPolicy:      retable.ErrorPolicy{},
+		rootElement:      "table",
+		rowElement:       "row",
+		cellAttributes:   false,
+		indent:           "  ",
+		hooks:            nil,
+		metrics:          nil,
+		recoverPanics:    false,
+		pointerHandling:  retable.PointerKeep,
+		zeroAsNull:       retable.ZeroAsNull{},
+		rawPolicy:        retable.RawTrust,
+	}
+}
+
+func (w *Writer[T]) clone() *Writer[T] {
+	c := new(Writer[T])
+	*c = *w
+	return c
+}
+
+// Capabilities returns the capabilities of the XML writer.
+func (w *Writer[T]) Capabilities() retable.WriterCapabilities {
+	return retable.WriterCapabilities{
+		Format:    "xml",
+		Streaming: true,
+	}
+}
+
+// Write calls WriteView with the result of Viewer.NewView(table)
+// using the writer's viewer if not nil or else retable.DefaultViewer.
+func (w *Writer[T]) Write(ctx context.Context, dest io.Writer, table T) error {
+	viewer := w.viewer
+	if viewer == nil {
+		var err error
+		viewer, err = retable.SelectViewer(table)
+		if err != nil {
+			return err
+		}
+	}
+	return w.WriteWithViewer(ctx, dest, viewer, table)
+}
+
+// WriteWithViewer calls WriteView with the result of viewer.NewView(table).
+func (w *Writer[T]) WriteWithViewer(ctx context.Context, dest io.Writer, viewer retable.Viewer, table T) error {
+	view, err := viewer.NewView("", table)
+	if err != nil {
+		return err
+	}
+	return w.WriteView(ctx, dest, view)
+}
+
+// WriteView writes the view to dest as XML.
+// A non empty view title is written as title
+// attribute of the root element.
+//
+// An OpenTelemetry span is created for the write
+// and metrics are recorded if a MetricsRecorder is set.
+func (w *Writer[T]) WriteView(ctx context.Context, dest io.Writer, view retable.View) (err error) {
+	ctx, iw := retable.StartInstrumentedWrite(ctx, w.metrics, "xml", view.Title(), dest)
+	defer func() { err = iw.End(ctx, err) }()
+
+	view = w.pointerHandling.View(view)
+	view = w.zeroAsNull.View(view)
+	hooks := iw.Hooks(w.hooks)
+	columns := view.Columns()
+	names := make([]string, len(columns))
+	for col, column := range columns {
+		names[col] = ElementName(column)
+	}
+
+	b := bufio.NewWriter(iw)
+	b.WriteString(xml.Header)
+	b.WriteString("<" + w.rootElement)
+	if title := view.Title(); title != "" {
+		b.WriteString(` title="`)
+		xml.EscapeText(b, []byte(title))
+		b.WriteString(`"`)
+	}
+	b.WriteString(">\n")
+	for row := 0; row < view.NumRows(); row++ {
+		hooks.RowStart(ctx, view, row)
+		err = w.writeRow(ctx, b, view, row, names, hooks)
+		hooks.RowEnd(ctx, view, row, err)
+		if err != nil {
+			return err
+		}
+	}
+	b.WriteString("</" + w.rootElement + ">\n")
+	return b.Flush()
+}
+
+func (w *Writer[T]) writeRow(ctx context.Context, b *bufio.Writer, view retable.View, row int, names []string, hooks *retable.WriteHooks) error {
+	b.WriteString(w.indent + "<" + w.rowElement)
+	if !w.cellAttributes {
+		b.WriteString(">")
+	}
+	for col, name := range names {
+		if !retable.CellExists(view, row, col) {
+			continue
+		}
+		start := hooks.CellStart()
+		str, isRaw, err := w.cellString(ctx, view, row, col)
+		hooks.CellEnd(ctx, view, row, col, start)
+		if err != nil {
+			var policyErr error
+			str, isRaw, policyErr = w.errorPolicy.FormatError(view, row, col, err)
+			if policyErr != nil {
+				err = hooks.CellError(ctx, view, row, col, err)
+				if err != nil {
+					return retable.NewCellError(view, row, col, err)
+				}
+			}
+		}
+		if w.cellAttributes {
+			b.WriteString(" " + name + `="`)
+			xml.EscapeText(b, []byte(str))
+			b.WriteString(`"`)
+			continue
+		}
+		b.WriteString("<" + name + ">")
+		if isRaw {
+			b.WriteString(str)
+		} else {
+			xml.EscapeText(b, []byte(str))
+		}
+		b.WriteString("</" + name + ">")
+	}
+	if w.cellAttributes {
+		b.WriteString("/>\n")
+	} else {
+		b.WriteString("</" + w.rowElement + ">\n")
+	}
+	return nil
+}
+
+// cellString returns the formatted string of a cell
+// and if it is raw XML that must not be escaped.
+func (w *Writer[T]) cellString(ctx context.Context, view retable.View, row, col int) (str string, isRaw bool, err error) {
+	if ctx.Err() != nil {
+		return "", false, ctx.Err()
+	}
+
+	colFormatter, ok := w.columnFormatters[col]
+	if !ok {
+		colFormatter = retable.ViewColumnFormatter(view, col)
+		ok = colFormatter != nil
+	}
+	colFormatter = w.rawPolicy.WrapFormatter(colFormatter)
+	if ok && w.recoverPanics {
+		colFormatter = retable.SafeCellFormatter(colFormatter)
+	}
+	if ok {
+		str, isRaw, err := colFormatter.FormatCell(ctx, view, row, col)
+		if err == nil {
+			return str, isRaw, nil
+		}
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return "", false, err
+		}
+		// Continue after errors.ErrUnsupported
+	}
+
+	typeFormatter := w.rawPolicy.WrapFormatter(w.typeFormatters)
+	if w.recoverPanics {
+		typeFormatter = retable.SafeCellFormatter(typeFormatter)
+	}
+	str, isRaw, err = typeFormatter.FormatCell(ctx, view, row, col)
+	if err == nil {
+		return str, isRaw, nil
+	}
+	if !errors.Is(err, errors.ErrUnsupported) {
+		return "", false, err
+	}
+	// Continue after errors.ErrUnsupported
+
+	// Use fallback methods for formatting
+	v := retable.AsReflectCellView(view).ReflectCell(row, col)
+	if retable.IsNullLike(v) {
+		return w.columnNulls.Policy(view, col, w.nullPolicy).FormatNull(view, row, col)
+	}
+	if cellErr := retable.CellErrorValue(view, row, col); cellErr != nil {
+		return w.errorPolicy.FormatError(view, row, col, cellErr)
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	return fmt.Sprint(v.Interface()), false, nil
+}
+
+// ElementName returns column as valid XML element
+// or attribute name by replacing invalid characters
+// with underscores.
+func ElementName(column string) string {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(column) {
+		switch {
+		case r == '_' || unicode.IsLetter(r):
+			b.WriteRune(r)
+		case i > 0 && (r == '-' || r == '.' || unicode.IsDigit(r)):
+			b.WriteRune(r)
+		case i == 0 && unicode.IsDigit(r):
+			b.WriteRune('_')
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "_"
+	}
+	return b.String()
+}
+
+func (w *Writer[T]) WithTableViewer(viewer retable.Viewer) *Writer[T] {
+	mod := w.clone()
+	mod.viewer = viewer
+	return mod
+}
+
+// WithColumnFormatter returns a new writer with the passed formatter
+// for the column at columnIndex.
+// If nil is passed as formatter, then a previous set formatter
+// for the column is removed.
+func (w *Writer[T]) WithColumnFormatter(columnIndex int, formatter retable.CellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.columnFormatters = make(map[int]retable.CellFormatter)
+	for key, val := range w.columnFormatters {
+		mod.columnFormatters[key] = val
+	}
+	if formatter == nil {
+		delete(mod.columnFormatters, columnIndex)
+	} else {
+		mod.columnFormatters[columnIndex] = formatter
+	}
+	return mod
+}
+
+// WithRecoverPanics returns a new writer that recovers
+// panics of column and type formatters and returns them
+// as errors with the coordinates of the formatted cell,
+// see retable.SafeCellFormatter.
+func (w *Writer[T]) WithRecoverPanics(recoverPanics bool) *Writer[T] {
+	mod := w.clone()
+	mod.recoverPanics = recoverPanics
+	return mod
+}
+
+// WithRawPolicy returns a new writer that handles
+// raw strings returned by column and type formatters
+// according to policy, see retable.RawPolicy.
+func (w *Writer[T]) WithRawPolicy(policy retable.RawPolicy) *Writer[T] {
+	mod := w.clone()
+	mod.rawPolicy = policy
+	return mod
+}
+
+// WithPointerHandling returns a new writer that formats
+// cells with pointer values according to handling,
+// see retable.PointerHandling.
+func (w *Writer[T]) WithPointerHandling(handling retable.PointerHandling) *Writer[T] {
+	mod := w.clone()
+	mod.pointerHandling = handling
+	return mod
+}
+
+// WithZeroAsNull returns a new writer that handles
+// the zero values of the cells selected by zeroAsNull
+// like null values using the null policy,
+// for example to render 0 amounts as empty cells.
+func (w *Writer[T]) WithZeroAsNull(zeroAsNull retable.ZeroAsNull) *Writer[T] {
+	mod := w.clone()
+	mod.zeroAsNull = zeroAsNull
+	return mod
+}
+
+func (w *Writer[T]) WithTypeFormatters(formatter *retable.ReflectTypeCellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.typeFormatters = formatter
+	return mod
+}
+
+func (w *Writer[T]) WithTypeFormatter(typ reflect.Type, fmt retable.CellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.typeFormatters = w.typeFormatters.WithTypeFormatter(typ, fmt)
+	return mod
+}
+
+// WithDefaults returns a new writer with the type formatters
+// of retable.NewDefaultFormatters added to the type formatters
+// of the writer. Already configured type formatters take precedence.
+func (w *Writer[T]) WithDefaults() *Writer[T] {
+	mod := w.clone()
+	mod.typeFormatters = retable.NewDefaultFormatters().WithFormatters(w.typeFormatters)
+	return mod
+}
+
+func (w *Writer[T]) WithNullPolicy(policy retable.NullPolicy) *Writer[T] {
+	mod := w.clone()
+	mod.nullPolicy = policy
+	return mod
+}
+
+// WithColumnNilValue returns a new writer that renders
+// null-like values of the column with columnIndex
+// as the passed nilValue instead of using the null policy of the writer.
+func (w *Writer[T]) WithColumnNilValue(columnIndex int, nilValue string) *Writer[T] {
+	mod := w.clone()
+	mod.columnNulls = w.columnNulls.WithIndex(columnIndex, retable.RenderNullAs(nilValue))
+	return mod
+}
+
+// WithColumnTitleNilValue returns a new writer that renders
+// null-like values of the columns with the passed title
+// as nilValue instead of using the null policy of the writer.
+func (w *Writer[T]) WithColumnTitleNilValue(title string, nilValue string) *Writer[T] {
+	mod := w.clone()
+	mod.columnNulls = w.columnNulls.WithTitle(title, retable.RenderNullAs(nilValue))
+	return mod
+}
+
+// WithColumnNullPolicies returns a new writer that handles
+// null-like values of the columns selected by policies
+// with their own null policy.
+func (w *Writer[T]) WithColumnNullPolicies(policies retable.ColumnNullPolicies) *Writer[T] {
+	mod := w.clone()
+	mod.columnNulls = policies
+	return mod
+}
+
+// WithErrorPolicy returns a new writer that handles
+// cells with error values and cells whose formatting failed
+// with the passed policy.
+// The default policy aborts writing with a retable.CellError.
+func (w *Writer[T]) WithErrorPolicy(policy retable.ErrorPolicy) *Writer[T] {
+	mod := w.clone()
+	mod.errorPolicy = policy
+	return mod
+}
+
+// WithRootElement returns a new writer using
+// the passed name for the root element, default is "table".
+func (w *Writer[T]) WithRootElement(name string) *Writer[T] {
+	mod := w.clone()
+	mod.rootElement = ElementName(name)
+	return mod
+}
+
+// WithRowElement returns a new writer using
+// the passed name for row elements, default is "row".
+func (w *Writer[T]) WithRowElement(name string) *Writer[T] {
+	mod := w.clone()
+	mod.rowElement = ElementName(name)
+	return mod
+}
+
+// WithCellAttributes returns a new writer that writes cells
+// as attributes of the row elements instead of child elements.
+func (w *Writer[T]) WithCellAttributes(cellAttributes bool) *Writer[T] {
+	mod := w.clone()
+	mod.cellAttributes = cellAttributes
+	return mod
+}
+
+// WithIndent returns a new writer using indent
+// before row elements, default are two spaces.
+func (w *Writer[T]) WithIndent(indent string) *Writer[T] {
+	mod := w.clone()
+	mod.indent = indent
+	return mod
+}
+
+// WithWriteHooks returns a new writer that calls hooks
+// while writing the data rows of a view.
+func (w *Writer[T]) WithWriteHooks(hooks *retable.WriteHooks) *Writer[T] {
+	mod := w.clone()
+	mod.hooks = hooks
+	return mod
+}
+
+// WithMetricsRecorder returns a new writer that records
+// the statistics of every written view with recorder.
+func (w *Writer[T]) WithMetricsRecorder(recorder retable.MetricsRecorder) *Writer[T] {
+	mod := w.clone()
+	mod.metrics = recorder
+	return mod
+}
+
+func (w *Writer[T]) RootElement() string {
+	return w.rootElement
+}
+
+func (w *Writer[T]) RowElement() string {
+	return w.rowElement
+}
+
+func (w *Writer[T]) CellAttributes() bool {
+	return w.cellAttributes
+}
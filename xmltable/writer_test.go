@@ -0,0 +1,75 @@
+package xmltable
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-retable"
+)
+
+func TestWriter_WriteView(t *testing.T) {
+	view := &retable.AnyValuesView{
+		Tit:  "People & Ages",
+		Cols: []string{"Name", "Age", "1st Year"},
+		Rows: [][]any{{"Erik <E>", 42, 1982}, {"Anna", nil, 1990}},
+	}
+	tests := []struct {
+		name     string
+		writer   *Writer[any]
+		wantDest string
+	}{
+		{
+			name:   "elements",
+			writer: NewWriter[any](),
+			wantDest: xml.Header +
+				`<table title="People &amp; Ages">` + "\n" +
+				`  <row><Name>Erik &lt;E&gt;</Name><Age>42</Age><_1st_Year>1982</_1st_Year></row>` + "\n" +
+				`  <row><Name>Anna</Name><Age></Age><_1st_Year>1990</_1st_Year></row>` + "\n" +
+				`</table>` + "\n",
+		},
+		{
+			name: "attributes",
+			writer: NewWriter[any]().
+				WithRootElement("people").
+				WithRowElement("person").
+				WithCellAttributes(true).
+				WithIndent(""),
+			wantDest: xml.Header +
+				`<people title="People &amp; Ages">` + "\n" +
+				`<person Name="Erik &lt;E&gt;" Age="42" _1st_Year="1982"/>` + "\n" +
+				`<person Name="Anna" Age="" _1st_Year="1990"/>` + "\n" +
+				`</people>` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dest bytes.Buffer
+			err := tt.writer.WriteView(context.Background(), &dest, view)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantDest, dest.String())
+
+			// Read back what was written
+			read, err := Read(&dest, tt.writer.RowElement())
+			require.NoError(t, err)
+			require.Equal(t, view.Tit, read.Tit)
+			require.Equal(t, []string{"Name", "Age", "_1st_Year"}, read.Cols)
+			require.Equal(t, [][]string{{"Erik <E>", "42", "1982"}, {"Anna", "", "1990"}}, read.Rows)
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	input := `<orders>
+		<order id="1"><item>Apple</item><qty>3</qty></order>
+		<order id="2"><qty>1</qty><note>Gift <b>wrapped</b></note></order>
+	</orders>`
+	view, err := Read(strings.NewReader(input), "order")
+	require.NoError(t, err)
+	require.Equal(t, []string{"id", "item", "qty", "note"}, view.Cols)
+	require.Equal(t, [][]string{{"1", "Apple", "3"}, {"2", "", "1", "Gift wrapped"}}, view.Rows)
+}
@@ -0,0 +1,316 @@
+// Package yamltable writes views as YAML
+// sequences of maps with the column titles as keys.
+package yamltable
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/domonda/go-retable"
+)
+
+// Writer writes the rows of views as a YAML sequence
+// of maps with the column titles as keys in column order:
+//
+//   - Name: Erik
+//     Age: 42
+//   - Name: Anna
+//     Age: null
+//
+// Cells formatted by column or type formatters
+// and error cells are written as strings,
+// null cells as YAML null, and all other cells
+// as YAML values of their Go types.
+// Cells that don't exist in sparse views are omitted.
+type Writer[T any] struct {
+	viewer           retable.Viewer
+	columnFormatters map[int]retable.CellFormatter
+	typeFormatters   *retable.ReflectTypeCellFormatter
+	errorPolicy      retable.ErrorPolicy
+	indent           int
+	hooks            *retable.WriteHooks
+	metrics          retable.MetricsRecorder
+	recoverPanics    bool
+	pointerHandling  retable.PointerHandling
+	zeroAsNull       retable.ZeroAsNull
+}
+
+func NewWriter[T any]() *Writer[T] {
+	return &Writer[T]{
+		columnFormatters: make(map[int]retable.CellFormatter),
+		typeFormatters:   nil, // OK to use nil retable.TypeFormatters
+		errorPolicy:      retable.ErrorPolicy{},
+		indent:           2,
+		hooks:            nil,
+		metrics:          nil,
+		recoverPanics:    false,
+		pointerHandling:  retable.PointerKeep,
+		zeroAsNull:       retable.ZeroAsNull{},
+	}
+}
+
+func (w *Writer[T]) clone() *Writer[T] {
+	c := new(Writer[T])
+	*c = *w
+	return c
+}
+
+// Capabilities returns the capabilities of the YAML writer.
+func (w *Writer[T]) Capabilities() retable.WriterCapabilities {
+	return retable.WriterCapabilities{
+		Format:      "yaml",
+		NativeTypes: true,
+	}
+}
+
+// Write calls WriteView with the result of Viewer.NewView(table)
+// using the writer's viewer if not nil or else retable.DefaultViewer.
+func (w *Writer[T]) Write(ctx context.Context, dest io.Writer, table T) error {
+	viewer := w.viewer
+	if viewer == nil {
+		var err error
+		viewer, err = retable.SelectViewer(table)
+		if err != nil {
+			return err
+		}
+	}
+	return w.WriteWithViewer(ctx, dest, viewer, table)
+}
+
+// WriteWithViewer calls WriteView with the result of viewer.NewView(table).
+func (w *Writer[T]) WriteWithViewer(ctx context.Context, dest io.Writer, viewer retable.Viewer, table T) error {
+	view, err := viewer.NewView("", table)
+	if err != nil {
+		return err
+	}
+	return w.WriteView(ctx, dest, view)
+}
+
+// WriteView writes the rows of view to dest
+// as YAML sequence of maps.
+//
+// An OpenTelemetry span is created for the write
+// and metrics are recorded if a MetricsRecorder is set.
+func (w *Writer[T]) WriteView(ctx context.Context, dest io.Writer, view retable.View) (err error) {
+	ctx, iw := retable.StartInstrumentedWrite(ctx, w.metrics, "yaml", view.Title(), dest)
+	defer func() { err = iw.End(ctx, err) }()
+
+	view = w.pointerHandling.View(view)
+	view = w.zeroAsNull.View(view)
+	hooks := iw.Hooks(w.hooks)
+	seq := &yaml.Node{Kind: yaml.SequenceNode}
+	for row := 0; row < view.NumRows(); row++ {
+		hooks.RowStart(ctx, view, row)
+		rowNode, err := w.rowNode(ctx, view, row, hooks)
+		hooks.RowEnd(ctx, view, row, err)
+		if err != nil {
+			return err
+		}
+		seq.Content = append(seq.Content, rowNode)
+	}
+
+	encoder := yaml.NewEncoder(iw)
+	encoder.SetIndent(w.indent)
+	err = encoder.Encode(seq)
+	if err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func (w *Writer[T]) rowNode(ctx context.Context, view retable.View, row int, hooks *retable.WriteHooks) (*yaml.Node, error) {
+	rowNode := &yaml.Node{Kind: yaml.MappingNode}
+	for col, column := range view.Columns() {
+		if !retable.CellExists(view, row, col) {
+			continue
+		}
+		start := hooks.CellStart()
+		valueNode, err := w.cellNode(ctx, view, row, col)
+		hooks.CellEnd(ctx, view, row, col, start)
+		if err != nil {
+			str, _, policyErr := w.errorPolicy.FormatError(view, row, col, err)
+			if policyErr != nil {
+				err = hooks.CellError(ctx, view, row, col, err)
+				if err != nil {
+					return nil, retable.NewCellError(view, row, col, err)
+				}
+			}
+			valueNode = stringNode(str)
+		}
+		rowNode.Content = append(rowNode.Content, stringNode(column), valueNode)
+	}
+	return rowNode, nil
+}
+
+func (w *Writer[T]) cellNode(ctx context.Context, view retable.View, row, col int) (*yaml.Node, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	colFormatter, ok := w.columnFormatters[col]
+	if !ok {
+		colFormatter = retable.ViewColumnFormatter(view, col)
+		ok = colFormatter != nil
+	}
+	if ok && w.recoverPanics {
+		colFormatter = retable.SafeCellFormatter(colFormatter)
+	}
+	if ok {
+		str, _, err := colFormatter.FormatCell(ctx, view, row, col)
+		if err == nil {
+			return stringNode(str), nil
+		}
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return nil, err
+		}
+		// Continue after errors.ErrUnsupported
+	}
+
+	var typeFormatter retable.CellFormatter = w.typeFormatters
+	if w.recoverPanics {
+		typeFormatter = retable.SafeCellFormatter(typeFormatter)
+	}
+	str, _, err := typeFormatter.FormatCell(ctx, view, row, col)
+	if err == nil {
+		return stringNode(str), nil
+	}
+	if !errors.Is(err, errors.ErrUnsupported) {
+		return nil, err
+	}
+	// Continue after errors.ErrUnsupported
+
+	// Use fallback methods for formatting
+	v := retable.AsReflectCellView(view).ReflectCell(row, col)
+	if retable.IsNullLike(v) {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+	if cellErr := retable.CellErrorValue(view, row, col); cellErr != nil {
+		str, _, err := w.errorPolicy.FormatError(view, row, col, cellErr)
+		if err != nil {
+			return nil, err
+		}
+		return stringNode(str), nil
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if n, ok := v.Interface().(retable.RawNumber); ok {
+		// Write the exact digits as unquoted number
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: string(n)}, nil
+	}
+	node := new(yaml.Node)
+	err = node.Encode(v.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// stringNode returns a YAML string node that is quoted
+// if str would otherwise be parsed as another type.
+func stringNode(str string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: str}
+}
+
+func (w *Writer[T]) WithTableViewer(viewer retable.Viewer) *Writer[T] {
+	mod := w.clone()
+	mod.viewer = viewer
+	return mod
+}
+
+// WithColumnFormatter returns a new writer with the passed formatter
+// for the column at columnIndex.
+// If nil is passed as formatter, then a previous set formatter
+// for the column is removed.
+func (w *Writer[T]) WithColumnFormatter(columnIndex int, formatter retable.CellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.columnFormatters = make(map[int]retable.CellFormatter)
+	for key, val := range w.columnFormatters {
+		mod.columnFormatters[key] = val
+	}
+	if formatter == nil {
+		delete(mod.columnFormatters, columnIndex)
+	} else {
+		mod.columnFormatters[columnIndex] = formatter
+	}
+	return mod
+}
+
+// WithRecoverPanics returns a new writer that recovers
+// panics of column and type formatters and returns them
+// as errors with the coordinates of the formatted cell,
+// see retable.SafeCellFormatter.
+func (w *Writer[T]) WithRecoverPanics(recoverPanics bool) *Writer[T] {
+	mod := w.clone()
+	mod.recoverPanics = recoverPanics
+	return mod
+}
+
+// WithPointerHandling returns a new writer that formats
+// cells with pointer values according to handling,
+// see retable.PointerHandling.
+func (w *Writer[T]) WithPointerHandling(handling retable.PointerHandling) *Writer[T] {
+	mod := w.clone()
+	mod.pointerHandling = handling
+	return mod
+}
+
+// WithZeroAsNull returns a new writer that handles
+// the zero values of the cells selected by zeroAsNull
+// like null values using the null policy,
+// for example to render 0 amounts as empty cells.
+func (w *Writer[T]) WithZeroAsNull(zeroAsNull retable.ZeroAsNull) *Writer[T] {
+	mod := w.clone()
+	mod.zeroAsNull = zeroAsNull
+	return mod
+}
+
+func (w *Writer[T]) WithTypeFormatters(formatter *retable.ReflectTypeCellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.typeFormatters = formatter
+	return mod
+}
+
+func (w *Writer[T]) WithTypeFormatter(typ reflect.Type, fmt retable.CellFormatter) *Writer[T] {
+	mod := w.clone()
+	mod.typeFormatters = w.typeFormatters.WithTypeFormatter(typ, fmt)
+	return mod
+}
+
+// WithErrorPolicy returns a new writer that handles
+// cells with error values and cells whose formatting failed
+// with the passed policy.
+// The default policy aborts writing with a retable.CellError.
+func (w *Writer[T]) WithErrorPolicy(policy retable.ErrorPolicy) *Writer[T] {
+	mod := w.clone()
+	mod.errorPolicy = policy
+	return mod
+}
+
+// WithIndent returns a new writer using
+// the passed number of spaces for indentation, default is 2.
+func (w *Writer[T]) WithIndent(spaces int) *Writer[T] {
+	mod := w.clone()
+	mod.indent = spaces
+	return mod
+}
+
+// WithWriteHooks returns a new writer that calls hooks
+// while writing the data rows of a view.
+func (w *Writer[T]) WithWriteHooks(hooks *retable.WriteHooks) *Writer[T] {
+	mod := w.clone()
+	mod.hooks = hooks
+	return mod
+}
+
+// WithMetricsRecorder returns a new writer that records
+// the statistics of every written view with recorder.
+func (w *Writer[T]) WithMetricsRecorder(recorder retable.MetricsRecorder) *Writer[T] {
+	mod := w.clone()
+	mod.metrics = recorder
+	return mod
+}
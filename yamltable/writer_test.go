@@ -0,0 +1,91 @@
+package yamltable
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-retable"
+)
+
+func TestWriter_WriteView(t *testing.T) {
+	tests := []struct {
+		name     string
+		writer   *Writer[any]
+		view     retable.View
+		wantDest string
+		wantErr  bool
+	}{
+		{
+			name:   "key order and types",
+			writer: NewWriter[any](),
+			view: &retable.AnyValuesView{
+				Cols: []string{"Name", "Age", "Active", "Code"},
+				Rows: [][]any{{"Erik", 42, true, "007"}, {"yes", nil, false, "a: b"}},
+			},
+			wantDest: "" +
+				"- Name: Erik\n" +
+				"  Age: 42\n" +
+				"  Active: true\n" +
+				"  Code: \"007\"\n" +
+				"- Name: \"yes\"\n" +
+				"  Age: null\n" +
+				"  Active: false\n" +
+				"  Code: 'a: b'\n",
+		},
+		{
+			name: "formatters and errors",
+			writer: NewWriter[any]().
+				WithColumnFormatter(0, retable.PercentCellFormatter(0)).
+				WithErrorPolicy(retable.RenderErrorMessage()),
+			view: &retable.AnyValuesView{
+				Cols: []string{"Share", "Error"},
+				Rows: [][]any{{0.5, errors.New("failed")}},
+			},
+			wantDest: "" +
+				"- Share: 50%\n" +
+				"  Error: failed\n",
+		},
+		{
+			name:   "raw numbers",
+			writer: NewWriter[any](),
+			view: &retable.AnyValuesView{
+				Cols: []string{"Big", "Exact"},
+				Rows: [][]any{{retable.RawNumber("123456789012345678901234567890"), retable.RawNumber("0.10")}},
+			},
+			wantDest: "" +
+				"- Big: 123456789012345678901234567890\n" +
+				"  Exact: 0.10\n",
+		},
+		{
+			name:     "empty view",
+			writer:   NewWriter[any](),
+			view:     &retable.AnyValuesView{Cols: []string{"A"}},
+			wantDest: "[]\n",
+		},
+		{
+			name:   "error aborts",
+			writer: NewWriter[any](),
+			view: &retable.AnyValuesView{
+				Cols: []string{"Error"},
+				Rows: [][]any{{errors.New("failed")}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dest bytes.Buffer
+			err := tt.writer.WriteView(context.Background(), &dest, tt.view)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantDest, dest.String())
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package retable
+
+import (
+	"maps"
+	"reflect"
+)
+
+// ZeroAsNull selects cells whose zero values
+// are treated as null by writers, for example to render
+// 0 amounts as empty cells instead of "0".
+// Cells are selected by the type of their value
+// or by their column index or title.
+// Non nil pointers are dereferenced to check their values.
+//
+// The zero value selects no cells.
+type ZeroAsNull struct {
+	Types   map[reflect.Type]bool
+	Indices map[int]bool
+	Titles  map[string]bool
+}
+
+// IsEmpty returns true if no cells are selected.
+func (z ZeroAsNull) IsEmpty() bool {
+	return len(z.Types) == 0 && len(z.Indices) == 0 && len(z.Titles) == 0
+}
+
+// IsNullCell returns true if the cell at row and col
+// of view is selected and has a zero value.
+func (z ZeroAsNull) IsNullCell(view View, row, col int) bool {
+	if z.IsEmpty() {
+		return false
+	}
+	return z.isNull(view, AsReflectCellView(view).ReflectCell(row, col), col)
+}
+
+func (z ZeroAsNull) isNull(view View, val reflect.Value, col int) bool {
+	if val.Kind() == reflect.Pointer && !val.IsNil() {
+		val = val.Elem()
+	}
+	if !val.IsValid() || !val.IsZero() {
+		return false
+	}
+	if z.Types[val.Type()] || z.Indices[col] {
+		return true
+	}
+	if len(z.Titles) > 0 {
+		if columns := view.Columns(); col >= 0 && col < len(columns) {
+			return z.Titles[columns[col]]
+		}
+	}
+	return false
+}
+
+// WithTypes returns a copy of z that also selects
+// the zero values of the passed types.
+func (z ZeroAsNull) WithTypes(types ...reflect.Type) ZeroAsNull {
+	z.Types = maps.Clone(z.Types)
+	if z.Types == nil {
+		z.Types = make(map[reflect.Type]bool, len(types))
+	}
+	for _, t := range types {
+		z.Types[t] = true
+	}
+	return z
+}
+
+// WithIndices returns a copy of z that also selects
+// the zero values of the columns with the passed indices.
+func (z ZeroAsNull) WithIndices(cols ...int) ZeroAsNull {
+	z.Indices = maps.Clone(z.Indices)
+	if z.Indices == nil {
+		z.Indices = make(map[int]bool, len(cols))
+	}
+	for _, col := range cols {
+		z.Indices[col] = true
+	}
+	return z
+}
+
+// WithTitles returns a copy of z that also selects
+// the zero values of the columns with the passed titles.
+func (z ZeroAsNull) WithTitles(titles ...string) ZeroAsNull {
+	z.Titles = maps.Clone(z.Titles)
+	if z.Titles == nil {
+		z.Titles = make(map[string]bool, len(titles))
+	}
+	for _, title := range titles {
+		z.Titles[title] = true
+	}
+	return z
+}
+
+// View returns a View wrapping source that returns nil
+// for the cells selected by z with zero values
+// so they are handled like null values by writers and validators.
+// If z is empty, then source is returned unchanged.
+//
+// The returned View forwards the ColumnFormatterView,
+// ColumnDescriptionView, ColumnVisibilityView,
+// and SparseCellView interfaces of source.
+// The row groups of a RowGroupsView source are kept.
+func (z ZeroAsNull) View(source View) View {
+	if z.IsEmpty() {
+		return source
+	}
+	view := &zeroAsNullView{source: AsReflectCellView(source), zeroAsNull: z}
+	if groups, ok := source.(RowGroupsView); ok {
+		return &rowGroupsZeroAsNullView{zeroAsNullView: view, groups: groups}
+	}
+	return view
+}
+
+var _ RowGroupsView = new(rowGroupsZeroAsNullView)
+
+type rowGroupsZeroAsNullView struct {
+	*zeroAsNullView
+	groups RowGroupsView
+}
+
+func (v *rowGroupsZeroAsNullView) RowGroup(row int) (RowGroup, bool) {
+	return v.groups.RowGroup(row)
+}
+
+var (
+	_ ColumnFormatterView   = new(zeroAsNullView)
+	_ ColumnDescriptionView = new(zeroAsNullView)
+	_ ColumnVisibilityView  = new(zeroAsNullView)
+	_ SparseCellView        = new(zeroAsNullView)
+)
+
+type zeroAsNullView struct {
+	source     ReflectCellView
+	zeroAsNull ZeroAsNull
+}
+
+func (v *zeroAsNullView) Title() string     { return v.source.Title() }
+func (v *zeroAsNullView) Columns() []string { return v.source.Columns() }
+func (v *zeroAsNullView) NumRows() int      { return v.source.NumRows() }
+
+func (v *zeroAsNullView) Cell(row, col int) any {
+	if v.zeroAsNull.isNull(v.source, v.source.ReflectCell(row, col), col) {
+		return nil
+	}
+	return v.source.Cell(row, col)
+}
+
+func (v *zeroAsNullView) ReflectCell(row, col int) reflect.Value {
+	val := v.source.ReflectCell(row, col)
+	if v.zeroAsNull.isNull(v.source, val, col) {
+		return reflect.Value{}
+	}
+	return val
+}
+
+func (v *zeroAsNullView) ColumnFormatter(col int) CellFormatter {
+	return ViewColumnFormatter(v.source, col)
+}
+
+func (v *zeroAsNullView) ColumnDescription(col int) string {
+	return ViewColumnDescription(v.source, col)
+}
+
+func (v *zeroAsNullView) ColumnHidden(col int) bool {
+	return ViewColumnHidden(v.source, col)
+}
+
+func (v *zeroAsNullView) CellExists(row, col int) bool {
+	return CellExists(v.source, row, col)
+}
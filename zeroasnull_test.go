@@ -0,0 +1,40 @@
+package retable
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZeroAsNull(t *testing.T) {
+	zero := 0
+	source := &AnyValuesView{
+		Cols: []string{"Amount", "Count", "Name", "Price"},
+		Rows: [][]any{
+			{0.0, 0, "", &zero},
+			{1.5, 2, "x", new(int)},
+		},
+	}
+	var empty ZeroAsNull
+	require.True(t, empty.IsEmpty())
+	require.Same(t, source, empty.View(source))
+
+	zeroAsNull := empty.WithTypes(reflect.TypeFor[float64]()).WithTitles("Name").WithIndices(3)
+	require.True(t, empty.IsEmpty(), "copy not modified")
+	require.True(t, zeroAsNull.IsNullCell(source, 0, 0))
+	require.False(t, zeroAsNull.IsNullCell(source, 0, 1))
+	require.True(t, zeroAsNull.IsNullCell(source, 0, 2))
+	require.True(t, zeroAsNull.IsNullCell(source, 0, 3), "pointer to zero")
+	require.False(t, zeroAsNull.IsNullCell(source, 1, 0))
+
+	view := zeroAsNull.View(source)
+	require.Equal(t, []any{nil, 0, nil, nil}, []any{view.Cell(0, 0), view.Cell(0, 1), view.Cell(0, 2), view.Cell(0, 3)})
+	require.Equal(t, 1.5, view.Cell(1, 0))
+	require.True(t, IsNullLike(AsReflectCellView(view).ReflectCell(0, 0)))
+
+	grouped := zeroAsNull.View(NewGroupedRowsView(source, 1))
+	require.Implements(t, (*RowGroupsView)(nil), grouped)
+	_, isHeaderRow := grouped.(RowGroupsView).RowGroup(0)
+	require.True(t, isHeaderRow)
+}